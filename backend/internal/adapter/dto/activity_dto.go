@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/permissions"
 )
 
 // ==========================================
@@ -27,13 +28,24 @@ type ActivityResponse struct {
 	GroupID                   string    `json:"group_id"`
 	Title                     string    `json:"title"`
 	Description               *string   `json:"description,omitempty"`
-	DueDate                   time.Time `json:"due_date"`
+	DueDate                   time.Time `json:"due_date"`       // UTC instant
+	DueDateLocal              string    `json:"due_date_local"` // the same instant in the group's time zone
+	TimeZone                  string    `json:"time_zone"`
 	IsActive                  bool      `json:"is_active"`
 	TotalVideoDurationMinutes int       `json:"total_video_duration_minutes"`
 	TotalQuestionsCount       int       `json:"total_questions_count"`
 	TotalExerciseListsCount   int       `json:"total_exercise_lists_count"`
 	CreatedAt                 time.Time `json:"created_at"`
 	UpdatedAt                 time.Time `json:"updated_at"`
+	// Only present for group staff.
+	SubmissionCounts *ActivitySubmissionCountsResponse `json:"submission_counts,omitempty"`
+}
+
+type ActivitySubmissionCountsResponse struct {
+	Created  int `json:"created"`
+	Pending  int `json:"pending"`
+	Approved int `json:"approved"`
+	Reproved int `json:"reproved"`
 }
 
 type ActivityDetailResponse struct {
@@ -42,11 +54,15 @@ type ActivityDetailResponse struct {
 	Title                     string               `json:"title"`
 	Description               *string              `json:"description,omitempty"`
 	DueDate                   time.Time            `json:"due_date"`
+	DueDateLocal              string               `json:"due_date_local"`
+	TimeZone                  string               `json:"time_zone"`
 	IsActive                  bool                 `json:"is_active"`
 	TotalVideoDurationMinutes int                  `json:"total_video_duration_minutes"`
 	TotalQuestionsCount       int                  `json:"total_questions_count"`
 	TotalExerciseListsCount   int                  `json:"total_exercise_lists_count"`
 	Attachments               []AttachmentResponse `json:"attachments"`
+	CanReview                 bool                 `json:"can_review"`
+	CanSubmit                 bool                 `json:"can_submit"`
 	CreatedAt                 time.Time            `json:"created_at"`
 	UpdatedAt                 time.Time            `json:"updated_at"`
 }
@@ -64,11 +80,12 @@ type ActivityListResponse struct {
 // ==========================================
 
 type AttachmentResponse struct {
-	PublicID    string `json:"id"`
-	Filename    string `json:"filename"`
-	ContentType string `json:"content_type"`
-	SizeBytes   int64  `json:"size_bytes"`
-	URL         string `json:"url"`
+	PublicID    string  `json:"id"`
+	Filename    string  `json:"filename"`
+	ContentType string  `json:"content_type"`
+	SizeBytes   int64   `json:"size_bytes"`
+	Checksum    *string `json:"checksum,omitempty"`
+	DownloadURL string  `json:"download_url"` // API path that checks membership, then redirects
 }
 
 // ==========================================
@@ -81,16 +98,36 @@ func ActivityToResponse(a *entity.Activity) ActivityResponse {
 		GroupID:                   a.GroupPublicID,
 		Title:                     a.Title,
 		Description:               a.Description,
-		DueDate:                   a.DueDate,
+		DueDate:                   a.DueDate.UTC(),
+		DueDateLocal:              localDueDate(a),
+		TimeZone:                  a.GroupTimeZone,
 		IsActive:                  a.IsActive,
 		TotalVideoDurationMinutes: a.TotalVideoDurationMinutes,
 		TotalQuestionsCount:       a.TotalQuestionsCount,
 		TotalExerciseListsCount:   a.TotalExerciseListsCount,
 		CreatedAt:                 a.CreatedAt,
 		UpdatedAt:                 a.UpdatedAt,
+		SubmissionCounts:          activitySubmissionCountsToResponse(a.SubmissionCounts),
+	}
+}
+
+func activitySubmissionCountsToResponse(counts map[entity.ActivitySubmissionStatus]int) *ActivitySubmissionCountsResponse {
+	if counts == nil {
+		return nil
+	}
+	return &ActivitySubmissionCountsResponse{
+		Created:  counts[entity.ActivitySubmissionStatusCreated],
+		Pending:  counts[entity.ActivitySubmissionStatusPending],
+		Approved: counts[entity.ActivitySubmissionStatusApproved],
+		Reproved: counts[entity.ActivitySubmissionStatusReproved],
 	}
 }
 
+// localDueDate renders the due date as RFC3339 in the group's time zone.
+func localDueDate(a *entity.Activity) string {
+	return a.DueDate.In(entity.TimeZoneLocation(a.GroupTimeZone)).Format(time.RFC3339)
+}
+
 func ActivitiesToResponse(activities []entity.Activity) []ActivityResponse {
 	result := make([]ActivityResponse, len(activities))
 	for i := range activities {
@@ -99,34 +136,39 @@ func ActivitiesToResponse(activities []entity.Activity) []ActivityResponse {
 	return result
 }
 
-func ActivityDetailToResponse(a *entity.Activity, attachments []entity.ActivityAttachment) ActivityDetailResponse {
+func ActivityDetailToResponse(a *entity.Activity, attachments []entity.ActivityAttachment, requester permissions.Requester) ActivityDetailResponse {
 	attResp := make([]AttachmentResponse, len(attachments))
 	for i := range attachments {
-		attResp[i] = AttachmentToResponse(&attachments[i])
+		attResp[i] = AttachmentToResponse(a.PublicID, &attachments[i])
 	}
 	return ActivityDetailResponse{
 		PublicID:                  a.PublicID,
 		GroupID:                   a.GroupPublicID,
 		Title:                     a.Title,
 		Description:               a.Description,
-		DueDate:                   a.DueDate,
+		DueDate:                   a.DueDate.UTC(),
+		DueDateLocal:              localDueDate(a),
+		TimeZone:                  a.GroupTimeZone,
 		IsActive:                  a.IsActive,
 		TotalVideoDurationMinutes: a.TotalVideoDurationMinutes,
 		TotalQuestionsCount:       a.TotalQuestionsCount,
 		TotalExerciseListsCount:   a.TotalExerciseListsCount,
 		Attachments:               attResp,
+		CanReview:                 requester.CanReviewActivity(),
+		CanSubmit:                 requester.CanSubmitActivity(),
 		CreatedAt:                 a.CreatedAt,
 		UpdatedAt:                 a.UpdatedAt,
 	}
 }
 
-func AttachmentToResponse(a *entity.ActivityAttachment) AttachmentResponse {
+func AttachmentToResponse(activityPublicID string, a *entity.ActivityAttachment) AttachmentResponse {
 	return AttachmentResponse{
 		PublicID:    a.FilePublicID,
 		Filename:    a.Filename,
 		ContentType: a.ContentType,
 		SizeBytes:   a.SizeBytes,
-		URL:         a.URL,
+		Checksum:    a.Checksum,
+		DownloadURL: "/activities/" + activityPublicID + "/attachments/" + a.FilePublicID + "/download",
 	}
 }
 
@@ -205,6 +247,47 @@ func ActivityItemToResponse(item *entity.ActivityItem) ActivityItemResponse {
 	}
 }
 
+type ActivityItemDetailResponse struct {
+	ActivityItemResponse
+	Question *QuestionResponse `json:"question,omitempty"`
+}
+
+// ActivityItemDetailToResponse maps an item with its hydrated question. When
+// showAnswers is false the question is serialized without its answer key.
+func ActivityItemDetailToResponse(item *entity.ActivityItem, showAnswers bool) ActivityItemDetailResponse {
+	resp := ActivityItemDetailResponse{ActivityItemResponse: ActivityItemToResponse(item)}
+	if item.Question == nil {
+		return resp
+	}
+
+	var q QuestionResponse
+	if showAnswers {
+		q = QuestionToResponse(item.Question)
+	} else {
+		q = QuestionToStudentResponse(item.Question)
+	}
+	resp.Question = &q
+	return resp
+}
+
+// ActivityItemListResponse carries the (possibly filtered) items plus the
+// number of items of each type in the whole activity.
+type ActivityItemListResponse struct {
+	Data   []ActivityItemResponse `json:"data"`
+	Counts map[string]int         `json:"counts"`
+}
+
+func ActivityItemListToResponse(items []entity.ActivityItem, counts map[entity.ActivityItemType]int) ActivityItemListResponse {
+	resp := ActivityItemListResponse{
+		Data:   ActivityItemsToResponse(items),
+		Counts: make(map[string]int, len(entity.ActivityItemTypes)),
+	}
+	for _, t := range entity.ActivityItemTypes {
+		resp.Counts[string(t)] = counts[t]
+	}
+	return resp
+}
+
 func ActivityItemsToResponse(items []entity.ActivityItem) []ActivityItemResponse {
 	result := make([]ActivityItemResponse, len(items))
 	for i := range items {
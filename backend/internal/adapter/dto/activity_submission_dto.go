@@ -19,16 +19,23 @@ type ReviewActivitySubmissionRequest struct {
 	FeedbackNotes *string `json:"feedback_notes,omitempty"`
 }
 
+type ReopenActivitySubmissionRequest struct {
+	Reason string `json:"reason"`
+}
+
 type ActivitySubmissionResponse struct {
-	PublicID      string                        `json:"id"`
-	Activity      ActivitySubmissionActivityRef `json:"activity"`
-	User          ActivitySubmissionUserRef     `json:"user"`
-	Status        string                        `json:"status"`
-	Notes         *string                       `json:"notes,omitempty"`
-	FeedbackNotes *string                       `json:"feedback_notes,omitempty"`
-	ReviewedAt    *time.Time                    `json:"reviewed_at,omitempty"`
-	ReviewedBy    *ActivitySubmissionUserRef    `json:"reviewed_by,omitempty"`
-	SubmittedAt   time.Time                     `json:"submitted_at"`
+	PublicID        string                        `json:"id"`
+	Activity        ActivitySubmissionActivityRef `json:"activity"`
+	User            ActivitySubmissionUserRef     `json:"user"`
+	Status          string                        `json:"status"`
+	Notes           *string                       `json:"notes,omitempty"`
+	FeedbackNotes   *string                       `json:"feedback_notes,omitempty"`
+	ReviewedAt      *time.Time                    `json:"reviewed_at,omitempty"`
+	ReviewedBy      *ActivitySubmissionUserRef    `json:"reviewed_by,omitempty"`
+	ReopenReason    *string                       `json:"reopen_reason,omitempty"`
+	ReopenedAt      *time.Time                    `json:"reopened_at,omitempty"`
+	AttachmentCount int                           `json:"attachment_count"`
+	SubmittedAt     time.Time                     `json:"submitted_at"`
 }
 
 type ActivitySubmissionActivityRef struct {
@@ -62,11 +69,14 @@ func ActivitySubmissionToResponse(s *entity.ActivitySubmission) ActivitySubmissi
 			Name:      s.UserName,
 			AvatarURL: s.UserAvatarURL,
 		},
-		Status:        string(s.Status),
-		Notes:         s.Notes,
-		FeedbackNotes: s.FeedbackNotes,
-		ReviewedAt:    s.ReviewedAt,
-		SubmittedAt:   s.SubmittedAt,
+		Status:          string(s.Status),
+		Notes:           s.Notes,
+		FeedbackNotes:   s.FeedbackNotes,
+		ReviewedAt:      s.ReviewedAt,
+		ReopenReason:    s.ReopenReason,
+		ReopenedAt:      s.ReopenedAt,
+		AttachmentCount: s.AttachmentCount,
+		SubmittedAt:     s.SubmittedAt,
 	}
 	if s.ReviewerPublicID != nil && s.ReviewerName != nil {
 		resp.ReviewedBy = &ActivitySubmissionUserRef{
@@ -85,6 +95,48 @@ func ActivitySubmissionsToResponse(submissions []entity.ActivitySubmission) []Ac
 	return result
 }
 
+// ==========================================
+// Review Queue
+// ==========================================
+
+type ReviewQueueItemResponse struct {
+	ActivitySubmissionResponse
+	Group           ReviewQueueGroupRef `json:"group"`
+	ActivityDueDate time.Time           `json:"activity_due_date"`
+}
+
+type ReviewQueueGroupRef struct {
+	PublicID string `json:"id"`
+	Name     string `json:"name"`
+}
+
+type ReviewQueueListResponse struct {
+	Data       []ReviewQueueItemResponse `json:"data"`
+	PageNumber int                       `json:"page_number"`
+	PageSize   int                       `json:"page_size"`
+	TotalItems int                       `json:"total_items"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+type ReviewQueueCountResponse struct {
+	Count int `json:"count"`
+}
+
+func ReviewQueueToResponse(items []entity.ReviewQueueItem) []ReviewQueueItemResponse {
+	result := make([]ReviewQueueItemResponse, len(items))
+	for i := range items {
+		result[i] = ReviewQueueItemResponse{
+			ActivitySubmissionResponse: ActivitySubmissionToResponse(&items[i].Submission),
+			Group: ReviewQueueGroupRef{
+				PublicID: items[i].GroupPublicID,
+				Name:     items[i].GroupName,
+			},
+			ActivityDueDate: items[i].ActivityDueDate,
+		}
+	}
+	return result
+}
+
 // ==========================================
 // Update Notes
 // ==========================================
@@ -98,10 +150,26 @@ type UpdateActivitySubmissionNotesRequest struct {
 // ==========================================
 
 type QuestionStatusResponse struct {
-	QuestionID string `json:"question_id"`
-	Passed     bool   `json:"passed"`
-	Attempts   int    `json:"attempts"`
-	LastScore  *int   `json:"last_score,omitempty"`
+	QuestionID        string `json:"question_id"`
+	Passed            bool   `json:"passed"`
+	Attempts          int    `json:"attempts"`
+	AttemptsRemaining *int   `json:"attempts_remaining,omitempty"`
+	LastScore         *int   `json:"last_score,omitempty"`
+}
+
+// ==========================================
+// Member Progress
+// ==========================================
+
+type ActivityProgressResponse struct {
+	ActivityID       string                   `json:"activity_id"`
+	Title            string                   `json:"title"`
+	DueDate          time.Time                `json:"due_date"`
+	SubmissionStatus *string                  `json:"submission_status"`
+	TotalQuestions   int                      `json:"total_questions"`
+	PassedQuestions  int                      `json:"passed_questions"`
+	PassRate         *float64                 `json:"pass_rate"`
+	Questions        []QuestionStatusResponse `json:"questions"`
 }
 
 // ==========================================
@@ -109,27 +177,29 @@ type QuestionStatusResponse struct {
 // ==========================================
 
 type SubmissionAttachmentResponse struct {
-	FileID      string `json:"id"`
-	Filename    string `json:"filename"`
-	ContentType string `json:"content_type"`
-	SizeBytes   int64  `json:"size_bytes"`
-	URL         string `json:"url"`
+	FileID      string  `json:"id"`
+	Filename    string  `json:"filename"`
+	ContentType string  `json:"content_type"`
+	SizeBytes   int64   `json:"size_bytes"`
+	Checksum    *string `json:"checksum,omitempty"`
+	DownloadURL string  `json:"download_url"` // API path that checks access, then redirects
 }
 
-func SubmissionAttachmentToResponse(a *entity.ActivitySubmissionAttachment) SubmissionAttachmentResponse {
+func SubmissionAttachmentToResponse(submissionPublicID string, a *entity.ActivitySubmissionAttachment) SubmissionAttachmentResponse {
 	return SubmissionAttachmentResponse{
 		FileID:      a.FilePublicID,
 		Filename:    a.Filename,
 		ContentType: a.ContentType,
 		SizeBytes:   a.SizeBytes,
-		URL:         a.URL,
+		Checksum:    a.Checksum,
+		DownloadURL: "/activity-submissions/" + submissionPublicID + "/attachments/" + a.FilePublicID + "/download",
 	}
 }
 
-func SubmissionAttachmentsToResponse(attachments []entity.ActivitySubmissionAttachment) []SubmissionAttachmentResponse {
+func SubmissionAttachmentsToResponse(submissionPublicID string, attachments []entity.ActivitySubmissionAttachment) []SubmissionAttachmentResponse {
 	result := make([]SubmissionAttachmentResponse, len(attachments))
 	for i := range attachments {
-		result[i] = SubmissionAttachmentToResponse(&attachments[i])
+		result[i] = SubmissionAttachmentToResponse(submissionPublicID, &attachments[i])
 	}
 	return result
 }
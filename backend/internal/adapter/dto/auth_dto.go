@@ -6,8 +6,10 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"`
+	Token            string `json:"token"`
+	CSRFToken        string `json:"csrf_token"`
+	ExpiresAt        int64  `json:"expires_at"`         // access token expiry; refresh before it
+	SessionExpiresAt int64  `json:"session_expires_at"` // sign in again after it
 }
 
 type VerifyEmailRequest struct {
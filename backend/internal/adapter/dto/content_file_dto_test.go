@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"testing"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+func TestContentFilesUseDownloadRoute(t *testing.T) {
+	fileID := 7
+
+	handout := HandoutToResponse(&entity.Handout{PublicID: "h1", FileKey: "handouts/secret.pdf"})
+	if got, want := handout.File.DownloadURL, "/handouts/h1/download"; got != want {
+		t.Errorf("handout download_url = %q, want %q", got, want)
+	}
+
+	uploaded := OpenExerciseListToResponse(&entity.OpenExerciseList{PublicID: "l1", FileID: &fileID, FileKey: "lists/secret.pdf"})
+	if uploaded.File == nil || uploaded.File.DownloadURL != "/exercise-lists/l1/download" {
+		t.Errorf("uploaded list file = %+v, want the download route", uploaded.File)
+	}
+	if uploaded.FileURL != nil {
+		t.Errorf("uploaded list file_url = %q, want none", *uploaded.FileURL)
+	}
+
+	external := "https://example.com/list.pdf"
+	linked := OpenExerciseListToResponse(&entity.OpenExerciseList{PublicID: "l2", FileURL: &external})
+	if linked.File != nil || linked.FileURL == nil || *linked.FileURL != external {
+		t.Errorf("linked list = %+v, want only the external file_url", linked)
+	}
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "proximos-passos/backend/internal/domain/entity"
+
+// ContentCreatorResponse identifies who created a piece of content without
+// exposing their email.
+type ContentCreatorResponse struct {
+	PublicID string `json:"id"`
+	Name     string `json:"name"`
+}
+
+func contentCreatorToResponse(publicID, name string) *ContentCreatorResponse {
+	if publicID == "" {
+		return nil
+	}
+	return &ContentCreatorResponse{PublicID: publicID, Name: name}
+}
+
+type ContentUsageResponse struct {
+	ActivityID    string `json:"activity_id"`
+	ActivityTitle string `json:"activity_title"`
+	GroupID       string `json:"group_id"`
+	GroupName     string `json:"group_name"`
+}
+
+type ContentUsageListResponse struct {
+	Data []ContentUsageResponse `json:"data"`
+}
+
+func ContentUsagesToResponse(refs []entity.ActivityRef) ContentUsageListResponse {
+	data := make([]ContentUsageResponse, len(refs))
+	for i, ref := range refs {
+		data[i] = ContentUsageResponse{
+			ActivityID:    ref.PublicID,
+			ActivityTitle: ref.Title,
+			GroupID:       ref.GroupPublicID,
+			GroupName:     ref.GroupName,
+		}
+	}
+	return ContentUsageListResponse{Data: data}
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type OutboxEmailResponse struct {
+	PublicID      string     `json:"id"`
+	Recipient     string     `json:"recipient"`
+	Subject       string     `json:"subject"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type OutboxEmailListResponse struct {
+	Data       []OutboxEmailResponse `json:"data"`
+	PageNumber int                   `json:"page_number"`
+	PageSize   int                   `json:"page_size"`
+	TotalItems int                   `json:"total_items"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+func OutboxEmailToResponse(e *entity.OutboxEmail) OutboxEmailResponse {
+	return OutboxEmailResponse{
+		PublicID:      e.PublicID,
+		Recipient:     e.Recipient,
+		Subject:       e.Subject,
+		Status:        string(e.Status),
+		Attempts:      e.Attempts,
+		LastError:     e.LastError,
+		NextAttemptAt: e.NextAttemptAt,
+		SentAt:        e.SentAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+func OutboxEmailsToResponse(emails []entity.OutboxEmail) []OutboxEmailResponse {
+	result := make([]OutboxEmailResponse, len(emails))
+	for i := range emails {
+		result[i] = OutboxEmailToResponse(&emails[i])
+	}
+	return result
+}
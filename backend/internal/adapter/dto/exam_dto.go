@@ -11,6 +11,7 @@ type CreateExamRequest struct {
 	Title         string  `json:"title"`
 	Description   *string `json:"description,omitempty"`
 	Year          int     `json:"year"`
+	Phase         *int    `json:"phase,omitempty"`
 }
 
 type UpdateExamRequest struct {
@@ -18,6 +19,7 @@ type UpdateExamRequest struct {
 	Title         *string `json:"title,omitempty"`
 	Description   *string `json:"description,omitempty"`
 	Year          *int    `json:"year,omitempty"`
+	Phase         *int    `json:"phase,omitempty"`
 }
 
 type ExamInstitutionResponse struct {
@@ -27,14 +29,16 @@ type ExamInstitutionResponse struct {
 }
 
 type ExamResponse struct {
-	PublicID    string                  `json:"id"`
-	Institution ExamInstitutionResponse `json:"institution"`
-	Title       string                  `json:"title"`
-	Description *string                 `json:"description,omitempty"`
-	Year        int                     `json:"year"`
-	IsActive    bool                    `json:"is_active"`
-	CreatedAt   time.Time               `json:"created_at"`
-	UpdatedAt   time.Time               `json:"updated_at"`
+	PublicID      string                  `json:"id"`
+	Institution   ExamInstitutionResponse `json:"institution"`
+	Title         string                  `json:"title"`
+	Description   *string                 `json:"description,omitempty"`
+	Year          int                     `json:"year"`
+	Phase         *int                    `json:"phase,omitempty"`
+	QuestionCount int                     `json:"question_count"`
+	IsActive      bool                    `json:"is_active"`
+	CreatedAt     time.Time               `json:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at"`
 }
 
 type ExamListResponse struct {
@@ -59,12 +63,14 @@ func ExamToResponse(e *entity.Exam) ExamResponse {
 			Name:     e.InstitutionName,
 			Acronym:  e.InstitutionAcronym,
 		},
-		Title:       e.Title,
-		Description: e.Description,
-		Year:        e.Year,
-		IsActive:    e.IsActive,
-		CreatedAt:   e.CreatedAt,
-		UpdatedAt:   e.UpdatedAt,
+		Title:         e.Title,
+		Description:   e.Description,
+		Year:          e.Year,
+		Phase:         e.Phase,
+		QuestionCount: e.QuestionCount,
+		IsActive:      e.IsActive,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/permissions"
 )
 
 // ==========================================
@@ -18,22 +19,46 @@ type CreateGroupRequest struct {
 }
 
 type UpdateGroupRequest struct {
-	Name           *string `json:"name,omitempty"`
-	Description    *string `json:"description,omitempty"`
-	AccessType     *string `json:"access_type,omitempty"`
-	VisibilityType *string `json:"visibility_type,omitempty"`
+	Name                 *string `json:"name,omitempty"`
+	Description          *string `json:"description,omitempty"`
+	AccessType           *string `json:"access_type,omitempty"`
+	VisibilityType       *string `json:"visibility_type,omitempty"`
+	LeaderboardEnabled   *bool   `json:"leaderboard_enabled,omitempty"`
+	LeaderboardAnonymous *bool   `json:"leaderboard_anonymous,omitempty"`
+	AllowPastDue         *bool   `json:"allow_past_due,omitempty"`
+	TimeZone             *string `json:"time_zone,omitempty"`
 }
 
 type GroupResponse struct {
-	PublicID       string    `json:"id"`
-	Name           string    `json:"name"`
-	Description    *string   `json:"description,omitempty"`
-	AccessType     string    `json:"access_type"`
-	VisibilityType string    `json:"visibility_type"`
-	ThumbnailURL   *string   `json:"thumbnail_url,omitempty"`
-	IsActive       bool      `json:"is_active"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	PublicID             string    `json:"id"`
+	Name                 string    `json:"name"`
+	Description          *string   `json:"description,omitempty"`
+	AccessType           string    `json:"access_type"`
+	VisibilityType       string    `json:"visibility_type"`
+	ThumbnailURL         *string   `json:"thumbnail_url,omitempty"`
+	LeaderboardEnabled   bool      `json:"leaderboard_enabled"`
+	LeaderboardAnonymous bool      `json:"leaderboard_anonymous"`
+	AllowPastDue         bool      `json:"allow_past_due"`
+	TimeZone             string    `json:"time_zone"`
+	PendingMembersCount  *int      `json:"pending_members_count,omitempty"` // only for admins and group staff
+	JoinCode             *string   `json:"join_code,omitempty"`             // only for admins and group staff
+	CanEdit              *bool     `json:"can_edit,omitempty"`              // only on single-group reads
+	CanManageMembers     *bool     `json:"can_manage_members,omitempty"`    // only on single-group reads
+	IsActive             bool      `json:"is_active"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+type GroupPreviewResponse struct {
+	GroupResponse
+	MemberCount int                      `json:"member_count"`
+	AdminCount  int                      `json:"admin_count"`
+	Membership  MembershipStatusResponse `json:"membership"`
+}
+
+type MembershipStatusResponse struct {
+	Status string `json:"status"`
+	Role   string `json:"role,omitempty"`
 }
 
 type GroupListResponse struct {
@@ -46,18 +71,32 @@ type GroupListResponse struct {
 
 func GroupToResponse(g *entity.Group) GroupResponse {
 	return GroupResponse{
-		PublicID:       g.PublicID,
-		Name:           g.Name,
-		Description:    g.Description,
-		AccessType:     string(g.AccessType),
-		VisibilityType: string(g.VisibilityType),
-		ThumbnailURL:   g.ThumbnailURL,
-		IsActive:       g.IsActive,
-		CreatedAt:      g.CreatedAt,
-		UpdatedAt:      g.UpdatedAt,
+		PublicID:             g.PublicID,
+		Name:                 g.Name,
+		Description:          g.Description,
+		AccessType:           string(g.AccessType),
+		VisibilityType:       string(g.VisibilityType),
+		ThumbnailURL:         g.ThumbnailURL,
+		LeaderboardEnabled:   g.LeaderboardEnabled,
+		LeaderboardAnonymous: g.LeaderboardAnonymous,
+		AllowPastDue:         g.AllowPastDue,
+		TimeZone:             g.TimeZone,
+		IsActive:             g.IsActive,
+		CreatedAt:            g.CreatedAt,
+		UpdatedAt:            g.UpdatedAt,
 	}
 }
 
+// GroupToResponseFor maps g like GroupToResponse and adds the requester's
+// capability flags.
+func GroupToResponseFor(g *entity.Group, requester permissions.Requester) GroupResponse {
+	resp := GroupToResponse(g)
+	canEdit, canManageMembers := requester.CanEditGroup(), requester.CanManageMembers()
+	resp.CanEdit = &canEdit
+	resp.CanManageMembers = &canManageMembers
+	return resp
+}
+
 func GroupsToResponse(groups []entity.Group) []GroupResponse {
 	result := make([]GroupResponse, len(groups))
 	for i := range groups {
@@ -75,10 +114,55 @@ type AddMemberRequest struct {
 	Role         string `json:"role,omitempty"`
 }
 
+type ApproveMembersRequest struct {
+	UserPublicIDs []string `json:"user_ids"`
+}
+
+type BulkApproveResponse struct {
+	Approved int `json:"approved"`
+	Skipped  int `json:"skipped"`
+}
+
 type UpdateMemberRoleRequest struct {
 	Role string `json:"role"`
 }
 
+type JoinGroupByCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type JoinCodeResponse struct {
+	JoinCode string `json:"join_code"`
+}
+
+// UpdateUploadSettingsRequest replaces a group's submission upload settings.
+// A null or missing field restores the platform default.
+type UpdateUploadSettingsRequest struct {
+	MaxSizeBytes *int64    `json:"max_size_bytes"`
+	AllowedTypes *[]string `json:"allowed_types"`
+}
+
+type UploadSettingsResponse struct {
+	MaxSizeBytes       int64    `json:"max_size_bytes"`
+	AllowedTypes       []string `json:"allowed_types"`
+	MaxSizeCeiling     int64    `json:"max_size_ceiling_bytes"`
+	CustomMaxSize      bool     `json:"custom_max_size"`
+	CustomAllowedTypes bool     `json:"custom_allowed_types"`
+}
+
+type MemberImportRowResponse struct {
+	Row    int     `json:"row"`
+	Email  string  `json:"email"`
+	Role   string  `json:"role"`
+	Status string  `json:"status"`
+	Reason *string `json:"reason,omitempty"`
+}
+
+type MemberImportResponse struct {
+	Results []MemberImportRowResponse `json:"results"`
+	Summary map[string]int            `json:"summary"` // row count per status
+}
+
 type GroupMemberResponse struct {
 	UserPublicID string    `json:"user_id"`
 	Name         string    `json:"name"`
@@ -26,18 +26,20 @@ type HandoutFileResponse struct {
 	Filename    string `json:"filename"`
 	ContentType string `json:"content_type"`
 	SizeBytes   int64  `json:"size_bytes"`
-	URL         string `json:"url"`
+	DownloadURL string `json:"download_url"` // API path that streams the file and counts the download
 }
 
 type HandoutResponse struct {
-	PublicID    string                 `json:"id"`
-	Title       string                 `json:"title"`
-	Description *string                `json:"description,omitempty"`
-	File        HandoutFileResponse    `json:"file"`
-	Topics      []HandoutTopicResponse `json:"topics"`
-	IsActive    bool                   `json:"is_active"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	PublicID      string                  `json:"id"`
+	Title         string                  `json:"title"`
+	Description   *string                 `json:"description,omitempty"`
+	File          HandoutFileResponse     `json:"file"`
+	Topics        []HandoutTopicResponse  `json:"topics"`
+	DownloadCount int                     `json:"download_count"`
+	IsActive      bool                    `json:"is_active"`
+	CreatedAt     time.Time               `json:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at"`
+	CreatedBy     *ContentCreatorResponse `json:"created_by,omitempty"`
 }
 
 type HandoutListResponse struct {
@@ -70,12 +72,14 @@ func HandoutToResponse(h *entity.Handout) HandoutResponse {
 			Filename:    h.Filename,
 			ContentType: h.ContentType,
 			SizeBytes:   h.SizeBytes,
-			URL:         h.FileURL,
+			DownloadURL: "/handouts/" + h.PublicID + "/download",
 		},
-		Topics:    topics,
-		IsActive:  h.IsActive,
-		CreatedAt: h.CreatedAt,
-		UpdatedAt: h.UpdatedAt,
+		Topics:        topics,
+		DownloadCount: h.DownloadCount,
+		IsActive:      h.IsActive,
+		CreatedAt:     h.CreatedAt,
+		UpdatedAt:     h.UpdatedAt,
+		CreatedBy:     contentCreatorToResponse(h.CreatedByPublicID, h.CreatedByName),
 	}
 }
 
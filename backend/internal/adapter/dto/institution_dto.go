@@ -20,6 +20,7 @@ type InstitutionResponse struct {
 	PublicID  string    `json:"id"`
 	Name      string    `json:"name"`
 	Acronym   string    `json:"acronym"`
+	LogoURL   *string   `json:"logo_url,omitempty"`
 	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -38,6 +39,7 @@ func InstitutionToResponse(i *entity.Institution) InstitutionResponse {
 		PublicID:  i.PublicID,
 		Name:      i.Name,
 		Acronym:   i.Acronym,
+		LogoURL:   i.LogoURL,
 		IsActive:  i.IsActive,
 		CreatedAt: i.CreatedAt,
 		UpdatedAt: i.UpdatedAt,
@@ -27,19 +27,21 @@ type OpenExerciseListFileResponse struct {
 	Filename    string `json:"filename"`
 	ContentType string `json:"content_type"`
 	SizeBytes   int64  `json:"size_bytes"`
-	URL         string `json:"url"`
+	DownloadURL string `json:"download_url"` // API path that streams the file and counts the download
 }
 
 type OpenExerciseListResponse struct {
-	PublicID    string                          `json:"id"`
-	Title       string                          `json:"title"`
-	Description *string                         `json:"description,omitempty"`
-	File        *OpenExerciseListFileResponse   `json:"file,omitempty"`
-	FileURL     *string                         `json:"file_url,omitempty"`
-	Topics      []OpenExerciseListTopicResponse `json:"topics"`
-	IsActive    bool                            `json:"is_active"`
-	CreatedAt   time.Time                       `json:"created_at"`
-	UpdatedAt   time.Time                       `json:"updated_at"`
+	PublicID      string                          `json:"id"`
+	Title         string                          `json:"title"`
+	Description   *string                         `json:"description,omitempty"`
+	File          *OpenExerciseListFileResponse   `json:"file,omitempty"`
+	FileURL       *string                         `json:"file_url,omitempty"`
+	Topics        []OpenExerciseListTopicResponse `json:"topics"`
+	DownloadCount int                             `json:"download_count"`
+	IsActive      bool                            `json:"is_active"`
+	CreatedAt     time.Time                       `json:"created_at"`
+	UpdatedAt     time.Time                       `json:"updated_at"`
+	CreatedBy     *ContentCreatorResponse         `json:"created_by,omitempty"`
 }
 
 type OpenExerciseListListResponse struct {
@@ -64,14 +66,16 @@ func OpenExerciseListToResponse(oel *entity.OpenExerciseList) OpenExerciseListRe
 	}
 
 	resp := OpenExerciseListResponse{
-		PublicID:    oel.PublicID,
-		Title:       oel.Title,
-		Description: oel.Description,
-		FileURL:     oel.FileURL,
-		Topics:      topics,
-		IsActive:    oel.IsActive,
-		CreatedAt:   oel.CreatedAt,
-		UpdatedAt:   oel.UpdatedAt,
+		PublicID:      oel.PublicID,
+		Title:         oel.Title,
+		Description:   oel.Description,
+		FileURL:       oel.FileURL,
+		Topics:        topics,
+		DownloadCount: oel.DownloadCount,
+		IsActive:      oel.IsActive,
+		CreatedAt:     oel.CreatedAt,
+		UpdatedAt:     oel.UpdatedAt,
+		CreatedBy:     contentCreatorToResponse(oel.CreatedByPublicID, oel.CreatedByName),
 	}
 
 	if oel.FileID != nil {
@@ -80,17 +84,10 @@ func OpenExerciseListToResponse(oel *entity.OpenExerciseList) OpenExerciseListRe
 			Filename:    oel.Filename,
 			ContentType: oel.ContentType,
 			SizeBytes:   oel.SizeBytes,
-			URL:         oel.ResolvedURL,
+			DownloadURL: "/exercise-lists/" + oel.PublicID + "/download",
 		}
 	}
 
-	if oel.FileURL != nil {
-		resp.FileURL = oel.FileURL
-	} else if oel.FileID != nil {
-		url := oel.ResolvedURL
-		resp.FileURL = &url
-	}
-
 	return resp
 }
 
@@ -0,0 +1,134 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+// Optional is a PATCH body field that tells an omitted key from an explicit
+// null, which pointer fields cannot do.
+type Optional[T any] struct {
+	Set   bool // the key was present
+	Value *T   // nil when the key was omitted or null
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		o.Value = nil
+		return nil
+	}
+	o.Value = new(T)
+	return json.Unmarshal(data, o.Value)
+}
+
+// IsNull reports whether the key was sent as null.
+func (o Optional[T]) IsNull() bool {
+	return o.Set && o.Value == nil
+}
+
+// required returns the field's value for a column that cannot be cleared,
+// recording an explicit null on v.
+func required[T any](v *apperror.ValidationError, field string, o Optional[T]) *T {
+	if o.IsNull() {
+		v.Add(field, "This field cannot be null.")
+	}
+	return o.Value
+}
+
+// clearable returns the field's value for an optional text column. An
+// explicit null becomes the empty string, which the update use cases treat
+// as clearing the column.
+func clearable(o Optional[string]) *string {
+	if o.IsNull() {
+		empty := ""
+		return &empty
+	}
+	return o.Value
+}
+
+type PatchGroupRequest struct {
+	Name                 Optional[string] `json:"name" swaggertype:"string"`
+	Description          Optional[string] `json:"description" swaggertype:"string"` // null clears it
+	AccessType           Optional[string] `json:"access_type" swaggertype:"string"`
+	VisibilityType       Optional[string] `json:"visibility_type" swaggertype:"string"`
+	LeaderboardEnabled   Optional[bool]   `json:"leaderboard_enabled" swaggertype:"boolean"`
+	LeaderboardAnonymous Optional[bool]   `json:"leaderboard_anonymous" swaggertype:"boolean"`
+	AllowPastDue         Optional[bool]   `json:"allow_past_due" swaggertype:"boolean"`
+	TimeZone             Optional[string] `json:"time_zone" swaggertype:"string"`
+}
+
+// Update returns the equivalent PUT body.
+func (p PatchGroupRequest) Update() (UpdateGroupRequest, error) {
+	v := apperror.NewValidationError()
+	req := UpdateGroupRequest{
+		Name:                 required(v, "name", p.Name),
+		Description:          clearable(p.Description),
+		AccessType:           required(v, "access_type", p.AccessType),
+		VisibilityType:       required(v, "visibility_type", p.VisibilityType),
+		LeaderboardEnabled:   required(v, "leaderboard_enabled", p.LeaderboardEnabled),
+		LeaderboardAnonymous: required(v, "leaderboard_anonymous", p.LeaderboardAnonymous),
+		AllowPastDue:         required(v, "allow_past_due", p.AllowPastDue),
+		TimeZone:             required(v, "time_zone", p.TimeZone),
+	}
+	return req, v.Err()
+}
+
+type PatchActivityRequest struct {
+	Title       Optional[string] `json:"title" swaggertype:"string"`
+	Description Optional[string] `json:"description" swaggertype:"string"` // null clears it
+	DueDate     Optional[string] `json:"due_date" swaggertype:"string"`
+}
+
+// Update returns the equivalent PUT body.
+func (p PatchActivityRequest) Update() (UpdateActivityRequest, error) {
+	v := apperror.NewValidationError()
+	req := UpdateActivityRequest{
+		Title:       required(v, "title", p.Title),
+		Description: clearable(p.Description),
+		DueDate:     required(v, "due_date", p.DueDate),
+	}
+	return req, v.Err()
+}
+
+type PatchUserRequest struct {
+	Name      Optional[string] `json:"name" swaggertype:"string"`
+	Email     Optional[string] `json:"email" swaggertype:"string"`
+	AvatarURL Optional[string] `json:"avatar_url" swaggertype:"string"` // null clears it
+	Role      Optional[string] `json:"role" swaggertype:"string"`
+	IsActive  Optional[bool]   `json:"is_active" swaggertype:"boolean"`
+}
+
+// Update returns the equivalent PUT body.
+func (p PatchUserRequest) Update() (UpdateUserRequest, error) {
+	v := apperror.NewValidationError()
+	req := UpdateUserRequest{
+		Name:      required(v, "name", p.Name),
+		Email:     required(v, "email", p.Email),
+		AvatarURL: clearable(p.AvatarURL),
+		Role:      required(v, "role", p.Role),
+		IsActive:  required(v, "is_active", p.IsActive),
+	}
+	return req, v.Err()
+}
+
+type PatchMeRequest struct {
+	Name Optional[string] `json:"name" swaggertype:"string"`
+	Lang Optional[string] `json:"lang" swaggertype:"string"` // null clears the preference
+
+	ActivityReminders Optional[bool] `json:"activity_reminders" swaggertype:"boolean"`
+}
+
+// Update returns the equivalent PUT body.
+func (p PatchMeRequest) Update() (UpdateMeRequest, error) {
+	v := apperror.NewValidationError()
+	req := UpdateMeRequest{
+		Name: required(v, "name", p.Name),
+		Lang: clearable(p.Lang),
+
+		ActivityReminders: required(v, "activity_reminders", p.ActivityReminders),
+	}
+	return req, v.Err()
+}
@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func TestOptionalTellsOmittedFromNull(t *testing.T) {
+	var p PatchMeRequest
+	if err := json.Unmarshal([]byte(`{"lang":null,"name":"Ana"}`), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	if p.ActivityReminders.Set {
+		t.Error("an omitted key was marked as set")
+	}
+	if !p.Lang.IsNull() {
+		t.Error("an explicit null was not reported as null")
+	}
+	if p.Name.IsNull() || p.Name.Value == nil || *p.Name.Value != "Ana" {
+		t.Errorf("name = %+v, want Ana", p.Name)
+	}
+}
+
+func TestPatchMeRequestUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantInvalid []string
+		check       func(t *testing.T, req UpdateMeRequest)
+	}{
+		{
+			name: "omitted fields stay unchanged",
+			body: `{}`,
+			check: func(t *testing.T, req UpdateMeRequest) {
+				if req.Name != nil || req.Lang != nil || req.ActivityReminders != nil {
+					t.Errorf("got %+v, want every field nil", req)
+				}
+			},
+		},
+		{
+			name: "null clears the language",
+			body: `{"lang":null}`,
+			check: func(t *testing.T, req UpdateMeRequest) {
+				if req.Lang == nil || *req.Lang != "" {
+					t.Errorf("lang = %v, want the empty string that clears it", req.Lang)
+				}
+			},
+		},
+		{
+			name: "values pass through",
+			body: `{"name":"Ana","activity_reminders":false}`,
+			check: func(t *testing.T, req UpdateMeRequest) {
+				if req.Name == nil || *req.Name != "Ana" || req.ActivityReminders == nil || *req.ActivityReminders {
+					t.Errorf("got %+v", req)
+				}
+			},
+		},
+		{
+			name:        "null on required fields",
+			body:        `{"name":null,"activity_reminders":null}`,
+			wantInvalid: []string{"name", "activity_reminders"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p PatchMeRequest
+			if err := json.Unmarshal([]byte(tt.body), &p); err != nil {
+				t.Fatal(err)
+			}
+			req, err := p.Update()
+
+			if tt.wantInvalid == nil {
+				if err != nil {
+					t.Fatalf("Update: %v", err)
+				}
+				tt.check(t, req)
+				return
+			}
+			var appErr *apperror.AppError
+			if !errors.As(err, &appErr) || appErr.Code != apperror.CodeInvalidInput {
+				t.Fatalf("err = %v, want INVALID_INPUT", err)
+			}
+			for _, field := range tt.wantInvalid {
+				if _, ok := appErr.Errors[field]; !ok {
+					t.Errorf("no validation message for %s", field)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchGroupRequestRejectsNullRequiredFields(t *testing.T) {
+	var p PatchGroupRequest
+	if err := json.Unmarshal([]byte(`{"description":null,"time_zone":null}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	req, err := p.Update()
+
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("err = %v, want a validation error", err)
+	}
+	if _, ok := appErr.Errors["time_zone"]; !ok || len(appErr.Errors) != 1 {
+		t.Errorf("errors = %v, want only time_zone", appErr.Errors)
+	}
+	if req.Description == nil || *req.Description != "" {
+		t.Errorf("description = %v, want it cleared", req.Description)
+	}
+}
@@ -14,7 +14,11 @@ type UpdateQuestionRequest struct {
 	Type               *string               `json:"type,omitempty"`
 	Statement          *string               `json:"statement,omitempty"`
 	ExpectedAnswerText *string               `json:"expected_answer_text,omitempty"`
+	ExpectedKeywords   []string              `json:"expected_keywords,omitempty"`
 	PassingScore       *int                  `json:"passing_score,omitempty"`
+	MaxAttempts        *int                  `json:"max_attempts,omitempty"`
+	Difficulty         *string               `json:"difficulty,omitempty"`
+	Tags               []string              `json:"tags,omitempty"`
 	ExamID             *string               `json:"exam_id,omitempty"`
 	TopicIDs           []string              `json:"topic_ids,omitempty"`
 	Options            []QuestionOptionInput `json:"options,omitempty"`
@@ -50,7 +54,9 @@ type QuestionImageResponse struct {
 	Filename    string `json:"filename"`
 	ContentType string `json:"content_type"`
 	SizeBytes   int64  `json:"size_bytes"`
-	URL         string `json:"url"`
+	URL         string `json:"url"`          // the WebP copy for clients accepting image/webp
+	OriginalURL string `json:"original_url"` // always the uploaded file
+	WebPURL     string `json:"webp_url,omitempty"`
 }
 
 type QuestionOptionResponse struct {
@@ -58,7 +64,7 @@ type QuestionOptionResponse struct {
 	OriginalOrder int                     `json:"original_order"`
 	Text          *string                 `json:"text,omitempty"`
 	Images        []QuestionImageResponse `json:"images"`
-	IsCorrect     bool                    `json:"is_correct"`
+	IsCorrect     *bool                   `json:"is_correct,omitempty"` // nil in student responses
 }
 
 type QuestionExamResponse struct {
@@ -74,11 +80,16 @@ type QuestionResponse struct {
 	Type               string                   `json:"type"`
 	Statement          string                   `json:"statement"`
 	ExpectedAnswerText *string                  `json:"expected_answer_text,omitempty"`
+	ExpectedKeywords   []string                 `json:"expected_keywords,omitempty"`
 	PassingScore       *int                     `json:"passing_score,omitempty"`
+	MaxAttempts        *int                     `json:"max_attempts,omitempty"`
+	Difficulty         *string                  `json:"difficulty,omitempty"`
 	Exam               *QuestionExamResponse    `json:"exam"`
 	Images             []QuestionImageResponse  `json:"images"`
 	Options            []QuestionOptionResponse `json:"options"`
 	Topics             []QuestionTopicResponse  `json:"topics"`
+	Tags               []string                 `json:"tags"`
+	ArchivedAt         *time.Time               `json:"archived_at,omitempty"`
 	IsActive           bool                     `json:"is_active"`
 	MedianDifficulty   *float64                 `json:"median_difficulty,omitempty"`
 	MedianLogic        *float64                 `json:"median_logic,omitempty"`
@@ -86,6 +97,18 @@ type QuestionResponse struct {
 	MedianTheory       *float64                 `json:"median_theory,omitempty"`
 	CreatedAt          time.Time                `json:"created_at"`
 	UpdatedAt          time.Time                `json:"updated_at"`
+	CreatedBy          *ContentCreatorResponse  `json:"created_by,omitempty"`
+}
+
+type QuestionDuplicateResponse struct {
+	PublicID   string  `json:"id"`
+	Statement  string  `json:"statement"`
+	Similarity float64 `json:"similarity"`
+}
+
+type CreateQuestionResponse struct {
+	QuestionResponse
+	PossibleDuplicates []QuestionDuplicateResponse `json:"possible_duplicates,omitempty"`
 }
 
 type QuestionListResponse struct {
@@ -100,6 +123,37 @@ type QuestionListResponse struct {
 // Mapping functions
 // ==========================================
 
+func questionImageToResponse(img entity.QuestionImage) QuestionImageResponse {
+	return QuestionImageResponse{
+		PublicID:    img.FilePublicID,
+		Filename:    img.Filename,
+		ContentType: img.ContentType,
+		SizeBytes:   img.SizeBytes,
+		URL:         img.URL,
+		OriginalURL: img.URL,
+		WebPURL:     img.WebPURL,
+	}
+}
+
+// PreferWebP points the url of every image that has a WebP copy at that
+// copy. original_url keeps pointing at the uploaded file.
+func (r *QuestionResponse) PreferWebP() {
+	for i := range r.Images {
+		r.Images[i].preferWebP()
+	}
+	for i := range r.Options {
+		for j := range r.Options[i].Images {
+			r.Options[i].Images[j].preferWebP()
+		}
+	}
+}
+
+func (r *QuestionImageResponse) preferWebP() {
+	if r.WebPURL != "" {
+		r.URL = r.WebPURL
+	}
+}
+
 func QuestionToResponse(q *entity.Question) QuestionResponse {
 	topics := make([]QuestionTopicResponse, len(q.Topics))
 	for i, t := range q.Topics {
@@ -111,36 +165,29 @@ func QuestionToResponse(q *entity.Question) QuestionResponse {
 
 	images := make([]QuestionImageResponse, len(q.Images))
 	for i, img := range q.Images {
-		images[i] = QuestionImageResponse{
-			PublicID:    img.FilePublicID,
-			Filename:    img.Filename,
-			ContentType: img.ContentType,
-			SizeBytes:   img.SizeBytes,
-			URL:         img.URL,
-		}
+		images[i] = questionImageToResponse(img)
 	}
 
 	options := make([]QuestionOptionResponse, len(q.Options))
 	for i, opt := range q.Options {
 		optImages := make([]QuestionImageResponse, len(opt.Images))
 		for j, img := range opt.Images {
-			optImages[j] = QuestionImageResponse{
-				PublicID:    img.FilePublicID,
-				Filename:    img.Filename,
-				ContentType: img.ContentType,
-				SizeBytes:   img.SizeBytes,
-				URL:         img.URL,
-			}
+			optImages[j] = questionImageToResponse(img)
 		}
 		options[i] = QuestionOptionResponse{
 			PublicID:      opt.PublicID,
 			OriginalOrder: opt.OriginalOrder,
 			Text:          opt.Text,
 			Images:        optImages,
-			IsCorrect:     opt.IsCorrect,
+			IsCorrect:     &opt.IsCorrect,
 		}
 	}
 
+	tags := q.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
 	var exam *QuestionExamResponse
 	if q.ExamPublicID != "" {
 		exam = &QuestionExamResponse{
@@ -157,11 +204,16 @@ func QuestionToResponse(q *entity.Question) QuestionResponse {
 		Type:               q.Type,
 		Statement:          q.Statement,
 		ExpectedAnswerText: q.ExpectedAnswerText,
+		ExpectedKeywords:   q.ExpectedKeywords,
 		PassingScore:       q.PassingScore,
+		MaxAttempts:        q.MaxAttempts,
+		Difficulty:         q.Difficulty,
 		Exam:               exam,
 		Images:             images,
 		Options:            options,
 		Topics:             topics,
+		Tags:               tags,
+		ArchivedAt:         q.ArchivedAt,
 		IsActive:           q.IsActive,
 		MedianDifficulty:   q.MedianDifficulty,
 		MedianLogic:        q.MedianLogic,
@@ -169,7 +221,36 @@ func QuestionToResponse(q *entity.Question) QuestionResponse {
 		MedianTheory:       q.MedianTheory,
 		CreatedAt:          q.CreatedAt,
 		UpdatedAt:          q.UpdatedAt,
+		CreatedBy:          contentCreatorToResponse(q.CreatedByPublicID, q.CreatedByName),
+	}
+}
+
+type BulkDeleteQuestionsRequest struct {
+	IDs   []string `json:"ids"`
+	Force bool     `json:"force"` // also remove the activity items that reference in-use questions
+}
+
+type QuestionBulkDeleteResultResponse struct {
+	PublicID   string                 `json:"id"`
+	Status     string                 `json:"status"`
+	Activities []ContentUsageResponse `json:"activities,omitempty"`
+}
+
+type QuestionBulkDeleteResponse struct {
+	Results []QuestionBulkDeleteResultResponse `json:"results"`
+	Summary map[string]int                     `json:"summary"` // question count per status
+}
+
+func QuestionDuplicatesToResponse(duplicates []entity.QuestionDuplicate) []QuestionDuplicateResponse {
+	result := make([]QuestionDuplicateResponse, len(duplicates))
+	for i, d := range duplicates {
+		result[i] = QuestionDuplicateResponse{
+			PublicID:   d.PublicID,
+			Statement:  d.Statement,
+			Similarity: d.Similarity,
+		}
 	}
+	return result
 }
 
 func QuestionsToResponse(questions []entity.Question) []QuestionResponse {
@@ -180,6 +261,26 @@ func QuestionsToResponse(questions []entity.Question) []QuestionResponse {
 	return result
 }
 
+// QuestionToStudentResponse maps a question for non-admin users, leaving out
+// the answer key: option correctness, expected answer and keywords.
+func QuestionToStudentResponse(q *entity.Question) QuestionResponse {
+	resp := QuestionToResponse(q)
+	resp.ExpectedAnswerText = nil
+	resp.ExpectedKeywords = nil
+	for i := range resp.Options {
+		resp.Options[i].IsCorrect = nil
+	}
+	return resp
+}
+
+func QuestionsToStudentResponse(questions []entity.Question) []QuestionResponse {
+	result := make([]QuestionResponse, len(questions))
+	for i := range questions {
+		result[i] = QuestionToStudentResponse(&questions[i])
+	}
+	return result
+}
+
 func QuestionFeedbackToResponse(fb *entity.QuestionFeedback) QuestionFeedbackResponse {
 	return QuestionFeedbackResponse{
 		PublicID:         fb.PublicID,
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/grading"
 )
 
 type SubmitAnswerRequest struct {
@@ -12,15 +13,40 @@ type SubmitAnswerRequest struct {
 	ActivityID       *string `json:"activity_id,omitempty"`
 }
 
+type ReviewQuestionSubmissionRequest struct {
+	Score    *int    `json:"score"`
+	Passed   *bool   `json:"passed,omitempty"`
+	Feedback *string `json:"feedback,omitempty"`
+}
+
+type StartQuestionAttemptRequest struct {
+	ActivityID *string `json:"activity_id,omitempty"`
+}
+
+type QuestionAttemptStartResponse struct {
+	StartedAt time.Time `json:"started_at"`
+}
+
+type QuestionTimeStatsResponse struct {
+	QuestionID             string   `json:"question_id"`
+	TimedAttempts          int      `json:"timed_attempts"`
+	AverageDurationSeconds *float64 `json:"average_duration_seconds"`
+}
+
 type QuestionSubmissionResponse struct {
-	PublicID       string                        `json:"id"`
-	Question       QuestionSubmissionQuestionRef `json:"question"`
-	OptionSelected *QuestionSubmissionOptionRef  `json:"option_selected,omitempty"`
-	AnswerText     *string                       `json:"answer_text,omitempty"`
-	Score          *int                          `json:"score"`
-	AnswerFeedback *string                       `json:"answer_feedback,omitempty"`
-	Passed         bool                          `json:"passed"`
-	SubmittedAt    time.Time                     `json:"submitted_at"`
+	PublicID        string                        `json:"id"`
+	Question        QuestionSubmissionQuestionRef `json:"question"`
+	OptionSelected  *QuestionSubmissionOptionRef  `json:"option_selected,omitempty"`
+	AnswerText      *string                       `json:"answer_text,omitempty"`
+	Score           *int                          `json:"score"`
+	SuggestedScore  *int                          `json:"suggested_score,omitempty"`
+	AnswerFeedback  *string                       `json:"answer_feedback,omitempty"`
+	Passed          bool                          `json:"passed"`
+	StartedAt       *time.Time                    `json:"started_at,omitempty"`
+	DurationSeconds *int                          `json:"duration_seconds,omitempty"`
+	Attachment      *SubmissionAttachmentResponse `json:"attachment,omitempty"`
+	ReviewedAt      *time.Time                    `json:"reviewed_at,omitempty"`
+	SubmittedAt     time.Time                     `json:"submitted_at"`
 }
 
 type QuestionSubmissionQuestionRef struct {
@@ -51,11 +77,24 @@ func QuestionSubmissionToResponse(s *entity.QuestionSubmission) QuestionSubmissi
 			Type:      s.QuestionType,
 			Statement: s.QuestionStatement,
 		},
-		AnswerText:     s.AnswerText,
-		Score:          s.Score,
-		AnswerFeedback: s.AnswerFeedback,
-		Passed:         s.Passed,
-		SubmittedAt:    s.SubmittedAt,
+		AnswerText:      s.AnswerText,
+		Score:           s.Score,
+		AnswerFeedback:  s.AnswerFeedback,
+		Passed:          s.Passed,
+		StartedAt:       s.StartedAt,
+		DurationSeconds: s.DurationSeconds,
+		ReviewedAt:      s.ReviewedAt,
+		SubmittedAt:     s.SubmittedAt,
+	}
+	if s.Attachment != nil {
+		resp.Attachment = &SubmissionAttachmentResponse{
+			FileID:      s.Attachment.FilePublicID,
+			Filename:    s.Attachment.Filename,
+			ContentType: s.Attachment.ContentType,
+			SizeBytes:   s.Attachment.SizeBytes,
+			Checksum:    s.Attachment.Checksum,
+			DownloadURL: "/question-submissions/" + s.PublicID + "/attachment/download",
+		}
 	}
 	if s.OptionPublicID != "" {
 		resp.OptionSelected = &QuestionSubmissionOptionRef{
@@ -74,3 +113,23 @@ func QuestionSubmissionsToResponse(submissions []entity.QuestionSubmission) []Qu
 	}
 	return result
 }
+
+// QuestionSubmissionToReviewResponse adds the keyword-based suggested score
+// for reviewers. It is left out when the viewer wrote the answer, since
+// resubmitting would otherwise reveal how many expected keywords were hit.
+// The stored score remains authoritative.
+func QuestionSubmissionToReviewResponse(s *entity.QuestionSubmission, viewerPublicID string) QuestionSubmissionResponse {
+	resp := QuestionSubmissionToResponse(s)
+	if s.UserPublicID != viewerPublicID && s.QuestionType == "open_ended" && s.AnswerText != nil {
+		resp.SuggestedScore = grading.SuggestScore(*s.AnswerText, s.QuestionKeywords)
+	}
+	return resp
+}
+
+func QuestionSubmissionsToReviewResponse(submissions []entity.QuestionSubmission, viewerPublicID string) []QuestionSubmissionResponse {
+	result := make([]QuestionSubmissionResponse, len(submissions))
+	for i := range submissions {
+		result[i] = QuestionSubmissionToReviewResponse(&submissions[i], viewerPublicID)
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"testing"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+func TestQuestionSubmissionSuggestedScoreOnlyForReviewers(t *testing.T) {
+	answer := "photosynthesis needs light and chlorophyll"
+	sub := &entity.QuestionSubmission{
+		QuestionType:     "open_ended",
+		AnswerText:       &answer,
+		QuestionKeywords: []string{"light", "chlorophyll"},
+		UserPublicID:     "student",
+	}
+
+	if got := QuestionSubmissionToResponse(sub).SuggestedScore; got != nil {
+		t.Errorf("plain response suggested %d, want none", *got)
+	}
+	if got := QuestionSubmissionToReviewResponse(sub, "student").SuggestedScore; got != nil {
+		t.Errorf("author's own response suggested %d, want none", *got)
+	}
+	if got := QuestionSubmissionToReviewResponse(sub, "reviewer").SuggestedScore; got == nil {
+		t.Error("reviewer response has no suggested score")
+	}
+}
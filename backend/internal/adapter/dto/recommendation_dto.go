@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type RecommendationTopicResponse struct {
+	PublicID string  `json:"id"`
+	Name     string  `json:"name"`
+	Attempts int     `json:"attempts"`
+	PassRate float64 `json:"pass_rate"`
+}
+
+type RecommendationResponse struct {
+	Type      string                      `json:"type"`
+	PublicID  string                      `json:"id"`
+	Title     string                      `json:"title"`
+	CreatedAt time.Time                   `json:"created_at"`
+	Topic     RecommendationTopicResponse `json:"topic"` // the weak topic this item was picked for
+}
+
+func RecommendationsToResponse(recs []entity.Recommendation) []RecommendationResponse {
+	result := make([]RecommendationResponse, len(recs))
+	for i, rec := range recs {
+		result[i] = RecommendationResponse{
+			Type:      string(rec.ContentType),
+			PublicID:  rec.PublicID,
+			Title:     rec.Title,
+			CreatedAt: rec.CreatedAt,
+			Topic: RecommendationTopicResponse{
+				PublicID: rec.Topic.TopicPublicID,
+				Name:     rec.Topic.TopicName,
+				Attempts: rec.Topic.Attempts,
+				PassRate: float64(rec.Topic.Passed) / float64(rec.Topic.Attempts),
+			},
+		}
+	}
+	return result
+}
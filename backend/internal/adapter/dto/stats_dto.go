@@ -0,0 +1,124 @@
+package dto
+
+import "proximos-passos/backend/internal/domain/entity"
+
+type GroupActivityCountsResponse struct {
+	Active int `json:"active"`
+	Past   int `json:"past"`
+}
+
+type GroupStatsResponse struct {
+	MembersByRole                  map[string]int              `json:"members_by_role"`
+	PendingRequests                int                         `json:"pending_requests"`
+	Activities                     GroupActivityCountsResponse `json:"activities"`
+	SubmissionsByStatus            map[string]int              `json:"submissions_by_status"`
+	AverageReviewTurnaroundSeconds *float64                    `json:"average_review_turnaround_seconds"`
+}
+
+func GroupStatsToResponse(s *entity.GroupStats) GroupStatsResponse {
+	members := make(map[string]int, len(s.MembersByRole))
+	for role, count := range s.MembersByRole {
+		members[string(role)] = count
+	}
+
+	submissions := make(map[string]int, len(s.SubmissionsByStatus))
+	for status, count := range s.SubmissionsByStatus {
+		submissions[string(status)] = count
+	}
+
+	return GroupStatsResponse{
+		MembersByRole:   members,
+		PendingRequests: s.PendingRequests,
+		Activities: GroupActivityCountsResponse{
+			Active: s.ActiveActivities,
+			Past:   s.PastActivities,
+		},
+		SubmissionsByStatus:            submissions,
+		AverageReviewTurnaroundSeconds: s.AvgReviewTurnaroundSeconds,
+	}
+}
+
+type LeaderboardEntryResponse struct {
+	Rank            int     `json:"rank"`
+	UserID          *string `json:"user_id"` // nil when anonymized
+	Name            *string `json:"name"`
+	AvatarURL       *string `json:"avatar_url,omitempty"`
+	PassedQuestions int     `json:"passed_questions"`
+	TotalScore      int     `json:"total_score"`
+	IsCurrentUser   bool    `json:"is_current_user"`
+}
+
+func LeaderboardToResponse(entries []entity.LeaderboardEntry, currentUserPublicID string) []LeaderboardEntryResponse {
+	result := make([]LeaderboardEntryResponse, len(entries))
+	for i, e := range entries {
+		resp := LeaderboardEntryResponse{
+			Rank:            e.Rank,
+			AvatarURL:       e.UserAvatarURL,
+			PassedQuestions: e.PassedQuestions,
+			TotalScore:      e.TotalScore,
+			IsCurrentUser:   e.UserPublicID != "" && e.UserPublicID == currentUserPublicID,
+		}
+		if e.UserPublicID != "" {
+			userID, name := e.UserPublicID, e.UserName
+			resp.UserID = &userID
+			resp.Name = &name
+		}
+		result[i] = resp
+	}
+	return result
+}
+
+type ContentStatsResponse struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Views       int    `json:"views"`
+	Downloads   int    `json:"downloads"`
+	UniqueUsers int    `json:"unique_users"`
+}
+
+type ContentStatsListResponse struct {
+	Data       []ContentStatsResponse `json:"data"`
+	WindowDays int                    `json:"window_days"`
+}
+
+func ContentStatsToResponse(stats []entity.ContentAccessStats, windowDays int) ContentStatsListResponse {
+	data := make([]ContentStatsResponse, len(stats))
+	for i, s := range stats {
+		data[i] = ContentStatsResponse{
+			Type:        string(s.ContentType),
+			ID:          s.PublicID,
+			Title:       s.Title,
+			Views:       s.Views,
+			Downloads:   s.Downloads,
+			UniqueUsers: s.UniqueUsers,
+		}
+	}
+	return ContentStatsListResponse{Data: data, WindowDays: windowDays}
+}
+
+type TopicMasteryResponse struct {
+	TopicID      string   `json:"topic_id"`
+	Name         string   `json:"name"`
+	ParentID     *string  `json:"parent_id"`
+	Attempts     int      `json:"attempts"`
+	Passed       int      `json:"passed"`
+	PassRate     float64  `json:"pass_rate"`
+	AverageScore *float64 `json:"average_score"`
+}
+
+func TopicMasteryToResponse(entries []entity.TopicMastery) []TopicMasteryResponse {
+	result := make([]TopicMasteryResponse, len(entries))
+	for i, m := range entries {
+		result[i] = TopicMasteryResponse{
+			TopicID:      m.TopicPublicID,
+			Name:         m.TopicName,
+			ParentID:     m.ParentPublicID,
+			Attempts:     m.Attempts,
+			Passed:       m.Passed,
+			PassRate:     float64(m.Passed) / float64(m.Attempts),
+			AverageScore: m.AverageScore,
+		}
+	}
+	return result
+}
@@ -44,6 +44,20 @@ type TopicListResponse struct {
 	TotalPages int             `json:"total_pages"`
 }
 
+type ResolveTopicsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type TopicPathItemResponse struct {
+	PublicID string `json:"id"`
+	Name     string `json:"name"`
+}
+
+type ResolvedTopicResponse struct {
+	Name string                  `json:"name"`
+	Path []TopicPathItemResponse `json:"path"` // ancestors, root first
+}
+
 // ==========================================
 // Mapping functions
 // ==========================================
@@ -71,3 +85,15 @@ func TopicsToResponse(topics []entity.Topic) []TopicResponse {
 	}
 	return result
 }
+
+func TopicBreadcrumbsToResponse(breadcrumbs map[string]entity.TopicBreadcrumb) map[string]ResolvedTopicResponse {
+	result := make(map[string]ResolvedTopicResponse, len(breadcrumbs))
+	for publicID, b := range breadcrumbs {
+		path := make([]TopicPathItemResponse, len(b.Ancestors))
+		for i, a := range b.Ancestors {
+			path[i] = TopicPathItemResponse{PublicID: a.PublicID, Name: a.Name}
+		}
+		result[publicID] = ResolvedTopicResponse{Name: b.Name, Path: path}
+	}
+	return result
+}
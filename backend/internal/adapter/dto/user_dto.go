@@ -23,6 +23,24 @@ type UpdateUserRequest struct {
 
 type UpdateMeRequest struct {
 	Name *string `json:"name,omitempty"`
+	// Lang is "pt-BR" or "en"; an empty string clears the preference.
+	Lang *string `json:"lang,omitempty"`
+	// ActivityReminders turns due-date reminder emails on or off.
+	ActivityReminders *bool `json:"activity_reminders,omitempty"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type RequestEmailChangeRequest struct {
+	Email           string `json:"email"`
+	CurrentPassword string `json:"current_password"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
 }
 
 type UserResponse struct {
@@ -32,9 +50,13 @@ type UserResponse struct {
 	Email           string     `json:"email"`
 	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 	AvatarURL       *string    `json:"avatar_url,omitempty"`
-	IsActive        bool       `json:"is_active"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	Lang            *string    `json:"lang,omitempty"`
+	// ActivityReminders is false once the user opted out of due-date reminders.
+	ActivityReminders bool      `json:"activity_reminders"`
+	Status            string    `json:"status"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type UserListResponse struct {
@@ -47,15 +69,18 @@ type UserListResponse struct {
 
 func UserToResponse(u *entity.User) UserResponse {
 	return UserResponse{
-		PublicID:        u.PublicID,
-		Role:            string(u.Role),
-		Name:            u.Name,
-		Email:           u.Email,
-		EmailVerifiedAt: u.EmailVerifiedAt,
-		AvatarURL:       u.AvatarURL,
-		IsActive:        u.IsActive,
-		CreatedAt:       u.CreatedAt,
-		UpdatedAt:       u.UpdatedAt,
+		PublicID:          u.PublicID,
+		Role:              string(u.Role),
+		Name:              u.Name,
+		Email:             u.Email,
+		EmailVerifiedAt:   u.EmailVerifiedAt,
+		AvatarURL:         u.AvatarURL,
+		Lang:              u.Lang,
+		ActivityReminders: u.ActivityReminders,
+		Status:            string(u.Status),
+		IsActive:          u.IsActive,
+		CreatedAt:         u.CreatedAt,
+		UpdatedAt:         u.UpdatedAt,
 	}
 }
 
@@ -66,3 +91,83 @@ func UsersToResponse(users []entity.User) []UserResponse {
 	}
 	return result
 }
+
+type BatchUsersRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// UserProfileResponse is the public face of a user, without account details
+// such as email or status.
+type UserProfileResponse struct {
+	PublicID  string  `json:"id"`
+	Name      string  `json:"name"`
+	AvatarURL *string `json:"avatar_url,omitempty"`
+}
+
+func UserProfilesToResponse(users map[string]*entity.User) map[string]UserProfileResponse {
+	result := make(map[string]UserProfileResponse, len(users))
+	for publicID, u := range users {
+		result[publicID] = UserProfileResponse{
+			PublicID:  u.PublicID,
+			Name:      u.Name,
+			AvatarURL: u.AvatarURL,
+		}
+	}
+	return result
+}
+
+type MembershipResponse struct {
+	GroupID   string `json:"group_id"`
+	GroupName string `json:"group_name"`
+	Role      string `json:"role"`
+}
+
+// MeContextResponse bundles the current user, whose role is the platform
+// role, with their group memberships.
+type MeContextResponse struct {
+	User        UserResponse         `json:"user"`
+	Memberships []MembershipResponse `json:"memberships"`
+}
+
+func MeContextToResponse(user *entity.User, memberships []entity.GroupMembership) MeContextResponse {
+	resp := MeContextResponse{
+		User:        UserToResponse(user),
+		Memberships: make([]MembershipResponse, len(memberships)),
+	}
+	for i, m := range memberships {
+		resp.Memberships[i] = MembershipResponse{
+			GroupID:   m.GroupPublicID,
+			GroupName: m.GroupName,
+			Role:      string(m.Role),
+		}
+	}
+	return resp
+}
+
+// SessionResponse describes one signed-in device. Current marks the session
+// the request was made with.
+type SessionResponse struct {
+	PublicID   string    `json:"id"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func SessionsToResponse(sessions []entity.Session, currentPublicID string) []SessionResponse {
+	result := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		result[i] = SessionResponse{
+			PublicID:   s.PublicID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			Current:    s.PublicID == currentPublicID,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+		}
+	}
+	return result
+}
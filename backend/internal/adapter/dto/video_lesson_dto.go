@@ -42,6 +42,7 @@ type VideoLessonResponse struct {
 	IsActive        bool                       `json:"is_active"`
 	CreatedAt       time.Time                  `json:"created_at"`
 	UpdatedAt       time.Time                  `json:"updated_at"`
+	CreatedBy       *ContentCreatorResponse    `json:"created_by,omitempty"`
 }
 
 type VideoLessonListResponse struct {
@@ -75,6 +76,7 @@ func VideoLessonToResponse(vl *entity.VideoLesson) VideoLessonResponse {
 		IsActive:        vl.IsActive,
 		CreatedAt:       vl.CreatedAt,
 		UpdatedAt:       vl.UpdatedAt,
+		CreatedBy:       contentCreatorToResponse(vl.CreatedByPublicID, vl.CreatedByName),
 	}
 
 	if vl.FileID != nil {
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type CreateWebhookRequest struct {
+	URL string `json:"url"`
+}
+
+type WebhookResponse struct {
+	PublicID  string    `json:"id"`
+	URL       string    `json:"url"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateWebhookResponse includes the signing secret, which is not returned
+// by any other endpoint.
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookListResponse struct {
+	Data       []WebhookResponse `json:"data"`
+	PageNumber int               `json:"page_number"`
+	PageSize   int               `json:"page_size"`
+	TotalItems int               `json:"total_items"`
+	TotalPages int               `json:"total_pages"`
+}
+
+func WebhookToResponse(w *entity.Webhook) WebhookResponse {
+	return WebhookResponse{
+		PublicID:  w.PublicID,
+		URL:       w.URL,
+		IsActive:  w.IsActive,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+func WebhooksToResponse(webhooks []entity.Webhook) []WebhookResponse {
+	result := make([]WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		result[i] = WebhookToResponse(&webhooks[i])
+	}
+	return result
+}
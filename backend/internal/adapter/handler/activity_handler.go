@@ -1,11 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
-	"math"
 	"net/http"
-	"strconv"
-	"time"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
@@ -29,14 +25,17 @@ func (h *ActivityHandler) RegisterRoutes(mux *http.ServeMux, authMW func(http.Ha
 	mux.Handle("GET /groups/{groupId}/activities/past", authMW(http.HandlerFunc(h.ListPast)))
 	mux.Handle("GET /activities/{id}", authMW(http.HandlerFunc(h.GetByID)))
 	mux.Handle("PUT /activities/{id}", authMW(http.HandlerFunc(h.Update)))
+	mux.Handle("PATCH /activities/{id}", authMW(http.HandlerFunc(h.Update)))
 	mux.Handle("DELETE /activities/{id}", authMW(http.HandlerFunc(h.Delete)))
 	mux.Handle("POST /activities/{id}/attachments", authMW(http.HandlerFunc(h.UploadAttachment)))
+	mux.Handle("GET /activities/{id}/attachments/{fileId}/download", authMW(http.HandlerFunc(h.DownloadAttachment)))
 	mux.Handle("DELETE /activities/{id}/attachments/{fileId}", authMW(http.HandlerFunc(h.DeleteAttachment)))
 
 	// Activity Items
 	mux.Handle("POST /activities/{id}/items", authMW(http.HandlerFunc(h.CreateItem)))
 	mux.Handle("GET /activities/{id}/items", authMW(http.HandlerFunc(h.ListItems)))
 	mux.Handle("PUT /activities/{id}/items/reorder", authMW(http.HandlerFunc(h.ReorderItems)))
+	mux.Handle("GET /activity-items/{itemId}", authMW(http.HandlerFunc(h.GetItem)))
 	mux.Handle("PUT /activity-items/{itemId}", authMW(http.HandlerFunc(h.UpdateItem)))
 	mux.Handle("DELETE /activity-items/{itemId}", authMW(http.HandlerFunc(h.DeleteItem)))
 }
@@ -64,21 +63,15 @@ func (h *ActivityHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.CreateActivityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
-		return
-	}
-
-	dueDate, err := time.Parse(time.RFC3339, req.DueDate)
-	if err != nil {
-		response.Error(w, apperror.WithDetails(apperror.CodeInvalidInput, "Invalid due_date format. Use RFC3339 (e.g., 2025-12-31T23:59:59Z).", http.StatusBadRequest, nil))
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	input := usecase.CreateActivityInput{
 		Title:       req.Title,
 		Description: req.Description,
-		DueDate:     dueDate,
+		DueDate:     req.DueDate,
 	}
 
 	activity, err := h.uc.Create(r.Context(), groupPublicID, requesterPublicID, input)
@@ -87,12 +80,12 @@ func (h *ActivityHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.ActivityToResponse(activity))
+	response.Created(w, "/activities/"+activity.PublicID, dto.ActivityToResponse(activity))
 }
 
 // GetByID godoc
 // @Summary     Get activity details
-// @Description Returns an activity with its attachments
+// @Description Returns an activity with its attachments and the caller's can_review and can_submit flags
 // @Tags        activities
 // @Produce     json
 // @Security    CookieAuth
@@ -115,12 +108,18 @@ func (h *ActivityHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.ActivityDetailToResponse(activity, attachments))
+	requester, err := h.uc.Permissions(r.Context(), activity, requesterPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ActivityDetailToResponse(activity, attachments, requester))
 }
 
 // Update godoc
 // @Summary     Update an activity
-// @Description Updates an activity (group admin only)
+// @Description Updates an activity (group admin only). PATCH also accepts a null description, which clears it
 // @Tags        activities
 // @Accept      json
 // @Produce     json
@@ -131,6 +130,7 @@ func (h *ActivityHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Failure     400 {object} apperror.AppError
 // @Failure     403 {object} apperror.AppError
 // @Router      /activities/{id} [put]
+// @Router      /activities/{id} [patch]
 func (h *ActivityHandler) Update(w http.ResponseWriter, r *http.Request) {
 	activityPublicID := r.PathValue("id")
 	requesterPublicID := middleware.UserPublicID(r.Context())
@@ -139,24 +139,16 @@ func (h *ActivityHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req dto.UpdateActivityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	req, err := decodeUpdate[dto.UpdateActivityRequest, dto.PatchActivityRequest](r)
+	if err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	input := usecase.UpdateActivityInput{
 		Title:       req.Title,
 		Description: req.Description,
-	}
-
-	if req.DueDate != nil {
-		dueDate, err := time.Parse(time.RFC3339, *req.DueDate)
-		if err != nil {
-			response.Error(w, apperror.WithDetails(apperror.CodeInvalidInput, "Invalid due_date format. Use RFC3339.", http.StatusBadRequest, nil))
-			return
-		}
-		input.DueDate = &dueDate
+		DueDate:     req.DueDate,
 	}
 
 	activity, err := h.uc.Update(r.Context(), activityPublicID, requesterPublicID, input)
@@ -203,6 +195,7 @@ func (h *ActivityHandler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Param       page_number query int false "Page number"
 // @Param       page_size query int false "Page size"
 // @Param       title query string false "Filter by title"
+// @Param       status query string false "Filter by the caller's progress: todo (not submitted, draft or reproved), submitted or approved"
 // @Success     200 {object} dto.ActivityListResponse
 // @Router      /groups/{groupId}/activities/upcoming [get]
 func (h *ActivityHandler) ListUpcoming(w http.ResponseWriter, r *http.Request) {
@@ -214,31 +207,26 @@ func (h *ActivityHandler) ListUpcoming(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
-	}
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.ActivityFilter{
-		Title: r.URL.Query().Get("title"),
+		Title:        r.URL.Query().Get("title"),
+		MemberStatus: r.URL.Query().Get("status"),
 	}
 
-	activities, total, err := h.uc.ListUpcoming(r.Context(), groupPublicID, requesterPublicID, requesterRole, pageNumber, pageSize, filter)
+	activities, total, err := h.uc.ListUpcoming(r.Context(), groupPublicID, requesterPublicID, requesterRole, pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
+	pagination := response.Paginate(total, pageNumber, pageSize)
 	response.JSON(w, http.StatusOK, dto.ActivityListResponse{
 		Data:       dto.ActivitiesToResponse(activities),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: total,
-		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
@@ -252,6 +240,7 @@ func (h *ActivityHandler) ListUpcoming(w http.ResponseWriter, r *http.Request) {
 // @Param       page_number query int false "Page number"
 // @Param       page_size query int false "Page size"
 // @Param       title query string false "Filter by title"
+// @Param       status query string false "Filter by the caller's progress: todo (not submitted, draft or reproved), submitted or approved"
 // @Success     200 {object} dto.ActivityListResponse
 // @Router      /groups/{groupId}/activities/past [get]
 func (h *ActivityHandler) ListPast(w http.ResponseWriter, r *http.Request) {
@@ -263,31 +252,26 @@ func (h *ActivityHandler) ListPast(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
-	}
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.ActivityFilter{
-		Title: r.URL.Query().Get("title"),
+		Title:        r.URL.Query().Get("title"),
+		MemberStatus: r.URL.Query().Get("status"),
 	}
 
-	activities, total, err := h.uc.ListPast(r.Context(), groupPublicID, requesterPublicID, requesterRole, pageNumber, pageSize, filter)
+	activities, total, err := h.uc.ListPast(r.Context(), groupPublicID, requesterPublicID, requesterRole, pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
+	pagination := response.Paginate(total, pageNumber, pageSize)
 	response.JSON(w, http.StatusOK, dto.ActivityListResponse{
 		Data:       dto.ActivitiesToResponse(activities),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: total,
-		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
@@ -311,8 +295,8 @@ func (h *ActivityHandler) UploadAttachment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -329,7 +313,38 @@ func (h *ActivityHandler) UploadAttachment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.AttachmentToResponse(attachment))
+	response.JSON(w, http.StatusCreated, dto.AttachmentToResponse(activityPublicID, attachment))
+}
+
+// DownloadAttachment godoc
+// @Summary     Download an attachment
+// @Description Redirects a group member to a short-lived signed URL for the attachment
+// @Tags        activities
+// @Security    CookieAuth
+// @Param       id path string true "Activity public ID"
+// @Param       fileId path string true "File public ID"
+// @Success     302
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Router      /activities/{id}/attachments/{fileId}/download [get]
+func (h *ActivityHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	activityPublicID := r.PathValue("id")
+	filePublicID := r.PathValue("fileId")
+	requesterPublicID := middleware.UserPublicID(r.Context())
+	requesterRole := middleware.UserRole(r.Context())
+	if requesterPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	url, err := h.uc.GetAttachmentDownloadURL(r.Context(), activityPublicID, filePublicID, requesterPublicID, requesterRole)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, url, http.StatusFound)
 }
 
 // DeleteAttachment godoc
@@ -372,8 +387,8 @@ func (h *ActivityHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.CreateActivityItemRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -393,7 +408,7 @@ func (h *ActivityHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.ActivityItemToResponse(item))
+	response.Created(w, "/activity-items/"+item.PublicID, dto.ActivityItemToResponse(item))
 }
 
 func (h *ActivityHandler) ListItems(w http.ResponseWriter, r *http.Request) {
@@ -405,13 +420,31 @@ func (h *ActivityHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items, err := h.uc.ListItems(r.Context(), activityPublicID, requesterPublicID, requesterRole)
+	items, counts, err := h.uc.ListItems(r.Context(), activityPublicID, requesterPublicID, requesterRole, r.URL.Query().Get("type"))
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.ActivityItemsToResponse(items))
+	response.JSON(w, http.StatusOK, dto.ActivityItemListToResponse(items, counts))
+}
+
+func (h *ActivityHandler) GetItem(w http.ResponseWriter, r *http.Request) {
+	itemPublicID := r.PathValue("itemId")
+	requesterPublicID := middleware.UserPublicID(r.Context())
+	requesterRole := middleware.UserRole(r.Context())
+	if requesterPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	item, showAnswers, err := h.uc.GetItemDetail(r.Context(), itemPublicID, requesterPublicID, requesterRole)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ActivityItemDetailToResponse(item, showAnswers))
 }
 
 func (h *ActivityHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
@@ -423,8 +456,8 @@ func (h *ActivityHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.UpdateActivityItemRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -467,8 +500,8 @@ func (h *ActivityHandler) ReorderItems(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.ReorderActivityItemsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -1,8 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
-	"math"
+	"errors"
 	"net/http"
 
 	"proximos-passos/backend/internal/adapter/dto"
@@ -10,6 +9,7 @@ import (
 	"proximos-passos/backend/internal/adapter/response"
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/usecase"
 )
 
@@ -21,9 +21,9 @@ func NewActivitySubmissionHandler(uc *usecase.ActivitySubmissionUseCase) *Activi
 	return &ActivitySubmissionHandler{uc: uc}
 }
 
-func (h *ActivitySubmissionHandler) RegisterRoutes(mux *http.ServeMux, authMW func(http.Handler) http.Handler) {
+func (h *ActivitySubmissionHandler) RegisterRoutes(mux *http.ServeMux, authMW, idempotentMW func(http.Handler) http.Handler) {
 	// User submits to an activity
-	mux.Handle("POST /activities/{id}/submissions", authMW(http.HandlerFunc(h.Submit)))
+	mux.Handle("POST /activities/{id}/submissions", authMW(idempotentMW(http.HandlerFunc(h.Submit))))
 	// User gets their own submission for an activity
 	mux.Handle("GET /activities/{id}/submissions/mine", authMW(http.HandlerFunc(h.GetMySubmission)))
 	// List all submissions for an activity (group admin)
@@ -34,6 +34,7 @@ func (h *ActivitySubmissionHandler) RegisterRoutes(mux *http.ServeMux, authMW fu
 	mux.Handle("GET /activity-submissions/{id}", authMW(http.HandlerFunc(h.GetByID)))
 	// Review a submission (group admin)
 	mux.Handle("PUT /activity-submissions/{id}/review", authMW(http.HandlerFunc(h.Review)))
+	mux.Handle("POST /activity-submissions/{id}/reopen", authMW(http.HandlerFunc(h.Reopen)))
 	// Update submission notes (owner, while pending or reproved)
 	mux.Handle("PUT /activity-submissions/{id}", authMW(http.HandlerFunc(h.UpdateNotes)))
 	// Resubmit a reproved submission (owner)
@@ -44,9 +45,13 @@ func (h *ActivitySubmissionHandler) RegisterRoutes(mux *http.ServeMux, authMW fu
 	mux.Handle("GET /activity-submissions/{id}/question-attempts", authMW(http.HandlerFunc(h.GetSubmissionQuestionAttempts)))
 	mux.Handle("GET /activity-submissions/{id}/attachments", authMW(http.HandlerFunc(h.ListAttachments)))
 	mux.Handle("POST /activity-submissions/{id}/attachments", authMW(http.HandlerFunc(h.UploadAttachment)))
+	mux.Handle("GET /activity-submissions/{id}/attachments/{fileId}/download", authMW(http.HandlerFunc(h.DownloadAttachment)))
 	mux.Handle("DELETE /activity-submissions/{id}/attachments/{fileId}", authMW(http.HandlerFunc(h.DeleteAttachment)))
 	// List user's own activity submissions
 	mux.Handle("GET /me/activity-submissions", authMW(http.HandlerFunc(h.ListMySubmissions)))
+	// Pending submissions in the groups the user staffs
+	mux.Handle("GET /me/review-queue", authMW(http.HandlerFunc(h.ListReviewQueue)))
+	mux.Handle("GET /me/review-queue/count", authMW(http.HandlerFunc(h.CountReviewQueue)))
 }
 
 // Submit godoc
@@ -71,7 +76,11 @@ func (h *ActivitySubmissionHandler) Submit(w http.ResponseWriter, r *http.Reques
 	}
 
 	var req dto.SubmitActivityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(r, &req); err != nil {
+		if errors.Is(err, apperror.ErrRequestTooLarge) {
+			response.Error(w, err)
+			return
+		}
 		// Allow empty body (no notes)
 		req = dto.SubmitActivityRequest{}
 	}
@@ -88,7 +97,7 @@ func (h *ActivitySubmissionHandler) Submit(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.ActivitySubmissionToResponse(sub))
+	response.Created(w, "/activity-submissions/"+sub.PublicID, dto.ActivitySubmissionToResponse(sub))
 }
 
 // GetMySubmission godoc
@@ -124,11 +133,12 @@ func (h *ActivitySubmissionHandler) GetMySubmission(w http.ResponseWriter, r *ht
 
 // ListByActivity godoc
 // @Summary     List submissions for an activity
-// @Description Lists all submissions for an activity (group admin only)
+// @Description Lists the submissions for an activity (group admin only). Drafts are hidden unless requested through status.
 // @Tags        activity-submissions
 // @Produce     json
 // @Security    CookieAuth
 // @Param       id path string true "Activity public ID"
+// @Param       status query string false "Comma-separated statuses (created, pending, approved, reproved)"
 // @Param       page_number query int false "Page number"
 // @Param       page_size query int false "Page size"
 // @Success     200 {object} dto.ActivitySubmissionListResponse
@@ -142,32 +152,33 @@ func (h *ActivitySubmissionHandler) ListByActivity(w http.ResponseWriter, r *htt
 		return
 	}
 
-	page, size := parsePagination(r)
+	page, size, offset := response.ParsePagination(r, response.DefaultPageSize)
 
-	subs, total, err := h.uc.ListByActivity(r.Context(), activityPublicID, requesterPublicID, requesterRole, page, size)
+	subs, total, err := h.uc.ListByActivity(r.Context(), activityPublicID, requesterPublicID, requesterRole, size, offset, r.URL.Query().Get("status"))
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
+	pagination := response.Paginate(total, page, size)
 	response.JSON(w, http.StatusOK, dto.ActivitySubmissionListResponse{
 		Data:       dto.ActivitySubmissionsToResponse(subs),
-		PageNumber: page,
-		PageSize:   size,
-		TotalItems: total,
-		TotalPages: totalPages,
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
 // GetByID godoc
 // @Summary     Get an activity submission
-// @Description Returns a specific activity submission by ID
+// @Description Returns a specific activity submission by ID to its owner, the group's admins and supervisors, and platform admins
 // @Tags        activity-submissions
 // @Produce     json
 // @Security    CookieAuth
 // @Param       id path string true "Submission public ID"
 // @Success     200 {object} dto.ActivitySubmissionResponse
+// @Failure     404 {object} apperror.AppError
 // @Router      /activity-submissions/{id} [get]
 func (h *ActivitySubmissionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
@@ -207,8 +218,8 @@ func (h *ActivitySubmissionHandler) Review(w http.ResponseWriter, r *http.Reques
 	}
 
 	var req dto.ReviewActivitySubmissionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -229,12 +240,55 @@ func (h *ActivitySubmissionHandler) Review(w http.ResponseWriter, r *http.Reques
 	response.JSON(w, http.StatusOK, dto.ActivitySubmissionToResponse(sub))
 }
 
+// Reopen godoc
+// @Summary     Reopen a reviewed activity submission
+// @Description Moves an approved or reproved submission back to pending for re-review and notifies the student (group admin only)
+// @Tags        activity-submissions
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id path string true "Submission public ID"
+// @Param       body body dto.ReopenActivitySubmissionRequest true "Reopen reason"
+// @Success     200 {object} dto.ActivitySubmissionResponse
+// @Failure     403 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError
+// @Router      /activity-submissions/{id}/reopen [post]
+func (h *ActivitySubmissionHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+	requesterPublicID := middleware.UserPublicID(r.Context())
+	requesterRole := middleware.UserRole(r.Context())
+	if requesterPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ReopenActivitySubmissionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	sub, err := h.uc.Reopen(r.Context(), usecase.ReopenActivitySubmissionInput{
+		SubmissionPublicID: publicID,
+		ReviewerPublicID:   requesterPublicID,
+		ReviewerRole:       requesterRole,
+		Reason:             req.Reason,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ActivitySubmissionToResponse(sub))
+}
+
 // ListMySubmissions godoc
 // @Summary     List my activity submissions
 // @Description Lists the current user's activity submissions
 // @Tags        activity-submissions
 // @Produce     json
 // @Security    CookieAuth
+// @Param       status query string false "Comma-separated statuses (created, pending, approved, reproved)"
 // @Param       page_number query int false "Page number"
 // @Param       page_size query int false "Page size"
 // @Success     200 {object} dto.ActivitySubmissionListResponse
@@ -246,24 +300,85 @@ func (h *ActivitySubmissionHandler) ListMySubmissions(w http.ResponseWriter, r *
 		return
 	}
 
-	page, size := parsePagination(r)
+	page, size, offset := response.ParsePagination(r, response.DefaultPageSize)
 
-	subs, total, err := h.uc.ListMySubmissions(r.Context(), userPublicID, page, size)
+	subs, total, err := h.uc.ListMySubmissions(r.Context(), userPublicID, size, offset, r.URL.Query().Get("status"))
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
+	pagination := response.Paginate(total, page, size)
 	response.JSON(w, http.StatusOK, dto.ActivitySubmissionListResponse{
 		Data:       dto.ActivitySubmissionsToResponse(subs),
-		PageNumber: page,
-		PageSize:   size,
-		TotalItems: total,
-		TotalPages: totalPages,
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	})
+}
+
+// ListReviewQueue godoc
+// @Summary     List my review queue
+// @Description Lists pending submissions, oldest first, across the groups where the current user is an admin or supervisor
+// @Tags        activity-submissions
+// @Produce     json
+// @Security    CookieAuth
+// @Param       page_number query int false "Page number"
+// @Param       page_size query int false "Page size"
+// @Success     200 {object} dto.ReviewQueueListResponse
+// @Failure     401 {object} apperror.AppError
+// @Router      /me/review-queue [get]
+func (h *ActivitySubmissionHandler) ListReviewQueue(w http.ResponseWriter, r *http.Request) {
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	page, size, offset := response.ParsePagination(r, response.DefaultPageSize)
+
+	items, total, err := h.uc.ListReviewQueue(r.Context(), userPublicID, size, offset)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	pagination := response.Paginate(total, page, size)
+	response.JSON(w, http.StatusOK, dto.ReviewQueueListResponse{
+		Data:       dto.ReviewQueueToResponse(items),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
+// CountReviewQueue godoc
+// @Summary     Count my review queue
+// @Description Returns how many submissions await review across the groups where the current user is an admin or supervisor
+// @Tags        activity-submissions
+// @Produce     json
+// @Security    CookieAuth
+// @Success     200 {object} dto.ReviewQueueCountResponse
+// @Failure     401 {object} apperror.AppError
+// @Router      /me/review-queue/count [get]
+func (h *ActivitySubmissionHandler) CountReviewQueue(w http.ResponseWriter, r *http.Request) {
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	count, err := h.uc.CountReviewQueue(r.Context(), userPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ReviewQueueCountResponse{Count: count})
+}
+
 // UpdateNotes godoc
 // @Summary     Update submission notes
 // @Description Updates the notes on a pending activity submission (owner only)
@@ -284,8 +399,8 @@ func (h *ActivitySubmissionHandler) UpdateNotes(w http.ResponseWriter, r *http.R
 	}
 
 	var req dto.UpdateActivitySubmissionNotesRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -375,17 +490,21 @@ func (h *ActivitySubmissionHandler) GetQuestionStatuses(w http.ResponseWriter, r
 		return
 	}
 
+	response.JSON(w, http.StatusOK, questionStatusesToResponse(statuses))
+}
+
+func questionStatusesToResponse(statuses []usecase.QuestionStatus) []dto.QuestionStatusResponse {
 	result := make([]dto.QuestionStatusResponse, len(statuses))
 	for i, s := range statuses {
 		result[i] = dto.QuestionStatusResponse{
-			QuestionID: s.QuestionPublicID,
-			Passed:     s.Passed,
-			Attempts:   s.Attempts,
-			LastScore:  s.LastScore,
+			QuestionID:        s.QuestionPublicID,
+			Passed:            s.Passed,
+			Attempts:          s.Attempts,
+			AttemptsRemaining: s.AttemptsRemaining,
+			LastScore:         s.LastScore,
 		}
 	}
-
-	response.JSON(w, http.StatusOK, result)
+	return result
 }
 
 // ListAttachments godoc
@@ -410,7 +529,34 @@ func (h *ActivitySubmissionHandler) ListAttachments(w http.ResponseWriter, r *ht
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.SubmissionAttachmentsToResponse(attachments))
+	response.JSON(w, http.StatusOK, dto.SubmissionAttachmentsToResponse(publicID, attachments))
+}
+
+// DownloadAttachment godoc
+// @Summary     Download a submission attachment
+// @Description Redirects whoever may see the submission to a short-lived signed URL for the attachment
+// @Tags        activity-submissions
+// @Security    CookieAuth
+// @Param       id path string true "Submission public ID"
+// @Param       fileId path string true "File public ID"
+// @Success     302
+// @Failure     404 {object} apperror.AppError
+// @Router      /activity-submissions/{id}/attachments/{fileId}/download [get]
+func (h *ActivitySubmissionHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	url, err := h.uc.GetAttachmentDownloadURL(r.Context(), r.PathValue("id"), r.PathValue("fileId"), userPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, url, http.StatusFound)
 }
 
 // UploadAttachment godoc
@@ -431,8 +577,8 @@ func (h *ActivitySubmissionHandler) UploadAttachment(w http.ResponseWriter, r *h
 		return
 	}
 
-	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -449,17 +595,22 @@ func (h *ActivitySubmissionHandler) UploadAttachment(w http.ResponseWriter, r *h
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.SubmissionAttachmentToResponse(att))
+	response.JSON(w, http.StatusCreated, dto.SubmissionAttachmentToResponse(publicID, att))
 }
 
 // GetSubmissionQuestionAttempts godoc
 // @Summary     Get question attempts for a submission
-// @Description Returns all question submissions linked to an activity submission (admin only)
+// @Description Returns a page of the question submissions linked to an activity submission, newest first (group admin or supervisor)
 // @Tags        activity-submissions
 // @Produce     json
 // @Security    CookieAuth
-// @Param       id path string true "Submission public ID"
-// @Success     200 {array} dto.QuestionSubmissionResponse
+// @Param       id          path  string true  "Submission public ID"
+// @Param       question_id query string false "Only attempts at this question"
+// @Param       passed      query bool   false "Only passed (true) or failed (false) attempts"
+// @Param       page_number query int    false "Page number" default(1)
+// @Param       page_size   query int    false "Page size"   default(20)
+// @Success     200 {object} dto.QuestionSubmissionListResponse
+// @Failure     400 {object} apperror.AppError
 // @Router      /activity-submissions/{id}/question-attempts [get]
 func (h *ActivitySubmissionHandler) GetSubmissionQuestionAttempts(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
@@ -470,13 +621,38 @@ func (h *ActivitySubmissionHandler) GetSubmissionQuestionAttempts(w http.Respons
 		return
 	}
 
-	attempts, err := h.uc.GetSubmissionQuestionAttempts(r.Context(), publicID, requesterPublicID, requesterRole)
+	filter := repository.QuestionSubmissionFilter{
+		QuestionPublicID: r.URL.Query().Get("question_id"),
+	}
+	switch r.URL.Query().Get("passed") {
+	case "":
+	case "true":
+		passed := true
+		filter.Passed = &passed
+	case "false":
+		passed := false
+		filter.Passed = &passed
+	default:
+		response.Error(w, apperror.ErrInvalidInput)
+		return
+	}
+
+	page, size, offset := response.ParsePagination(r, response.DefaultPageSize)
+
+	attempts, total, err := h.uc.GetSubmissionQuestionAttempts(r.Context(), publicID, requesterPublicID, requesterRole, size, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.QuestionSubmissionsToResponse(attempts))
+	pagination := response.Paginate(total, page, size)
+	response.JSON(w, http.StatusOK, dto.QuestionSubmissionListResponse{
+		Data:       dto.QuestionSubmissionsToReviewResponse(attempts, requesterPublicID),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	})
 }
 
 // DeleteAttachment godoc
@@ -1,12 +1,14 @@
 package handler
 
 import (
-	"encoding/json"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
 	"net/http"
-	"strings"
 	"time"
 
 	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/middleware"
 	"proximos-passos/backend/internal/adapter/response"
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
@@ -18,21 +20,63 @@ type AuthHandler struct {
 	authUC     *usecase.AuthUseCase
 	userUC     *usecase.UserUseCase
 	setupInput *usecase.SetupAdminInput
+	cookies    CookieConfig
 }
 
-func NewAuthHandler(authUC *usecase.AuthUseCase, userUC *usecase.UserUseCase, setupInput *usecase.SetupAdminInput) *AuthHandler {
-	return &AuthHandler{authUC: authUC, userUC: userUC, setupInput: setupInput}
+// CookieConfig holds the attributes shared by the session and CSRF cookies.
+// Logout clears the cookies with the same attributes, since browsers only
+// drop a cookie when domain and path match the one they stored.
+type CookieConfig struct {
+	Domain   string
+	Path     string
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// DefaultCookieConfig returns Secure, SameSite=Lax cookies scoped to "/".
+func DefaultCookieConfig() CookieConfig {
+	return CookieConfig{Path: "/", Secure: true, SameSite: http.SameSiteLaxMode}
+}
+
+func (c CookieConfig) cookie(name, value string, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		HttpOnly: httpOnly,
+		Secure:   c.Secure,
+		SameSite: c.SameSite,
+	}
+}
+
+func NewAuthHandler(authUC *usecase.AuthUseCase, userUC *usecase.UserUseCase, setupInput *usecase.SetupAdminInput, cookies CookieConfig) *AuthHandler {
+	return &AuthHandler{authUC: authUC, userUC: userUC, setupInput: setupInput, cookies: cookies}
 }
 
 func (h *AuthHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /auth/setup", h.SetupAdmin)
 	mux.HandleFunc("POST /auth/register", h.Register)
 	mux.HandleFunc("POST /auth/login", h.Login)
+	mux.HandleFunc("POST /auth/refresh", h.Refresh)
 	mux.HandleFunc("POST /auth/logout", h.Logout)
 	mux.HandleFunc("POST /auth/verify-email", h.VerifyEmail)
 	mux.HandleFunc("POST /auth/request-verification", h.RequestVerification)
 }
 
+// RegisterImpersonationRoutes registers the "view as user" endpoints. Stopping
+// only requires authentication since the session then belongs to the target.
+func (h *AuthHandler) RegisterImpersonationRoutes(mux *http.ServeMux, adminMW, authMW func(http.Handler) http.Handler) {
+	mux.Handle("POST /admin/impersonate/stop", authMW(http.HandlerFunc(h.StopImpersonation)))
+	mux.Handle("POST /admin/impersonate/{userId}", adminMW(http.HandlerFunc(h.Impersonate)))
+}
+
+// RegisterSessionRoutes registers the routes that act on the caller's own
+// sessions. Impersonating admins cannot end the target's sessions.
+func (h *AuthHandler) RegisterSessionRoutes(mux *http.ServeMux, mw func(http.Handler) http.Handler) {
+	mux.Handle("POST /auth/logout-all", mw(middleware.RejectImpersonation(http.HandlerFunc(h.LogoutAll))))
+}
+
 func (h *AuthHandler) RegisterProtectedRoutes(mux *http.ServeMux, mw func(http.Handler) http.Handler) {
 	mux.Handle("POST /auth/resend-verification", mw(http.HandlerFunc(h.ResendVerification)))
 }
@@ -66,18 +110,19 @@ func (h *AuthHandler) SetupAdmin(w http.ResponseWriter, r *http.Request) {
 // @Success     201  {object} dto.UserResponse
 // @Failure     400  {object} apperror.AppError
 // @Failure     409  {object} apperror.AppError
+// @Failure     422  {object} apperror.AppError
 // @Failure     500  {object} apperror.AppError
 // @Router      /auth/register [post]
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req dto.RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	input := usecase.CreateUserInput{
 		Name:     req.Name,
-		Email:    strings.ToLower(strings.TrimSpace(req.Email)),
+		Email:    req.Email,
 		Password: req.Password,
 		Role:     entity.UserRoleRegular,
 	}
@@ -105,57 +150,198 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 // @Router      /auth/login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req dto.LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	output, err := h.authUC.Login(r.Context(), usecase.LoginInput{
-		Email:    strings.ToLower(strings.TrimSpace(req.Email)),
+		Email:    req.Email,
 		Password: req.Password,
+		Client:   clientInfo(r),
 	})
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     jwt.CookieName,
-		Value:    output.Token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteNoneMode,
-		Expires:  time.Unix(output.ExpiresAt, 0),
-	})
+	h.writeSession(w, output)
+}
+
+// Refresh godoc
+// @Summary     Refresh the session token
+// @Description Issues a new access token for the current session, even if the previous one has expired, as long as the session itself is live
+// @Tags        auth
+// @Produce     json
+// @Success     200 {object} dto.LoginResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(jwt.CookieName)
+	if err != nil {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	output, err := h.authUC.Refresh(r.Context(), cookie.Value)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	h.writeSession(w, output)
+}
+
+// writeSession sets the JWT and CSRF cookies for a freshly issued token and
+// responds with the login payload. The cookies outlive the access token so
+// it can still be refreshed until the session ends; Max-Age mirrors Expires
+// so the lifetime holds even against a skewed client clock.
+func (h *AuthHandler) writeSession(w http.ResponseWriter, output *usecase.LoginOutput) {
+	expiresAt := time.Unix(output.SessionExpiresAt, 0)
+	maxAge := max(1, int(time.Until(expiresAt).Seconds()))
+
+	token := h.cookies.cookie(jwt.CookieName, output.Token, true)
+	token.Expires = expiresAt
+	token.MaxAge = maxAge
+	http.SetCookie(w, token)
+
+	csrfToken, err := generateCSRFToken()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	csrf := h.cookies.cookie(middleware.CSRFCookieName, csrfToken, false)
+	csrf.Expires = expiresAt
+	csrf.MaxAge = maxAge
+	http.SetCookie(w, csrf)
 
 	response.JSON(w, http.StatusOK, dto.LoginResponse{
-		Token:     output.Token,
-		ExpiresAt: output.ExpiresAt,
+		Token:            output.Token,
+		CSRFToken:        csrfToken,
+		ExpiresAt:        output.ExpiresAt,
+		SessionExpiresAt: output.SessionExpiresAt,
 	})
 }
 
 // Logout godoc
 // @Summary     Logout
-// @Description Clears the JWT cookie
+// @Description Revokes the current session and clears the JWT and CSRF cookies
 // @Tags        auth
 // @Produce     json
 // @Success     204 "No Content"
+// @Failure     500 {object} apperror.AppError
 // @Router      /auth/logout [post]
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     jwt.CookieName,
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteNoneMode,
-		MaxAge:   -1,
-	})
+	if cookie, err := r.Cookie(jwt.CookieName); err == nil {
+		if err := h.authUC.Logout(r.Context(), cookie.Value); err != nil {
+			response.Error(w, err)
+			return
+		}
+	}
+
+	h.clearSession(w)
+	w.WriteHeader(http.StatusNoContent)
+}
 
+// LogoutAll godoc
+// @Summary     Logout everywhere
+// @Description Revokes every session of the current user, on all devices, and clears the JWT and CSRF cookies
+// @Tags        auth
+// @Produce     json
+// @Security    CookieAuth
+// @Success     204 "No Content"
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	if err := h.authUC.LogoutAll(r.Context(), middleware.UserPublicID(r.Context())); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	h.clearSession(w)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// clearSession expires the JWT and CSRF cookies.
+func (h *AuthHandler) clearSession(w http.ResponseWriter) {
+	token := h.cookies.cookie(jwt.CookieName, "", true)
+	token.MaxAge = -1
+	http.SetCookie(w, token)
+
+	csrf := h.cookies.cookie(middleware.CSRFCookieName, "", false)
+	csrf.MaxAge = -1
+	http.SetCookie(w, csrf)
+}
+
+// Impersonate godoc
+// @Summary     Impersonate a user
+// @Description Replaces the admin's session with a short-lived session of the target user. The start and stop are recorded, and requests made with it are audited with both actors (admin only)
+// @Tags        auth
+// @Produce     json
+// @Security    CookieAuth
+// @Param       userId path     string true "Target user public ID (UUID)"
+// @Success     200    {object} dto.LoginResponse
+// @Failure     401    {object} apperror.AppError
+// @Failure     403    {object} apperror.AppError
+// @Failure     404    {object} apperror.AppError
+// @Failure     500    {object} apperror.AppError
+// @Router      /admin/impersonate/{userId} [post]
+func (h *AuthHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	output, err := h.authUC.Impersonate(ctx, middleware.UserPublicID(ctx), middleware.SessionPublicID(ctx), r.PathValue("userId"), clientInfo(r))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	h.writeSession(w, output)
+}
+
+// StopImpersonation godoc
+// @Summary     Stop impersonating
+// @Description Ends an impersonated session and restores the admin session it was started from. Answers 401 if that session has ended, so the admin must log in again
+// @Tags        auth
+// @Produce     json
+// @Security    CookieAuth
+// @Success     200 {object} dto.LoginResponse
+// @Failure     400 {object} apperror.AppError
+// @Failure     401 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /admin/impersonate/stop [post]
+func (h *AuthHandler) StopImpersonation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	output, err := h.authUC.StopImpersonation(ctx, middleware.ImpersonatorPublicID(ctx), middleware.SessionPublicID(ctx), clientInfo(r))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	h.writeSession(w, output)
+}
+
+// clientInfo describes the device behind r for the session list. The address
+// is the peer of the connection, so behind a reverse proxy it is the proxy's.
+func clientInfo(r *http.Request) usecase.ClientInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return usecase.ClientInfo{UserAgent: r.UserAgent(), IPAddress: ip}
+}
+
+func generateCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // VerifyEmail godoc
 // @Summary     Verify email
 // @Description Verifies a user's email using a verification token
@@ -170,8 +356,8 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 // @Router      /auth/verify-email [post]
 func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	var req dto.VerifyEmailRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -197,13 +383,13 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 // @Param       body body     dto.RequestVerificationRequest true "Email"
 // @Success     204  "No Content"
 // @Failure     400  {object} apperror.AppError
-// @Failure     429  {object} apperror.AppError
+// @Failure     429  {object} apperror.AppError "retry_after_seconds and the Retry-After header hold the remaining cooldown"
 // @Failure     500  {object} apperror.AppError
 // @Router      /auth/request-verification [post]
 func (h *AuthHandler) RequestVerification(w http.ResponseWriter, r *http.Request) {
 	var req dto.RequestVerificationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -229,12 +415,13 @@ func (h *AuthHandler) RequestVerification(w http.ResponseWriter, r *http.Request
 // @Failure     403  {object} apperror.AppError
 // @Failure     404  {object} apperror.AppError
 // @Failure     409  {object} apperror.AppError
+// @Failure     429  {object} apperror.AppError "retry_after_seconds and the Retry-After header hold the remaining cooldown"
 // @Failure     500  {object} apperror.AppError
 // @Router      /auth/resend-verification [post]
 func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
 	var req dto.ResendVerificationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/adapter/middleware"
+	"proximos-passos/backend/internal/infrastructure/jwt"
+	"proximos-passos/backend/internal/usecase"
+)
+
+func cookiesByName(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := make(map[string]*http.Cookie)
+	for _, c := range rec.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	return cookies
+}
+
+func TestSessionCookiesUseConfiguredAttributes(t *testing.T) {
+	h := &AuthHandler{cookies: CookieConfig{
+		Domain:   "example.com",
+		Path:     "/api",
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+	}}
+	sessionTTL := 24 * time.Hour
+	sessionExpiresAt := time.Now().Add(sessionTTL).Unix()
+
+	rec := httptest.NewRecorder()
+	h.writeSession(rec, &usecase.LoginOutput{Token: "token", SessionExpiresAt: sessionExpiresAt})
+	cookies := cookiesByName(rec)
+
+	for name, httpOnly := range map[string]bool{jwt.CookieName: true, middleware.CSRFCookieName: false} {
+		c := cookies[name]
+		if c == nil {
+			t.Fatalf("%s cookie was not set", name)
+		}
+		if c.Domain != "example.com" || c.Path != "/api" || !c.Secure || c.SameSite != http.SameSiteNoneMode {
+			t.Errorf("%s cookie = Domain %q, Path %q, Secure %v, SameSite %v; want the configured attributes", name, c.Domain, c.Path, c.Secure, c.SameSite)
+		}
+		if c.HttpOnly != httpOnly {
+			t.Errorf("%s cookie HttpOnly = %v, want %v", name, c.HttpOnly, httpOnly)
+		}
+		if want := int(sessionTTL.Seconds()); c.MaxAge < want-5 || c.MaxAge > want {
+			t.Errorf("%s cookie Max-Age = %d, want about %d", name, c.MaxAge, want)
+		}
+		if c.Expires.Unix() != sessionExpiresAt {
+			t.Errorf("%s cookie Expires = %v, want %v", name, c.Expires, time.Unix(sessionExpiresAt, 0))
+		}
+	}
+	if cookies[jwt.CookieName].Value != "token" {
+		t.Errorf("session cookie value = %q, want the token", cookies[jwt.CookieName].Value)
+	}
+}
+
+func TestDefaultCookieConfig(t *testing.T) {
+	h := &AuthHandler{cookies: DefaultCookieConfig()}
+	rec := httptest.NewRecorder()
+	h.writeSession(rec, &usecase.LoginOutput{Token: "token", SessionExpiresAt: time.Now().Add(time.Hour).Unix()})
+
+	c := cookiesByName(rec)[jwt.CookieName]
+	if c == nil {
+		t.Fatal("session cookie was not set")
+	}
+	if !c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteLaxMode || c.Path != "/" || c.Domain != "" {
+		t.Errorf("cookie = HttpOnly %v, Secure %v, SameSite %v, Path %q, Domain %q; want HttpOnly, Secure, Lax, \"/\" and no domain", c.HttpOnly, c.Secure, c.SameSite, c.Path, c.Domain)
+	}
+}
+
+func TestClearSessionExpiresCookiesWithMatchingAttributes(t *testing.T) {
+	h := &AuthHandler{cookies: CookieConfig{Domain: "example.com", Path: "/api", Secure: true, SameSite: http.SameSiteStrictMode}}
+	rec := httptest.NewRecorder()
+	h.clearSession(rec)
+	cookies := cookiesByName(rec)
+
+	for _, name := range []string{jwt.CookieName, middleware.CSRFCookieName} {
+		c := cookies[name]
+		if c == nil {
+			t.Fatalf("%s cookie was not cleared", name)
+		}
+		if c.Value != "" || c.MaxAge >= 0 {
+			t.Errorf("%s cookie = value %q, Max-Age %d; want an empty, expired cookie", name, c.Value, c.MaxAge)
+		}
+		if c.Domain != "example.com" || c.Path != "/api" {
+			t.Errorf("%s cookie = Domain %q, Path %q; want the configured ones so the browser drops it", name, c.Domain, c.Path)
+		}
+	}
+}
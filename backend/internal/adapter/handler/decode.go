@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+// decodeJSON decodes the JSON body of r into v. Bodies cut off by the
+// MaxBytes middleware answer 413, anything else that fails to parse 400.
+func decodeJSON(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return bodyError(err, apperror.ErrInvalidBody)
+	}
+	return nil
+}
+
+// bodyError maps a failure reading the request body to ErrRequestTooLarge
+// when the body went past its limit, and to fallback otherwise.
+func bodyError(err error, fallback *apperror.AppError) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return apperror.ErrRequestTooLarge
+	}
+	return fallback
+}
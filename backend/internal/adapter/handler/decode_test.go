@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"valid", `{"name":"a"}`, nil},
+		{"malformed", `{"name":`, apperror.ErrInvalidBody},
+		{"over the body limit", `{"name":"` + strings.Repeat("a", 64) + `"}`, apperror.ErrRequestTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, 32)
+
+			var v struct{ Name string }
+			if err := decodeJSON(r, &v); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"proximos-passos/backend/internal/domain/service"
+)
+
+// streamFile writes obj as a download named filename, relaying the partial
+// response details when obj holds only the requested range. It closes the
+// object body.
+func streamFile(w http.ResponseWriter, r *http.Request, obj *service.StorageObject, filename string) {
+	defer obj.Body.Close()
+
+	contentType := obj.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	h.Set("Accept-Ranges", "bytes")
+	h.Set("Cache-Control", "private, no-cache")
+	if obj.ContentLength > 0 {
+		h.Set("Content-Length", strconv.FormatInt(obj.ContentLength, 10))
+	}
+	if obj.ETag != "" {
+		h.Set("ETag", obj.ETag)
+	}
+	if !obj.LastModified.IsZero() {
+		h.Set("Last-Modified", obj.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	status := http.StatusOK
+	if obj.ContentRange != "" {
+		h.Set("Content-Range", obj.ContentRange)
+		status = http.StatusPartialContent
+	}
+	w.WriteHeader(status)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, obj.Body); err != nil {
+		log.Printf("download of %q interrupted: %v", filename, err)
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/usecase"
+)
+
+type EmailOutboxHandler struct {
+	uc *usecase.EmailOutboxUseCase
+}
+
+func NewEmailOutboxHandler(uc *usecase.EmailOutboxUseCase) *EmailOutboxHandler {
+	return &EmailOutboxHandler{uc: uc}
+}
+
+func (h *EmailOutboxHandler) RegisterRoutes(mux *http.ServeMux, adminMW func(http.Handler) http.Handler) {
+	mux.Handle("GET /emails/failed", adminMW(http.HandlerFunc(h.ListFailed)))
+	mux.Handle("POST /emails/{id}/retry", adminMW(http.HandlerFunc(h.Retry)))
+}
+
+// ListFailed godoc
+// @Summary     List failed emails
+// @Description Returns a paginated list of queued emails that failed permanently after exhausting their retries (admin only)
+// @Tags        emails
+// @Produce     json
+// @Security    CookieAuth
+// @Param       page_number query    int false "Page number" default(1)
+// @Param       page_size   query    int false "Page size"   default(20)
+// @Success     200         {object} dto.OutboxEmailListResponse
+// @Failure     401         {object} apperror.AppError
+// @Failure     403         {object} apperror.AppError
+// @Failure     500         {object} apperror.AppError
+// @Router      /emails/failed [get]
+func (h *EmailOutboxHandler) ListFailed(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
+
+	emails, totalItems, err := h.uc.ListFailed(r.Context(), pageSize, offset)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSON(w, http.StatusOK, dto.OutboxEmailListResponse{
+		Data:       dto.OutboxEmailsToResponse(emails),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	})
+}
+
+// Retry godoc
+// @Summary     Retry a failed email
+// @Description Puts a permanently failed email back in the delivery queue with a fresh attempt budget (admin only)
+// @Tags        emails
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path  string true "Email public ID (UUID)"
+// @Success     204 "No Content"
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /emails/{id}/retry [post]
+func (h *EmailOutboxHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	if err := h.uc.Retry(r.Context(), r.PathValue("id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
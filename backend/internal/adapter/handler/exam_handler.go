@@ -1,14 +1,12 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
 	"proximos-passos/backend/internal/adapter/response"
-	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/usecase"
 )
@@ -49,8 +47,8 @@ func (h *ExamHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func(ht
 // @Router      /exams [post]
 func (h *ExamHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateExamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -61,6 +59,7 @@ func (h *ExamHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Title:         req.Title,
 		Description:   req.Description,
 		Year:          req.Year,
+		Phase:         req.Phase,
 	}
 
 	exam, err := h.uc.Create(r.Context(), userPublicID, input)
@@ -69,7 +68,7 @@ func (h *ExamHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.ExamToResponse(exam))
+	response.Created(w, "/exams/"+exam.PublicID, dto.ExamToResponse(exam))
 }
 
 // List godoc
@@ -79,7 +78,7 @@ func (h *ExamHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number    query    int    false "Page number" default(1)
-// @Param       page_size      query    int    false "Page size"   default(10)
+// @Param       page_size      query    int    false "Page size"   default(20)
 // @Param       institution_id query    string false "Filter by institution ID (UUID)"
 // @Param       year           query    int    false "Filter by year"
 // @Success     200            {object} dto.ExamListResponse
@@ -88,8 +87,7 @@ func (h *ExamHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Failure     500            {object} apperror.AppError
 // @Router      /exams [get]
 func (h *ExamHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.ExamFilter{
 		InstitutionID: r.URL.Query().Get("institution_id"),
@@ -101,29 +99,20 @@ func (h *ExamHandler) List(w http.ResponseWriter, r *http.Request) {
 			filter.Year = &year
 		}
 	}
-	exams, totalItems, totalPages, err := h.uc.List(r.Context(), pageNumber, pageSize, filter)
+	exams, totalItems, err := h.uc.List(r.Context(), pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	response.JSON(w, http.StatusOK, dto.ExamListResponse{
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.ExamListResponse{
 		Data:       dto.ExamsToResponse(exams),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, "")
 }
 
 // GetByID godoc
@@ -172,8 +161,8 @@ func (h *ExamHandler) Update(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
 	var req dto.UpdateExamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -182,6 +171,7 @@ func (h *ExamHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Title:         req.Title,
 		Description:   req.Description,
 		Year:          req.Year,
+		Phase:         req.Phase,
 	}
 
 	exam, err := h.uc.Update(r.Context(), publicID, input)
@@ -215,12 +205,6 @@ func (h *ExamHandler) GetDetails(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	questionCount, err := h.qRepo.CountByExamID(r.Context(), exam.ID)
-	if err != nil {
-		response.Error(w, err)
-		return
-	}
-
 	topicIDs, err := h.qRepo.TopicPublicIDsByExamID(r.Context(), exam.ID)
 	if err != nil {
 		response.Error(w, err)
@@ -229,7 +213,7 @@ func (h *ExamHandler) GetDetails(w http.ResponseWriter, r *http.Request) {
 
 	resp := dto.ExamDetailResponse{
 		Exam:          dto.ExamToResponse(exam),
-		QuestionCount: questionCount,
+		QuestionCount: exam.QuestionCount,
 		TopicIDs:      topicIDs,
 	}
 
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
@@ -22,12 +20,13 @@ func NewGroupHandler(uc *usecase.GroupUseCase) *GroupHandler {
 	return &GroupHandler{uc: uc}
 }
 
-func (h *GroupHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func(http.Handler) http.Handler) {
+func (h *GroupHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW, idempotentMW func(http.Handler) http.Handler) {
 	mux.Handle("GET /groups", authMW(http.HandlerFunc(h.List)))
 	mux.Handle("GET /groups/{id}", authMW(http.HandlerFunc(h.GetByID)))
 	mux.Handle("GET /groups/{id}/preview", authMW(http.HandlerFunc(h.GetPreview)))
-	mux.Handle("POST /groups", authMW(http.HandlerFunc(h.Create)))
+	mux.Handle("POST /groups", authMW(idempotentMW(http.HandlerFunc(h.Create))))
 	mux.Handle("PUT /groups/{id}", adminMW(http.HandlerFunc(h.Update)))
+	mux.Handle("PATCH /groups/{id}", adminMW(http.HandlerFunc(h.Update)))
 	mux.Handle("DELETE /groups/{id}", adminMW(http.HandlerFunc(h.Delete)))
 	mux.Handle("PUT /groups/{id}/thumbnail", adminMW(http.HandlerFunc(h.UploadThumbnail)))
 	mux.Handle("DELETE /groups/{id}/thumbnail", adminMW(http.HandlerFunc(h.DeleteThumbnail)))
@@ -35,22 +34,31 @@ func (h *GroupHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func(h
 
 func (h *GroupHandler) RegisterSelfRoutes(mux *http.ServeMux, mw func(http.Handler) http.Handler) {
 	mux.Handle("GET /me/groups", mw(http.HandlerFunc(h.ListMyGroups)))
+	mux.Handle("DELETE /me/groups/{id}", mw(http.HandlerFunc(h.LeaveGroup)))
 }
 
 func (h *GroupHandler) RegisterMemberRoutes(mux *http.ServeMux, adminMW, authMW func(http.Handler) http.Handler) {
 	mux.Handle("POST /groups/{id}/join", authMW(http.HandlerFunc(h.JoinGroup)))
+	mux.Handle("POST /groups/join", authMW(http.HandlerFunc(h.JoinGroupByCode)))
 	mux.Handle("GET /groups/{id}/membership", authMW(http.HandlerFunc(h.CheckMembership)))
 	mux.Handle("POST /groups/{id}/members", adminMW(http.HandlerFunc(h.AddMember)))
+	mux.Handle("POST /groups/{id}/members/import", adminMW(http.HandlerFunc(h.ImportMembers)))
 	mux.Handle("GET /groups/{id}/members", authMW(http.HandlerFunc(h.ListMembers)))
 	mux.Handle("PUT /groups/{id}/members/{userId}", adminMW(http.HandlerFunc(h.UpdateMemberRole)))
 	mux.Handle("DELETE /groups/{id}/members/{userId}", adminMW(http.HandlerFunc(h.RemoveMember)))
 
 	// Group-admin routes (authMW — group admin check is in use case)
 	mux.Handle("PUT /groups/{id}/admin/update", authMW(http.HandlerFunc(h.UpdateAsGroupAdmin)))
+	mux.Handle("PATCH /groups/{id}/admin/update", authMW(http.HandlerFunc(h.UpdateAsGroupAdmin)))
 	mux.Handle("PUT /groups/{id}/admin/thumbnail", authMW(http.HandlerFunc(h.UploadThumbnailAsGroupAdmin)))
 	mux.Handle("DELETE /groups/{id}/admin/thumbnail", authMW(http.HandlerFunc(h.DeleteThumbnailAsGroupAdmin)))
+	mux.Handle("POST /groups/{id}/admin/join-code", authMW(http.HandlerFunc(h.RotateJoinCode)))
+	mux.Handle("GET /groups/{id}/admin/upload-settings", authMW(http.HandlerFunc(h.GetUploadSettings)))
+	mux.Handle("PUT /groups/{id}/admin/upload-settings", authMW(http.HandlerFunc(h.UpdateUploadSettings)))
 	mux.Handle("GET /groups/{id}/members/pending", authMW(http.HandlerFunc(h.ListPendingMembers)))
 	mux.Handle("POST /groups/{id}/members/{userId}/approve", authMW(http.HandlerFunc(h.ApproveMember)))
+	mux.Handle("POST /groups/{id}/members/pending/approve", authMW(http.HandlerFunc(h.ApproveMembers)))
+	mux.Handle("POST /groups/{id}/members/pending/approve-all", authMW(http.HandlerFunc(h.ApproveAllPending)))
 	mux.Handle("POST /groups/{id}/members/{userId}/reject", authMW(http.HandlerFunc(h.RejectMember)))
 	mux.Handle("DELETE /groups/{id}/admin/members/{userId}", authMW(http.HandlerFunc(h.RemoveMemberAsGroupAdmin)))
 	mux.Handle("PUT /groups/{id}/admin/members/{userId}/role", authMW(http.HandlerFunc(h.UpdateMemberRoleAsGroupAdmin)))
@@ -72,8 +80,8 @@ func (h *GroupHandler) RegisterMemberRoutes(mux *http.ServeMux, adminMW, authMW
 // @Router      /groups [post]
 func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateGroupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -97,7 +105,7 @@ func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.GroupToResponse(group))
+	response.Created(w, "/groups/"+group.PublicID, dto.GroupToResponse(group))
 }
 
 // List godoc
@@ -107,52 +115,42 @@ func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query    int false "Page number" default(1)
-// @Param       page_size   query    int false "Page size"   default(10)
+// @Param       page_size   query    int false "Page size"   default(20)
+// @Param       sort        query    string false "Sort key: created_at, updated_at or name; prefix with - for descending"
 // @Success     200         {object} dto.GroupListResponse
 // @Failure     401         {object} apperror.AppError
 // @Failure     403         {object} apperror.AppError
 // @Failure     500         {object} apperror.AppError
 // @Router      /groups [get]
 func (h *GroupHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 	userRole := middleware.UserRole(r.Context())
 
 	filter := repository.GroupFilter{
 		Name:       r.URL.Query().Get("name"),
 		AccessType: r.URL.Query().Get("access_type"),
+		Sort:       r.URL.Query().Get("sort"),
 	}
 
-	groups, totalItems, err := h.uc.List(r.Context(), pageNumber, pageSize, userRole, filter)
+	groups, totalItems, err := h.uc.List(r.Context(), pageSize, offset, userRole, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	totalPages := (totalItems + pageSize - 1) / pageSize
-
-	response.JSON(w, http.StatusOK, dto.GroupListResponse{
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.GroupListResponse{
 		Data:       dto.GroupsToResponse(groups),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, string(userRole))
 }
 
 // GetByID godoc
 // @Summary     Get a group
-// @Description Returns a group by its public ID
+// @Description Returns a group by its public ID, with can_edit and can_manage_members flags for the caller
 // @Tags        groups
 // @Produce     json
 // @Security    CookieAuth
@@ -174,31 +172,64 @@ func (h *GroupHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.GroupToResponse(group))
+	pendingCount, err := h.uc.PendingMemberCount(r.Context(), group, userPublicID, userRole)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	requester, err := h.uc.Permissions(r.Context(), group, userPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	resp := dto.GroupToResponseFor(group, requester)
+	resp.PendingMembersCount = pendingCount
+	if pendingCount != nil {
+		// Same audience as the pending count: platform admins and group staff.
+		resp.JoinCode = group.JoinCode
+	}
+	response.JSON(w, http.StatusOK, resp)
 }
 
 // GetPreview godoc
 // @Summary     Get group preview
-// @Description Returns basic group info for join page (ignores visibility)
+// @Description Returns basic group info for the join page with member and admin counts and the caller's membership. Private groups are hidden unless the caller already has a membership or passes the join code
 // @Tags        groups
 // @Produce     json
 // @Security    CookieAuth
-// @Param       id  path     string true "Group public ID (UUID)"
-// @Success     200 {object} dto.GroupResponse
-// @Failure     401 {object} apperror.AppError
-// @Failure     404 {object} apperror.AppError
-// @Failure     500 {object} apperror.AppError
+// @Param       id   path     string true  "Group public ID (UUID)"
+// @Param       code query    string false "Join code of a private group"
+// @Success     200  {object} dto.GroupPreviewResponse
+// @Failure     401  {object} apperror.AppError
+// @Failure     404  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
 // @Router      /groups/{id}/preview [get]
 func (h *GroupHandler) GetPreview(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	group, err := h.uc.GetPreview(r.Context(), publicID)
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	preview, err := h.uc.GetPreview(r.Context(), publicID, userPublicID, r.URL.Query().Get("code"))
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.GroupToResponse(group))
+	response.JSON(w, http.StatusOK, dto.GroupPreviewResponse{
+		GroupResponse: dto.GroupToResponse(preview.Group),
+		MemberCount:   preview.MemberCount,
+		AdminCount:    preview.AdminCount,
+		Membership: dto.MembershipStatusResponse{
+			Status: preview.MembershipStatus,
+			Role:   preview.MembershipRole,
+		},
+	})
 }
 
 // ListMyGroups godoc
@@ -208,8 +239,11 @@ func (h *GroupHandler) GetPreview(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query    int false "Page number" default(1)
-// @Param       page_size   query    int false "Page size"   default(10)
+// @Param       page_size   query    int false "Page size"   default(20)
+// @Param       sort        query    string false "Sort key: created_at, updated_at or name; prefix with - for descending"
+// @Param       role        query    string false "Only groups where the user has this role (admin, supervisor or member)"
 // @Success     200         {object} dto.GroupListResponse
+// @Failure     400         {object} apperror.AppError
 // @Failure     401         {object} apperror.AppError
 // @Failure     500         {object} apperror.AppError
 // @Router      /me/groups [get]
@@ -220,45 +254,63 @@ func (h *GroupHandler) ListMyGroups(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.GroupFilter{
 		Name:           r.URL.Query().Get("name"),
 		AccessType:     r.URL.Query().Get("access_type"),
 		VisibilityType: r.URL.Query().Get("visibility_type"),
+		Sort:           r.URL.Query().Get("sort"),
+		Role:           r.URL.Query().Get("role"),
 	}
 
-	groups, totalItems, err := h.uc.ListMyGroups(r.Context(), userPublicID, pageNumber, pageSize, filter)
+	groups, totalItems, err := h.uc.ListMyGroups(r.Context(), userPublicID, pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.GroupListResponse{
+		Data:       dto.GroupsToResponse(groups),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, userPublicID)
+}
+
+// LeaveGroup godoc
+// @Summary     Leave a group
+// @Description Removes the authenticated user's membership of a group. The group's only admin cannot leave until another admin is promoted.
+// @Tags        me
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Group public ID (UUID)"
+// @Success     204 "No Content"
+// @Failure     401 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError "Only admin of the group"
+// @Failure     500 {object} apperror.AppError
+// @Router      /me/groups/{id} [delete]
+func (h *GroupHandler) LeaveGroup(w http.ResponseWriter, r *http.Request) {
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
 	}
 
-	totalPages := (totalItems + pageSize - 1) / pageSize
+	if err := h.uc.Leave(r.Context(), r.PathValue("id"), userPublicID); err != nil {
+		response.Error(w, err)
+		return
+	}
 
-	response.JSON(w, http.StatusOK, dto.GroupListResponse{
-		Data:       dto.GroupsToResponse(groups),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // Update godoc
 // @Summary     Update a group
-// @Description Updates group fields by its public ID
+// @Description Updates group fields by its public ID. PATCH also accepts a null description, which clears it
 // @Tags        groups
 // @Accept      json
 // @Produce     json
@@ -272,18 +324,23 @@ func (h *GroupHandler) ListMyGroups(w http.ResponseWriter, r *http.Request) {
 // @Failure     404  {object} apperror.AppError
 // @Failure     500  {object} apperror.AppError
 // @Router      /groups/{id} [put]
+// @Router      /groups/{id} [patch]
 func (h *GroupHandler) Update(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	var req dto.UpdateGroupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	req, err := decodeUpdate[dto.UpdateGroupRequest, dto.PatchGroupRequest](r)
+	if err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	input := usecase.UpdateGroupInput{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:                 req.Name,
+		Description:          req.Description,
+		LeaderboardEnabled:   req.LeaderboardEnabled,
+		LeaderboardAnonymous: req.LeaderboardAnonymous,
+		AllowPastDue:         req.AllowPastDue,
+		TimeZone:             req.TimeZone,
 	}
 
 	if req.AccessType != nil {
@@ -348,8 +405,8 @@ func (h *GroupHandler) Delete(w http.ResponseWriter, r *http.Request) {
 func (h *GroupHandler) UploadThumbnail(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	if err := r.ParseMultipartForm(5 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -394,6 +451,67 @@ func (h *GroupHandler) DeleteThumbnail(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, dto.GroupToResponse(group))
 }
 
+// ImportMembers godoc
+// @Summary     Import members from CSV
+// @Description Adds the users listed in a CSV file of "email,role" rows to a group. Each row is processed independently and reported with its outcome (added, approved, already_member, role_updated, invited or failed with a reason code). Emails without an account are invited to sign up and join the group once they verify that email.
+// @Tags        group-members
+// @Accept      multipart/form-data
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id   path     string true "Group public ID (UUID)"
+// @Param       file formData file   true "CSV file (max 1MB, up to 1000 rows)"
+// @Success     200  {object} dto.MemberImportResponse
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     403  {object} apperror.AppError
+// @Failure     404  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /groups/{id}/members/import [post]
+func (h *GroupHandler) ImportMembers(w http.ResponseWriter, r *http.Request) {
+	groupPublicID := r.PathValue("id")
+
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		response.Error(w, apperror.ErrInvalidInput)
+		return
+	}
+	defer file.Close()
+
+	creatorPublicID := middleware.UserPublicID(r.Context())
+
+	results, err := h.uc.ImportMembers(r.Context(), groupPublicID, creatorPublicID, file)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	resp := dto.MemberImportResponse{
+		Results: make([]dto.MemberImportRowResponse, len(results)),
+		Summary: make(map[string]int),
+	}
+	for i, res := range results {
+		row := dto.MemberImportRowResponse{
+			Row:    res.Row,
+			Email:  res.Email,
+			Role:   string(res.Role),
+			Status: res.Status,
+		}
+		if res.Reason != "" {
+			reason := string(res.Reason)
+			row.Reason = &reason
+		}
+		resp.Results[i] = row
+		resp.Summary[res.Status]++
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
 // AddMember godoc
 // @Summary     Add a member to a group
 // @Description Adds a user as a member of a group
@@ -415,8 +533,8 @@ func (h *GroupHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 	groupPublicID := r.PathValue("id")
 
 	var req dto.AddMemberRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -468,7 +586,8 @@ func (h *GroupHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 // @Security    CookieAuth
 // @Param       id          path     string true  "Group public ID (UUID)"
 // @Param       page_number query    int    false  "Page number" default(1)
-// @Param       page_size   query    int    false  "Page size"   default(10)
+// @Param       page_size   query    int    false  "Page size"   default(20)
+// @Param       sort        query    string false  "Sort key: joined_at (default, newest first), name, email or role (admins, supervisors, then members, each by name); prefix with - for descending"
 // @Success     200         {object} dto.GroupMemberListResponse
 // @Failure     401         {object} apperror.AppError
 // @Failure     403         {object} apperror.AppError
@@ -477,31 +596,19 @@ func (h *GroupHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 // @Router      /groups/{id}/members [get]
 func (h *GroupHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
 	groupPublicID := r.PathValue("id")
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	requesterPublicID := middleware.UserPublicID(r.Context())
 	requesterRole := middleware.UserRole(r.Context())
 	role := r.URL.Query().Get("role")
+	sort := r.URL.Query().Get("sort")
 
-	members, totalItems, err := h.uc.ListMembers(r.Context(), groupPublicID, requesterPublicID, requesterRole, pageNumber, pageSize, role)
+	members, totalItems, err := h.uc.ListMembers(r.Context(), groupPublicID, requesterPublicID, requesterRole, pageSize, offset, role, sort)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	totalPages := (totalItems + pageSize - 1) / pageSize
-
 	memberResponses := make([]dto.GroupMemberResponse, len(members))
 	for i, m := range members {
 		memberResponses[i] = dto.GroupMemberResponse{
@@ -516,12 +623,13 @@ func (h *GroupHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
 	response.JSON(w, http.StatusOK, dto.GroupMemberListResponse{
 		Data:       memberResponses,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
@@ -540,6 +648,7 @@ func (h *GroupHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
 // @Failure     401    {object} apperror.AppError
 // @Failure     403    {object} apperror.AppError
 // @Failure     404    {object} apperror.AppError
+// @Failure     409    {object} apperror.AppError "Would leave the group without an admin"
 // @Failure     500    {object} apperror.AppError
 // @Router      /groups/{id}/members/{userId} [put]
 func (h *GroupHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
@@ -547,8 +656,8 @@ func (h *GroupHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request)
 	userPublicID := r.PathValue("userId")
 
 	var req dto.UpdateMemberRoleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -576,6 +685,7 @@ func (h *GroupHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request)
 // @Failure     401    {object} apperror.AppError
 // @Failure     403    {object} apperror.AppError
 // @Failure     404    {object} apperror.AppError
+// @Failure     409    {object} apperror.AppError "Would leave the group without an admin"
 // @Failure     500    {object} apperror.AppError
 // @Router      /groups/{id}/members/{userId} [delete]
 func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
@@ -592,13 +702,14 @@ func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
 
 // JoinGroup godoc
 // @Summary     Join a group
-// @Description Allows the authenticated user to join a group
+// @Description Allows the authenticated user to join a public group. Open groups accept immediately; closed groups create a pending request. Private groups require a join code.
 // @Tags        group-members
 // @Produce     json
 // @Security    CookieAuth
 // @Param       id  path     string true "Group public ID (UUID)"
 // @Success     200 {object} map[string]string
 // @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError "Private group"
 // @Failure     404 {object} apperror.AppError
 // @Failure     409 {object} apperror.AppError
 // @Failure     500 {object} apperror.AppError
@@ -626,6 +737,145 @@ func (h *GroupHandler) JoinGroup(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, map[string]string{"status": status})
 }
 
+// JoinGroupByCode godoc
+// @Summary     Join a group with a join code
+// @Description Requests membership of the group holding the code, including private groups
+// @Tags        group-members
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       body body     dto.JoinGroupByCodeRequest true "Join code"
+// @Success     200  {object} map[string]string
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     404  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /groups/join [post]
+func (h *GroupHandler) JoinGroupByCode(w http.ResponseWriter, r *http.Request) {
+	var req dto.JoinGroupByCodeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	group, member, err := h.uc.JoinGroupByCode(r.Context(), req.Code, userPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	status := "pending"
+	if member.AcceptedByID != nil {
+		status = "accepted"
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"group_id": group.PublicID, "status": status})
+}
+
+// RotateJoinCode generates a new join code for the group (group or platform admins)
+func (h *GroupHandler) RotateJoinCode(w http.ResponseWriter, r *http.Request) {
+	groupPublicID := r.PathValue("id")
+
+	requesterPublicID := middleware.UserPublicID(r.Context())
+	if requesterPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	code, err := h.uc.RotateJoinCode(r.Context(), groupPublicID, requesterPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.JoinCodeResponse{JoinCode: code})
+}
+
+// GetUploadSettings godoc
+// @Summary     Get submission upload settings
+// @Description Returns the effective size and type limits for submission attachments in the group (group or platform admins)
+// @Tags        groups
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Group public ID (UUID)"
+// @Success     200 {object} dto.UploadSettingsResponse
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Router      /groups/{id}/admin/upload-settings [get]
+func (h *GroupHandler) GetUploadSettings(w http.ResponseWriter, r *http.Request) {
+	groupPublicID := r.PathValue("id")
+
+	requesterPublicID := middleware.UserPublicID(r.Context())
+	if requesterPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	settings, err := h.uc.GetUploadSettings(r.Context(), groupPublicID, requesterPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, uploadSettingsToResponse(settings))
+}
+
+// UpdateUploadSettings godoc
+// @Summary     Update submission upload settings
+// @Description Replaces the group's submission attachment limits; null fields restore the platform defaults. The max size cannot exceed the platform ceiling (group or platform admins)
+// @Tags        groups
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id   path     string                          true "Group public ID (UUID)"
+// @Param       body body     dto.UpdateUploadSettingsRequest true "Upload settings"
+// @Success     200  {object} dto.UploadSettingsResponse
+// @Failure     403  {object} apperror.AppError
+// @Failure     422  {object} apperror.AppError
+// @Router      /groups/{id}/admin/upload-settings [put]
+func (h *GroupHandler) UpdateUploadSettings(w http.ResponseWriter, r *http.Request) {
+	groupPublicID := r.PathValue("id")
+
+	requesterPublicID := middleware.UserPublicID(r.Context())
+	if requesterPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	var req dto.UpdateUploadSettingsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	settings, err := h.uc.UpdateUploadSettings(r.Context(), groupPublicID, requesterPublicID, usecase.UpdateSubmissionUploadSettingsInput{
+		MaxSizeBytes: req.MaxSizeBytes,
+		AllowedTypes: req.AllowedTypes,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, uploadSettingsToResponse(settings))
+}
+
+func uploadSettingsToResponse(s *usecase.SubmissionUploadSettings) dto.UploadSettingsResponse {
+	return dto.UploadSettingsResponse{
+		MaxSizeBytes:       s.MaxSizeBytes,
+		AllowedTypes:       s.AllowedTypes,
+		MaxSizeCeiling:     s.MaxSizeCeiling,
+		CustomMaxSize:      s.CustomMaxSize,
+		CustomAllowedTypes: s.CustomAllowedTypes,
+	}
+}
+
 // CheckMembership godoc
 // @Summary     Check membership status
 // @Description Returns the authenticated user's membership status for a group
@@ -670,15 +920,19 @@ func (h *GroupHandler) UpdateAsGroupAdmin(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var req dto.UpdateGroupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	req, err := decodeUpdate[dto.UpdateGroupRequest, dto.PatchGroupRequest](r)
+	if err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	input := usecase.UpdateGroupInput{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:                 req.Name,
+		Description:          req.Description,
+		LeaderboardEnabled:   req.LeaderboardEnabled,
+		LeaderboardAnonymous: req.LeaderboardAnonymous,
+		AllowPastDue:         req.AllowPastDue,
+		TimeZone:             req.TimeZone,
 	}
 
 	if req.AccessType != nil {
@@ -709,8 +963,8 @@ func (h *GroupHandler) UploadThumbnailAsGroupAdmin(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if err := r.ParseMultipartForm(5 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -748,11 +1002,10 @@ func (h *GroupHandler) DeleteThumbnailAsGroupAdmin(w http.ResponseWriter, r *htt
 	response.JSON(w, http.StatusOK, dto.GroupToResponse(group))
 }
 
-// ListPendingMembers returns pending member requests for group admins
+// ListPendingMembers returns pending member requests for group staff and platform admins
 func (h *GroupHandler) ListPendingMembers(w http.ResponseWriter, r *http.Request) {
 	groupPublicID := r.PathValue("id")
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	requesterPublicID := middleware.UserPublicID(r.Context())
 	if requesterPublicID == "" {
@@ -760,24 +1013,12 @@ func (h *GroupHandler) ListPendingMembers(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	members, totalItems, err := h.uc.ListPendingMembers(r.Context(), groupPublicID, requesterPublicID, pageNumber, pageSize)
+	members, totalItems, err := h.uc.ListPendingMembers(r.Context(), groupPublicID, requesterPublicID, pageSize, offset)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	totalPages := (totalItems + pageSize - 1) / pageSize
-
 	memberResponses := make([]dto.GroupMemberResponse, len(members))
 	for i, m := range members {
 		memberResponses[i] = dto.GroupMemberResponse{
@@ -792,16 +1033,17 @@ func (h *GroupHandler) ListPendingMembers(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
 	response.JSON(w, http.StatusOK, dto.GroupMemberListResponse{
 		Data:       memberResponses,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
-// ApproveMember approves a pending member request
+// ApproveMember approves a pending member request (group or platform admins)
 func (h *GroupHandler) ApproveMember(w http.ResponseWriter, r *http.Request) {
 	groupPublicID := r.PathValue("id")
 	userPublicID := r.PathValue("userId")
@@ -820,7 +1062,51 @@ func (h *GroupHandler) ApproveMember(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// RejectMember rejects a pending member request
+// ApproveMembers approves the pending requests of the listed users (group or platform admins)
+func (h *GroupHandler) ApproveMembers(w http.ResponseWriter, r *http.Request) {
+	groupPublicID := r.PathValue("id")
+
+	approverPublicID := middleware.UserPublicID(r.Context())
+	if approverPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ApproveMembersRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.uc.ApproveMany(r.Context(), groupPublicID, req.UserPublicIDs, approverPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.BulkApproveResponse{Approved: result.Approved, Skipped: result.Skipped})
+}
+
+// ApproveAllPending approves every pending member request (group or platform admins)
+func (h *GroupHandler) ApproveAllPending(w http.ResponseWriter, r *http.Request) {
+	groupPublicID := r.PathValue("id")
+
+	approverPublicID := middleware.UserPublicID(r.Context())
+	if approverPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	result, err := h.uc.ApproveAllPending(r.Context(), groupPublicID, approverPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.BulkApproveResponse{Approved: result.Approved, Skipped: result.Skipped})
+}
+
+// RejectMember rejects a pending member request (group or platform admins)
 func (h *GroupHandler) RejectMember(w http.ResponseWriter, r *http.Request) {
 	groupPublicID := r.PathValue("id")
 	userPublicID := r.PathValue("userId")
@@ -873,6 +1159,7 @@ func (h *GroupHandler) RemoveMemberAsGroupAdmin(w http.ResponseWriter, r *http.R
 // @Failure     401    {object} apperror.AppError
 // @Failure     403    {object} apperror.AppError
 // @Failure     404    {object} apperror.AppError
+// @Failure     409    {object} apperror.AppError "Would leave the group without an admin"
 // @Failure     500    {object} apperror.AppError
 // @Router      /groups/{id}/admin/members/{userId}/role [put]
 func (h *GroupHandler) UpdateMemberRoleAsGroupAdmin(w http.ResponseWriter, r *http.Request) {
@@ -885,8 +1172,8 @@ func (h *GroupHandler) UpdateMemberRoleAsGroupAdmin(w http.ResponseWriter, r *ht
 	}
 
 	var req dto.UpdateMemberRoleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
@@ -25,8 +23,10 @@ func (h *HandoutHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func
 	mux.Handle("POST /handouts", adminMW(http.HandlerFunc(h.Create)))
 	mux.Handle("GET /handouts", authMW(http.HandlerFunc(h.List)))
 	mux.Handle("GET /handouts/{id}", authMW(http.HandlerFunc(h.GetByID)))
+	mux.Handle("GET /handouts/{id}/download", authMW(http.HandlerFunc(h.Download)))
 	mux.Handle("PUT /handouts/{id}", adminMW(http.HandlerFunc(h.Update)))
 	mux.Handle("POST /handouts/{id}/file", adminMW(http.HandlerFunc(h.ReplaceFile)))
+	mux.Handle("GET /handouts/{id}/usages", adminMW(http.HandlerFunc(h.ListUsages)))
 	mux.Handle("DELETE /handouts/{id}", adminMW(http.HandlerFunc(h.Delete)))
 }
 
@@ -51,8 +51,8 @@ func (h *HandoutHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func
 func (h *HandoutHandler) Create(w http.ResponseWriter, r *http.Request) {
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(50 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -88,7 +88,7 @@ func (h *HandoutHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.HandoutToResponse(handout))
+	response.Created(w, "/handouts/"+handout.PublicID, dto.HandoutToResponse(handout))
 }
 
 // List godoc
@@ -98,7 +98,7 @@ func (h *HandoutHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query int    false "Page number" default(1)
-// @Param       page_size   query int    false "Page size"   default(10)
+// @Param       page_size   query int    false "Page size"   default(20)
 // @Param       title       query string false "Filter by title (partial match)"
 // @Param       topic_id    query string false "Filter by topic public ID (UUID)"
 // @Success     200 {object} dto.HandoutListResponse
@@ -107,8 +107,7 @@ func (h *HandoutHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Failure     500 {object} apperror.AppError
 // @Router      /handouts [get]
 func (h *HandoutHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.HandoutFilter{
 		Title: r.URL.Query().Get("title"),
@@ -123,29 +122,20 @@ func (h *HandoutHandler) List(w http.ResponseWriter, r *http.Request) {
 		filter.TopicIDs = topicIDs
 	}
 
-	handouts, totalItems, totalPages, err := h.uc.List(r.Context(), pageNumber, pageSize, filter)
+	handouts, totalItems, err := h.uc.List(r.Context(), pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	response.JSON(w, http.StatusOK, dto.HandoutListResponse{
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.HandoutListResponse{
 		Data:       dto.HandoutsToResponse(handouts),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, "")
 }
 
 // GetByID godoc
@@ -164,7 +154,7 @@ func (h *HandoutHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *HandoutHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	handout, err := h.uc.GetByPublicID(r.Context(), publicID)
+	handout, err := h.uc.GetByPublicID(r.Context(), publicID, middleware.UserPublicID(r.Context()))
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -173,6 +163,30 @@ func (h *HandoutHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, dto.HandoutToResponse(handout))
 }
 
+// Download godoc
+// @Summary     Download a handout
+// @Description Streams the handout's file and counts the download. Supports Range requests.
+// @Tags        handouts
+// @Produce     octet-stream
+// @Security    CookieAuth
+// @Param       id    path   string true  "Handout public ID (UUID)"
+// @Param       Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success     200 {file} file
+// @Success     206 {file} file
+// @Failure     401 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     416 {object} apperror.AppError
+// @Router      /handouts/{id}/download [get]
+func (h *HandoutHandler) Download(w http.ResponseWriter, r *http.Request) {
+	handout, obj, err := h.uc.OpenDownload(r.Context(), r.PathValue("id"), middleware.UserPublicID(r.Context()), r.Header.Get("Range"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	streamFile(w, r, obj, handout.Filename)
+}
+
 // Update godoc
 // @Summary     Update a handout
 // @Description Updates handout fields by public ID (admin only)
@@ -194,8 +208,8 @@ func (h *HandoutHandler) Update(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
 	var req dto.UpdateHandoutRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -234,8 +248,8 @@ func (h *HandoutHandler) ReplaceFile(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(50 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -263,22 +277,51 @@ func (h *HandoutHandler) ReplaceFile(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, dto.HandoutToResponse(handout))
 }
 
+// ListUsages godoc
+// @Summary     List handout usages
+// @Description Returns the activities, and their groups, whose items reference a handout (admin only)
+// @Tags        handouts
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Handout public ID (UUID)"
+// @Success     200 {object} dto.ContentUsageListResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /handouts/{id}/usages [get]
+func (h *HandoutHandler) ListUsages(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+
+	usages, err := h.uc.ListUsages(r.Context(), publicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ContentUsagesToResponse(usages))
+}
+
 // Delete godoc
 // @Summary     Delete a handout
 // @Description Soft-deletes a handout by public ID (admin only)
 // @Tags        handouts
 // @Security    CookieAuth
-// @Param       id path string true "Handout public ID (UUID)"
+// @Param       id    path  string true  "Handout public ID (UUID)"
+// @Param       force query bool   false "Also remove the activity items that reference it"
 // @Success     204
 // @Failure     401 {object} apperror.AppError
 // @Failure     403 {object} apperror.AppError
 // @Failure     404 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError
 // @Failure     500 {object} apperror.AppError
 // @Router      /handouts/{id} [delete]
 func (h *HandoutHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	if err := h.uc.Delete(r.Context(), publicID); err != nil {
+	force := r.URL.Query().Get("force") == "true"
+
+	if err := h.uc.Delete(r.Context(), publicID, force); err != nil {
 		response.Error(w, err)
 		return
 	}
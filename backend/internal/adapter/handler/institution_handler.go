@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
@@ -29,6 +27,8 @@ func (h *InstitutionHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW
 	mux.Handle("GET /institutions/{id}/details", authMW(http.HandlerFunc(h.GetDetails)))
 	mux.Handle("PUT /institutions/{id}", adminMW(http.HandlerFunc(h.Update)))
 	mux.Handle("DELETE /institutions/{id}", adminMW(http.HandlerFunc(h.Delete)))
+	mux.Handle("PUT /institutions/{id}/logo", adminMW(http.HandlerFunc(h.UploadLogo)))
+	mux.Handle("DELETE /institutions/{id}/logo", adminMW(http.HandlerFunc(h.DeleteLogo)))
 }
 
 // Create godoc
@@ -48,8 +48,8 @@ func (h *InstitutionHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW
 // @Router      /institutions [post]
 func (h *InstitutionHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateInstitutionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -66,7 +66,7 @@ func (h *InstitutionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.InstitutionToResponse(institution))
+	response.Created(w, "/institutions/"+institution.PublicID, dto.InstitutionToResponse(institution))
 }
 
 // List godoc
@@ -76,7 +76,7 @@ func (h *InstitutionHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query    int    false "Page number" default(1)
-// @Param       page_size   query    int    false "Page size"   default(10)
+// @Param       page_size   query    int    false "Page size"   default(20)
 // @Param       name        query    string false "Filter by name or acronym (partial match)"
 // @Success     200         {object} dto.InstitutionListResponse
 // @Failure     401         {object} apperror.AppError
@@ -84,36 +84,26 @@ func (h *InstitutionHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Failure     500         {object} apperror.AppError
 // @Router      /institutions [get]
 func (h *InstitutionHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.InstitutionFilter{
 		Name: r.URL.Query().Get("name"),
 	}
 
-	institutions, totalItems, totalPages, err := h.uc.List(r.Context(), pageNumber, pageSize, filter)
+	institutions, totalItems, err := h.uc.List(r.Context(), pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	response.JSON(w, http.StatusOK, dto.InstitutionListResponse{
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.InstitutionListResponse{
 		Data:       dto.InstitutionsToResponse(institutions),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, "")
 }
 
 // GetByID godoc
@@ -162,8 +152,8 @@ func (h *InstitutionHandler) Update(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
 	var req dto.UpdateInstitutionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -205,6 +195,71 @@ func (h *InstitutionHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UploadLogo godoc
+// @Summary     Upload institution logo
+// @Description Uploads a logo image for an institution (admin only)
+// @Tags        institutions
+// @Accept      multipart/form-data
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id   path     string true "Institution public ID (UUID)"
+// @Param       logo formData file   true "Logo image (max 5MB, jpeg/png/webp/gif)"
+// @Success     200  {object} dto.InstitutionResponse
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     403  {object} apperror.AppError
+// @Failure     404  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /institutions/{id}/logo [put]
+func (h *InstitutionHandler) UploadLogo(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
+		return
+	}
+
+	file, header, err := r.FormFile("logo")
+	if err != nil {
+		response.Error(w, apperror.ErrInvalidInput)
+		return
+	}
+	defer file.Close()
+
+	institution, err := h.uc.UploadLogo(r.Context(), publicID, header.Filename, header.Header.Get("Content-Type"), header.Size, file)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.InstitutionToResponse(institution))
+}
+
+// DeleteLogo godoc
+// @Summary     Delete institution logo
+// @Description Removes the logo of an institution (admin only)
+// @Tags        institutions
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Institution public ID (UUID)"
+// @Success     200 {object} dto.InstitutionResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /institutions/{id}/logo [delete]
+func (h *InstitutionHandler) DeleteLogo(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+
+	institution, err := h.uc.DeleteLogo(r.Context(), publicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.InstitutionToResponse(institution))
+}
+
 // GetDetails godoc
 // @Summary     Get institution details
 // @Description Returns an institution with question count and related topic IDs (admin only)
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/infrastructure/metrics"
+)
+
+type MetricsHandler struct {
+	token      string
+	collectors []metrics.Collector
+}
+
+// NewMetricsHandler serves the given collectors. When token is non-empty,
+// scrapes must send it as a bearer token.
+func NewMetricsHandler(token string, collectors ...metrics.Collector) *MetricsHandler {
+	return &MetricsHandler{token: token, collectors: collectors}
+}
+
+func (h *MetricsHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /metrics", h.Metrics)
+}
+
+// Metrics godoc
+// @Summary     Prometheus metrics
+// @Description Exposes HTTP request and database pool metrics in the Prometheus text format. Requires a bearer token when METRICS_TOKEN is set.
+// @Tags        health
+// @Produce     plain
+// @Success     200 {string} string "Prometheus exposition"
+// @Failure     401 {object} apperror.AppError
+// @Router      /metrics [get]
+func (h *MetricsHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	if h.token != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			response.Error(w, apperror.ErrUnauthorized)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	for _, c := range h.collectors {
+		c.Collect(w)
+	}
+}
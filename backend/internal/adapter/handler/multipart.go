@@ -0,0 +1,48 @@
+package handler
+
+import "proximos-passos/backend/internal/domain/uploadlimits"
+
+// multipartMaxMemory is how much of a multipart body ParseMultipartForm keeps
+// in memory before spilling file parts to disk. It is not a size limit; each
+// use case enforces its configured upload limit.
+const multipartMaxMemory = 32 << 20
+
+// multipartOverhead is the room left on top of an upload's file limit for
+// the form's boundaries, part headers and text fields.
+const multipartOverhead = 1 << 20
+
+// questionUploadImages is how many images a single question request may
+// carry, across the statement and its options.
+const questionUploadImages = 20
+
+// UploadRoutes maps every route that parses a multipart form to the largest
+// body it accepts, for the MaxBytes middleware. The per-file limits are still
+// checked by the use cases; this only bounds what is read off the wire.
+// Submission attachments use the ceiling because groups may raise their own
+// limit up to it.
+func UploadRoutes(limits uploadlimits.Limits) map[string]int64 {
+	ceilings := uploadlimits.Ceilings()
+
+	routes := map[string]int64{
+		"POST /activities/{id}/attachments":           limits.ActivityAttachment,
+		"POST /activity-submissions/{id}/attachments": ceilings.SubmissionAttachment,
+		"POST /question-submissions/{id}/attachment":  ceilings.SubmissionAttachment,
+		"PUT /groups/{id}/thumbnail":                  limits.Thumbnail,
+		"PUT /groups/{id}/admin/thumbnail":            limits.Thumbnail,
+		"PUT /institutions/{id}/logo":                 limits.Thumbnail,
+		"PUT /me/avatar":                              limits.Avatar,
+		"POST /handouts":                              limits.Handout,
+		"POST /handouts/{id}/file":                    limits.Handout,
+		"POST /exercise-lists":                        limits.ExerciseList,
+		"POST /exercise-lists/{id}/file":              limits.ExerciseList,
+		"POST /video-lessons":                         limits.Video,
+		"POST /video-lessons/{id}/file":               limits.Video,
+		"POST /questions":                             questionUploadImages * limits.QuestionImage,
+		"PUT /questions/{id}":                         questionUploadImages * limits.QuestionImage,
+		"POST /questions/{id}/images":                 questionUploadImages * limits.QuestionImage,
+	}
+	for pattern, limit := range routes {
+		routes[pattern] = limit + multipartOverhead
+	}
+	return routes
+}
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
@@ -25,8 +23,10 @@ func (h *OpenExerciseListHandler) RegisterRoutes(mux *http.ServeMux, adminMW, au
 	mux.Handle("POST /exercise-lists", adminMW(http.HandlerFunc(h.Create)))
 	mux.Handle("GET /exercise-lists", authMW(http.HandlerFunc(h.List)))
 	mux.Handle("GET /exercise-lists/{id}", authMW(http.HandlerFunc(h.GetByID)))
+	mux.Handle("GET /exercise-lists/{id}/download", authMW(http.HandlerFunc(h.Download)))
 	mux.Handle("PUT /exercise-lists/{id}", adminMW(http.HandlerFunc(h.Update)))
 	mux.Handle("POST /exercise-lists/{id}/file", adminMW(http.HandlerFunc(h.ReplaceFile)))
+	mux.Handle("GET /exercise-lists/{id}/usages", adminMW(http.HandlerFunc(h.ListUsages)))
 	mux.Handle("DELETE /exercise-lists/{id}", adminMW(http.HandlerFunc(h.Delete)))
 }
 
@@ -52,8 +52,8 @@ func (h *OpenExerciseListHandler) RegisterRoutes(mux *http.ServeMux, adminMW, au
 func (h *OpenExerciseListHandler) Create(w http.ResponseWriter, r *http.Request) {
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(50 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -104,7 +104,7 @@ func (h *OpenExerciseListHandler) Create(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.OpenExerciseListToResponse(oel))
+	response.Created(w, "/exercise-lists/"+oel.PublicID, dto.OpenExerciseListToResponse(oel))
 }
 
 // List godoc
@@ -114,7 +114,7 @@ func (h *OpenExerciseListHandler) Create(w http.ResponseWriter, r *http.Request)
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query int    false "Page number" default(1)
-// @Param       page_size   query int    false "Page size"   default(10)
+// @Param       page_size   query int    false "Page size"   default(20)
 // @Param       title       query string false "Filter by title (partial match)"
 // @Param       topic_id    query string false "Filter by topic public ID (UUID)"
 // @Success     200 {object} dto.OpenExerciseListListResponse
@@ -123,8 +123,7 @@ func (h *OpenExerciseListHandler) Create(w http.ResponseWriter, r *http.Request)
 // @Failure     500 {object} apperror.AppError
 // @Router      /exercise-lists [get]
 func (h *OpenExerciseListHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.OpenExerciseListFilter{
 		Title: r.URL.Query().Get("title"),
@@ -139,29 +138,20 @@ func (h *OpenExerciseListHandler) List(w http.ResponseWriter, r *http.Request) {
 		filter.TopicIDs = topicIDs
 	}
 
-	lists, totalItems, totalPages, err := h.uc.List(r.Context(), pageNumber, pageSize, filter)
+	lists, totalItems, err := h.uc.List(r.Context(), pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	response.JSON(w, http.StatusOK, dto.OpenExerciseListListResponse{
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.OpenExerciseListListResponse{
 		Data:       dto.OpenExerciseListsToResponse(lists),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, "")
 }
 
 // GetByID godoc
@@ -180,7 +170,7 @@ func (h *OpenExerciseListHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *OpenExerciseListHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	oel, err := h.uc.GetByPublicID(r.Context(), publicID)
+	oel, err := h.uc.GetByPublicID(r.Context(), publicID, middleware.UserPublicID(r.Context()))
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -189,6 +179,35 @@ func (h *OpenExerciseListHandler) GetByID(w http.ResponseWriter, r *http.Request
 	response.JSON(w, http.StatusOK, dto.OpenExerciseListToResponse(oel))
 }
 
+// Download godoc
+// @Summary     Download an open exercise list
+// @Description Streams the list's uploaded file and counts the download, or redirects to its external URL. Supports Range requests.
+// @Tags        exercise-lists
+// @Produce     octet-stream
+// @Security    CookieAuth
+// @Param       id    path   string true  "Open exercise list public ID (UUID)"
+// @Param       Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success     200 {file} file
+// @Success     206 {file} file
+// @Success     302
+// @Failure     401 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     416 {object} apperror.AppError
+// @Router      /exercise-lists/{id}/download [get]
+func (h *OpenExerciseListHandler) Download(w http.ResponseWriter, r *http.Request) {
+	oel, obj, err := h.uc.OpenDownload(r.Context(), r.PathValue("id"), middleware.UserPublicID(r.Context()), r.Header.Get("Range"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if obj == nil {
+		http.Redirect(w, r, oel.ResolvedURL, http.StatusFound)
+		return
+	}
+	streamFile(w, r, obj, oel.Filename)
+}
+
 // Update godoc
 // @Summary     Update an open exercise list
 // @Description Updates exercise list fields by public ID (admin only)
@@ -210,8 +229,8 @@ func (h *OpenExerciseListHandler) Update(w http.ResponseWriter, r *http.Request)
 	publicID := r.PathValue("id")
 
 	var req dto.UpdateOpenExerciseListRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -251,8 +270,8 @@ func (h *OpenExerciseListHandler) ReplaceFile(w http.ResponseWriter, r *http.Req
 	publicID := r.PathValue("id")
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(50 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -280,22 +299,51 @@ func (h *OpenExerciseListHandler) ReplaceFile(w http.ResponseWriter, r *http.Req
 	response.JSON(w, http.StatusOK, dto.OpenExerciseListToResponse(oel))
 }
 
+// ListUsages godoc
+// @Summary     List exercise list usages
+// @Description Returns the activities, and their groups, whose items reference an exercise list (admin only)
+// @Tags        exercise-lists
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Exercise list public ID (UUID)"
+// @Success     200 {object} dto.ContentUsageListResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /exercise-lists/{id}/usages [get]
+func (h *OpenExerciseListHandler) ListUsages(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+
+	usages, err := h.uc.ListUsages(r.Context(), publicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ContentUsagesToResponse(usages))
+}
+
 // Delete godoc
 // @Summary     Delete an open exercise list
 // @Description Soft-deletes an exercise list by public ID (admin only)
 // @Tags        exercise-lists
 // @Security    CookieAuth
-// @Param       id path string true "Exercise list public ID (UUID)"
+// @Param       id    path  string true  "Exercise list public ID (UUID)"
+// @Param       force query bool   false "Also remove the activity items that reference it"
 // @Success     204
 // @Failure     401 {object} apperror.AppError
 // @Failure     403 {object} apperror.AppError
 // @Failure     404 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError
 // @Failure     500 {object} apperror.AppError
 // @Router      /exercise-lists/{id} [delete]
 func (h *OpenExerciseListHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	if err := h.uc.Delete(r.Context(), publicID); err != nil {
+	force := r.URL.Query().Get("force") == "true"
+
+	if err := h.uc.Delete(r.Context(), publicID, force); err != nil {
 		response.Error(w, err)
 		return
 	}
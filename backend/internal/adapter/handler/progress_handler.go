@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/middleware"
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/usecase"
+)
+
+type ProgressHandler struct {
+	uc *usecase.ProgressUseCase
+}
+
+func NewProgressHandler(uc *usecase.ProgressUseCase) *ProgressHandler {
+	return &ProgressHandler{uc: uc}
+}
+
+func (h *ProgressHandler) RegisterRoutes(mux *http.ServeMux, authMW func(http.Handler) http.Handler) {
+	mux.Handle("GET /groups/{id}/members/{userId}/progress", authMW(http.HandlerFunc(h.GetMemberProgress)))
+}
+
+// GetMemberProgress godoc
+// @Summary     Get a member's progress in a group
+// @Description Returns, for each activity of the group, the member's submission status and question pass rate. Members can only see their own progress; group admins, supervisors and platform admins can see any member's.
+// @Tags        groups
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id     path     string true "Group public ID (UUID)"
+// @Param       userId path     string true "Member user public ID (UUID)"
+// @Success     200    {array}  dto.ActivityProgressResponse
+// @Failure     401    {object} apperror.AppError
+// @Failure     403    {object} apperror.AppError
+// @Failure     404    {object} apperror.AppError
+// @Failure     500    {object} apperror.AppError
+// @Router      /groups/{id}/members/{userId}/progress [get]
+func (h *ProgressHandler) GetMemberProgress(w http.ResponseWriter, r *http.Request) {
+	groupID := r.PathValue("id")
+	userID := r.PathValue("userId")
+	requesterPublicID := middleware.UserPublicID(r.Context())
+	requesterRole := middleware.UserRole(r.Context())
+
+	progress, err := h.uc.GetMemberProgress(r.Context(), groupID, userID, requesterPublicID, requesterRole)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result := make([]dto.ActivityProgressResponse, len(progress))
+	for i, p := range progress {
+		var status *string
+		if p.SubmissionStatus != nil {
+			s := string(*p.SubmissionStatus)
+			status = &s
+		}
+		result[i] = dto.ActivityProgressResponse{
+			ActivityID:       p.ActivityPublicID,
+			Title:            p.Title,
+			DueDate:          p.DueDate,
+			SubmissionStatus: status,
+			TotalQuestions:   p.TotalQuestions,
+			PassedQuestions:  p.PassedQuestions,
+			PassRate:         p.PassRate,
+			Questions:        questionStatusesToResponse(p.Questions),
+		}
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
@@ -11,6 +11,7 @@ import (
 	"proximos-passos/backend/internal/adapter/middleware"
 	"proximos-passos/backend/internal/adapter/response"
 	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/usecase"
 )
@@ -23,20 +24,24 @@ func NewQuestionHandler(uc *usecase.QuestionUseCase) *QuestionHandler {
 	return &QuestionHandler{uc: uc}
 }
 
-func (h *QuestionHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func(http.Handler) http.Handler) {
-	mux.Handle("POST /questions", adminMW(http.HandlerFunc(h.Create)))
+func (h *QuestionHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW, idempotentMW func(http.Handler) http.Handler) {
+	mux.Handle("POST /questions", adminMW(idempotentMW(http.HandlerFunc(h.Create))))
 	mux.Handle("GET /questions", authMW(http.HandlerFunc(h.List)))
 	mux.Handle("GET /questions/{id}", authMW(http.HandlerFunc(h.GetByID)))
 	mux.Handle("PUT /questions/{id}", adminMW(http.HandlerFunc(h.Update)))
 	mux.Handle("POST /questions/{id}/images", adminMW(http.HandlerFunc(h.AddImages)))
 	mux.Handle("DELETE /questions/{id}/images/{imageId}", adminMW(http.HandlerFunc(h.RemoveImage)))
+	mux.Handle("GET /questions/{id}/usages", adminMW(http.HandlerFunc(h.ListUsages)))
 	mux.Handle("DELETE /questions/{id}", adminMW(http.HandlerFunc(h.Delete)))
+	mux.Handle("POST /questions/bulk-delete", adminMW(http.HandlerFunc(h.DeleteMany)))
+	mux.Handle("POST /questions/{id}/archive", adminMW(http.HandlerFunc(h.Archive)))
+	mux.Handle("DELETE /questions/{id}/archive", adminMW(http.HandlerFunc(h.Unarchive)))
 	mux.Handle("POST /questions/{id}/feedback", authMW(http.HandlerFunc(h.CreateFeedback)))
 }
 
 // Create godoc
 // @Summary     Create a question
-// @Description Creates a new question with optional image uploads (admin only)
+// @Description Creates a new question with optional image uploads (admin only). Similar existing questions are returned in possible_duplicates.
 // @Tags        questions
 // @Accept      multipart/form-data
 // @Produce     json
@@ -44,11 +49,15 @@ func (h *QuestionHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW fun
 // @Param       type                formData string   true  "Question type (open_ended or closed_ended)"
 // @Param       statement           formData string   true  "Question statement"
 // @Param       expected_answer_text formData string  false "Expected answer text"
+// @Param       expected_keywords   formData []string false "Keywords used to suggest a score for open-ended answers"
 // @Param       passing_score       formData int      false "Passing score (0-100)"
+// @Param       max_attempts        formData int      false "Maximum attempts per activity (unlimited when omitted)"
+// @Param       difficulty          formData string   false "Difficulty (easy, medium or hard)"
+// @Param       tags                formData []string false "Free-form tags"
 // @Param       exam_id             formData string   false "Exam public ID"
 // @Param       topic_ids           formData []string false "Topic public IDs"
 // @Param       images              formData file     false "Image files"
-// @Success     201 {object} dto.QuestionResponse
+// @Success     201 {object} dto.CreateQuestionResponse
 // @Failure     400 {object} apperror.AppError
 // @Failure     401 {object} apperror.AppError
 // @Failure     403 {object} apperror.AppError
@@ -57,8 +66,8 @@ func (h *QuestionHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW fun
 func (h *QuestionHandler) Create(w http.ResponseWriter, r *http.Request) {
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(50 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -78,6 +87,16 @@ func (h *QuestionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	var maxAttempts *int
+	if ma := r.FormValue("max_attempts"); ma != "" {
+		v, err := strconv.Atoi(ma)
+		if err != nil {
+			response.Error(w, apperror.ErrInvalidInput)
+			return
+		}
+		maxAttempts = &v
+	}
+
 	examPublicID := r.FormValue("exam_id")
 	topicIDs := r.Form["topic_ids"]
 	imageFiles := r.MultipartForm.File["images"]
@@ -125,13 +144,17 @@ func (h *QuestionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	q, createErr := h.uc.Create(
+	q, duplicates, createErr := h.uc.Create(
 		r.Context(),
 		userPublicID,
 		qType,
 		statement,
 		expectedAnswerText,
+		r.Form["expected_keywords"],
 		passingScore,
+		maxAttempts,
+		r.FormValue("difficulty"),
+		r.Form["tags"],
 		examPublicID,
 		topicIDs,
 		imageFiles,
@@ -142,33 +165,45 @@ func (h *QuestionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.QuestionToResponse(q))
+	resp := dto.CreateQuestionResponse{QuestionResponse: negotiateImages(w, r, dto.QuestionToResponse(q))}
+	if len(duplicates) > 0 {
+		resp.PossibleDuplicates = dto.QuestionDuplicatesToResponse(duplicates)
+	}
+	response.Created(w, "/questions/"+q.PublicID, resp)
 }
 
 // List godoc
 // @Summary     List questions
-// @Description Returns a paginated list of questions (admin only)
+// @Description Returns a paginated list of questions. Non-admins receive them without the answer key.
 // @Tags        questions
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query int    false "Page number" default(1)
-// @Param       page_size   query int    false "Page size"   default(10)
+// @Param       page_size   query int    false "Page size"   default(20)
 // @Param       statement   query string false "Filter by statement (partial match)"
 // @Param       type        query string false "Filter by type (open_ended or closed_ended)"
 // @Param       topic_id    query string false "Filter by topic public ID (UUID)"
 // @Param       exam_id     query string false "Filter by exam public ID (UUID)"
+// @Param       institution_id query string false "Filter by institution public ID (UUID), via the question's exam"
+// @Param       difficulty  query string false "Filter by difficulty (easy, medium or hard)"
+// @Param       tag         query string false "Filter by tag"
+// @Param       archived    query bool   false "List archived questions instead of active ones"
+// @Param       sort        query string false "Sort key: created_at, updated_at, statement or type; prefix with - for descending"
 // @Success     200 {object} dto.QuestionListResponse
 // @Failure     401 {object} apperror.AppError
 // @Failure     403 {object} apperror.AppError
 // @Failure     500 {object} apperror.AppError
 // @Router      /questions [get]
 func (h *QuestionHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.QuestionFilter{
-		Statement: r.URL.Query().Get("statement"),
-		Type:      r.URL.Query().Get("type"),
+		Statement:  r.URL.Query().Get("statement"),
+		Type:       r.URL.Query().Get("type"),
+		Difficulty: r.URL.Query().Get("difficulty"),
+		Tag:        r.URL.Query().Get("tag"),
+		Archived:   r.URL.Query().Get("archived") == "true",
+		Sort:       r.URL.Query().Get("sort"),
 	}
 
 	if topicPublicIDs := r.URL.Query()["topic_id"]; len(topicPublicIDs) > 0 {
@@ -198,34 +233,25 @@ func (h *QuestionHandler) List(w http.ResponseWriter, r *http.Request) {
 		filter.InstitutionID = &institutionID
 	}
 
-	questions, totalItems, totalPages, err := h.uc.List(r.Context(), pageNumber, pageSize, filter)
+	questions, totalItems, err := h.uc.List(r.Context(), pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	response.JSON(w, http.StatusOK, dto.QuestionListResponse{
-		Data:       dto.QuestionsToResponse(questions),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.QuestionListResponse{
+		Data:       questionsResponseFor(w, r, questions),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, "")
 }
 
 // GetByID godoc
 // @Summary     Get a question
-// @Description Returns a question by its public ID (admin only)
+// @Description Returns a question by its public ID. Non-admins receive it without the answer key.
 // @Tags        questions
 // @Produce     json
 // @Security    CookieAuth
@@ -245,7 +271,56 @@ func (h *QuestionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.QuestionToResponse(q))
+	response.JSON(w, http.StatusOK, questionResponseFor(w, r, q))
+}
+
+// questionResponseFor hides the answer key unless the requester is an admin.
+func questionResponseFor(w http.ResponseWriter, r *http.Request, q *entity.Question) dto.QuestionResponse {
+	if middleware.UserRole(r.Context()) == entity.UserRoleAdmin {
+		return negotiateImages(w, r, dto.QuestionToResponse(q))
+	}
+	return negotiateImages(w, r, dto.QuestionToStudentResponse(q))
+}
+
+func questionsResponseFor(w http.ResponseWriter, r *http.Request, questions []entity.Question) []dto.QuestionResponse {
+	result := dto.QuestionsToStudentResponse(questions)
+	if middleware.UserRole(r.Context()) == entity.UserRoleAdmin {
+		result = dto.QuestionsToResponse(questions)
+	}
+	w.Header().Add("Vary", "Accept")
+	if acceptsWebP(r.Header.Get("Accept")) {
+		for i := range result {
+			result[i].PreferWebP()
+		}
+	}
+	return result
+}
+
+// negotiateImages serves WebP image URLs to clients whose Accept header
+// lists image/webp.
+func negotiateImages(w http.ResponseWriter, r *http.Request, resp dto.QuestionResponse) dto.QuestionResponse {
+	w.Header().Add("Vary", "Accept")
+	if acceptsWebP(r.Header.Get("Accept")) {
+		resp.PreferWebP()
+	}
+	return resp
+}
+
+func acceptsWebP(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaType), "image/webp") {
+			continue
+		}
+		_, q, found := strings.Cut(strings.ReplaceAll(params, " ", ""), "q=")
+		if !found {
+			return true
+		}
+		if v, err := strconv.ParseFloat(q, 64); err == nil && v > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // Update godoc
@@ -271,8 +346,8 @@ func (h *QuestionHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var input usecase.UpdateQuestionInput
 
 	if strings.HasPrefix(contentType, "multipart/form-data") {
-		if err := r.ParseMultipartForm(50 << 20); err != nil {
-			response.Error(w, apperror.ErrFileTooLarge)
+		if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+			response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 			return
 		}
 
@@ -285,14 +360,31 @@ func (h *QuestionHandler) Update(w http.ResponseWriter, r *http.Request) {
 		if v := r.FormValue("expected_answer_text"); v != "" {
 			input.ExpectedAnswerText = &v
 		}
+		if kws, ok := r.Form["expected_keywords"]; ok {
+			input.ExpectedKeywords = kws
+		}
 		if v := r.FormValue("passing_score"); v != "" {
 			ps, err := strconv.Atoi(v)
 			if err == nil {
 				input.PassingScore = &ps
 			}
 		}
-		if v := r.FormValue("exam_id"); v != "" {
-			input.ExamID = &v
+		if v := r.FormValue("max_attempts"); v != "" {
+			ma, err := strconv.Atoi(v)
+			if err != nil {
+				response.Error(w, apperror.ErrInvalidInput)
+				return
+			}
+			input.MaxAttempts = &ma
+		}
+		if v, ok := r.Form["difficulty"]; ok {
+			input.Difficulty = &v[0]
+		}
+		if tags, ok := r.Form["tags"]; ok {
+			input.Tags = tags
+		}
+		if v, ok := r.Form["exam_id"]; ok {
+			input.ExamID = &v[0]
 		}
 		if ids := r.Form["topic_ids"]; len(ids) > 0 {
 			input.TopicIDs = ids
@@ -319,8 +411,8 @@ func (h *QuestionHandler) Update(w http.ResponseWriter, r *http.Request) {
 	} else {
 		// JSON body
 		var req dto.UpdateQuestionRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			response.Error(w, apperror.ErrInvalidBody)
+		if err := decodeJSON(r, &req); err != nil {
+			response.Error(w, err)
 			return
 		}
 
@@ -328,7 +420,11 @@ func (h *QuestionHandler) Update(w http.ResponseWriter, r *http.Request) {
 			Type:               req.Type,
 			Statement:          req.Statement,
 			ExpectedAnswerText: req.ExpectedAnswerText,
+			ExpectedKeywords:   req.ExpectedKeywords,
 			PassingScore:       req.PassingScore,
+			MaxAttempts:        req.MaxAttempts,
+			Difficulty:         req.Difficulty,
+			Tags:               req.Tags,
 			ExamID:             req.ExamID,
 			TopicIDs:           req.TopicIDs,
 		}
@@ -350,7 +446,7 @@ func (h *QuestionHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.QuestionToResponse(q))
+	response.JSON(w, http.StatusOK, negotiateImages(w, r, dto.QuestionToResponse(q)))
 }
 
 // AddImages godoc
@@ -373,8 +469,8 @@ func (h *QuestionHandler) AddImages(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(50 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -390,7 +486,7 @@ func (h *QuestionHandler) AddImages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.QuestionToResponse(q))
+	response.JSON(w, http.StatusOK, negotiateImages(w, r, dto.QuestionToResponse(q)))
 }
 
 // RemoveImage godoc
@@ -416,14 +512,54 @@ func (h *QuestionHandler) RemoveImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.QuestionToResponse(q))
+	response.JSON(w, http.StatusOK, negotiateImages(w, r, dto.QuestionToResponse(q)))
+}
+
+// ListUsages godoc
+// @Summary     List question usages
+// @Description Returns the activities, and their groups, whose items reference a question (admin only)
+// @Tags        questions
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Question public ID (UUID)"
+// @Success     200 {object} dto.ContentUsageListResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /questions/{id}/usages [get]
+func (h *QuestionHandler) ListUsages(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+
+	usages, err := h.uc.ListUsages(r.Context(), publicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ContentUsagesToResponse(usages))
 }
 
-// Delete godoc ... (omitted doc changes block)
+// Delete godoc
+// @Summary     Delete a question
+// @Description Soft-deletes a question by public ID (admin only)
+// @Tags        questions
+// @Security    CookieAuth
+// @Param       id    path  string true  "Question public ID (UUID)"
+// @Param       force query bool   false "Also remove the activity items that reference it"
+// @Success     204
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /questions/{id} [delete]
 func (h *QuestionHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	if err := h.uc.Delete(r.Context(), publicID); err != nil {
+	force := r.URL.Query().Get("force") == "true"
+
+	if err := h.uc.Delete(r.Context(), publicID, force); err != nil {
 		response.Error(w, err)
 		return
 	}
@@ -431,6 +567,93 @@ func (h *QuestionHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeleteMany godoc
+// @Summary     Delete questions in bulk
+// @Description Soft-deletes up to 100 questions in one transaction and reports each outcome (deleted, not_found or in_use with the referencing activities). In-use questions are skipped unless force is set (admin only)
+// @Tags        questions
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       body body     dto.BulkDeleteQuestionsRequest true "Question public IDs"
+// @Success     200  {object} dto.QuestionBulkDeleteResponse
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     403  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /questions/bulk-delete [post]
+func (h *QuestionHandler) DeleteMany(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkDeleteQuestionsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	results, err := h.uc.DeleteMany(r.Context(), req.IDs, req.Force)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	resp := dto.QuestionBulkDeleteResponse{
+		Results: make([]dto.QuestionBulkDeleteResultResponse, len(results)),
+		Summary: make(map[string]int),
+	}
+	for i, res := range results {
+		row := dto.QuestionBulkDeleteResultResponse{PublicID: res.PublicID, Status: res.Status}
+		if len(res.Activities) > 0 {
+			row.Activities = dto.ContentUsagesToResponse(res.Activities).Data
+		}
+		resp.Results[i] = row
+		resp.Summary[res.Status]++
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// Archive godoc
+// @Summary     Archive a question
+// @Description Hides a question from listings and new activities while keeping it readable for existing items and submissions (admin only)
+// @Tags        questions
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Question public ID (UUID)"
+// @Success     200 {object} dto.QuestionResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /questions/{id}/archive [post]
+func (h *QuestionHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, true)
+}
+
+// Unarchive godoc
+// @Summary     Unarchive a question
+// @Description Makes an archived question available again (admin only)
+// @Tags        questions
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Question public ID (UUID)"
+// @Success     200 {object} dto.QuestionResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /questions/{id}/archive [delete]
+func (h *QuestionHandler) Unarchive(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, false)
+}
+
+func (h *QuestionHandler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	q, err := h.uc.SetArchived(r.Context(), r.PathValue("id"), archived)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, negotiateImages(w, r, dto.QuestionToResponse(q)))
+}
+
 // CreateFeedback godoc
 // @Summary     Create feedback for a question
 // @Description Creates subjective difficulty feedback for a question (auth required)
@@ -451,8 +674,8 @@ func (h *QuestionHandler) CreateFeedback(w http.ResponseWriter, r *http.Request)
 	userPublicID := middleware.UserPublicID(r.Context())
 
 	var req dto.CreateQuestionFeedbackRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/middleware"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+	"proximos-passos/backend/internal/infrastructure/jwt"
+	"proximos-passos/backend/internal/usecase"
+)
+
+type fakeQuestionRepo struct {
+	repository.QuestionRepository
+	question *entity.Question
+}
+
+func (f *fakeQuestionRepo) GetByPublicID(context.Context, string) (*entity.Question, error) {
+	q := *f.question
+	q.Options = append([]entity.QuestionOption(nil), f.question.Options...)
+	return &q, nil
+}
+
+type fakeSessionRepo struct {
+	repository.SessionRepository
+}
+
+func (fakeSessionRepo) Touch(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (f *fakeUserRepo) GetByPublicID(_ context.Context, publicID string) (*entity.User, error) {
+	return f.users[publicID], nil
+}
+
+func TestGetQuestionHidesAnswerKeyFromStudents(t *testing.T) {
+	answer := "Photosynthesis"
+	questions := &fakeQuestionRepo{question: &entity.Question{
+		PublicID:           "q-1",
+		Type:               "closed_ended",
+		ExpectedAnswerText: &answer,
+		ExpectedKeywords:   []string{"light"},
+		Options:            []entity.QuestionOption{{PublicID: "o-1", IsCorrect: true}},
+	}}
+	users := &fakeUserRepo{users: map[string]*entity.User{
+		"admin":   {ID: 1, PublicID: "admin", Role: entity.UserRoleAdmin, Status: entity.UserStatusActive},
+		"student": {ID: 2, PublicID: "student", Role: entity.UserRoleRegular, Status: entity.UserStatusActive},
+	}}
+	jwtService := jwt.NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	uc := usecase.NewQuestionUseCase(questions, nil, nil, nil, users, nil, nil, nil, uploadlimits.Default())
+
+	mux := http.NewServeMux()
+	passthrough := func(next http.Handler) http.Handler { return next }
+	NewQuestionHandler(uc).RegisterRoutes(mux, passthrough, middleware.AuthWithRole(jwtService, fakeSessionRepo{}, users), passthrough)
+
+	get := func(t *testing.T, userPublicID string) dto.QuestionResponse {
+		t.Helper()
+		token, err := jwtService.Generate(users.users[userPublicID], "session-1", time.Now().Add(10*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/questions/q-1", nil)
+		req.AddCookie(&http.Cookie{Name: jwt.CookieName, Value: token})
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+		var resp dto.QuestionResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	student := get(t, "student")
+	if student.ExpectedAnswerText != nil || student.ExpectedKeywords != nil || student.Options[0].IsCorrect != nil {
+		t.Errorf("student response leaks the answer key: %+v", student)
+	}
+
+	admin := get(t, "admin")
+	if admin.ExpectedAnswerText == nil || len(admin.ExpectedKeywords) != 1 {
+		t.Errorf("admin response lost the expected answer: %+v", admin)
+	}
+	if admin.Options[0].IsCorrect == nil || !*admin.Options[0].IsCorrect {
+		t.Errorf("admin response lost the correct flag: %+v", admin.Options[0])
+	}
+}
+
+func TestMalformedMaxAttemptsIsRejected(t *testing.T) {
+	h := &QuestionHandler{}
+	handlers := map[string]http.HandlerFunc{
+		http.MethodPost: h.Create,
+		http.MethodPut:  h.Update,
+	}
+
+	for method, serve := range handlers {
+		for _, value := range []string{"abc", "1.5", "3 tries"} {
+			t.Run(method+" "+value, func(t *testing.T) {
+				var body bytes.Buffer
+				form := multipart.NewWriter(&body)
+				form.WriteField("type", "open_ended")
+				form.WriteField("statement", "Why?")
+				form.WriteField("max_attempts", value)
+				form.Close()
+
+				req := httptest.NewRequest(method, "/questions/q-1", &body)
+				req.Header.Set("Content-Type", form.FormDataContentType())
+				rec := httptest.NewRecorder()
+				serve(rec, req)
+
+				if rec.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want 400", rec.Code)
+				}
+				var got apperror.AppError
+				if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got.Code != apperror.CodeInvalidInput {
+					t.Errorf("code = %q, want %q", got.Code, apperror.CodeInvalidInput)
+				}
+			})
+		}
+	}
+}
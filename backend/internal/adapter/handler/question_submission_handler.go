@@ -2,9 +2,9 @@ package handler
 
 import (
 	"encoding/json"
-	"math"
+	"errors"
+	"io"
 	"net/http"
-	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
@@ -21,11 +21,17 @@ func NewQuestionSubmissionHandler(uc *usecase.QuestionSubmissionUseCase) *Questi
 	return &QuestionSubmissionHandler{uc: uc}
 }
 
-func (h *QuestionSubmissionHandler) RegisterRoutes(mux *http.ServeMux, authMW func(http.Handler) http.Handler) {
-	mux.Handle("POST /questions/{id}/submissions", authMW(http.HandlerFunc(h.Submit)))
+func (h *QuestionSubmissionHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW, idempotentMW func(http.Handler) http.Handler) {
+	mux.Handle("POST /questions/{id}/start", authMW(http.HandlerFunc(h.StartAttempt)))
+	mux.Handle("GET /questions/{id}/time-stats", adminMW(http.HandlerFunc(h.GetTimeStats)))
+	mux.Handle("POST /questions/{id}/submissions", authMW(idempotentMW(http.HandlerFunc(h.Submit))))
 	mux.Handle("GET /questions/{id}/submissions", authMW(http.HandlerFunc(h.ListByQuestion)))
 	mux.Handle("GET /me/submissions", authMW(http.HandlerFunc(h.ListMySubmissions)))
 	mux.Handle("GET /me/submissions/{id}", authMW(http.HandlerFunc(h.GetByID)))
+	mux.Handle("GET /activities/{id}/questions/{questionId}/attempts/mine", authMW(http.HandlerFunc(h.ListMyAttempts)))
+	mux.Handle("POST /question-submissions/{id}/attachment", authMW(http.HandlerFunc(h.UploadAttachment)))
+	mux.Handle("GET /question-submissions/{id}/attachment/download", authMW(http.HandlerFunc(h.DownloadAttachment)))
+	mux.Handle("PUT /question-submissions/{id}/review", authMW(http.HandlerFunc(h.Review)))
 }
 
 func (h *QuestionSubmissionHandler) Submit(w http.ResponseWriter, r *http.Request) {
@@ -36,8 +42,8 @@ func (h *QuestionSubmissionHandler) Submit(w http.ResponseWriter, r *http.Reques
 	}
 
 	var req dto.SubmitAnswerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -57,7 +63,7 @@ func (h *QuestionSubmissionHandler) Submit(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.QuestionSubmissionToResponse(sub))
+	response.Created(w, "/me/submissions/"+sub.PublicID, dto.QuestionSubmissionToResponse(sub))
 }
 
 func (h *QuestionSubmissionHandler) ListByQuestion(w http.ResponseWriter, r *http.Request) {
@@ -67,46 +73,75 @@ func (h *QuestionSubmissionHandler) ListByQuestion(w http.ResponseWriter, r *htt
 		return
 	}
 
-	page, size := parsePagination(r)
+	page, size, offset := response.ParsePagination(r, response.DefaultPageSize)
 
-	subs, total, err := h.uc.ListByQuestion(r.Context(), questionID, page, size)
+	subs, total, err := h.uc.ListByQuestion(r.Context(), questionID, size, offset)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
+	pagination := response.Paginate(total, page, size)
 	response.JSON(w, http.StatusOK, dto.QuestionSubmissionListResponse{
-		Data:       dto.QuestionSubmissionsToResponse(subs),
-		PageNumber: page,
-		PageSize:   size,
-		TotalItems: total,
-		TotalPages: totalPages,
+		Data:       dto.QuestionSubmissionsToReviewResponse(subs, middleware.UserPublicID(r.Context())),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
 func (h *QuestionSubmissionHandler) ListMySubmissions(w http.ResponseWriter, r *http.Request) {
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	page, size := parsePagination(r)
+	page, size, offset := response.ParsePagination(r, response.DefaultPageSize)
 	statement := r.URL.Query().Get("statement")
 
-	subs, total, err := h.uc.ListMySubmissions(r.Context(), userPublicID, page, size, statement)
+	subs, total, err := h.uc.ListMySubmissions(r.Context(), userPublicID, size, offset, statement)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
+	pagination := response.Paginate(total, page, size)
 	response.JSON(w, http.StatusOK, dto.QuestionSubmissionListResponse{
 		Data:       dto.QuestionSubmissionsToResponse(subs),
-		PageNumber: page,
-		PageSize:   size,
-		TotalItems: total,
-		TotalPages: totalPages,
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
+// ListMyAttempts godoc
+// @Summary     List my attempts at a question in an activity
+// @Description Returns the current user's attempts at a question within an activity, newest first
+// @Tags        question-submissions
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id         path string true "Activity public ID"
+// @Param       questionId path string true "Question public ID"
+// @Success     200 {array} dto.QuestionSubmissionResponse
+// @Failure     404 {object} apperror.AppError
+// @Router      /activities/{id}/questions/{questionId}/attempts/mine [get]
+func (h *QuestionSubmissionHandler) ListMyAttempts(w http.ResponseWriter, r *http.Request) {
+	activityPublicID := r.PathValue("id")
+	questionPublicID := r.PathValue("questionId")
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	attempts, err := h.uc.ListMyAttempts(r.Context(), activityPublicID, questionPublicID, userPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.QuestionSubmissionsToResponse(attempts))
+}
+
 func (h *QuestionSubmissionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 	if publicID == "" {
@@ -123,18 +158,165 @@ func (h *QuestionSubmissionHandler) GetByID(w http.ResponseWriter, r *http.Reque
 	response.JSON(w, http.StatusOK, dto.QuestionSubmissionToResponse(sub))
 }
 
-func parsePagination(r *http.Request) (int, int) {
-	page := 1
-	size := 20
-	if p := r.URL.Query().Get("page_number"); p != "" {
-		if v, err := strconv.Atoi(p); err == nil && v > 0 {
-			page = v
-		}
+func (h *QuestionSubmissionHandler) StartAttempt(w http.ResponseWriter, r *http.Request) {
+	questionID := r.PathValue("id")
+	if questionID == "" {
+		response.Error(w, apperror.ErrInvalidInput)
+		return
+	}
+
+	var req dto.StartQuestionAttemptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, bodyError(err, apperror.ErrInvalidBody))
+		return
+	}
+
+	startedAt, err := h.uc.StartAttempt(r.Context(), usecase.StartAttemptInput{
+		QuestionPublicID: questionID,
+		UserPublicID:     middleware.UserPublicID(r.Context()),
+		ActivityPublicID: req.ActivityID,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.QuestionAttemptStartResponse{StartedAt: startedAt})
+}
+
+func (h *QuestionSubmissionHandler) GetTimeStats(w http.ResponseWriter, r *http.Request) {
+	questionID := r.PathValue("id")
+	if questionID == "" {
+		response.Error(w, apperror.ErrInvalidInput)
+		return
 	}
-	if s := r.URL.Query().Get("page_size"); s != "" {
-		if v, err := strconv.Atoi(s); err == nil && v > 0 && v <= 100 {
-			size = v
-		}
+
+	stats, err := h.uc.GetTimeStats(r.Context(), questionID)
+	if err != nil {
+		response.Error(w, err)
+		return
 	}
-	return page, size
+
+	response.JSON(w, http.StatusOK, dto.QuestionTimeStatsResponse{
+		QuestionID:             questionID,
+		TimedAttempts:          stats.TimedAttempts,
+		AverageDurationSeconds: stats.AverageDurationSeconds,
+	})
+}
+
+// UploadAttachment godoc
+// @Summary     Attach a file to an open-ended answer
+// @Description Attaches a file, such as a photo of handwritten work, to the caller's own open-ended answer, replacing any previous attachment. Size and type limits follow the group's submission upload settings. Reviewed answers, answers whose activity submission was already reviewed and answers to activities past their due date cannot be changed.
+// @Tags        question-submissions
+// @Accept      multipart/form-data
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id   path     string true "Question submission public ID (UUID)"
+// @Param       file formData file   true "Attachment file"
+// @Success     200  {object} dto.QuestionSubmissionResponse
+// @Failure     400  {object} apperror.AppError "Closed-ended answer, invalid type or file too large"
+// @Failure     401  {object} apperror.AppError
+// @Failure     403  {object} apperror.AppError
+// @Failure     404  {object} apperror.AppError
+// @Failure     409  {object} apperror.AppError "Answer reviewed, submission reviewed or activity past due"
+// @Failure     500  {object} apperror.AppError
+// @Router      /question-submissions/{id}/attachment [post]
+func (h *QuestionSubmissionHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Error(w, apperror.ErrInvalidBody)
+		return
+	}
+	defer file.Close()
+
+	sub, err := h.uc.UploadAttachment(r.Context(), publicID, userPublicID, header.Filename, header.Header.Get("Content-Type"), header.Size, file)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.QuestionSubmissionToResponse(sub))
+}
+
+// DownloadAttachment godoc
+// @Summary     Download an answer's attachment
+// @Description Redirects the answer's author, platform admins and the group's staff to a short-lived signed URL for the attachment
+// @Tags        question-submissions
+// @Security    CookieAuth
+// @Param       id path string true "Question submission public ID (UUID)"
+// @Success     302
+// @Failure     401 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Router      /question-submissions/{id}/attachment/download [get]
+func (h *QuestionSubmissionHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	userPublicID := middleware.UserPublicID(r.Context())
+	if userPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	url, err := h.uc.GetAttachmentDownloadURL(r.Context(), r.PathValue("id"), userPublicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// Review godoc
+// @Summary     Review an open-ended answer
+// @Description Sets the score, pass flag and feedback of a single open-ended attempt and notifies the student. Group admins and supervisors may review attempts made within their group's activities; platform admins may review any attempt. When passed is omitted it follows the question's passing score.
+// @Tags        question-submissions
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id   path     string                              true "Question submission public ID (UUID)"
+// @Param       body body     dto.ReviewQuestionSubmissionRequest true "Review data"
+// @Success     200  {object} dto.QuestionSubmissionResponse
+// @Failure     400  {object} apperror.AppError "Closed-ended answer or score outside 0-100"
+// @Failure     401  {object} apperror.AppError
+// @Failure     403  {object} apperror.AppError
+// @Failure     404  {object} apperror.AppError
+// @Router      /question-submissions/{id}/review [put]
+func (h *QuestionSubmissionHandler) Review(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+	reviewerPublicID := middleware.UserPublicID(r.Context())
+	if reviewerPublicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ReviewQuestionSubmissionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	sub, err := h.uc.Review(r.Context(), usecase.ReviewQuestionSubmissionInput{
+		SubmissionPublicID: publicID,
+		ReviewerPublicID:   reviewerPublicID,
+		Score:              req.Score,
+		Passed:             req.Passed,
+		Feedback:           req.Feedback,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.QuestionSubmissionToReviewResponse(sub, reviewerPublicID))
 }
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/middleware"
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/usecase"
+)
+
+type RecommendationHandler struct {
+	uc *usecase.RecommendationUseCase
+}
+
+func NewRecommendationHandler(uc *usecase.RecommendationUseCase) *RecommendationHandler {
+	return &RecommendationHandler{uc: uc}
+}
+
+func (h *RecommendationHandler) RegisterRoutes(mux *http.ServeMux, authMW func(http.Handler) http.Handler) {
+	mux.Handle("GET /me/recommendations", authMW(http.HandlerFunc(h.ListMine)))
+}
+
+// ListMine godoc
+// @Summary     Get my content recommendations
+// @Description Suggests questions, video lessons and handouts from the current user's weakest topics (lowest pass rate first, newest content first within a topic). Passed questions and already opened lessons and handouts are left out.
+// @Tags        stats
+// @Produce     json
+// @Security    CookieAuth
+// @Param       limit query    int false "Maximum items, up to 50" default(10)
+// @Success     200   {array}  dto.RecommendationResponse
+// @Failure     400   {object} apperror.AppError
+// @Failure     401   {object} apperror.AppError
+// @Failure     500   {object} apperror.AppError
+// @Router      /me/recommendations [get]
+func (h *RecommendationHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			response.Error(w, apperror.ErrInvalidInput)
+			return
+		}
+		limit = n
+	}
+
+	recs, err := h.uc.ForUser(r.Context(), middleware.UserPublicID(r.Context()), limit)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.RecommendationsToResponse(recs))
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/middleware"
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/usecase"
+)
+
+type StatsHandler struct {
+	uc *usecase.StatsUseCase
+}
+
+func NewStatsHandler(uc *usecase.StatsUseCase) *StatsHandler {
+	return &StatsHandler{uc: uc}
+}
+
+func (h *StatsHandler) RegisterRoutes(mux *http.ServeMux, authMW func(http.Handler) http.Handler) {
+	mux.Handle("GET /groups/{id}/stats", authMW(http.HandlerFunc(h.GroupStats)))
+	mux.Handle("GET /groups/{id}/leaderboard", authMW(http.HandlerFunc(h.GroupLeaderboard)))
+	mux.Handle("GET /me/topic-mastery", authMW(http.HandlerFunc(h.MyTopicMastery)))
+}
+
+func (h *StatsHandler) RegisterAdminRoutes(mux *http.ServeMux, adminMW func(http.Handler) http.Handler) {
+	mux.Handle("GET /content/stats", adminMW(http.HandlerFunc(h.ContentStats)))
+}
+
+// GroupStats godoc
+// @Summary     Get group statistics
+// @Description Returns member counts by role, pending join requests, active vs past activities, submission status distribution and average review turnaround (platform admins and group admins/supervisors)
+// @Tags        groups
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Group public ID (UUID)"
+// @Success     200 {object} dto.GroupStatsResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /groups/{id}/stats [get]
+func (h *StatsHandler) GroupStats(w http.ResponseWriter, r *http.Request) {
+	groupID := r.PathValue("id")
+	userPublicID := middleware.UserPublicID(r.Context())
+	userRole := middleware.UserRole(r.Context())
+
+	stats, err := h.uc.GroupStats(r.Context(), groupID, userPublicID, userRole)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.GroupStatsToResponse(stats))
+}
+
+// GroupLeaderboard godoc
+// @Summary     Get group leaderboard
+// @Description Ranks group members by distinct questions passed or by total best score across the group's activities. Must be enabled in the group settings; in anonymous mode non-staff members only see their own name.
+// @Tags        groups
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id          path     string true  "Group public ID (UUID)"
+// @Param       metric      query    string false "Ranking metric" Enums(passed, score) default(passed)
+// @Param       window_days query    int    false "Only count submissions from the last N days (all time when omitted)"
+// @Success     200         {array}  dto.LeaderboardEntryResponse
+// @Failure     400         {object} apperror.AppError
+// @Failure     401         {object} apperror.AppError
+// @Failure     403         {object} apperror.AppError "Not a member or leaderboard disabled"
+// @Failure     404         {object} apperror.AppError
+// @Failure     500         {object} apperror.AppError
+// @Router      /groups/{id}/leaderboard [get]
+func (h *StatsHandler) GroupLeaderboard(w http.ResponseWriter, r *http.Request) {
+	groupID := r.PathValue("id")
+	userPublicID := middleware.UserPublicID(r.Context())
+	userRole := middleware.UserRole(r.Context())
+
+	var window time.Duration
+	if v := r.URL.Query().Get("window_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			response.Error(w, apperror.ErrInvalidInput)
+			return
+		}
+		window = time.Duration(days) * 24 * time.Hour
+	}
+
+	entries, err := h.uc.GroupLeaderboard(r.Context(), groupID, userPublicID, userRole, window, r.URL.Query().Get("metric"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.LeaderboardToResponse(entries, userPublicID))
+}
+
+// MyTopicMastery godoc
+// @Summary     Get my topic mastery
+// @Description Returns the pass rate and average score of the current user's question submissions per topic. Attempts in a subtopic also count toward its ancestors.
+// @Tags        stats
+// @Produce     json
+// @Security    CookieAuth
+// @Success     200 {array}  dto.TopicMasteryResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /me/topic-mastery [get]
+func (h *StatsHandler) MyTopicMastery(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.uc.GetTopicMastery(r.Context(), middleware.UserPublicID(r.Context()))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.TopicMasteryToResponse(entries))
+}
+
+// ContentStats godoc
+// @Summary     Get content access statistics
+// @Description Ranks handouts, video lessons and open exercise lists by views and downloads. Repeat accesses by the same user within 10 minutes count once. (admin only)
+// @Tags        stats
+// @Produce     json
+// @Security    CookieAuth
+// @Param       type        query    string false "Content type" Enums(handout, video_lesson, open_exercise_list)
+// @Param       window_days query    int    false "Days to look back" default(30)
+// @Param       limit       query    int    false "Maximum entries, up to 100" default(20)
+// @Success     200 {object} dto.ContentStatsListResponse
+// @Failure     400 {object} apperror.AppError
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Router      /content/stats [get]
+func (h *StatsHandler) ContentStats(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	days := 30
+	if v := q.Get("window_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			response.Error(w, apperror.ErrInvalidInput)
+			return
+		}
+		days = n
+	}
+
+	limit := 20
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			response.Error(w, apperror.ErrInvalidInput)
+			return
+		}
+		limit = n
+	}
+
+	stats, err := h.uc.ContentStats(r.Context(), entity.ActivityItemType(q.Get("type")), time.Duration(days)*24*time.Hour, limit)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ContentStatsToResponse(stats, days))
+}
@@ -1,14 +1,11 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
 	"proximos-passos/backend/internal/adapter/response"
-	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/usecase"
 )
@@ -24,6 +21,7 @@ func NewTopicHandler(uc *usecase.TopicUseCase) *TopicHandler {
 func (h *TopicHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func(http.Handler) http.Handler) {
 	mux.Handle("POST /topics", adminMW(http.HandlerFunc(h.Create)))
 	mux.Handle("GET /topics", authMW(http.HandlerFunc(h.List)))
+	mux.Handle("POST /topics/resolve", authMW(http.HandlerFunc(h.Resolve)))
 	mux.Handle("GET /topics/{id}", authMW(http.HandlerFunc(h.GetByID)))
 	mux.Handle("PUT /topics/{id}", adminMW(http.HandlerFunc(h.Update)))
 	mux.Handle("DELETE /topics/{id}", adminMW(http.HandlerFunc(h.Delete)))
@@ -47,8 +45,8 @@ func (h *TopicHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW func(h
 // @Router      /topics [post]
 func (h *TopicHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateTopicRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -66,7 +64,7 @@ func (h *TopicHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.TopicToResponse(topic))
+	response.Created(w, "/topics/"+topic.PublicID, dto.TopicToResponse(topic))
 }
 
 // List godoc
@@ -76,7 +74,7 @@ func (h *TopicHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query    int    false "Page number" default(1)
-// @Param       page_size   query    int    false "Page size"   default(10)
+// @Param       page_size   query    int    false "Page size"   default(20)
 // @Param       name        query    string false "Filter by name (partial match)"
 // @Param       parent_id   query    string false "Filter by parent topic ID (UUID), use empty string for root topics"
 // @Success     200         {object} dto.TopicListResponse
@@ -85,8 +83,7 @@ func (h *TopicHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Failure     500         {object} apperror.AppError
 // @Router      /topics [get]
 func (h *TopicHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.TopicFilter{
 		Name: r.URL.Query().Get("name"),
@@ -97,29 +94,48 @@ func (h *TopicHandler) List(w http.ResponseWriter, r *http.Request) {
 		filter.ParentID = &parentID
 	}
 
-	topics, totalItems, totalPages, err := h.uc.List(r.Context(), pageNumber, pageSize, filter)
+	topics, totalItems, err := h.uc.List(r.Context(), pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.TopicListResponse{
+		Data:       dto.TopicsToResponse(topics),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, "")
+}
+
+// Resolve godoc
+// @Summary     Resolve topic IDs
+// @Description Maps up to 200 topic public IDs to their names and ancestor paths. Unknown IDs are omitted.
+// @Tags        topics
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       body body     dto.ResolveTopicsRequest true "Topic IDs"
+// @Success     200  {object} map[string]dto.ResolvedTopicResponse
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Router      /topics/resolve [post]
+func (h *TopicHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResolveTopicsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
 	}
-	if pageNumber < 1 {
-		pageNumber = 1
+
+	breadcrumbs, err := h.uc.Resolve(r.Context(), req.IDs)
+	if err != nil {
+		response.Error(w, err)
+		return
 	}
 
-	response.JSON(w, http.StatusOK, dto.TopicListResponse{
-		Data:       dto.TopicsToResponse(topics),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+	response.JSON(w, http.StatusOK, dto.TopicBreadcrumbsToResponse(breadcrumbs))
 }
 
 // GetByID godoc
@@ -168,8 +184,8 @@ func (h *TopicHandler) Update(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
 	var req dto.UpdateTopicRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -0,0 +1,22 @@
+package handler
+
+import "net/http"
+
+// decodeUpdate reads the body of an update endpoint served under both PUT
+// and PATCH. PUT bodies decode straight into U, where null and omitted keys
+// both leave a field untouched. PATCH bodies decode into P, whose Update
+// method turns an explicit null into a clear or a validation error.
+func decodeUpdate[U any, P interface{ Update() (U, error) }](r *http.Request) (U, error) {
+	if r.Method != http.MethodPatch {
+		var req U
+		err := decodeJSON(r, &req)
+		return req, err
+	}
+
+	var patch P
+	if err := decodeJSON(r, &patch); err != nil {
+		var zero U
+		return zero, err
+	}
+	return patch.Update()
+}
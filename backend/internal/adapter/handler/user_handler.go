@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"proximos-passos/backend/internal/adapter/dto"
 	"proximos-passos/backend/internal/adapter/middleware"
@@ -24,16 +22,27 @@ func NewUserHandler(uc *usecase.UserUseCase) *UserHandler {
 func (h *UserHandler) RegisterRoutes(mux *http.ServeMux, mw func(http.Handler) http.Handler) {
 	mux.Handle("POST /users", mw(http.HandlerFunc(h.Create)))
 	mux.Handle("GET /users", mw(http.HandlerFunc(h.List)))
+	mux.Handle("POST /users/batch", mw(http.HandlerFunc(h.Batch)))
 	mux.Handle("GET /users/{id}", mw(http.HandlerFunc(h.GetByID)))
 	mux.Handle("PUT /users/{id}", mw(http.HandlerFunc(h.Update)))
+	mux.Handle("PATCH /users/{id}", mw(http.HandlerFunc(h.Update)))
 	mux.Handle("DELETE /users/{id}", mw(http.HandlerFunc(h.Delete)))
+	mux.Handle("POST /users/{id}/suspend", mw(http.HandlerFunc(h.Suspend)))
+	mux.Handle("POST /users/{id}/reinstate", mw(http.HandlerFunc(h.Reinstate)))
 }
 
 func (h *UserHandler) RegisterSelfRoutes(mux *http.ServeMux, mw func(http.Handler) http.Handler) {
 	mux.Handle("GET /me", mw(http.HandlerFunc(h.GetMe)))
+	mux.Handle("GET /me/context", mw(http.HandlerFunc(h.GetMeContext)))
 	mux.Handle("PUT /me", mw(http.HandlerFunc(h.UpdateMe)))
+	mux.Handle("PATCH /me", mw(http.HandlerFunc(h.UpdateMe)))
+	mux.Handle("PUT /me/password", mw(middleware.RejectImpersonation(http.HandlerFunc(h.ChangePassword))))
+	mux.Handle("POST /me/email", mw(middleware.RejectImpersonation(http.HandlerFunc(h.RequestEmailChange))))
+	mux.Handle("POST /me/email/confirm", mw(middleware.RejectImpersonation(http.HandlerFunc(h.ConfirmEmailChange))))
 	mux.Handle("PUT /me/avatar", mw(http.HandlerFunc(h.UploadAvatar)))
 	mux.Handle("DELETE /me/avatar", mw(http.HandlerFunc(h.DeleteAvatar)))
+	mux.Handle("GET /me/sessions", mw(middleware.RejectImpersonation(http.HandlerFunc(h.ListSessions))))
+	mux.Handle("DELETE /me/sessions/{id}", mw(middleware.RejectImpersonation(http.HandlerFunc(h.RevokeSession))))
 }
 
 // Create godoc
@@ -49,12 +58,13 @@ func (h *UserHandler) RegisterSelfRoutes(mux *http.ServeMux, mw func(http.Handle
 // @Failure     401  {object} apperror.AppError
 // @Failure     403  {object} apperror.AppError
 // @Failure     409  {object} apperror.AppError
+// @Failure     422  {object} apperror.AppError
 // @Failure     500  {object} apperror.AppError
 // @Router      /users [post]
 func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -71,7 +81,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.UserToResponse(user))
+	response.Created(w, "/users/"+user.PublicID, dto.UserToResponse(user))
 }
 
 // List godoc
@@ -81,43 +91,62 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query    int false "Page number" default(1)
-// @Param       page_size   query    int false "Page size"   default(10)
+// @Param       page_size   query    int false "Page size"   default(20)
+// @Param       sort        query    string false "Sort key: created_at, updated_at, name, email or role; prefix with - for descending"
 // @Success     200         {object} dto.UserListResponse
 // @Failure     401         {object} apperror.AppError
 // @Failure     403         {object} apperror.AppError
 // @Failure     500         {object} apperror.AppError
 // @Router      /users [get]
 func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
-	users, totalItems, err := h.uc.ListAll(r.Context(), pageNumber, pageSize)
+	users, totalItems, err := h.uc.ListAll(r.Context(), pageSize, offset, r.URL.Query().Get("sort"))
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	totalPages := (totalItems + pageSize - 1) / pageSize
-
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
 	response.JSON(w, http.StatusOK, dto.UserListResponse{
 		Data:       dto.UsersToResponse(users),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
 	})
 }
 
+// Batch godoc
+// @Summary     Look up users in bulk
+// @Description Maps up to 200 user public IDs to their public profiles. Duplicates are collapsed and unknown IDs are omitted.
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       body body     dto.BatchUsersRequest true "User IDs"
+// @Success     200  {object} map[string]dto.UserProfileResponse
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     403  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /users/batch [post]
+func (h *UserHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	var req dto.BatchUsersRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	users, err := h.uc.GetManyByPublicID(r.Context(), req.IDs)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.UserProfilesToResponse(users))
+}
+
 // GetByID godoc
 // @Summary     Get a user
 // @Description Returns a user by their public ID
@@ -145,7 +174,7 @@ func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 // Update godoc
 // @Summary     Update a user
-// @Description Updates user fields by their public ID
+// @Description Updates user fields by their public ID. PATCH also accepts a null avatar_url, which removes it
 // @Tags        users
 // @Accept      json
 // @Produce     json
@@ -157,22 +186,26 @@ func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Failure     401  {object} apperror.AppError
 // @Failure     403  {object} apperror.AppError
 // @Failure     404  {object} apperror.AppError
+// @Failure     409  {object} apperror.AppError "Change would leave no active platform admin"
 // @Failure     500  {object} apperror.AppError
 // @Router      /users/{id} [put]
+// @Router      /users/{id} [patch]
 func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	var req dto.UpdateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	req, err := decodeUpdate[dto.UpdateUserRequest, dto.PatchUserRequest](r)
+	if err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	input := usecase.UpdateUserInput{
-		Name:      req.Name,
-		Email:     req.Email,
-		AvatarURL: req.AvatarURL,
-		IsActive:  req.IsActive,
+		Profile: usecase.UpdateProfileInput{
+			Name:      req.Name,
+			Email:     req.Email,
+			AvatarURL: req.AvatarURL,
+			IsActive:  req.IsActive,
+		},
 	}
 
 	if req.Role != nil {
@@ -180,7 +213,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 		input.Role = &role
 	}
 
-	user, err := h.uc.Update(r.Context(), publicID, input)
+	user, err := h.uc.Update(r.Context(), publicID, middleware.UserPublicID(r.Context()), input)
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -191,7 +224,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 // Delete godoc
 // @Summary     Delete a user
-// @Description Soft-deletes a user by their public ID
+// @Description Soft-deletes a user by their public ID and deactivates their group memberships
 // @Tags        users
 // @Produce     json
 // @Security    CookieAuth
@@ -200,6 +233,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 // @Failure     401 {object} apperror.AppError
 // @Failure     403 {object} apperror.AppError
 // @Failure     404 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError "User is the only admin of a group or the last active platform admin"
 // @Failure     500 {object} apperror.AppError
 // @Router      /users/{id} [delete]
 func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -213,6 +247,195 @@ func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Suspend godoc
+// @Summary     Suspend a user
+// @Description Blocks a user from signing in and ends their sessions while keeping their data and memberships (admin only)
+// @Tags        users
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "User public ID (UUID)"
+// @Success     200 {object} dto.UserResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError "Not an admin, or suspending yourself"
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /users/{id}/suspend [post]
+func (h *UserHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	user, err := h.uc.Suspend(r.Context(), r.PathValue("id"), middleware.UserPublicID(r.Context()))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.UserToResponse(user))
+}
+
+// Reinstate godoc
+// @Summary     Reinstate a user
+// @Description Lets a suspended user sign in again (admin only)
+// @Tags        users
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "User public ID (UUID)"
+// @Success     200 {object} dto.UserResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /users/{id}/reinstate [post]
+func (h *UserHandler) Reinstate(w http.ResponseWriter, r *http.Request) {
+	user, err := h.uc.Reinstate(r.Context(), r.PathValue("id"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.UserToResponse(user))
+}
+
+// ListSessions godoc
+// @Summary     List my sessions
+// @Description Lists the authenticated user's active sessions, marking the one used for this request
+// @Tags        me
+// @Produce     json
+// @Security    CookieAuth
+// @Success     200 {array}  dto.SessionResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /me/sessions [get]
+func (h *UserHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sessions, err := h.uc.ListSessions(ctx, middleware.UserPublicID(ctx))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.SessionsToResponse(sessions, middleware.SessionPublicID(ctx)))
+}
+
+// RevokeSession godoc
+// @Summary     Revoke a session
+// @Description Logs out one of the authenticated user's sessions, such as a lost device
+// @Tags        me
+// @Security    CookieAuth
+// @Param       id  path string true "Session public ID (UUID)"
+// @Success     204 "No Content"
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /me/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := h.uc.RevokeSession(ctx, middleware.UserPublicID(ctx), r.PathValue("id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangePassword godoc
+// @Summary     Change password
+// @Description Changes the authenticated user's password after verifying the current one. Every other session of the user is signed out
+// @Tags        me
+// @Accept      json
+// @Security    CookieAuth
+// @Param       body body dto.ChangePasswordRequest true "Current and new password"
+// @Success     204  "No Content"
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     422  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /me/password [put]
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	publicID := middleware.UserPublicID(r.Context())
+	if publicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.uc.ChangePassword(r.Context(), publicID, middleware.SessionPublicID(r.Context()), req.CurrentPassword, req.NewPassword); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestEmailChange godoc
+// @Summary     Request email change
+// @Description Sends a confirmation link to the new email after checking the current password. The current email stays active until it is confirmed. Shares the verification email cooldown.
+// @Tags        me
+// @Accept      json
+// @Security    CookieAuth
+// @Param       body body dto.RequestEmailChangeRequest true "New email and current password"
+// @Success     204  "No Content"
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     409  {object} apperror.AppError
+// @Failure     429  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /me/email [post]
+func (h *UserHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	publicID := middleware.UserPublicID(r.Context())
+	if publicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	var req dto.RequestEmailChangeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := h.uc.RequestEmailChange(r.Context(), publicID, req.CurrentPassword, req.Email); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ConfirmEmailChange godoc
+// @Summary     Confirm email change
+// @Description Replaces the user's email with the pending one and marks it verified. The token must have been issued to the signed-in user
+// @Tags        me
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       body body     dto.ConfirmEmailChangeRequest true "Confirmation token"
+// @Success     200  {object} dto.UserResponse
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     409  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /me/email/confirm [post]
+func (h *UserHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	var req dto.ConfirmEmailChangeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	user, err := h.uc.ConfirmEmailChange(r.Context(), middleware.UserPublicID(r.Context()), req.Token)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.UserToResponse(user))
+}
+
 // UploadAvatar godoc
 // @Summary     Upload avatar
 // @Description Uploads an avatar image for the authenticated user
@@ -234,8 +457,8 @@ func (h *UserHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := r.ParseMultipartForm(5 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -309,9 +532,36 @@ func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, dto.UserToResponse(user))
 }
 
+// GetMeContext godoc
+// @Summary     Get current user context
+// @Description Returns the authenticated user's profile, platform role and active group memberships with their roles
+// @Tags        me
+// @Produce     json
+// @Security    CookieAuth
+// @Success     200 {object} dto.MeContextResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /me/context [get]
+func (h *UserHandler) GetMeContext(w http.ResponseWriter, r *http.Request) {
+	publicID := middleware.UserPublicID(r.Context())
+	if publicID == "" {
+		response.Error(w, apperror.ErrUnauthorized)
+		return
+	}
+
+	user, memberships, err := h.uc.GetContext(r.Context(), publicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.MeContextToResponse(user, memberships))
+}
+
 // UpdateMe godoc
 // @Summary     Update current user
-// @Description Updates the authenticated user's profile (name only)
+// @Description Updates the authenticated user's profile (name, preferred language and activity reminder emails). PATCH also accepts a null lang, which clears the preference
 // @Tags        me
 // @Accept      json
 // @Produce     json
@@ -323,6 +573,7 @@ func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 // @Failure     404  {object} apperror.AppError
 // @Failure     500  {object} apperror.AppError
 // @Router      /me [put]
+// @Router      /me [patch]
 func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 	publicID := middleware.UserPublicID(r.Context())
 	if publicID == "" {
@@ -330,17 +581,21 @@ func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req dto.UpdateMeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	req, err := decodeUpdate[dto.UpdateMeRequest, dto.PatchMeRequest](r)
+	if err != nil {
+		response.Error(w, err)
 		return
 	}
 
 	input := usecase.UpdateUserInput{
-		Name: req.Name,
+		Profile: usecase.UpdateProfileInput{
+			Name:              req.Name,
+			Lang:              req.Lang,
+			ActivityReminders: req.ActivityReminders,
+		},
 	}
 
-	user, err := h.uc.Update(r.Context(), publicID, input)
+	user, err := h.uc.Update(r.Context(), publicID, publicID, input)
 	if err != nil {
 		response.Error(w, err)
 		return
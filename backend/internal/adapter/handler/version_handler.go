@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/buildinfo"
+)
+
+type VersionHandler struct {
+	info buildinfo.Info
+}
+
+func NewVersionHandler(info buildinfo.Info) *VersionHandler {
+	return &VersionHandler{info: info}
+}
+
+func (h *VersionHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /version", h.Version)
+}
+
+// Version godoc
+// @Summary     Build information
+// @Description Returns the git commit, build time and Go version of the running server
+// @Tags        health
+// @Produce     json
+// @Success     200 {object} dto.VersionResponse
+// @Router      /version [get]
+func (h *VersionHandler) Version(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, dto.VersionResponse{
+		Commit:    h.info.Commit,
+		BuildTime: h.info.BuildTime,
+		GoVersion: h.info.GoVersion,
+	})
+}
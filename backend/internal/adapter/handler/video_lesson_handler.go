@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -27,6 +26,7 @@ func (h *VideoLessonHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW
 	mux.Handle("GET /video-lessons/{id}", authMW(http.HandlerFunc(h.GetByID)))
 	mux.Handle("PUT /video-lessons/{id}", adminMW(http.HandlerFunc(h.Update)))
 	mux.Handle("POST /video-lessons/{id}/file", adminMW(http.HandlerFunc(h.ReplaceFile)))
+	mux.Handle("GET /video-lessons/{id}/usages", adminMW(http.HandlerFunc(h.ListUsages)))
 	mux.Handle("DELETE /video-lessons/{id}", adminMW(http.HandlerFunc(h.Delete)))
 }
 
@@ -53,8 +53,8 @@ func (h *VideoLessonHandler) RegisterRoutes(mux *http.ServeMux, adminMW, authMW
 func (h *VideoLessonHandler) Create(w http.ResponseWriter, r *http.Request) {
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(500 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -108,7 +108,7 @@ func (h *VideoLessonHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, dto.VideoLessonToResponse(vl))
+	response.Created(w, "/video-lessons/"+vl.PublicID, dto.VideoLessonToResponse(vl))
 }
 
 // List godoc
@@ -118,7 +118,7 @@ func (h *VideoLessonHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Security    CookieAuth
 // @Param       page_number query int    false "Page number" default(1)
-// @Param       page_size   query int    false "Page size"   default(10)
+// @Param       page_size   query int    false "Page size"   default(20)
 // @Param       title       query string false "Filter by title (partial match)"
 // @Param       topic_id    query string false "Filter by topic public ID (UUID)"
 // @Success     200 {object} dto.VideoLessonListResponse
@@ -127,8 +127,7 @@ func (h *VideoLessonHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Failure     500 {object} apperror.AppError
 // @Router      /video-lessons [get]
 func (h *VideoLessonHandler) List(w http.ResponseWriter, r *http.Request) {
-	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
 
 	filter := repository.VideoLessonFilter{
 		Title: r.URL.Query().Get("title"),
@@ -143,29 +142,20 @@ func (h *VideoLessonHandler) List(w http.ResponseWriter, r *http.Request) {
 		filter.TopicIDs = topicIDs
 	}
 
-	lessons, totalItems, totalPages, err := h.uc.List(r.Context(), pageNumber, pageSize, filter)
+	lessons, totalItems, err := h.uc.List(r.Context(), pageSize, offset, filter)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	response.JSON(w, http.StatusOK, dto.VideoLessonListResponse{
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSONWithETag(w, r, http.StatusOK, dto.VideoLessonListResponse{
 		Data:       dto.VideoLessonsToResponse(lessons),
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
-	})
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	}, "")
 }
 
 // GetByID godoc
@@ -184,7 +174,7 @@ func (h *VideoLessonHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *VideoLessonHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	vl, err := h.uc.GetByPublicID(r.Context(), publicID)
+	vl, err := h.uc.GetByPublicID(r.Context(), publicID, middleware.UserPublicID(r.Context()))
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -214,8 +204,8 @@ func (h *VideoLessonHandler) Update(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
 	var req dto.UpdateVideoLessonRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, apperror.ErrInvalidBody)
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
 		return
 	}
 
@@ -256,8 +246,8 @@ func (h *VideoLessonHandler) ReplaceFile(w http.ResponseWriter, r *http.Request)
 	publicID := r.PathValue("id")
 	userPublicID := middleware.UserPublicID(r.Context())
 
-	if err := r.ParseMultipartForm(500 << 20); err != nil {
-		response.Error(w, apperror.ErrFileTooLarge)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		response.Error(w, bodyError(err, apperror.ErrFileTooLarge))
 		return
 	}
 
@@ -285,22 +275,51 @@ func (h *VideoLessonHandler) ReplaceFile(w http.ResponseWriter, r *http.Request)
 	response.JSON(w, http.StatusOK, dto.VideoLessonToResponse(vl))
 }
 
+// ListUsages godoc
+// @Summary     List video lesson usages
+// @Description Returns the activities, and their groups, whose items reference a video lesson (admin only)
+// @Tags        video-lessons
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path     string true "Video lesson public ID (UUID)"
+// @Success     200 {object} dto.ContentUsageListResponse
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /video-lessons/{id}/usages [get]
+func (h *VideoLessonHandler) ListUsages(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+
+	usages, err := h.uc.ListUsages(r.Context(), publicID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.ContentUsagesToResponse(usages))
+}
+
 // Delete godoc
 // @Summary     Delete a video lesson
 // @Description Soft-deletes a video lesson by public ID (admin only)
 // @Tags        video-lessons
 // @Security    CookieAuth
-// @Param       id path string true "Video lesson public ID (UUID)"
+// @Param       id    path  string true  "Video lesson public ID (UUID)"
+// @Param       force query bool   false "Also remove the activity items that reference it"
 // @Success     204
 // @Failure     401 {object} apperror.AppError
 // @Failure     403 {object} apperror.AppError
 // @Failure     404 {object} apperror.AppError
+// @Failure     409 {object} apperror.AppError
 // @Failure     500 {object} apperror.AppError
 // @Router      /video-lessons/{id} [delete]
 func (h *VideoLessonHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	publicID := r.PathValue("id")
 
-	if err := h.uc.Delete(r.Context(), publicID); err != nil {
+	force := r.URL.Query().Get("force") == "true"
+
+	if err := h.uc.Delete(r.Context(), publicID, force); err != nil {
 		response.Error(w, err)
 		return
 	}
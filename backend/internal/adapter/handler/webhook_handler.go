@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+
+	"proximos-passos/backend/internal/adapter/dto"
+	"proximos-passos/backend/internal/adapter/middleware"
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/usecase"
+)
+
+type WebhookHandler struct {
+	uc *usecase.WebhookUseCase
+}
+
+func NewWebhookHandler(uc *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{uc: uc}
+}
+
+func (h *WebhookHandler) RegisterRoutes(mux *http.ServeMux, adminMW func(http.Handler) http.Handler) {
+	mux.Handle("POST /webhooks", adminMW(http.HandlerFunc(h.Create)))
+	mux.Handle("GET /webhooks", adminMW(http.HandlerFunc(h.List)))
+	mux.Handle("DELETE /webhooks/{id}", adminMW(http.HandlerFunc(h.Delete)))
+}
+
+// Create godoc
+// @Summary     Register a webhook
+// @Description Registers a subscriber URL for domain events and returns its signing secret (admin only). Deliveries carry their Unix time in the X-Webhook-Timestamp header and an HMAC-SHA256 over "timestamp.body" in the X-Webhook-Signature header.
+// @Tags        webhooks
+// @Accept      json
+// @Produce     json
+// @Security    CookieAuth
+// @Param       body body     dto.CreateWebhookRequest true "Webhook data"
+// @Success     201  {object} dto.CreateWebhookResponse
+// @Failure     400  {object} apperror.AppError
+// @Failure     401  {object} apperror.AppError
+// @Failure     403  {object} apperror.AppError
+// @Failure     500  {object} apperror.AppError
+// @Router      /webhooks [post]
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	userPublicID := middleware.UserPublicID(r.Context())
+
+	webhook, err := h.uc.Create(r.Context(), userPublicID, req.URL)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, dto.CreateWebhookResponse{
+		WebhookResponse: dto.WebhookToResponse(webhook),
+		Secret:          webhook.Secret,
+	})
+}
+
+// List godoc
+// @Summary     List webhooks
+// @Description Returns a paginated list of active webhook subscriptions (admin only)
+// @Tags        webhooks
+// @Produce     json
+// @Security    CookieAuth
+// @Param       page_number query    int false "Page number" default(1)
+// @Param       page_size   query    int false "Page size"   default(20)
+// @Success     200         {object} dto.WebhookListResponse
+// @Failure     401         {object} apperror.AppError
+// @Failure     403         {object} apperror.AppError
+// @Failure     500         {object} apperror.AppError
+// @Router      /webhooks [get]
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, offset := response.ParsePagination(r, response.DefaultPageSize)
+
+	webhooks, totalItems, err := h.uc.List(r.Context(), pageSize, offset)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	pagination := response.Paginate(totalItems, pageNumber, pageSize)
+	response.JSON(w, http.StatusOK, dto.WebhookListResponse{
+		Data:       dto.WebhooksToResponse(webhooks),
+		PageNumber: pagination.PageNumber,
+		PageSize:   pagination.PageSize,
+		TotalItems: pagination.TotalItems,
+		TotalPages: pagination.TotalPages,
+	})
+}
+
+// Delete godoc
+// @Summary     Delete a webhook
+// @Description Deactivates a webhook subscription by public ID (admin only)
+// @Tags        webhooks
+// @Produce     json
+// @Security    CookieAuth
+// @Param       id  path  string true "Webhook public ID (UUID)"
+// @Success     204 "No Content"
+// @Failure     401 {object} apperror.AppError
+// @Failure     403 {object} apperror.AppError
+// @Failure     404 {object} apperror.AppError
+// @Failure     500 {object} apperror.AppError
+// @Router      /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+
+	if err := h.uc.Delete(r.Context(), publicID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"log"
 	"net/http"
 
 	"proximos-passos/backend/internal/adapter/response"
@@ -14,42 +15,31 @@ import (
 type contextKey string
 
 const (
-	userPublicIDKey contextKey = "user_public_id"
-	userRoleKey     contextKey = "user_role"
+	userPublicIDKey         contextKey = "user_public_id"
+	userRoleKey             contextKey = "user_role"
+	impersonatorPublicIDKey contextKey = "impersonator_public_id"
+	sessionPublicIDKey      contextKey = "session_public_id"
 )
 
-func Auth(jwtService *jwt.Service) func(http.Handler) http.Handler {
+func Auth(jwtService *jwt.Service, sessions repository.SessionRepository) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			cookie, err := r.Cookie(jwt.CookieName)
-			if err != nil {
+			claims, ok := authenticate(r, jwtService, sessions)
+			if !ok {
 				response.Error(w, apperror.ErrUnauthorized)
 				return
 			}
 
-			claims, err := jwtService.Parse(cookie.Value)
-			if err != nil {
-				response.Error(w, apperror.ErrUnauthorized)
-				return
-			}
-
-			ctx := context.WithValue(r.Context(), userPublicIDKey, claims.UserPublicID)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(withClaims(r, claims)))
 		})
 	}
 }
 
-func AuthWithRole(jwtService *jwt.Service, repo repository.UserRepository) func(http.Handler) http.Handler {
+func AuthWithRole(jwtService *jwt.Service, sessions repository.SessionRepository, repo repository.UserRepository) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			cookie, err := r.Cookie(jwt.CookieName)
-			if err != nil {
-				response.Error(w, apperror.ErrUnauthorized)
-				return
-			}
-
-			claims, err := jwtService.Parse(cookie.Value)
-			if err != nil {
+			claims, ok := authenticate(r, jwtService, sessions)
+			if !ok {
 				response.Error(w, apperror.ErrUnauthorized)
 				return
 			}
@@ -59,9 +49,13 @@ func AuthWithRole(jwtService *jwt.Service, repo repository.UserRepository) func(
 				response.Error(w, apperror.ErrUnauthorized)
 				return
 			}
+			if user.Status == entity.UserStatusSuspended {
+				response.Error(w, apperror.ErrAccountSuspended)
+				return
+			}
 
-			ctx := context.WithValue(r.Context(), userPublicIDKey, claims.UserPublicID)
-			ctx = context.WithValue(ctx, userRoleKey, user.Role)
+			r = applyUserLocale(w, r, user)
+			ctx := context.WithValue(withClaims(r, claims), userRoleKey, user.Role)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -81,18 +75,70 @@ func RequireAdmin(repo repository.UserRepository) func(http.Handler) http.Handle
 				response.Error(w, apperror.ErrUnauthorized)
 				return
 			}
+			if user.Status == entity.UserStatusSuspended {
+				response.Error(w, apperror.ErrAccountSuspended)
+				return
+			}
 
 			if user.Role != entity.UserRoleAdmin {
 				response.Error(w, apperror.ErrForbidden)
 				return
 			}
 
+			r = applyUserLocale(w, r, user)
 			ctx := context.WithValue(r.Context(), userRoleKey, user.Role)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// authenticate parses the session cookie and checks that the session behind
+// it has not been revoked. Tokens without a session ID predate server-side
+// sessions and are rejected.
+func authenticate(r *http.Request, jwtService *jwt.Service, sessions repository.SessionRepository) (*jwt.Claims, bool) {
+	cookie, err := r.Cookie(jwt.CookieName)
+	if err != nil {
+		return nil, false
+	}
+
+	claims, err := jwtService.Parse(cookie.Value)
+	if err != nil || claims.ID == "" {
+		return nil, false
+	}
+
+	active, err := sessions.Touch(r.Context(), claims.ID)
+	if err != nil || !active {
+		return nil, false
+	}
+	return claims, true
+}
+
+// withClaims stores the authenticated user in the request context. For
+// impersonated sessions it also records the admin behind them, and writes an
+// audit line naming both actors.
+func withClaims(r *http.Request, claims *jwt.Claims) context.Context {
+	ctx := context.WithValue(r.Context(), userPublicIDKey, claims.UserPublicID)
+	ctx = context.WithValue(ctx, sessionPublicIDKey, claims.ID)
+	if claims.ImpersonatedBy == "" {
+		return ctx
+	}
+
+	log.Printf("audit: %s %s by admin %s impersonating user %s", r.Method, r.URL.Path, claims.ImpersonatedBy, claims.UserPublicID)
+	return context.WithValue(ctx, impersonatorPublicIDKey, claims.ImpersonatedBy)
+}
+
+// RejectImpersonation blocks the wrapped handler for impersonated sessions,
+// guarding account changes that only the real user may make.
+func RejectImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ImpersonatorPublicID(r.Context()) != "" {
+			response.Error(w, apperror.ErrImpersonationNotAllowed)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func UserPublicID(ctx context.Context) string {
 	v, _ := ctx.Value(userPublicIDKey).(string)
 	return v
@@ -102,3 +148,17 @@ func UserRole(ctx context.Context) entity.UserRole {
 	v, _ := ctx.Value(userRoleKey).(entity.UserRole)
 	return v
 }
+
+// ImpersonatorPublicID returns the admin acting on behalf of the user, or an
+// empty string for regular sessions.
+func ImpersonatorPublicID(ctx context.Context) string {
+	v, _ := ctx.Value(impersonatorPublicIDKey).(string)
+	return v
+}
+
+// SessionPublicID returns the public ID of the session the request was
+// authenticated with.
+func SessionPublicID(ctx context.Context) string {
+	v, _ := ctx.Value(sessionPublicIDKey).(string)
+	return v
+}
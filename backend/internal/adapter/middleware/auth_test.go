@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	user *entity.User
+}
+
+func (f *fakeUserRepo) GetByPublicID(context.Context, string) (*entity.User, error) {
+	return f.user, nil
+}
+
+func TestRequireAdminRejectsSuspendedAdmins(t *testing.T) {
+	repo := &fakeUserRepo{user: &entity.User{PublicID: "admin", Role: entity.UserRoleAdmin, Status: entity.UserStatusSuspended}}
+	called := false
+	handler := RequireAdmin(repo)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+	req = req.WithContext(context.WithValue(req.Context(), userPublicIDKey, "admin"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if called {
+		t.Error("the suspended admin reached the handler")
+	}
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), string(apperror.CodeAccountSuspended)) {
+		t.Errorf("status = %d, body = %s; want 403 %s", rec.Code, rec.Body.String(), apperror.CodeAccountSuspended)
+	}
+
+	repo.user.Status = entity.UserStatusActive
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !called {
+		t.Error("the reinstated admin was rejected")
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+const (
+	CSRFCookieName = "csrf_token"
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+// csrfExemptRoutes lists the routes used before a session exists, and so
+// before the csrf_token cookie is issued. None of them act on the caller's
+// session cookie, so a forged cross-site request gains nothing.
+var csrfExemptRoutes = map[string]bool{
+	"POST /auth/setup":                true,
+	"POST /auth/register":             true,
+	"POST /auth/login":                true,
+	"POST /auth/refresh":              true,
+	"POST /auth/verify-email":         true,
+	"POST /auth/request-verification": true,
+}
+
+// CSRF enforces double-submit cookie protection: for state-changing methods the
+// X-CSRF-Token header must match the csrf_token cookie issued at login.
+func CSRF(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if csrfExemptRoutes[r.Method+" "+r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cookie, err := r.Cookie(CSRFCookieName)
+			if err != nil || cookie.Value == "" {
+				response.Error(w, apperror.ErrCSRF)
+				return
+			}
+
+			header := r.Header.Get(CSRFHeaderName)
+			if header == "" || subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+				response.Error(w, apperror.ErrCSRF)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveCSRF(t *testing.T, enabled bool, r *http.Request) int {
+	t.Helper()
+	h := CSRF(enabled)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec.Code
+}
+
+func csrfRequest(method, path, cookie, header string) *http.Request {
+	r := httptest.NewRequest(method, path, nil)
+	if cookie != "" {
+		r.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: cookie})
+	}
+	if header != "" {
+		r.Header.Set(CSRFHeaderName, header)
+	}
+	return r
+}
+
+func TestCSRF(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		req     *http.Request
+		want    int
+	}{
+		{"matching token", true, csrfRequest("POST", "/groups", "abc", "abc"), http.StatusNoContent},
+		{"missing header", true, csrfRequest("POST", "/groups", "abc", ""), http.StatusForbidden},
+		{"missing cookie", true, csrfRequest("POST", "/groups", "", "abc"), http.StatusForbidden},
+		{"mismatched token", true, csrfRequest("DELETE", "/groups/1", "abc", "abd"), http.StatusForbidden},
+		{"safe method", true, csrfRequest("GET", "/groups", "", ""), http.StatusNoContent},
+		{"disabled", false, csrfRequest("POST", "/groups", "", ""), http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serveCSRF(t, tt.enabled, tt.req); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSRFExemptsPreSessionRoutes(t *testing.T) {
+	for _, path := range []string{"/auth/setup", "/auth/register", "/auth/login", "/auth/refresh", "/auth/verify-email", "/auth/request-verification"} {
+		if got := serveCSRF(t, true, csrfRequest("POST", path, "", "")); got != http.StatusNoContent {
+			t.Errorf("POST %s: status = %d, want %d", path, got, http.StatusNoContent)
+		}
+	}
+	if got := serveCSRF(t, true, csrfRequest("POST", "/auth/logout-all", "", "")); got != http.StatusForbidden {
+		t.Errorf("POST /auth/logout-all: status = %d, want %d", got, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// Idempotency makes retried requests safe: the first response for a given
+// Idempotency-Key, route and user is stored for ttl and replayed verbatim to
+// any retry, Location header included. A retry whose body differs from the
+// original is rejected instead. Server errors and panics release the key so
+// the request can be retried. Requests without the header pass through
+// untouched. Must run after Auth or AuthWithRole.
+func Idempotency(repo repository.IdempotencyRepository, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				response.Error(w, apperror.ErrInvalidIdempotencyKey)
+				return
+			}
+
+			userPublicID := UserPublicID(r.Context())
+			if userPublicID == "" {
+				response.Error(w, apperror.ErrUnauthorized)
+				return
+			}
+			route := r.Method + " " + r.URL.Path
+
+			reserved, err := repo.Reserve(r.Context(), userPublicID, key, route, time.Now().Add(ttl))
+			if err != nil {
+				response.Error(w, err)
+				return
+			}
+			if !reserved {
+				replay(w, r, repo, userPublicID, key, route)
+				return
+			}
+
+			// Store the outcome even if the client has already gone away,
+			// since that is exactly when it will retry.
+			ctx := context.WithoutCancel(r.Context())
+			release := func() {
+				if err := repo.Release(ctx, userPublicID, key, route); err != nil {
+					log.Printf("failed to release idempotency key: %v", err)
+				}
+			}
+
+			defer func() {
+				if p := recover(); p != nil {
+					release()
+					panic(p)
+				}
+			}()
+
+			// The body is hashed as the handler reads it, so uploads are
+			// never buffered just to be hashed.
+			body := &hashingBody{ReadCloser: r.Body, hash: sha256.New()}
+			r.Body = body
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				release()
+				return
+			}
+			if err := repo.Complete(ctx, userPublicID, key, route, body.sum(), rec.status, rec.Header().Get("Content-Type"), rec.Header().Get("Location"), rec.body.Bytes()); err != nil {
+				log.Printf("failed to store idempotent response: %v", err)
+			}
+		})
+	}
+}
+
+func replay(w http.ResponseWriter, r *http.Request, repo repository.IdempotencyRepository, userPublicID, key, route string) {
+	stored, err := repo.Get(r.Context(), userPublicID, key, route)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if stored == nil || stored.StatusCode == nil {
+		response.Error(w, apperror.ErrIdempotencyKeyInUse)
+		return
+	}
+	body := &hashingBody{ReadCloser: r.Body, hash: sha256.New()}
+	if stored.RequestHash != nil && !bytes.Equal(body.sum(), stored.RequestHash) {
+		response.Error(w, apperror.ErrIdempotencyKeyMismatch)
+		return
+	}
+
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	if stored.Location != "" {
+		w.Header().Set("Location", stored.Location)
+	}
+	w.Header().Set(IdempotencyReplayedHeader, "true")
+	w.WriteHeader(*stored.StatusCode)
+	w.Write(stored.ResponseBody)
+}
+
+// responseRecorder passes the response through while keeping a copy of the
+// status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if !rr.wroteHeader {
+		rr.status = status
+		rr.wroteHeader = true
+	}
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.wroteHeader = true
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
+
+// hashingBody hashes a request body as it is read.
+type hashingBody struct {
+	io.ReadCloser
+	hash hash.Hash
+}
+
+func (b *hashingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.hash.Write(p[:n])
+	return n, err
+}
+
+// sum drains whatever the handler left unread and returns the hash of the
+// whole body.
+func (b *hashingBody) sum() []byte {
+	io.Copy(io.Discard, b)
+	return b.hash.Sum(nil)
+}
+
+// PurgeIdempotencyKeys deletes expired idempotency records every interval
+// until ctx is cancelled.
+func PurgeIdempotencyKeys(ctx context.Context, repo repository.IdempotencyRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := repo.DeleteExpired(ctx); err != nil {
+			log.Printf("failed to purge expired idempotency keys: %v", err)
+		} else if deleted > 0 {
+			log.Printf("purged %d expired idempotency keys", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
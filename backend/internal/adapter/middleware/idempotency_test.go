@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+// memoryIdempotencyRepo keeps idempotency records in memory, keyed by user,
+// key and route.
+type memoryIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]*entity.IdempotencyRecord
+}
+
+func newMemoryIdempotencyRepo() *memoryIdempotencyRepo {
+	return &memoryIdempotencyRepo{records: map[string]*entity.IdempotencyRecord{}}
+}
+
+func (m *memoryIdempotencyRepo) id(userPublicID, key, route string) string {
+	return userPublicID + "|" + key + "|" + route
+}
+
+func (m *memoryIdempotencyRepo) Reserve(_ context.Context, userPublicID, key, route string, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := m.id(userPublicID, key, route)
+	if rec, ok := m.records[id]; ok && rec.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	m.records[id] = &entity.IdempotencyRecord{Key: key, Route: route, ExpiresAt: expiresAt}
+	return true, nil
+}
+
+func (m *memoryIdempotencyRepo) Get(_ context.Context, userPublicID, key, route string) (*entity.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec, ok := m.records[m.id(userPublicID, key, route)]
+	if !ok || !rec.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	copied := *rec
+	return &copied, nil
+}
+
+func (m *memoryIdempotencyRepo) Complete(_ context.Context, userPublicID, key, route string, requestHash []byte, statusCode int, contentType, location string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec := m.records[m.id(userPublicID, key, route)]
+	rec.RequestHash = requestHash
+	rec.StatusCode = &statusCode
+	rec.ContentType = contentType
+	rec.Location = location
+	rec.ResponseBody = append([]byte(nil), body...)
+	return nil
+}
+
+func (m *memoryIdempotencyRepo) Release(_ context.Context, userPublicID, key, route string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, m.id(userPublicID, key, route))
+	return nil
+}
+
+func (m *memoryIdempotencyRepo) DeleteExpired(_ context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int64
+	for id, rec := range m.records {
+		if !rec.ExpiresAt.After(time.Now()) {
+			delete(m.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func idempotentRequest(key, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/groups", strings.NewReader(body))
+	r.Header.Set(IdempotencyKeyHeader, key)
+	return r.WithContext(context.WithValue(r.Context(), userPublicIDKey, "user-1"))
+}
+
+func TestIdempotencyReplaysCreatedResource(t *testing.T) {
+	calls := 0
+	handler := Idempotency(newMemoryIdempotencyRepo(), time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/groups/g-1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"g-1"}`))
+	}))
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, idempotentRequest("k-1", `{"name":"a"}`))
+
+	retry := httptest.NewRecorder()
+	handler.ServeHTTP(retry, idempotentRequest("k-1", `{"name":"a"}`))
+
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+	if retry.Code != http.StatusCreated {
+		t.Errorf("replayed status = %d, want 201", retry.Code)
+	}
+	if got := retry.Header().Get("Location"); got != "/groups/g-1" {
+		t.Errorf("replayed Location = %q, want /groups/g-1", got)
+	}
+	if got := retry.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("replayed Content-Type = %q", got)
+	}
+	if got := retry.Header().Get(IdempotencyReplayedHeader); got != "true" {
+		t.Errorf("%s = %q, want true", IdempotencyReplayedHeader, got)
+	}
+	if retry.Body.String() != first.Body.String() {
+		t.Errorf("replayed body = %q, want %q", retry.Body.String(), first.Body.String())
+	}
+}
+
+func TestIdempotencyReleasesKeyAfterServerError(t *testing.T) {
+	calls := 0
+	handler := Idempotency(newMemoryIdempotencyRepo(), time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest("k-1", `{}`))
+	retry := httptest.NewRecorder()
+	handler.ServeHTTP(retry, idempotentRequest("k-1", `{}`))
+
+	if calls != 2 || retry.Code != http.StatusCreated {
+		t.Errorf("calls = %d, retry status = %d; want the retry to run again and succeed", calls, retry.Code)
+	}
+}
+
+func TestIdempotencyRejectsDifferentBody(t *testing.T) {
+	calls := 0
+	handler := Idempotency(newMemoryIdempotencyRepo(), time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// Leave part of the body unread; the hash must still cover all of it.
+		r.Body.Read(make([]byte, 4))
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest("k-1", `{"name":"a"}`))
+
+	same := httptest.NewRecorder()
+	handler.ServeHTTP(same, idempotentRequest("k-1", `{"name":"a"}`))
+	if same.Code != http.StatusCreated {
+		t.Errorf("same body: status = %d, want the replayed 201", same.Code)
+	}
+
+	different := httptest.NewRecorder()
+	handler.ServeHTTP(different, idempotentRequest("k-1", `{"name":"b"}`))
+	if different.Code != http.StatusUnprocessableEntity || !strings.Contains(different.Body.String(), string(apperror.CodeIdempotencyKeyMismatch)) {
+		t.Errorf("different body: status = %d, body = %s; want %s", different.Code, different.Body.String(), apperror.CodeIdempotencyKeyMismatch)
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyReleasesKeyAfterPanic(t *testing.T) {
+	repo := newMemoryIdempotencyRepo()
+	handler := Idempotency(repo, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("the panic was swallowed")
+			}
+		}()
+		handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest("k-1", `{}`))
+	}()
+
+	if rec, _ := repo.Get(context.Background(), "user-1", "k-1", "POST /groups"); rec != nil {
+		t.Error("the key stayed reserved after the panic")
+	}
+}
+
+func TestPurgeIdempotencyKeysDeletesExpired(t *testing.T) {
+	repo := newMemoryIdempotencyRepo()
+	repo.records["expired"] = &entity.IdempotencyRecord{ExpiresAt: time.Now().Add(-time.Minute)}
+	repo.records["live"] = &entity.IdempotencyRecord{ExpiresAt: time.Now().Add(time.Hour)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	PurgeIdempotencyKeys(ctx, repo, time.Hour)
+
+	if _, ok := repo.records["expired"]; ok {
+		t.Error("the expired key was kept")
+	}
+	if _, ok := repo.records["live"]; !ok {
+		t.Error("the live key was deleted")
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/i18n"
+)
+
+// ContentLanguageHeader carries the negotiated locale on the response; the
+// response package reads it back to translate error messages.
+const ContentLanguageHeader = "Content-Language"
+
+// Locale negotiates the request locale from Accept-Language, falling back to
+// English. AuthWithRole and RequireAdmin later override it with the user's own
+// preference when one is set.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+		w.Header().Set(ContentLanguageHeader, locale)
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	})
+}
+
+// applyUserLocale switches the request to user's preferred locale, if any.
+func applyUserLocale(w http.ResponseWriter, r *http.Request, user *entity.User) *http.Request {
+	if user.Lang == nil {
+		return r
+	}
+	locale := i18n.Resolve(*user.Lang)
+	w.Header().Set(ContentLanguageHeader, locale)
+	return r.WithContext(i18n.WithLocale(r.Context(), locale))
+}
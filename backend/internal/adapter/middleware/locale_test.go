@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/i18n"
+)
+
+func TestLocaleTranslatesErrors(t *testing.T) {
+	var contextLocale string
+	handler := Locale(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contextLocale = i18n.FromContext(r.Context())
+		response.Error(w, apperror.ErrGroupNotFound)
+	}))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantLocale     string
+	}{
+		{"portuguese", "pt-BR,pt;q=0.9,en;q=0.8", i18n.PortugueseBR},
+		{"english", "en-US", i18n.English},
+		{"unsupported language", "fr-FR", i18n.English},
+		{"no header", "", i18n.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get(ContentLanguageHeader); got != tt.wantLocale {
+				t.Errorf("Content-Language = %q, want %q", got, tt.wantLocale)
+			}
+			if contextLocale != tt.wantLocale {
+				t.Errorf("context locale = %q, want %q", contextLocale, tt.wantLocale)
+			}
+
+			var body apperror.AppError
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Code != apperror.CodeGroupNotFound {
+				t.Errorf("code = %q, want %q", body.Code, apperror.CodeGroupNotFound)
+			}
+			if want := i18n.Message(tt.wantLocale, apperror.ErrGroupNotFound); body.Message != want {
+				t.Errorf("message = %q, want %q", body.Message, want)
+			}
+		})
+	}
+}
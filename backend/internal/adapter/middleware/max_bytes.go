@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+// MaxBytes caps request bodies at limit bytes, or at the ceiling uploads
+// lists for the route pattern the request matches on mux: routes that parse
+// a multipart form carry files and need more room. Bodies declaring a larger
+// Content-Length are rejected up front with 413; others are cut off at the
+// cap, so a decoder reading past it fails instead of buffering without
+// bound.
+func MaxBytes(mux *http.ServeMux, limit int64, uploads map[string]int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			max := limit
+			if _, pattern := mux.Handler(r); pattern != "" {
+				if ceiling, ok := uploads[pattern]; ok {
+					max = ceiling
+				}
+			}
+
+			if r.ContentLength > max {
+				response.Error(w, apperror.ErrRequestTooLarge)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, max)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBytes(t *testing.T) {
+	mux := http.NewServeMux()
+	read := func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	mux.HandleFunc("POST /notes", read)
+	mux.HandleFunc("POST /uploads/{id}", read)
+
+	handler := MaxBytes(mux, 10, map[string]int64{"POST /uploads/{id}": 100})(mux)
+
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		contentType string
+		chunked     bool
+		want        int
+	}{
+		{"within limit", "/notes", strings.Repeat("a", 10), "application/json", false, http.StatusNoContent},
+		{"declared over limit", "/notes", strings.Repeat("a", 11), "application/json", false, http.StatusRequestEntityTooLarge},
+		{"streamed over limit", "/notes", strings.Repeat("a", 11), "application/json", true, http.StatusBadRequest},
+		{"multipart on a regular route", "/notes", strings.Repeat("a", 50), "multipart/form-data; boundary=x", false, http.StatusRequestEntityTooLarge},
+		{"upload route within its ceiling", "/uploads/1", strings.Repeat("a", 50), "multipart/form-data; boundary=x", false, http.StatusNoContent},
+		{"upload route over its ceiling", "/uploads/1", strings.Repeat("a", 101), "multipart/form-data; boundary=x", false, http.StatusRequestEntityTooLarge},
+		{"upload route streamed over its ceiling", "/uploads/1", strings.Repeat("a", 101), "multipart/form-data; boundary=x", true, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader = strings.NewReader(tt.body)
+			if tt.chunked {
+				// Hide the length so the request arrives without Content-Length.
+				body = io.MultiReader(body)
+			}
+			req := httptest.NewRequest(http.MethodPost, tt.path, body)
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"proximos-passos/backend/internal/infrastructure/metrics"
+)
+
+// unmatchedRoute labels requests no pattern matched, so probing random paths
+// cannot create unbounded series.
+const unmatchedRoute = "unmatched"
+
+// Metrics records request counts and durations. It must wrap the ServeMux
+// directly, or through PublicIDs: the mux sets r.Pattern on the request it
+// receives, and any middleware in between that derives a new request would
+// hide the route.
+func Metrics(m *metrics.HTTP) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			route := unmatchedRoute
+			if r.Pattern != "" {
+				// Patterns carry their method ("GET /groups/{id}"); it is
+				// already a label of its own.
+				_, path, found := strings.Cut(r.Pattern, " ")
+				if !found {
+					path = r.Pattern
+				}
+				route = path
+			}
+			m.Observe(route, r.Method, rec.status, time.Since(start))
+		})
+	}
+}
+
+// statusRecorder passes the response through, remembering only the status.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	if !sr.wroteHeader {
+		sr.status = status
+		sr.wroteHeader = true
+	}
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	sr.wroteHeader = true
+	return sr.ResponseWriter.Write(b)
+}
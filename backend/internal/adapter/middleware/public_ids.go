@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/publicid"
+)
+
+// PublicIDs answers 404 for requests whose ID path wildcards ({id},
+// {groupId}, ...) are not UUIDs, before they reach a handler and fail as a
+// database error on a uuid column. It wraps the ServeMux so it can see which
+// pattern the request matches, and must sit directly inside Metrics: it
+// passes the request on unchanged and records the pattern on rejected ones.
+func PublicIDs(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern != "" && !validPublicIDs(pattern, r.URL.EscapedPath()) {
+			r.Pattern = pattern
+			response.Error(w, apperror.ErrNotFound)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// validPublicIDs reports whether every ID wildcard of pattern holds a UUID in
+// path.
+func validPublicIDs(pattern, path string) bool {
+	// Patterns may start with a method and a host: "GET /groups/{id}".
+	if _, rest, found := strings.Cut(pattern, " "); found {
+		pattern = rest
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, seg := range strings.Split(pattern, "/") {
+		name, ok := strings.CutPrefix(seg, "{")
+		if !ok || i >= len(segments) {
+			continue
+		}
+		name = strings.TrimSuffix(name, "}")
+		if name != "id" && !strings.HasSuffix(name, "Id") {
+			continue
+		}
+		if !publicid.Valid(segments[i]) {
+			return false
+		}
+	}
+	return true
+}
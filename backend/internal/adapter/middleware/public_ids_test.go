@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPublicIDs(t *testing.T) {
+	const groupID = "3f2b8c1e-9d4a-4e6b-8a7f-1c2d3e4f5a6b"
+	const userID = "0A1B2C3D-4E5F-4A6B-9C8D-7E6F5A4B3C2D"
+
+	var reached string
+	mux := http.NewServeMux()
+	record := func(w http.ResponseWriter, r *http.Request) {
+		reached = r.URL.Path + " " + r.PathValue("id") + r.PathValue("userId") + " " + r.PathValue("slug")
+		w.WriteHeader(http.StatusNoContent)
+	}
+	mux.HandleFunc("GET /groups/{id}", record)
+	mux.HandleFunc("DELETE /groups/{id}/members/{userId}", record)
+	mux.HandleFunc("GET /pages/{slug}", record)
+	handler := PublicIDs(mux)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantStatus  int
+		wantReached string
+	}{
+		{"public ID", http.MethodGet, "/groups/" + groupID, http.StatusNoContent, "/groups/" + groupID + " " + groupID + " "},
+		{"nested public IDs", http.MethodDelete, "/groups/" + groupID + "/members/" + userID, http.StatusNoContent, "/groups/" + groupID + "/members/" + userID + " " + groupID + userID + " "},
+		{"internal numeric ID", http.MethodGet, "/groups/42", http.StatusNotFound, ""},
+		{"internal ID in a nested wildcard", http.MethodDelete, "/groups/" + groupID + "/members/7", http.StatusNotFound, ""},
+		{"malformed UUID", http.MethodGet, "/groups/" + groupID[:35], http.StatusNotFound, ""},
+		{"encoded slash", http.MethodGet, "/groups/" + groupID[:8] + "%2F" + groupID[9:], http.StatusNotFound, ""},
+		{"non-ID wildcard", http.MethodGet, "/pages/42", http.StatusNoContent, "/pages/42  42"},
+		{"unrouted path", http.MethodGet, "/nowhere/42", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached = ""
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if reached != tt.wantReached {
+				t.Errorf("handler saw %q, want %q", reached, tt.wantReached)
+			}
+			// A rejected ID must not be echoed back to the client.
+			if rec.Code == http.StatusNotFound {
+				last := tt.path[strings.LastIndex(tt.path, "/")+1:]
+				if strings.Contains(rec.Body.String(), last) {
+					t.Errorf("404 body %q exposes the requested ID %q", rec.Body.String(), last)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+// verifiedRoutes lists, by ServeMux pattern, the routes that create or submit
+// shared content and therefore need a verified email. Everything else, such
+// as /me, stays open so an unverified user can still fix their account.
+var verifiedRoutes = map[string]bool{
+	"POST /groups":                                true,
+	"POST /groups/{id}/join":                      true,
+	"POST /groups/join":                           true,
+	"POST /activities/{id}/submissions":           true,
+	"PUT /activity-submissions/{id}":              true,
+	"POST /activity-submissions/{id}/send":        true,
+	"POST /activity-submissions/{id}/resubmit":    true,
+	"POST /activity-submissions/{id}/attachments": true,
+	"POST /questions/{id}/start":                  true,
+	"POST /questions/{id}/submissions":            true,
+	"POST /question-submissions/{id}/attachment":  true,
+}
+
+// RequireVerified rejects users whose email is not verified on the routes in
+// verifiedRoutes. It must run after Auth or AuthWithRole.
+func RequireVerified(repo repository.UserRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !verifiedRoutes[r.Pattern] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := repo.GetByPublicID(r.Context(), UserPublicID(r.Context()))
+			if err != nil || user == nil {
+				response.Error(w, apperror.ErrUnauthorized)
+				return
+			}
+
+			if user.EmailVerifiedAt == nil {
+				response.Error(w, apperror.ErrEmailNotVerified)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// JSONWithETag writes v like JSON, tagging the response with a weak ETag
+// derived from scope and the encoded body. A GET whose If-None-Match already
+// holds the tag gets 304 Not Modified without a body. Scope must name
+// whatever the body varies by without revealing it, such as the requesting
+// user for per-user listings; use "" for responses shared by everyone.
+func JSONWithETag(w http.ResponseWriter, r *http.Request, status int, v any, scope string) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("failed to encode response: %v", err)
+		Error(w, err)
+		return
+	}
+	body = append(body, '\n')
+
+	etag := weakETag(scope, body)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if r.Method == http.MethodGet && etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+func weakETag(scope string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(scope))
+	h.Write([]byte{0})
+	h.Write(body)
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// etagMatches applies the weak comparison If-None-Match calls for, so a
+// strong form of the same tag also matches.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
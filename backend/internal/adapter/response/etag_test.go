@@ -0,0 +1,57 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJSONWithETag(t *testing.T) {
+	payload := map[string]string{"title": "Álgebra"}
+
+	first := httptest.NewRecorder()
+	JSONWithETag(first, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK, payload, "user-1")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.Len() == 0 {
+		t.Fatalf("first response = %d, ETag %q, %d body bytes; want 200 with a tag and a body", first.Code, etag, first.Body.Len())
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		scope       string
+		wantStatus  int
+	}{
+		{"matching tag", http.MethodGet, etag, "user-1", http.StatusNotModified},
+		{"strong form of the tag", http.MethodGet, etag[len("W/"):], "user-1", http.StatusNotModified},
+		{"tag among others", http.MethodGet, `W/"stale", ` + etag, "user-1", http.StatusNotModified},
+		{"wildcard", http.MethodGet, "*", "user-1", http.StatusNotModified},
+		{"stale tag", http.MethodGet, `W/"stale"`, "user-1", http.StatusOK},
+		{"other scope", http.MethodGet, etag, "user-2", http.StatusOK},
+		{"not a GET", http.MethodPost, etag, "user-1", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			rec := httptest.NewRecorder()
+			JSONWithETag(rec, req, http.StatusOK, payload, tt.scope)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusNotModified {
+				if rec.Body.Len() != 0 {
+					t.Errorf("304 body = %q, want it empty", rec.Body.String())
+				}
+				if got := rec.Header().Get("ETag"); got != etag {
+					t.Errorf("ETag = %q, want %q", got, etag)
+				}
+			} else if rec.Body.String() != first.Body.String() {
+				t.Errorf("body = %q, want %q", rec.Body.String(), first.Body.String())
+			}
+		})
+	}
+}
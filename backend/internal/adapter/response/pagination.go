@@ -0,0 +1,65 @@
+package response
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// maxOffset bounds (page_number-1)*page_size so huge page numbers
+	// cannot overflow the offset handed to the repositories.
+	maxOffset = math.MaxInt32
+)
+
+type Pagination struct {
+	PageNumber int
+	PageSize   int
+	TotalItems int
+	TotalPages int
+}
+
+// ParsePagination reads page_number and page_size from the query string.
+// Missing, non-numeric or non-positive values fall back to page 1 and
+// defaultSize, itself DefaultPageSize when not positive; page_size is capped
+// at MaxPageSize and page_number at the last page whose offset still fits in
+// maxOffset.
+func ParsePagination(r *http.Request, defaultSize int) (page, size, offset int) {
+	page = 1
+	size = defaultSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+
+	if v, err := strconv.Atoi(r.URL.Query().Get("page_number")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("page_size")); err == nil && v > 0 {
+		size = v
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	if page-1 > maxOffset/size {
+		page = maxOffset/size + 1
+	}
+
+	return page, size, (page - 1) * size
+}
+
+// Paginate builds the pagination metadata for a list response.
+func Paginate(total, page, size int) Pagination {
+	totalPages := 0
+	if size > 0 {
+		totalPages = (total + size - 1) / size
+	}
+	return Pagination{
+		PageNumber: page,
+		PageSize:   size,
+		TotalItems: total,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"math"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		defaultSize int
+		page, size  int
+		offset      int
+	}{
+		{"defaults", "", DefaultPageSize, 1, DefaultPageSize, 0},
+		{"caller default", "?page_number=2", 50, 2, 50, 50},
+		{"non-positive caller default", "", 0, 1, DefaultPageSize, 0},
+		{"caller default capped", "", 500, 1, MaxPageSize, 0},
+		{"explicit", "?page_number=3&page_size=15", 50, 3, 15, 30},
+		{"invalid values", "?page_number=-2&page_size=abc", 50, 1, 50, 0},
+		{"size capped", "?page_size=1000", DefaultPageSize, 1, MaxPageSize, 0},
+		{"huge page clamped", "?page_number=" + strconv.Itoa(math.MaxInt) + "&page_size=100", DefaultPageSize, maxOffset/100 + 1, 100, maxOffset / 100 * 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, size, offset := ParsePagination(httptest.NewRequest("GET", "/items"+tt.query, nil), tt.defaultSize)
+			if page != tt.page || size != tt.size || offset != tt.offset {
+				t.Errorf("got (%d, %d, %d), want (%d, %d, %d)", page, size, offset, tt.page, tt.size, tt.offset)
+			}
+			if offset < 0 || offset > maxOffset {
+				t.Errorf("offset %d out of range", offset)
+			}
+		})
+	}
+}
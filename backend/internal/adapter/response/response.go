@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 
 	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/i18n"
 )
 
 func JSON(w http.ResponseWriter, status int, v any) {
@@ -16,12 +18,26 @@ func JSON(w http.ResponseWriter, status int, v any) {
 	}
 }
 
+// Created writes v with 201 Created and a Location header pointing at the
+// canonical URL of the new resource.
+func Created(w http.ResponseWriter, location string, v any) {
+	w.Header().Set("Location", location)
+	JSON(w, http.StatusCreated, v)
+}
+
 func Error(w http.ResponseWriter, err error) {
-	if appErr, ok := err.(*apperror.AppError); ok {
-		JSON(w, appErr.HTTPStatus, appErr)
-		return
+	appErr, ok := err.(*apperror.AppError)
+	if !ok {
+		log.Printf("internal error: %v", err)
+		appErr = apperror.ErrInternalError
 	}
 
-	log.Printf("internal error: %v", err)
-	JSON(w, http.StatusInternalServerError, apperror.ErrInternalError)
+	// The code stays stable; only the human-readable message is translated
+	// into the locale negotiated by the Locale middleware.
+	localized := *appErr
+	localized.Message = i18n.Message(i18n.Resolve(w.Header().Get("Content-Language")), appErr)
+	if appErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(appErr.RetryAfter))
+	}
+	JSON(w, localized.HTTPStatus, &localized)
 }
@@ -0,0 +1,54 @@
+// Package buildinfo reports which build of the server is running.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Commit and BuildTime are injected at build time, e.g.
+//
+//	go build -ldflags "-X proximos-passos/backend/internal/buildinfo.Commit=$(git rev-parse HEAD)
+//	  -X proximos-passos/backend/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// When left empty they fall back to the VCS stamp Go embeds in the binary.
+var (
+	Commit    string
+	BuildTime string
+)
+
+const unknown = "unknown"
+
+type Info struct {
+	Commit    string
+	BuildTime string
+	GoVersion string
+}
+
+// Get returns the build details, preferring the ldflags values.
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = unknown
+	}
+	return info
+}
@@ -1,6 +1,10 @@
 package apperror
 
-import "net/http"
+import (
+	"math"
+	"net/http"
+	"time"
+)
 
 type Code string
 
@@ -49,20 +53,61 @@ const (
 	CodeActivityAlreadySubmitted      Code = "ACTIVITY_ALREADY_SUBMITTED"
 	CodeActivitySubmissionNotPending  Code = "ACTIVITY_SUBMISSION_NOT_PENDING"
 	CodeActivitySubmissionNotReproved Code = "ACTIVITY_SUBMISSION_NOT_REPROVED"
+	CodeCSRF                          Code = "CSRF_TOKEN_INVALID"
+	CodeAttemptLimitReached           Code = "ATTEMPT_LIMIT_REACHED"
+	CodeWeakPassword                  Code = "WEAK_PASSWORD"
+	CodeInvalidImage                  Code = "INVALID_IMAGE"
+	CodeWebhookNotFound               Code = "WEBHOOK_NOT_FOUND"
+	CodeLeaderboardDisabled           Code = "LEADERBOARD_DISABLED"
+	CodeDuplicateRow                  Code = "DUPLICATE_ROW"
+	CodeJoinCodeTaken                 Code = "JOIN_CODE_TAKEN"
+	CodeInvalidIdempotencyKey         Code = "INVALID_IDEMPOTENCY_KEY"
+	CodeIdempotencyKeyInUse           Code = "IDEMPOTENCY_KEY_IN_USE"
+	CodeEmailNotFound                 Code = "EMAIL_NOT_FOUND"
+	CodeEmailNotFailed                Code = "EMAIL_NOT_FAILED"
+	CodeDueDateInPast                 Code = "DUE_DATE_IN_PAST"
+	CodeActivitySubmissionNotReviewed Code = "ACTIVITY_SUBMISSION_NOT_REVIEWED"
+	CodeQuestionNotInActivity         Code = "QUESTION_NOT_IN_ACTIVITY"
+	CodeContentInUse                  Code = "CONTENT_IN_USE"
+	CodeImpersonationNotAllowed       Code = "IMPERSONATION_NOT_ALLOWED"
+	CodeNotImpersonating              Code = "NOT_IMPERSONATING"
+	CodeRequestTooLarge               Code = "REQUEST_TOO_LARGE"
+	CodeLastAdmin                     Code = "LAST_ADMIN"
+	CodeCannotRemoveSelf              Code = "CANNOT_REMOVE_SELF"
+	CodeAttachmentNotAllowed          Code = "ATTACHMENT_NOT_ALLOWED"
+	CodeSessionNotFound               Code = "SESSION_NOT_FOUND"
+	CodeInvalidEmail                  Code = "INVALID_EMAIL"
+	CodeQuestionArchived              Code = "QUESTION_ARCHIVED"
+	CodeInvalidTimeZone               Code = "INVALID_TIME_ZONE"
+	CodeRangeNotSatisfiable           Code = "RANGE_NOT_SATISFIABLE"
+	CodeAccountSuspended              Code = "ACCOUNT_SUSPENDED"
+	CodeLastPlatformAdmin             Code = "LAST_PLATFORM_ADMIN"
+	CodeAnswerReviewed                Code = "ANSWER_REVIEWED"
+	CodeActivityPastDue               Code = "ACTIVITY_PAST_DUE"
+	CodeIdempotencyKeyMismatch        Code = "IDEMPOTENCY_KEY_MISMATCH"
 )
 
 type AppError struct {
-	Code       Code   `json:"code"`
-	Message    string `json:"message"`
-	Details    any    `json:"details"`
-	HTTPStatus int    `json:"-"`
+	Code       Code              `json:"code"`
+	Message    string            `json:"message"`
+	Details    any               `json:"details"`
+	Errors     map[string]string `json:"errors,omitempty"`
+	RetryAfter int               `json:"retry_after_seconds,omitempty"` // seconds; response.Error also sends it as Retry-After
+	HTTPStatus int               `json:"-"`
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+// defaultMessages holds the message each code was first declared with, so a
+// message customised at the call site can be told apart from the standard one.
+var defaultMessages = map[Code]string{}
+
 func New(code Code, message string, httpStatus int) *AppError {
+	if _, ok := defaultMessages[code]; !ok {
+		defaultMessages[code] = message
+	}
 	return &AppError{
 		Code:       code,
 		Message:    message,
@@ -70,6 +115,11 @@ func New(code Code, message string, httpStatus int) *AppError {
 	}
 }
 
+// DefaultMessage returns the standard message declared for code.
+func DefaultMessage(code Code) string {
+	return defaultMessages[code]
+}
+
 func WithDetails(code Code, message string, httpStatus int, details any) *AppError {
 	return &AppError{
 		Code:       code,
@@ -79,6 +129,45 @@ func WithDetails(code Code, message string, httpStatus int, details any) *AppErr
 	}
 }
 
+// WithRetryAfter returns a copy of err telling the client to wait d before
+// retrying, rounded up to whole seconds and never less than one.
+func WithRetryAfter(err *AppError, d time.Duration) *AppError {
+	withRetry := *err
+	withRetry.RetryAfter = max(1, int(math.Ceil(d.Seconds())))
+	return &withRetry
+}
+
+// ValidationError collects field-level validation failures so that all of
+// them can be reported at once instead of stopping at the first one.
+type ValidationError struct {
+	fields map[string]string
+}
+
+func NewValidationError() *ValidationError {
+	return &ValidationError{fields: make(map[string]string)}
+}
+
+// Add records a message for field. Only the first message per field is kept.
+func (v *ValidationError) Add(field, message string) {
+	if _, ok := v.fields[field]; !ok {
+		v.fields[field] = message
+	}
+}
+
+// Err returns nil when no field failed, or an INVALID_INPUT error with
+// status 422 carrying the field messages otherwise.
+func (v *ValidationError) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &AppError{
+		Code:       CodeInvalidInput,
+		Message:    ErrInvalidInput.Message,
+		Errors:     v.fields,
+		HTTPStatus: http.StatusUnprocessableEntity,
+	}
+}
+
 var (
 	ErrInvalidInput                  = New(CodeInvalidInput, "The provided input is invalid.", http.StatusBadRequest)
 	ErrInvalidBody                   = New(CodeInvalidBody, "The request body could not be parsed.", http.StatusBadRequest)
@@ -99,7 +188,7 @@ var (
 	ErrMemberAlreadyExists           = New(CodeMemberAlreadyExists, "The user is already a member of this group.", http.StatusConflict)
 	ErrSetupUnavailable              = New(CodeSetupUnavailable, "Initial setup is no longer available.", http.StatusConflict)
 	ErrVerificationCooldown          = New(CodeVerificationCooldown, "Please wait before requesting another verification email.", http.StatusTooManyRequests)
-	ErrEmailNotVerified              = New(CodeEmailNotVerified, "Please verify your email before logging in.", http.StatusForbidden)
+	ErrEmailNotVerified              = New(CodeEmailNotVerified, "Please verify your email to continue.", http.StatusForbidden)
 	ErrActivityNotFound              = New(CodeActivityNotFound, "The requested activity was not found.", http.StatusNotFound)
 	ErrAttachmentNotFound            = New(CodeAttachmentNotFound, "The requested attachment was not found.", http.StatusNotFound)
 	ErrActivityTitleTaken            = New(CodeActivityTitleTaken, "An activity with this title already exists in the group.", http.StatusConflict)
@@ -123,4 +212,36 @@ var (
 	ErrActivityAlreadySubmitted      = New(CodeActivityAlreadySubmitted, "You have already submitted this activity.", http.StatusConflict)
 	ErrActivitySubmissionNotPending  = New(CodeActivitySubmissionNotPending, "This submission has already been reviewed and cannot be edited.", http.StatusConflict)
 	ErrActivitySubmissionNotReproved = New(CodeActivitySubmissionNotReproved, "This submission is not reproved and cannot be resubmitted.", http.StatusConflict)
+	ErrCSRF                          = New(CodeCSRF, "The CSRF token is missing or invalid.", http.StatusForbidden)
+	ErrAttemptLimitReached           = New(CodeAttemptLimitReached, "You have reached the maximum number of attempts for this question.", http.StatusConflict)
+	ErrWeakPassword                  = New(CodeWeakPassword, "Password does not meet the strength requirements.", http.StatusUnprocessableEntity)
+	ErrInvalidImage                  = New(CodeInvalidImage, "File is not a valid image or its dimensions are out of range.", http.StatusBadRequest)
+	ErrWebhookNotFound               = New(CodeWebhookNotFound, "The requested webhook was not found.", http.StatusNotFound)
+	ErrLeaderboardDisabled           = New(CodeLeaderboardDisabled, "The leaderboard is disabled for this group.", http.StatusForbidden)
+	ErrJoinCodeTaken                 = New(CodeJoinCodeTaken, "This join code is already in use.", http.StatusConflict)
+	ErrInvalidIdempotencyKey         = New(CodeInvalidIdempotencyKey, "The Idempotency-Key header must be between 1 and 255 characters.", http.StatusBadRequest)
+	ErrIdempotencyKeyInUse           = New(CodeIdempotencyKeyInUse, "A request with this idempotency key is still being processed.", http.StatusConflict)
+	ErrEmailNotFound                 = New(CodeEmailNotFound, "The requested email was not found.", http.StatusNotFound)
+	ErrEmailNotFailed                = New(CodeEmailNotFailed, "Only failed emails can be retried.", http.StatusConflict)
+	ErrDueDateInPast                 = New(CodeDueDateInPast, "The due date must be in the future.", http.StatusUnprocessableEntity)
+	ErrActivitySubmissionNotReviewed = New(CodeActivitySubmissionNotReviewed, "Only approved or reproved submissions can be reopened.", http.StatusConflict)
+	ErrQuestionNotInActivity         = New(CodeQuestionNotInActivity, "This question is not part of the activity.", http.StatusUnprocessableEntity)
+	ErrContentInUse                  = New(CodeContentInUse, "This content is used by one or more activities.", http.StatusConflict)
+	ErrImpersonationNotAllowed       = New(CodeImpersonationNotAllowed, "This action is not allowed while impersonating a user.", http.StatusForbidden)
+	ErrNotImpersonating              = New(CodeNotImpersonating, "This session is not impersonating a user.", http.StatusBadRequest)
+	ErrRequestTooLarge               = New(CodeRequestTooLarge, "The request body exceeds the maximum allowed size.", http.StatusRequestEntityTooLarge)
+	ErrLastAdmin                     = New(CodeLastAdmin, "This would leave a group without an admin. Promote another admin first.", http.StatusConflict)
+	ErrCannotRemoveSelf              = New(CodeCannotRemoveSelf, "You cannot remove yourself from a group. Leave the group instead.", http.StatusBadRequest)
+	ErrAttachmentNotAllowed          = New(CodeAttachmentNotAllowed, "Only open-ended answers accept attachments.", http.StatusBadRequest)
+	ErrSessionNotFound               = New(CodeSessionNotFound, "The requested session was not found.", http.StatusNotFound)
+	ErrInvalidEmail                  = New(CodeInvalidEmail, "The email address is not valid.", http.StatusBadRequest)
+	ErrQuestionArchived              = New(CodeQuestionArchived, "This question is archived and cannot be added to activities.", http.StatusConflict)
+	ErrInvalidTimeZone               = New(CodeInvalidTimeZone, "Time zone must be an IANA name such as America/Sao_Paulo.", http.StatusBadRequest)
+	ErrRangeNotSatisfiable           = New(CodeRangeNotSatisfiable, "The requested byte range is not available.", http.StatusRequestedRangeNotSatisfiable)
+	ErrAccountSuspended              = New(CodeAccountSuspended, "This account has been suspended.", http.StatusForbidden)
+	ErrLastPlatformAdmin             = New(CodeLastPlatformAdmin, "This would leave the platform without an active admin. Promote another admin first.", http.StatusConflict)
+	ErrNotFound                      = New(CodeNotFound, "The requested resource was not found.", http.StatusNotFound)
+	ErrAnswerReviewed                = New(CodeAnswerReviewed, "This answer has already been reviewed and cannot be changed.", http.StatusConflict)
+	ErrActivityPastDue               = New(CodeActivityPastDue, "The activity is past its due date.", http.StatusConflict)
+	ErrIdempotencyKeyMismatch        = New(CodeIdempotencyKeyMismatch, "This idempotency key was already used with a different request body.", http.StatusUnprocessableEntity)
 )
@@ -0,0 +1,36 @@
+// Package email normalizes user-supplied email addresses so that one mailbox
+// always maps to one account.
+package email
+
+import (
+	"net/mail"
+	"strings"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+// maxLength matches the users.email column limit.
+const maxLength = 255
+
+// Normalize trims and lowercases address and checks that it is a single bare
+// mailbox with a dotted domain, such as "name@example.com". Anything else,
+// including display-name forms like "Name <name@example.com>", yields
+// apperror.ErrInvalidEmail.
+func Normalize(address string) (string, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+	if address == "" || len(address) > maxLength {
+		return "", apperror.ErrInvalidEmail
+	}
+
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Name != "" || parsed.Address != address {
+		return "", apperror.ErrInvalidEmail
+	}
+
+	_, domain, _ := strings.Cut(address, "@")
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", apperror.ErrInvalidEmail
+	}
+
+	return address, nil
+}
@@ -0,0 +1,48 @@
+package email
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func TestNormalize(t *testing.T) {
+	valid := []struct {
+		in   string
+		want string
+	}{
+		{"ana@example.com", "ana@example.com"},
+		{"Ana.Silva@Example.COM", "ana.silva@example.com"},
+		{"  ana@example.com\t\n", "ana@example.com"},
+		{"ana+tag@mail.example.com.br", "ana+tag@mail.example.com.br"},
+	}
+	for _, tt := range valid {
+		got, err := Normalize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Normalize(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"   ",
+		"ana",
+		"ana@",
+		"@example.com",
+		"ana@localhost",
+		"ana@.example.com",
+		"ana@example.com.",
+		"ana@@example.com",
+		"ana @example.com",
+		"Ana <ana@example.com>",
+		"ana@example.com, bia@example.com",
+		strings.Repeat("a", maxLength) + "@example.com",
+	}
+	for _, in := range invalid {
+		if got, err := Normalize(in); !errors.Is(err, apperror.ErrInvalidEmail) {
+			t.Errorf("Normalize(%q) = %q, %v; want ErrInvalidEmail", in, got, err)
+		}
+	}
+}
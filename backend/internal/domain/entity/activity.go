@@ -7,6 +7,7 @@ type Activity struct {
 	PublicID                  string
 	GroupID                   int
 	GroupPublicID             string
+	GroupTimeZone             string
 	Title                     string
 	Description               *string
 	DueDate                   time.Time
@@ -17,6 +18,10 @@ type Activity struct {
 	TotalQuestionsCount       int
 	TotalExerciseListsCount   int
 	UpdatedAt                 time.Time
+
+	// SubmissionCounts tallies active submissions by status. It is only
+	// loaded for group staff and stays nil otherwise.
+	SubmissionCounts map[ActivitySubmissionStatus]int
 }
 
 type ActivityAttachment struct {
@@ -27,7 +32,7 @@ type ActivityAttachment struct {
 	Filename     string
 	ContentType  string
 	SizeBytes    int64
-	URL          string
+	Checksum     *string
 	CreatedAt    time.Time
 }
 
@@ -41,6 +46,15 @@ const (
 	ActivityItemTypeSimulatedExam    ActivityItemType = "simulated_exam"
 )
 
+// ActivityItemTypes lists every item type, in display order.
+var ActivityItemTypes = []ActivityItemType{
+	ActivityItemTypeQuestion,
+	ActivityItemTypeVideoLesson,
+	ActivityItemTypeHandout,
+	ActivityItemTypeOpenExerciseList,
+	ActivityItemTypeSimulatedExam,
+}
+
 type ActivityItem struct {
 	ID                       int
 	PublicID                 string
@@ -68,4 +82,16 @@ type ActivityItem struct {
 	MedianLogic              *float64
 	MedianLabor              *float64
 	MedianTheory             *float64
+
+	// Question is only loaded by ActivityUseCase.GetItemDetail.
+	Question *Question
+}
+
+// ActivityRef identifies an activity that references some content.
+type ActivityRef struct {
+	ID            int
+	PublicID      string
+	Title         string
+	GroupPublicID string
+	GroupName     string
 }
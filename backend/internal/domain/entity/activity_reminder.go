@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// ActivityReminder is a group member who has not yet submitted an activity
+// that is due soon and has not been reminded about it.
+type ActivityReminder struct {
+	ActivityID       int
+	ActivityPublicID string
+	ActivityTitle    string
+	DueDate          time.Time
+	GroupName        string
+	GroupTimeZone    string
+	UserID           int
+	UserName         string
+	UserEmail        string
+	UserLang         *string
+}
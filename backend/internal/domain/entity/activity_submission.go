@@ -11,6 +11,14 @@ const (
 	ActivitySubmissionStatusReproved ActivitySubmissionStatus = "reproved"
 )
 
+// ActivitySubmissionStatuses lists every submission status, in lifecycle order.
+var ActivitySubmissionStatuses = []ActivitySubmissionStatus{
+	ActivitySubmissionStatusCreated,
+	ActivitySubmissionStatusPending,
+	ActivitySubmissionStatusApproved,
+	ActivitySubmissionStatusReproved,
+}
+
 type ActivitySubmission struct {
 	ID            int
 	PublicID      string
@@ -21,6 +29,9 @@ type ActivitySubmission struct {
 	FeedbackNotes *string
 	ReviewedAt    *time.Time
 	ReviewedByID  *int
+	ReopenReason  *string // why a group admin sent a reviewed submission back to pending
+	ReopenedAt    *time.Time
+	ReopenedByID  *int
 	IsActive      bool
 	SubmittedAt   time.Time
 	UpdatedAt     time.Time
@@ -33,6 +44,16 @@ type ActivitySubmission struct {
 	UserAvatarURL    *string
 	ReviewerPublicID *string
 	ReviewerName     *string
+	AttachmentCount  int // active attachments, computed in the same query
+}
+
+// ReviewQueueItem is a pending submission awaiting review, with the group
+// context a reviewer needs to triage it alongside other groups' submissions.
+type ReviewQueueItem struct {
+	Submission      ActivitySubmission
+	GroupPublicID   string
+	GroupName       string
+	ActivityDueDate time.Time
 }
 
 type ActivitySubmissionAttachment struct {
@@ -43,6 +64,6 @@ type ActivitySubmissionAttachment struct {
 	Filename     string
 	ContentType  string
 	SizeBytes    int64
-	URL          string
+	Checksum     *string
 	CreatedAt    time.Time
 }
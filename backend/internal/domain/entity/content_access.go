@@ -0,0 +1,20 @@
+package entity
+
+type ContentAccessAction string
+
+const (
+	ContentAccessView     ContentAccessAction = "view"
+	ContentAccessDownload ContentAccessAction = "download"
+)
+
+// ContentAccessStats aggregates the recorded accesses to one piece of
+// content. ContentType is one of the handout, video lesson or open exercise
+// list item types.
+type ContentAccessStats struct {
+	ContentType ActivityItemType
+	PublicID    string
+	Title       string
+	Views       int
+	Downloads   int
+	UniqueUsers int
+}
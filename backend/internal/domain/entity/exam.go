@@ -9,6 +9,7 @@ type Exam struct {
 	Title         string
 	Description   *string
 	Year          int
+	Phase         *int // e.g. 1 for the first phase, nil for single-phase exams
 	IsActive      bool
 	CreatedByID   int
 	CreatedAt     time.Time
@@ -18,4 +19,7 @@ type Exam struct {
 	InstitutionPublicID string
 	InstitutionName     string
 	InstitutionAcronym  string
+
+	// Aggregated fields
+	QuestionCount int // active questions linked to the exam
 }
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type File struct {
+	ID          int
+	PublicID    string
+	Key         string
+	Filename    string
+	ContentType string
+	SizeBytes   int64
+	Checksum    *string // hex SHA-256 of the content
+	IsActive    bool
+	CreatedAt   time.Time
+}
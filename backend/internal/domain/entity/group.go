@@ -25,17 +25,35 @@ const (
 )
 
 type Group struct {
-	ID             int
-	PublicID       string
-	Name           string
-	Description    *string
-	AccessType     GroupAccessType
-	VisibilityType GroupVisibilityType
-	ThumbnailURL   *string
-	IsActive       bool
-	CreatedByID    int
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID                   int
+	PublicID             string
+	Name                 string
+	Description          *string
+	AccessType           GroupAccessType
+	VisibilityType       GroupVisibilityType
+	ThumbnailURL         *string
+	LeaderboardEnabled   bool
+	LeaderboardAnonymous bool
+	AllowPastDue         bool   // lets group admins create activities whose due date has already passed
+	TimeZone             string // IANA zone activity due dates are entered and shown in
+	// Submission upload overrides; nil falls back to the platform defaults
+	SubmissionMaxBytes     *int64
+	SubmissionAllowedTypes []string
+	JoinCode               *string // lets users join private groups; only shown to group staff
+	IsActive               bool
+	CreatedByID            int
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// TimeZoneLocation resolves a group's IANA time zone, falling back to UTC
+// when the name is unknown to this host.
+func TimeZoneLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
 
 type GroupMember struct {
@@ -54,3 +72,21 @@ type GroupMember struct {
 	UserEmail     string
 	UserAvatarURL *string
 }
+
+// GroupInvitation is a pending membership for an email address without an
+// account. It becomes a membership once an account with that address verifies
+// it.
+type GroupInvitation struct {
+	GroupID     int
+	Email       string
+	Role        MemberRole
+	InvitedByID int
+	CreatedAt   time.Time
+}
+
+// GroupMembership is a compact view of one of a user's active memberships.
+type GroupMembership struct {
+	GroupPublicID string
+	GroupName     string
+	Role          MemberRole
+}
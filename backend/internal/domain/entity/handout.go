@@ -3,15 +3,20 @@ package entity
 import "time"
 
 type Handout struct {
-	ID          int
-	PublicID    string
-	Title       string
-	Description *string
-	FileID      int
-	IsActive    bool
-	CreatedByID int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID            int
+	PublicID      string
+	Title         string
+	Description   *string
+	FileID        int
+	DownloadCount int // downloads still within the access event retention
+	IsActive      bool
+	CreatedByID   int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// Joined creator fields
+	CreatedByPublicID string
+	CreatedByName     string
 
 	// Joined fields
 	FilePublicID string
@@ -19,7 +24,6 @@ type Handout struct {
 	Filename     string
 	ContentType  string
 	SizeBytes    int64
-	FileURL      string
 
 	Topics []TopicRef
 }
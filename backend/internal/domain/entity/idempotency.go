@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord is the stored outcome of a request sent with an
+// Idempotency-Key header. StatusCode is nil while the request is in flight.
+type IdempotencyRecord struct {
+	ID           int
+	UserID       int
+	Key          string
+	Route        string
+	RequestHash  []byte // SHA-256 of the request body, set once completed
+	StatusCode   *int
+	ContentType  string
+	Location     string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
@@ -7,6 +7,7 @@ type Institution struct {
 	PublicID    string
 	Name        string
 	Acronym     string
+	LogoURL     *string
 	IsActive    bool
 	CreatedByID int
 	CreatedAt   time.Time
@@ -3,16 +3,21 @@ package entity
 import "time"
 
 type OpenExerciseList struct {
-	ID          int
-	PublicID    string
-	Title       string
-	Description *string
-	FileID      *int
-	FileURL     *string
-	IsActive    bool
-	CreatedByID int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID            int
+	PublicID      string
+	Title         string
+	Description   *string
+	FileID        *int
+	FileURL       *string
+	DownloadCount int // downloads still within the access event retention
+	IsActive      bool
+	CreatedByID   int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// Joined creator fields
+	CreatedByPublicID string
+	CreatedByName     string
 
 	// Joined fields (populated when file_id is set)
 	FilePublicID string
@@ -20,7 +25,7 @@ type OpenExerciseList struct {
 	Filename     string
 	ContentType  string
 	SizeBytes    int64
-	ResolvedURL  string // final URL: either from storage or file_url
+	ResolvedURL  string // the external file_url downloads redirect to; empty for uploads
 
 	Topics []TopicRef
 }
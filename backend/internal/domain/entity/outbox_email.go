@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type EmailStatus string
+
+const (
+	EmailStatusPending EmailStatus = "pending"
+	EmailStatusSent    EmailStatus = "sent"
+	EmailStatusFailed  EmailStatus = "failed"
+)
+
+// OutboxEmail is a rendered email waiting in (or dead-lettered from) the
+// delivery queue.
+type OutboxEmail struct {
+	ID            int
+	PublicID      string
+	Recipient     string
+	Subject       string
+	HTML          string
+	Text          string
+	Status        EmailStatus
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -8,13 +8,21 @@ type Question struct {
 	Type               string // "open_ended" or "closed_ended"
 	Statement          string
 	ExpectedAnswerText *string
+	ExpectedKeywords   []string // open-ended only; drives the suggested score hint
 	PassingScore       *int
+	MaxAttempts        *int    // nil means unlimited attempts within an activity
+	Difficulty         *string // "easy", "medium" or "hard"
 	ExamID             *int
+	ArchivedAt         *time.Time // set while hidden from listings and new activities
 	IsActive           bool
 	CreatedByID        int
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 
+	// Joined creator fields
+	CreatedByPublicID string
+	CreatedByName     string
+
 	// Joined exam fields
 	ExamPublicID           string
 	ExamTitle              string
@@ -23,6 +31,7 @@ type Question struct {
 	ExamInstitutionAcronym string
 
 	Topics           []TopicRef
+	Tags             []string
 	Images           []QuestionImage
 	Options          []QuestionOption
 	MedianDifficulty *float64
@@ -31,6 +40,14 @@ type Question struct {
 	MedianTheory     *float64
 }
 
+// QuestionDuplicate is an existing question whose statement closely matches
+// one being created.
+type QuestionDuplicate struct {
+	PublicID   string
+	Statement  string
+	Similarity float64
+}
+
 type QuestionImage struct {
 	FileID       int
 	FilePublicID string
@@ -39,6 +56,8 @@ type QuestionImage struct {
 	ContentType  string
 	SizeBytes    int64
 	URL          string
+	WebPKey      string // empty when no WebP variant was stored
+	WebPURL      string
 }
 
 type QuestionOption struct {
@@ -14,17 +14,58 @@ type QuestionSubmission struct {
 	Score                *int
 	AnswerFeedback       *string
 	Passed               bool
+	StartedAt            *time.Time
+	DurationSeconds      *int
+	AttachmentFileID     *int // open-ended only, e.g. a photo of handwritten work
+	ReviewedAt           *time.Time
+	ReviewedByID         *int
 	IsActive             bool
 	SubmittedAt          time.Time
 	UpdatedAt            time.Time
 
 	// Joined fields
-	QuestionPublicID  string
-	QuestionType      string
-	QuestionStatement string
-	UserPublicID      string
-	UserName          string
-	OptionPublicID    string
-	OptionText        *string
-	OptionIsCorrect   bool
+	QuestionPublicID    string
+	QuestionType        string
+	QuestionStatement   string
+	QuestionMaxAttempts *int
+	QuestionKeywords    []string
+	UserPublicID        string
+	UserName            string
+	OptionPublicID      string
+	OptionText          *string
+	OptionIsCorrect     bool
+	Attachment          *QuestionSubmissionAttachment
+}
+
+type QuestionSubmissionAttachment struct {
+	FileID       int
+	FilePublicID string
+	Key          string
+	Filename     string
+	ContentType  string
+	SizeBytes    int64
+	Checksum     *string
+}
+
+type QuestionTimeStats struct {
+	QuestionID             int
+	TimedAttempts          int
+	AverageDurationSeconds *float64
+}
+
+// AnswerDurationSeconds returns the whole seconds between startedAt and
+// submittedAt, clamped to zero to absorb clock skew.
+func AnswerDurationSeconds(startedAt, submittedAt time.Time) int {
+	d := int(submittedAt.Sub(startedAt).Seconds())
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// AttemptLimitReached reports whether a student who has made attempts at a
+// question limited to maxAttempts may not try again. A nil limit means
+// unlimited, and once an attempt passed the limit no longer applies.
+func AttemptLimitReached(maxAttempts *int, attempts int, passed bool) bool {
+	return maxAttempts != nil && !passed && attempts >= *maxAttempts
 }
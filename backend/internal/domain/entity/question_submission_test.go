@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAttemptLimitReached(t *testing.T) {
+	two := 2
+
+	tests := []struct {
+		name        string
+		maxAttempts *int
+		attempts    int
+		passed      bool
+		want        bool
+	}{
+		{"unlimited", nil, 10, false, false},
+		{"attempts left", &two, 1, false, false},
+		{"limit used up", &two, 2, false, true},
+		{"over the limit", &two, 3, false, true},
+		{"passed lifts the limit", &two, 2, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AttemptLimitReached(tt.maxAttempts, tt.attempts, tt.passed); got != tt.want {
+				t.Errorf("AttemptLimitReached(%v, %d, %t) = %t, want %t", tt.maxAttempts, tt.attempts, tt.passed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswerDurationSeconds(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		submitted time.Time
+		want      int
+	}{
+		{"same instant", start, 0},
+		{"whole seconds", start.Add(95 * time.Second), 95},
+		{"fractions truncated", start.Add(2*time.Minute + 900*time.Millisecond), 120},
+		{"clock skew clamped", start.Add(-3 * time.Second), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AnswerDurationSeconds(start, tt.submitted); got != tt.want {
+				t.Errorf("AnswerDurationSeconds = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
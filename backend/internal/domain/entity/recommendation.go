@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// Recommendation is a piece of content suggested to a student because it
+// covers one of their weak topics.
+type Recommendation struct {
+	ContentType ActivityItemType // question, video_lesson or handout
+	PublicID    string
+	Title       string
+	CreatedAt   time.Time
+	Topic       TopicMastery // the weak topic the content was picked for
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// Session is the server-side record behind a session token. A token is only
+// accepted while its session is neither revoked nor expired.
+type Session struct {
+	ID                    int
+	PublicID              string
+	UserID                int
+	ImpersonatorSessionID *int // impersonation only: the admin session restored when it stops
+	UserAgent             string
+	IPAddress             string
+	CreatedAt             time.Time
+	LastSeenAt            time.Time
+	ExpiresAt             time.Time
+	RevokedAt             *time.Time
+}
+
+type ImpersonationAction string
+
+const (
+	ImpersonationStart ImpersonationAction = "start"
+	ImpersonationStop  ImpersonationAction = "stop"
+)
+
+// ImpersonationEvent is one audited start or stop of an admin acting as
+// another user.
+type ImpersonationEvent struct {
+	AdminID      int
+	TargetUserID int
+	Action       ImpersonationAction
+	IPAddress    string
+}
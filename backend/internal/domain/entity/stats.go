@@ -0,0 +1,36 @@
+package entity
+
+// GroupStats summarizes membership, activities and submissions of a group.
+type GroupStats struct {
+	MembersByRole       map[MemberRole]int
+	PendingRequests     int
+	ActiveActivities    int
+	PastActivities      int
+	SubmissionsByStatus map[ActivitySubmissionStatus]int
+	// Mean time between submission and review over reviewed submissions,
+	// nil when nothing has been reviewed yet.
+	AvgReviewTurnaroundSeconds *float64
+}
+
+// LeaderboardEntry ranks a group member by the questions they passed within
+// the group's activities. Members with equal values share the same rank.
+type LeaderboardEntry struct {
+	Rank            int
+	UserID          int
+	UserPublicID    string
+	UserName        string
+	UserAvatarURL   *string
+	PassedQuestions int
+	TotalScore      int
+}
+
+// TopicMastery summarizes a student's question submissions within a topic.
+// Attempts at questions of a subtopic also count toward every ancestor.
+type TopicMastery struct {
+	TopicPublicID  string
+	TopicName      string
+	ParentPublicID *string
+	Attempts       int
+	Passed         int
+	AverageScore   *float64 // nil when no attempt was scored
+}
@@ -21,3 +21,11 @@ type Topic struct {
 	HandoutsCount      int
 	ExerciseListsCount int
 }
+
+// TopicBreadcrumb is a topic's name together with its ancestors, root
+// first, for breadcrumb display.
+type TopicBreadcrumb struct {
+	PublicID  string
+	Name      string
+	Ancestors []TopicRef
+}
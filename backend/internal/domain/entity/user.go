@@ -9,6 +9,16 @@ const (
 	UserRoleRegular UserRole = "regular"
 )
 
+// UserStatus tracks whether an account may sign in. Suspended accounts keep
+// their data and memberships but cannot authenticate until reinstated.
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+	UserStatusDeleted   UserStatus = "deleted"
+)
+
 type User struct {
 	ID                          int
 	PublicID                    string
@@ -19,7 +29,11 @@ type User struct {
 	LastVerificationTokenSentAt *time.Time
 	PasswordHash                string
 	AvatarURL                   *string
-	IsActive                    bool
-	CreatedAt                   time.Time
-	UpdatedAt                   time.Time
+	Lang                        *string
+	// ActivityReminders is false once the user opts out of due-date reminders.
+	ActivityReminders bool
+	Status            UserStatus
+	IsActive          bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
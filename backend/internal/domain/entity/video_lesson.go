@@ -15,6 +15,10 @@ type VideoLesson struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 
+	// Joined creator fields
+	CreatedByPublicID string
+	CreatedByName     string
+
 	// Joined fields (populated when file_id is set)
 	FilePublicID string
 	FileKey      string
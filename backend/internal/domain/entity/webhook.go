@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type Webhook struct {
+	ID          int
+	PublicID    string
+	URL         string
+	Secret      string
+	IsActive    bool
+	CreatedByID int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,91 @@
+// Package grading holds helpers that assist reviewers when scoring
+// open-ended answers. Nothing here decides a submission's final score.
+package grading
+
+import (
+	"strings"
+	"unicode"
+)
+
+var accentFolds = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n',
+}
+
+// Fold lowercases s and strips the diacritics used in Portuguese, so that
+// "Fotossíntese" and "fotossintese" compare equal.
+func Fold(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range strings.ToLower(s) {
+		if f, ok := accentFolds[r]; ok {
+			r = f
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// tokenize splits folded text into words made of letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsPhrase reports whether the phrase tokens appear consecutively in
+// words, which keeps matches on whole-word boundaries.
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 || len(phrase) > len(words) {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		match := true
+		for j, p := range phrase {
+			if words[i+j] != p {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchKeywords returns the keywords found in answer. Matching is case- and
+// accent-insensitive and only counts whole words; multi-word keywords must
+// appear as a contiguous phrase.
+func MatchKeywords(answer string, keywords []string) []string {
+	words := tokenize(answer)
+	var hits []string
+	for _, kw := range keywords {
+		if containsPhrase(words, tokenize(kw)) {
+			hits = append(hits, kw)
+		}
+	}
+	return hits
+}
+
+// SuggestScore returns the percentage (0-100) of keywords present in
+// answer, or nil when there are no keywords to match against.
+func SuggestScore(answer string, keywords []string) *int {
+	total := 0
+	for _, kw := range keywords {
+		if len(tokenize(kw)) > 0 {
+			total++
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	hits := len(MatchKeywords(answer, keywords))
+	score := (hits*100 + total/2) / total
+	return &score
+}
@@ -0,0 +1,75 @@
+package grading
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	tests := map[string]string{
+		"Fotossíntese":       "fotossintese",
+		"AÇÃO":               "acao",
+		"Pão, Coração e Mãe": "pao, coracao e mae",
+		"already plain 123":  "already plain 123",
+		"":                   "",
+	}
+	for in, want := range tests {
+		if got := Fold(in); got != want {
+			t.Errorf("Fold(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMatchKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		keywords []string
+		want     []string
+	}{
+		{"accents and case", "A FOTOSSINTESE usa luz", []string{"fotossíntese", "Luz"}, []string{"fotossíntese", "Luz"}},
+		{"partial match", "A clorofila absorve luz", []string{"clorofila", "glicose", "luz"}, []string{"clorofila", "luz"}},
+		{"whole words only", "luzes e fotossintético", []string{"luz", "fotossintese"}, nil},
+		{"contiguous phrase", "o ciclo de Calvin ocorre no estroma", []string{"ciclo de calvin", "ciclo calvin"}, []string{"ciclo de calvin"}},
+		{"punctuation between words", "água,luz;CO2", []string{"agua", "co2"}, []string{"agua", "co2"}},
+		{"empty keyword list", "qualquer resposta", nil, nil},
+		{"empty answer", "", []string{"luz"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchKeywords(tt.answer, tt.keywords); !slices.Equal(got, tt.want) {
+				t.Errorf("MatchKeywords = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		keywords []string
+		want     *int
+	}{
+		{"no keywords", "luz", nil, nil},
+		{"only blank keywords", "luz", []string{"", " - "}, nil},
+		{"all present", "luz e agua", []string{"luz", "água"}, intPtr(100)},
+		{"none present", "nada", []string{"luz", "água"}, intPtr(0)},
+		{"one of three rounds down", "luz", []string{"luz", "agua", "co2"}, intPtr(33)},
+		{"two of three rounds up", "luz agua", []string{"luz", "agua", "co2"}, intPtr(67)},
+		{"blank keywords are not counted", "luz", []string{"luz", ""}, intPtr(100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestScore(tt.answer, tt.keywords)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("SuggestScore = %d, want nil", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("SuggestScore = %v, want %d", got, *tt.want)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int { return &v }
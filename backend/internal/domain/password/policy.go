@@ -0,0 +1,100 @@
+// Package password validates user-chosen passwords against a configurable
+// strength policy.
+package password
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, line := range strings.Split(commonPasswordsFile, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			m[strings.ToLower(line)] = true
+		}
+	}
+	return m
+}()
+
+// Rule names reported in ErrWeakPassword details.
+const (
+	RuleMinLength        = "min_length"
+	RuleCharacterClasses = "character_classes"
+	RuleCommonPassword   = "common_password"
+)
+
+type Policy struct {
+	MinLength    int  // minimum number of characters
+	MinClasses   int  // minimum distinct classes among lowercase, uppercase, digits and symbols
+	RejectCommon bool // reject passwords found in the embedded common list
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		MinLength:    8,
+		MinClasses:   2,
+		RejectCommon: true,
+	}
+}
+
+// Validate returns nil when pw satisfies the policy, or an ErrWeakPassword
+// whose details name the first rule that failed.
+func (p Policy) Validate(pw string) error {
+	if len([]rune(pw)) < p.MinLength {
+		return weak(RuleMinLength, map[string]any{"min_length": p.MinLength})
+	}
+
+	if classes := countClasses(pw); classes < p.MinClasses {
+		return weak(RuleCharacterClasses, map[string]any{"min_classes": p.MinClasses})
+	}
+
+	if p.RejectCommon && commonPasswords[strings.ToLower(pw)] {
+		return weak(RuleCommonPassword, nil)
+	}
+
+	return nil
+}
+
+func countClasses(pw string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range pw {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, has := range []bool{lower, upper, digit, symbol} {
+		if has {
+			count++
+		}
+	}
+	return count
+}
+
+func weak(rule string, extra map[string]any) error {
+	details := map[string]any{"rule": rule}
+	for k, v := range extra {
+		details[k] = v
+	}
+	return apperror.WithDetails(
+		apperror.ErrWeakPassword.Code,
+		apperror.ErrWeakPassword.Message,
+		apperror.ErrWeakPassword.HTTPStatus,
+		details,
+	)
+}
@@ -0,0 +1,48 @@
+// Package permissions answers what a requester may do in a group from their
+// platform role and membership, mirroring the checks the use cases enforce so
+// clients can show or hide actions without re-deriving the rules.
+package permissions
+
+import "proximos-passos/backend/internal/domain/entity"
+
+// Requester is a user as seen from one group.
+type Requester struct {
+	Role   entity.UserRole
+	Member *entity.GroupMember // nil when the user has no membership in the group
+}
+
+// memberRole returns the requester's role in the group, or "" unless they
+// are an active, accepted member.
+func (r Requester) memberRole() entity.MemberRole {
+	if r.Member == nil || !r.Member.IsActive || r.Member.AcceptedByID == nil {
+		return ""
+	}
+	return r.Member.Role
+}
+
+func (r Requester) isPlatformAdmin() bool {
+	return r.Role == entity.UserRoleAdmin
+}
+
+// CanEditGroup reports whether the requester may change the group's settings.
+func (r Requester) CanEditGroup() bool {
+	return r.isPlatformAdmin() || r.memberRole() == entity.MemberRoleAdmin
+}
+
+// CanManageMembers reports whether the requester may approve, remove and
+// change the roles of members.
+func (r Requester) CanManageMembers() bool {
+	return r.isPlatformAdmin() || r.memberRole() == entity.MemberRoleAdmin
+}
+
+// CanReviewActivity reports whether the requester may approve or reprove
+// submissions to the group's activities.
+func (r Requester) CanReviewActivity() bool {
+	return r.isPlatformAdmin() || r.memberRole() == entity.MemberRoleAdmin
+}
+
+// CanSubmitActivity reports whether the requester may submit the group's
+// activities, which takes an accepted membership whatever the platform role.
+func (r Requester) CanSubmitActivity() bool {
+	return r.memberRole() != ""
+}
@@ -0,0 +1,23 @@
+// Package publicid checks the UUIDs that identify resources in URLs and
+// request bodies before they reach a uuid column.
+package publicid
+
+// Valid reports whether s has the canonical 8-4-4-4-12 hex form of a UUID.
+func Valid(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
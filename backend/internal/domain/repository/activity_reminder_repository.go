@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type ActivityReminderRepository interface {
+	// ListDue returns accepted members of active groups who have not submitted
+	// an active activity due between now and until, and who have not been
+	// reminded about it yet.
+	ListDue(ctx context.Context, until time.Time, limit int) ([]entity.ActivityReminder, error)
+	// Claim records that a reminder is being sent. It returns false when the
+	// member was already reminded about the activity.
+	Claim(ctx context.Context, activityID, userID int) (bool, error)
+	Release(ctx context.Context, activityID, userID int) error
+}
@@ -6,8 +6,19 @@ import (
 	"proximos-passos/backend/internal/domain/entity"
 )
 
+// Statuses an activity can have for the member listing it.
+const (
+	ActivityMemberStatusTodo      = "todo"      // not submitted, still a draft, or reproved
+	ActivityMemberStatusSubmitted = "submitted" // sent and awaiting review
+	ActivityMemberStatusApproved  = "approved"
+)
+
 type ActivityFilter struct {
 	Title string
+	// MemberStatus keeps only activities with that status for the user
+	// MemberID; empty means any.
+	MemberStatus string
+	MemberID     int
 }
 
 type ActivityRepository interface {
@@ -21,6 +32,9 @@ type ActivityRepository interface {
 	CountUpcoming(ctx context.Context, groupID int, filter ActivityFilter) (int, error)
 	ListPast(ctx context.Context, groupID int, limit, offset int, filter ActivityFilter) ([]entity.Activity, error)
 	CountPast(ctx context.Context, groupID int, filter ActivityFilter) (int, error)
+	ListByGroup(ctx context.Context, groupID int) ([]entity.Activity, error)
+	// LoadSubmissionCounts fills SubmissionCounts on each activity.
+	LoadSubmissionCounts(ctx context.Context, activities []entity.Activity) error
 
 	// Attachments
 	CreateFile(ctx context.Context, file *entity.ActivityAttachment, uploadedByID int) error
@@ -33,6 +47,14 @@ type ActivityRepository interface {
 	GetItemByPublicID(ctx context.Context, publicID string) (*entity.ActivityItem, error)
 	UpdateItem(ctx context.Context, item *entity.ActivityItem) error
 	DeleteItem(ctx context.Context, publicID string) error
-	ListItems(ctx context.Context, activityID int) ([]entity.ActivityItem, error)
+	ListItems(ctx context.Context, activityID int, itemType *entity.ActivityItemType) ([]entity.ActivityItem, error)
+	CountItemsByType(ctx context.Context, activityID int) (map[entity.ActivityItemType]int, error)
+	// HasQuestion reports whether the question is an item of the activity,
+	// directly or through a simulated exam item.
+	HasQuestion(ctx context.Context, activityID, questionID int) (bool, error)
 	ReorderItems(ctx context.Context, activityID int, orderedIDs []string) error
+	// ListContentReferences returns the active activities, with their group,
+	// that have an item pointing at the given content. Questions are also
+	// referenced through the simulated exams that contain them.
+	ListContentReferences(ctx context.Context, itemType entity.ActivityItemType, contentID int) ([]entity.ActivityRef, error)
 }
@@ -9,12 +9,20 @@ import (
 type ActivitySubmissionRepository interface {
 	Create(ctx context.Context, s *entity.ActivitySubmission) error
 	GetByPublicID(ctx context.Context, publicID string) (*entity.ActivitySubmission, error)
+	GetByID(ctx context.Context, id int) (*entity.ActivitySubmission, error)
 	GetByActivityAndUser(ctx context.Context, activityID, userID int) (*entity.ActivitySubmission, error)
-	ListByActivity(ctx context.Context, activityID int, limit, offset int) ([]entity.ActivitySubmission, error)
-	CountByActivity(ctx context.Context, activityID int) (int, error)
-	ListByUser(ctx context.Context, userID int, limit, offset int) ([]entity.ActivitySubmission, error)
-	CountByUser(ctx context.Context, userID int) (int, error)
+	// ListByActivity, CountByActivity, ListByUser and CountByUser only match
+	// submissions in statuses; an empty statuses matches every status.
+	ListByActivity(ctx context.Context, activityID int, limit, offset int, statuses []entity.ActivitySubmissionStatus) ([]entity.ActivitySubmission, error)
+	CountByActivity(ctx context.Context, activityID int, statuses []entity.ActivitySubmissionStatus) (int, error)
+	ListByUser(ctx context.Context, userID int, limit, offset int, statuses []entity.ActivitySubmissionStatus) ([]entity.ActivitySubmission, error)
+	CountByUser(ctx context.Context, userID int, statuses []entity.ActivitySubmissionStatus) (int, error)
+	// ListReviewQueue and CountReviewQueue match pending submissions in the
+	// groups where the user is an accepted admin or supervisor, oldest first.
+	ListReviewQueue(ctx context.Context, userID int, limit, offset int) ([]entity.ReviewQueueItem, error)
+	CountReviewQueue(ctx context.Context, userID int) (int, error)
 	UpdateStatus(ctx context.Context, s *entity.ActivitySubmission) error
+	Reopen(ctx context.Context, id int, reason string, reopenedByID int) (bool, error)
 	UpdateNotes(ctx context.Context, id int, notes *string) error
 	CreateFile(ctx context.Context, file *entity.ActivitySubmissionAttachment, uploadedByID int) error
 	DeleteFile(ctx context.Context, fileID int) error
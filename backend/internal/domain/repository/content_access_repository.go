@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type ContentAccessFilter struct {
+	ContentType entity.ActivityItemType // empty for every type
+	Since       time.Time
+}
+
+type ContentAccessRepository interface {
+	// Record stores an access unless the user already accessed the same
+	// content the same way within window.
+	Record(ctx context.Context, contentType entity.ActivityItemType, contentID int, userPublicID string, action entity.ContentAccessAction, window time.Duration) error
+	// DeleteBefore removes accesses recorded before the given time and
+	// returns how many were deleted.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	// ListStats returns the most accessed content since filter.Since, views
+	// and downloads combined.
+	ListStats(ctx context.Context, filter ContentAccessFilter, limit int) ([]entity.ContentAccessStats, error)
+}
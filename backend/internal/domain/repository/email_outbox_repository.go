@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type EmailOutboxRepository interface {
+	Enqueue(ctx context.Context, email *entity.OutboxEmail) error
+	// ClaimDue returns up to limit pending emails that are due, counting the
+	// claim as an attempt and hiding them from other claimers for lease.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.OutboxEmail, error)
+	MarkSent(ctx context.Context, id int) error
+	ScheduleRetry(ctx context.Context, id int, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id int, lastError string) error
+	GetByPublicID(ctx context.Context, publicID string) (*entity.OutboxEmail, error)
+	ListFailed(ctx context.Context, limit, offset int) ([]entity.OutboxEmail, error)
+	CountFailed(ctx context.Context) (int, error)
+	Requeue(ctx context.Context, id int) error
+	// DeleteSentBefore removes delivered emails sent before the cutoff and
+	// reports how many rows it deleted.
+	DeleteSentBefore(ctx context.Context, before time.Time) (int64, error)
+}
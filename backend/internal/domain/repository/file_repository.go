@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type FileRepository interface {
+	GetByChecksum(ctx context.Context, checksum string, sizeBytes int64) (*entity.File, error)
+	CountActiveByKey(ctx context.Context, key string) (int, error)
+}
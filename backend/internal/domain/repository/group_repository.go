@@ -10,6 +10,8 @@ type GroupFilter struct {
 	Name           string
 	AccessType     string
 	VisibilityType string
+	Sort           string // e.g. "name" or "-created_at"
+	Role           string // membership role; only applied by ListByUser and CountByUser
 }
 
 type GroupRepository interface {
@@ -24,18 +26,47 @@ type GroupRepository interface {
 	CountByUser(ctx context.Context, userID int, filter GroupFilter) (int, error)
 	Update(ctx context.Context, group *entity.Group) error
 	UpdateThumbnail(ctx context.Context, publicID string, thumbnailURL *string) error
+	GetByJoinCode(ctx context.Context, code string) (*entity.Group, error)
+	UpdateJoinCode(ctx context.Context, groupID int, code *string) error
+	// UpdateUploadSettings replaces the group's submission upload overrides;
+	// nil values restore the platform defaults.
+	UpdateUploadSettings(ctx context.Context, groupID int, maxBytes *int64, allowedTypes []string) error
 	Delete(ctx context.Context, publicID string) error
 
 	// Members
+	// AddMember inserts the membership, or reactivates the user's inactive
+	// one in place. It returns ErrMemberAlreadyExists when an active
+	// membership is already there.
 	AddMember(ctx context.Context, member *entity.GroupMember) error
 	GetMember(ctx context.Context, groupID, userID int) (*entity.GroupMember, error)
 	GetFirstAdminMember(ctx context.Context, groupID int) (*entity.GroupMember, error)
-	ListMembers(ctx context.Context, groupID int, limit, offset int, role string) ([]entity.GroupMember, error)
+	// ListAdmins returns the active, verified users holding the admin role in
+	// the group.
+	ListAdmins(ctx context.Context, groupID int) ([]entity.User, error)
+	ListMembers(ctx context.Context, groupID int, limit, offset int, role, sort string) ([]entity.GroupMember, error)
 	CountMembers(ctx context.Context, groupID int, role string) (int, error)
 	ListPendingMembers(ctx context.Context, groupID int, limit, offset int) ([]entity.GroupMember, error)
 	CountPendingMembers(ctx context.Context, groupID int) (int, error)
 	ApproveMember(ctx context.Context, groupID, userID, approvedByID int) error
-	ReactivateMember(ctx context.Context, groupID, userID int, acceptedByID *int) error
+	// ApprovePendingMembers approves, in one statement, the pending requests
+	// of the given users (all pending requests when userPublicIDs is nil) and
+	// returns the public IDs of the users actually approved.
+	ApprovePendingMembers(ctx context.Context, groupID int, userPublicIDs []string, approvedByID int) ([]string, error)
 	UpdateMemberRole(ctx context.Context, groupID, userID int, role entity.MemberRole) error
 	RemoveMember(ctx context.Context, groupID, userID int) error
+	// ListMembershipsByUser returns the user's accepted memberships of active
+	// groups.
+	ListMembershipsByUser(ctx context.Context, userID int) ([]entity.GroupMembership, error)
+	// ListSoleAdminGroups returns the active groups in which the user is the
+	// only active admin.
+	ListSoleAdminGroups(ctx context.Context, userID int) ([]entity.GroupMembership, error)
+
+	// Invitations
+	// UpsertInvitation records an invitation, replacing the role and inviter
+	// of an earlier one for the same group and email.
+	UpsertInvitation(ctx context.Context, invitation *entity.GroupInvitation) error
+	// AcceptInvitations turns every invitation for email into an accepted
+	// membership of userID and deletes them, returning how many memberships
+	// were added.
+	AcceptInvitations(ctx context.Context, userID int, email string) (int, error)
 }
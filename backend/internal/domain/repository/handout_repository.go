@@ -18,7 +18,9 @@ type HandoutRepository interface {
 	ReplaceFile(ctx context.Context, handoutID int, newFileID int) error
 	CreateFileAndReplace(ctx context.Context, handoutID int, handout *entity.Handout, uploadedByID int) error
 	SetTopics(ctx context.Context, handoutID int, topicIDs []int) error
-	Delete(ctx context.Context, publicID string) error
+	// Delete soft-deletes the handout, first removing the activity items
+	// that reference it when removeItems is set, in one transaction.
+	Delete(ctx context.Context, publicID string, removeItems bool) error
 	List(ctx context.Context, limit, offset int, filter HandoutFilter) ([]entity.Handout, error)
 	Count(ctx context.Context, filter HandoutFilter) (int, error)
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type IdempotencyRepository interface {
+	// Reserve claims key for the user and route. It returns false when an
+	// unexpired record for the same key already exists.
+	Reserve(ctx context.Context, userPublicID, key, route string, expiresAt time.Time) (bool, error)
+	Get(ctx context.Context, userPublicID, key, route string) (*entity.IdempotencyRecord, error)
+	// Complete stores the response of the reserved request along with the
+	// hash of its body, which retries must match.
+	Complete(ctx context.Context, userPublicID, key, route string, requestHash []byte, statusCode int, contentType, location string, body []byte) error
+	Release(ctx context.Context, userPublicID, key, route string) error
+	// DeleteExpired removes expired records and reports how many it deleted.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -15,6 +15,7 @@ type InstitutionRepository interface {
 	GetByPublicID(ctx context.Context, publicID string) (*entity.Institution, error)
 	GetByID(ctx context.Context, id int) (*entity.Institution, error)
 	Update(ctx context.Context, institution *entity.Institution) error
+	UpdateLogo(ctx context.Context, publicID string, logoURL *string) error
 	Delete(ctx context.Context, publicID string) error
 	List(ctx context.Context, limit, offset int, filter InstitutionFilter) ([]entity.Institution, error)
 	Count(ctx context.Context, filter InstitutionFilter) (int, error)
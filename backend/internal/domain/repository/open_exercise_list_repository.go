@@ -18,7 +18,9 @@ type OpenExerciseListRepository interface {
 	ReplaceFile(ctx context.Context, oelID int, newFileID int) error
 	CreateFileAndReplace(ctx context.Context, oelID int, oel *entity.OpenExerciseList, uploadedByID int) error
 	SetTopics(ctx context.Context, oelID int, topicIDs []int) error
-	Delete(ctx context.Context, publicID string) error
+	// Delete soft-deletes the open exercise list, first removing the activity items
+	// that reference it when removeItems is set, in one transaction.
+	Delete(ctx context.Context, publicID string, removeItems bool) error
 	List(ctx context.Context, limit, offset int, filter OpenExerciseListFilter) ([]entity.OpenExerciseList, error)
 	Count(ctx context.Context, filter OpenExerciseListFilter) (int, error)
 }
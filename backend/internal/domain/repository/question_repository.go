@@ -12,19 +12,37 @@ type QuestionFilter struct {
 	Type          string // "open_ended", "closed_ended", or "" for any
 	ExamID        *int
 	InstitutionID *int
+	Difficulty    string // "easy", "medium", "hard", or "" for any
+	Tag           string
+	Archived      bool   // list only archived questions instead of hiding them
+	Sort          string // e.g. "statement" or "-created_at"
+}
+
+// QuestionChanges lists the relations an update replaces alongside the
+// question's own fields. A nil slice leaves that relation untouched; an
+// empty one clears it.
+type QuestionChanges struct {
+	TopicIDs []int
+	Tags     []string
+	Options  []entity.QuestionOption
 }
 
 type QuestionRepository interface {
 	Create(ctx context.Context, q *entity.Question, topicIDs []int) error
 	GetByPublicID(ctx context.Context, publicID string) (*entity.Question, error)
-	Update(ctx context.Context, q *entity.Question) error
+	// Update writes the question's fields and the given relation changes in
+	// one transaction, so a failure leaves the question as it was.
+	Update(ctx context.Context, q *entity.Question, changes QuestionChanges) error
 	AddImages(ctx context.Context, questionID int, q *entity.Question, uploadedByID int) error
 	RemoveImage(ctx context.Context, questionID int, filePublicID string) error
-	SetTopics(ctx context.Context, questionID int, topicIDs []int) error
-	SetOptions(ctx context.Context, questionID int, options []entity.QuestionOption, createdByID int) error
 	CreateFeedback(ctx context.Context, feedback *entity.QuestionFeedback) error
-	Delete(ctx context.Context, publicID string) error
+	// DeleteMany soft-deletes the questions in one transaction, first
+	// removing the activity items and simulated exam entries that reference
+	// them when removeItems is set.
+	DeleteMany(ctx context.Context, questionIDs []int, removeItems bool) error
+	SetArchived(ctx context.Context, questionID int, archived bool) error
 	List(ctx context.Context, limit, offset int, filter QuestionFilter) ([]entity.Question, error)
+	FindSimilar(ctx context.Context, normalizedStatement string, minSimilarity float64, limit int) ([]entity.QuestionDuplicate, error)
 	Count(ctx context.Context, filter QuestionFilter) (int, error)
 	CountByExamID(ctx context.Context, examID int) (int, error)
 	TopicPublicIDsByExamID(ctx context.Context, examID int) ([]string, error)
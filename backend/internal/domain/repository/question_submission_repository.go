@@ -2,16 +2,41 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"proximos-passos/backend/internal/domain/entity"
 )
 
+// QuestionSubmissionFilter narrows the attempts listed under an activity
+// submission.
+type QuestionSubmissionFilter struct {
+	QuestionPublicID string
+	Passed           *bool
+}
+
 type QuestionSubmissionRepository interface {
-	Create(ctx context.Context, s *entity.QuestionSubmission) error
+	// Create records an attempt and consumes the user's pending attempt
+	// start, filling StartedAt and DurationSeconds from it, in one
+	// transaction. For attempts within an activity submission it locks that
+	// submission and returns ErrAttemptLimitReached when maxAttempts is
+	// already used up, so concurrent submits cannot go past the limit.
+	Create(ctx context.Context, s *entity.QuestionSubmission, maxAttempts *int) error
 	GetByPublicID(ctx context.Context, publicID string) (*entity.QuestionSubmission, error)
 	ListByUser(ctx context.Context, userID int, limit, offset int, statement string) ([]entity.QuestionSubmission, error)
 	CountByUser(ctx context.Context, userID int, statement string) (int, error)
 	ListByQuestion(ctx context.Context, questionID int, limit, offset int) ([]entity.QuestionSubmission, error)
 	CountByQuestion(ctx context.Context, questionID int) (int, error)
-	ListByActivitySubmission(ctx context.Context, activitySubmissionID int) ([]entity.QuestionSubmission, error)
+	ListByActivitySubmission(ctx context.Context, activitySubmissionID int, limit, offset int, filter QuestionSubmissionFilter) ([]entity.QuestionSubmission, error)
+	CountByActivitySubmission(ctx context.Context, activitySubmissionID int, filter QuestionSubmissionFilter) (int, error)
+	ListAllByActivitySubmission(ctx context.Context, activitySubmissionID int) ([]entity.QuestionSubmission, error)
+	ListByActivitySubmissionAndQuestion(ctx context.Context, activitySubmissionID, questionID int) ([]entity.QuestionSubmission, error)
+	// SetAttachment stores the file and links it to the submission, replacing
+	// and deactivating any previous attachment.
+	SetAttachment(ctx context.Context, submissionID int, attachment *entity.QuestionSubmissionAttachment, uploadedByID int) error
+	// Review stores the reviewer's score, pass flag and feedback on an attempt.
+	Review(ctx context.Context, s *entity.QuestionSubmission) error
+
+	// Time tracking
+	StartAttempt(ctx context.Context, userID, questionID int) (time.Time, error)
+	GetTimeStats(ctx context.Context, questionID int) (*entity.QuestionTimeStats, error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type RecommendationRepository interface {
+	// ListForTopic returns up to limit questions, video lessons and handouts
+	// in the topic or its descendants, newest first. Questions the user
+	// already passed and lessons or handouts they already opened are left
+	// out. Only ContentType, PublicID, Title and CreatedAt are set.
+	ListForTopic(ctx context.Context, userID int, topicPublicID string, limit int) ([]entity.Recommendation, error)
+}
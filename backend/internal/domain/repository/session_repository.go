@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	// Touch reports whether the session exists, is not revoked and has not
+	// expired, and records it as seen. last_seen_at is only written once a
+	// minute to keep authenticated reads cheap.
+	Touch(ctx context.Context, publicID string) (bool, error)
+	// GetActive returns the session if it is neither revoked nor expired,
+	// or nil.
+	GetActive(ctx context.Context, publicID string) (*entity.Session, error)
+	// GetActiveByID is GetActive by internal ID.
+	GetActiveByID(ctx context.Context, id int) (*entity.Session, error)
+	// ListActiveByUser returns the user's live sessions, most recently seen
+	// first.
+	ListActiveByUser(ctx context.Context, userID int) ([]entity.Session, error)
+	Revoke(ctx context.Context, publicID string) error
+	// RevokeForUser revokes the session only if it belongs to the user and
+	// is still live, reporting whether it did.
+	RevokeForUser(ctx context.Context, userID int, publicID string) (bool, error)
+	RevokeAllByUser(ctx context.Context, userID int) error
+	// RevokeOthersByUser revokes every session of the user except keepPublicID.
+	RevokeOthersByUser(ctx context.Context, userID int, keepPublicID string) error
+}
+
+// ImpersonationEventRepository persists the impersonation audit trail.
+type ImpersonationEventRepository interface {
+	Create(ctx context.Context, event *entity.ImpersonationEvent) error
+}
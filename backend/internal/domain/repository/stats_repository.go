@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type StatsRepository interface {
+	GroupStats(ctx context.Context, groupID int) (*entity.GroupStats, error)
+	// GroupLeaderboard ranks accepted members by metric ("passed" or "score"),
+	// counting only submissions made at or after since when it is set.
+	GroupLeaderboard(ctx context.Context, groupID int, since *time.Time, metric string) ([]entity.LeaderboardEntry, error)
+	// TopicMastery groups the user's question submissions by topic, rolling
+	// each submission up to the ancestors of its question's topics.
+	TopicMastery(ctx context.Context, userID int) ([]entity.TopicMastery, error)
+}
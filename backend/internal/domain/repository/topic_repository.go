@@ -21,4 +21,7 @@ type TopicRepository interface {
 	ReparentChildren(ctx context.Context, parentID int, newParentID *int) error
 	List(ctx context.Context, limit, offset int, filter TopicFilter) ([]entity.Topic, error)
 	Count(ctx context.Context, filter TopicFilter) (int, error)
+	// ListBreadcrumbs returns the breadcrumbs of the active topics among
+	// publicIDs. Unknown IDs are left out.
+	ListBreadcrumbs(ctx context.Context, publicIDs []string) ([]entity.TopicBreadcrumb, error)
 }
@@ -10,14 +10,26 @@ type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
 	GetByPublicID(ctx context.Context, publicID string) (*entity.User, error)
 	GetByPublicIDUnfiltered(ctx context.Context, publicID string) (*entity.User, error)
+	// GetManyByPublicID returns the active users among publicIDs keyed by
+	// public ID. Unknown IDs are left out.
+	GetManyByPublicID(ctx context.Context, publicIDs []string) (map[string]*entity.User, error)
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
-	List(ctx context.Context, limit, offset int) ([]entity.User, error)
-	ListAll(ctx context.Context, limit, offset int) ([]entity.User, error)
+	List(ctx context.Context, limit, offset int, sort string) ([]entity.User, error)
+	ListAll(ctx context.Context, limit, offset int, sort string) ([]entity.User, error)
 	Count(ctx context.Context) (int, error)
 	CountAll(ctx context.Context) (int, error)
+	// CountActiveAdminsExcept counts platform admins who are neither deleted
+	// nor suspended, leaving out the given user.
+	CountActiveAdminsExcept(ctx context.Context, userID int) (int, error)
 	Update(ctx context.Context, user *entity.User) error
 	UpdateAvatar(ctx context.Context, publicID string, avatarURL *string) error
+	UpdatePassword(ctx context.Context, publicID string, passwordHash string) error
+	// Delete soft-deletes the user together with their group memberships.
 	Delete(ctx context.Context, publicID string) error
+	// SetStatus moves a non-deleted user between active and suspended.
+	SetStatus(ctx context.Context, userID int, status entity.UserStatus) error
 	VerifyEmail(ctx context.Context, publicID string) error
 	UpdateLastVerificationSent(ctx context.Context, publicID string) error
+	SetPendingEmail(ctx context.Context, publicID string, email string) error
+	ConfirmPendingEmail(ctx context.Context, publicID string, email string) error
 }
@@ -18,7 +18,9 @@ type VideoLessonRepository interface {
 	ReplaceFile(ctx context.Context, vlID int, newFileID int) error
 	CreateFileAndReplace(ctx context.Context, vlID int, vl *entity.VideoLesson, uploadedByID int) error
 	SetTopics(ctx context.Context, vlID int, topicIDs []int) error
-	Delete(ctx context.Context, publicID string) error
+	// Delete soft-deletes the video lesson, first removing the activity items
+	// that reference it when removeItems is set, in one transaction.
+	Delete(ctx context.Context, publicID string, removeItems bool) error
 	List(ctx context.Context, limit, offset int, filter VideoLessonFilter) ([]entity.VideoLesson, error)
 	Count(ctx context.Context, filter VideoLessonFilter) (int, error)
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	GetByPublicID(ctx context.Context, publicID string) (*entity.Webhook, error)
+	Delete(ctx context.Context, publicID string) error
+	List(ctx context.Context, limit, offset int) ([]entity.Webhook, error)
+	Count(ctx context.Context) (int, error)
+	ListActive(ctx context.Context) ([]entity.Webhook, error)
+}
@@ -1,7 +1,18 @@
 package service
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
+// EmailService sends transactional emails. locale selects the language of
+// the email; an empty or unsupported locale uses the default.
 type EmailService interface {
-	SendVerificationEmail(ctx context.Context, to, name, verificationURL string) error
+	SendVerificationEmail(ctx context.Context, to, name, locale, verificationURL string) error
+	SendEmailChangeEmail(ctx context.Context, to, name, locale, confirmationURL string) error
+	SendSubmissionReopenedEmail(ctx context.Context, to, name, locale, activityTitle, reason, activityURL string) error
+	SendInvitationEmail(ctx context.Context, to, locale, inviterName, groupName, invitationURL string) error
+	SendJoinRequestEmail(ctx context.Context, to, name, locale, requesterName, groupName, groupURL string) error
+	SendAnswerReviewedEmail(ctx context.Context, to, name, locale, activityTitle string, passed bool, feedback, answerURL string) error
+	SendActivityReminderEmail(ctx context.Context, to, name, locale, activityTitle, groupName string, dueDate time.Time, activityURL string) error
 }
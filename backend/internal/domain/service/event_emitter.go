@@ -0,0 +1,17 @@
+package service
+
+import "context"
+
+// Event types published to external subscribers.
+const (
+	EventSubmissionReviewed = "submission.reviewed"
+	EventMemberApproved     = "member.approved"
+	EventActivityCreated    = "activity.created"
+	EventSubmissionReopened = "submission.reopened"
+)
+
+// EventEmitter publishes domain events. Implementations must not block the
+// caller: delivery happens in the background and failures are not reported.
+type EventEmitter interface {
+	Emit(ctx context.Context, eventType string, data any)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrUnsupportedImage is returned by an ImageEncoder for input types it
+// cannot convert.
+var ErrUnsupportedImage = errors.New("unsupported image type")
+
+// ImageEncoder transcodes uploaded images into WebP.
+type ImageEncoder interface {
+	// EncodeWebP converts src, an image of the given content type, and
+	// returns the WebP bytes.
+	EncodeWebP(ctx context.Context, contentType string, src io.Reader) ([]byte, error)
+}
@@ -3,10 +3,27 @@ package service
 import (
 	"context"
 	"io"
+	"time"
 )
 
 type StorageService interface {
 	Upload(ctx context.Context, key, contentType string, body io.Reader) (string, error)
 	Delete(ctx context.Context, key string) error
 	GetPublicURL(key string) string
+	// GetSignedURL returns a time-limited URL granting read access to key.
+	GetSignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// Open streams the object at key. byteRange is an HTTP Range header value
+	// and may be empty to read the whole object. An unsatisfiable range
+	// yields apperror.ErrRangeNotSatisfiable.
+	Open(ctx context.Context, key, byteRange string) (*StorageObject, error)
+}
+
+// StorageObject is an open object body; callers must close Body.
+type StorageObject struct {
+	Body          io.ReadCloser
+	ContentType   string
+	ContentLength int64
+	ContentRange  string // set when only part of the object was returned
+	ETag          string
+	LastModified  time.Time
 }
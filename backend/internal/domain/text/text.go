@@ -0,0 +1,48 @@
+// Package text cleans user-supplied names, titles and descriptions before
+// they are validated and stored.
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SingleLine trims s and drops control characters, line breaks included,
+// along with bidirectional formatting marks that can make stored text
+// render differently from how it reads.
+func SingleLine(s string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if disallowed(r) {
+			return -1
+		}
+		return r
+	}, s))
+}
+
+// MultiLine is SingleLine for free text: it keeps newlines and tabs,
+// normalizing CRLF and lone CR line endings to LF.
+func MultiLine(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if disallowed(r) {
+			return -1
+		}
+		return r
+	}, s))
+}
+
+func disallowed(r rune) bool {
+	if unicode.IsControl(r) {
+		return true
+	}
+	switch {
+	case r >= 0x202A && r <= 0x202E, // embeddings and overrides
+		r >= 0x2066 && r <= 0x2069: // isolates
+		return true
+	}
+	return false
+}
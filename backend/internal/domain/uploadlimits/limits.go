@@ -0,0 +1,86 @@
+// Package uploadlimits holds the maximum accepted size of every kind of
+// uploaded file, so the caps can be tuned from the environment instead of
+// being spread across handlers and use cases.
+package uploadlimits
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Limits are maximum upload sizes in bytes, one per kind of file.
+type Limits struct {
+	Avatar               int64
+	Thumbnail            int64 // group and institution thumbnails
+	QuestionImage        int64
+	ActivityAttachment   int64
+	SubmissionAttachment int64 // default for groups without their own limit
+	Handout              int64
+	ExerciseList         int64
+	Video                int64
+}
+
+func Default() Limits {
+	return Limits{
+		Avatar:               5 << 20,
+		Thumbnail:            5 << 20,
+		QuestionImage:        10 << 20,
+		ActivityAttachment:   10 << 20,
+		SubmissionAttachment: 10 << 20,
+		Handout:              50 << 20,
+		ExerciseList:         50 << 20,
+		Video:                500 << 20,
+	}
+}
+
+// Ceilings are the largest values Load accepts. SubmissionAttachment also
+// caps what a group may configure for itself.
+func Ceilings() Limits {
+	return Limits{
+		Avatar:               20 << 20,
+		Thumbnail:            20 << 20,
+		QuestionImage:        50 << 20,
+		ActivityAttachment:   100 << 20,
+		SubmissionAttachment: 100 << 20,
+		Handout:              500 << 20,
+		ExerciseList:         500 << 20,
+		Video:                5 << 30,
+	}
+}
+
+// Load starts from Default and overrides each limit whose UPLOAD_MAX_*_BYTES
+// variable is set, as read through getenv. Values must be positive and
+// within Ceilings.
+func Load(getenv func(string) string) (Limits, error) {
+	limits := Default()
+	ceilings := Ceilings()
+
+	settings := []struct {
+		env     string
+		value   *int64
+		ceiling int64
+	}{
+		{"UPLOAD_MAX_AVATAR_BYTES", &limits.Avatar, ceilings.Avatar},
+		{"UPLOAD_MAX_THUMBNAIL_BYTES", &limits.Thumbnail, ceilings.Thumbnail},
+		{"UPLOAD_MAX_QUESTION_IMAGE_BYTES", &limits.QuestionImage, ceilings.QuestionImage},
+		{"UPLOAD_MAX_ACTIVITY_ATTACHMENT_BYTES", &limits.ActivityAttachment, ceilings.ActivityAttachment},
+		{"UPLOAD_MAX_SUBMISSION_ATTACHMENT_BYTES", &limits.SubmissionAttachment, ceilings.SubmissionAttachment},
+		{"UPLOAD_MAX_HANDOUT_BYTES", &limits.Handout, ceilings.Handout},
+		{"UPLOAD_MAX_EXERCISE_LIST_BYTES", &limits.ExerciseList, ceilings.ExerciseList},
+		{"UPLOAD_MAX_VIDEO_BYTES", &limits.Video, ceilings.Video},
+	}
+
+	for _, s := range settings {
+		v := getenv(s.env)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 || n > s.ceiling {
+			return Limits{}, fmt.Errorf("%s must be an integer between 1 and %d", s.env, s.ceiling)
+		}
+		*s.value = n
+	}
+
+	return limits, nil
+}
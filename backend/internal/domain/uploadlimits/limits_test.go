@@ -0,0 +1,75 @@
+package uploadlimits
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func envFrom(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestLoadDefaults(t *testing.T) {
+	limits, err := Load(envFrom(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limits != Default() {
+		t.Errorf("got %+v, want the defaults", limits)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	limits, err := Load(envFrom(map[string]string{
+		"UPLOAD_MAX_AVATAR_BYTES": "1048576",
+		"UPLOAD_MAX_VIDEO_BYTES":  strconv.FormatInt(Ceilings().Video, 10),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Default()
+	want.Avatar = 1 << 20
+	want.Video = Ceilings().Video
+	if limits != want {
+		t.Errorf("got %+v, want %+v", limits, want)
+	}
+}
+
+func TestLoadRejectsInvalidValues(t *testing.T) {
+	tests := map[string]string{
+		"not a number":    "5MB",
+		"zero":            "0",
+		"negative":        "-1",
+		"above ceiling":   strconv.FormatInt(Ceilings().Handout+1, 10),
+		"overflows int64": "99999999999999999999",
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(envFrom(map[string]string{"UPLOAD_MAX_HANDOUT_BYTES": value}))
+			if err == nil || !strings.Contains(err.Error(), "UPLOAD_MAX_HANDOUT_BYTES") {
+				t.Errorf("err = %v, want one naming UPLOAD_MAX_HANDOUT_BYTES", err)
+			}
+		})
+	}
+}
+
+func TestDefaultsWithinCeilings(t *testing.T) {
+	d, c := Default(), Ceilings()
+	pairs := map[string][2]int64{
+		"avatar":                {d.Avatar, c.Avatar},
+		"thumbnail":             {d.Thumbnail, c.Thumbnail},
+		"question image":        {d.QuestionImage, c.QuestionImage},
+		"activity attachment":   {d.ActivityAttachment, c.ActivityAttachment},
+		"submission attachment": {d.SubmissionAttachment, c.SubmissionAttachment},
+		"handout":               {d.Handout, c.Handout},
+		"exercise list":         {d.ExerciseList, c.ExerciseList},
+		"video":                 {d.Video, c.Video},
+	}
+	for name, p := range pairs {
+		if p[0] < 1 || p[0] > p[1] {
+			t.Errorf("%s default %d is outside 1..%d", name, p[0], p[1])
+		}
+	}
+}
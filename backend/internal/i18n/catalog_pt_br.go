@@ -0,0 +1,81 @@
+package i18n
+
+import "proximos-passos/backend/internal/domain/apperror"
+
+var ptBR = map[apperror.Code]string{
+	apperror.CodeInvalidInput:                  "Os dados informados são inválidos.",
+	apperror.CodeInvalidBody:                   "Não foi possível interpretar o corpo da requisição.",
+	apperror.CodeInternalError:                 "Ocorreu um erro inesperado.",
+	apperror.CodeUserNotFound:                  "O usuário solicitado não foi encontrado.",
+	apperror.CodeEmailTaken:                    "O email informado já está cadastrado.",
+	apperror.CodeInvalidCredentials:            "Email ou senha inválidos.",
+	apperror.CodeUnauthorized:                  "É necessário estar autenticado.",
+	apperror.CodeForbidden:                     "Você não tem permissão para realizar esta ação.",
+	apperror.CodeInvalidToken:                  "O token de verificação é inválido ou expirou.",
+	apperror.CodeEmailAlreadyVerified:          "Este email já foi verificado.",
+	apperror.CodeEmailSendFailed:               "Não foi possível enviar o email de verificação.",
+	apperror.CodeInvalidFileType:               "O tipo de arquivo não é permitido.",
+	apperror.CodeFileTooLarge:                  "O arquivo excede o tamanho máximo permitido.",
+	apperror.CodeUploadFailed:                  "Não foi possível enviar o arquivo.",
+	apperror.CodeGroupNotFound:                 "O grupo solicitado não foi encontrado.",
+	apperror.CodeMemberNotFound:                "O membro solicitado não foi encontrado.",
+	apperror.CodeMemberAlreadyExists:           "O usuário já é membro deste grupo.",
+	apperror.CodeSetupUnavailable:              "A configuração inicial não está mais disponível.",
+	apperror.CodeVerificationCooldown:          "Aguarde antes de solicitar outro email de verificação.",
+	apperror.CodeEmailNotVerified:              "Verifique seu email para continuar.",
+	apperror.CodeActivityNotFound:              "A atividade solicitada não foi encontrada.",
+	apperror.CodeAttachmentNotFound:            "O anexo solicitado não foi encontrado.",
+	apperror.CodeActivityTitleTaken:            "Já existe uma atividade com este título no grupo.",
+	apperror.CodeTopicNotFound:                 "O tópico solicitado não foi encontrado.",
+	apperror.CodeTopicNameTaken:                "Já existe um tópico com este nome sob o mesmo tópico pai.",
+	apperror.CodeTopicHasChildren:              "Não é possível excluir um tópico que possui subtópicos.",
+	apperror.CodeHandoutNotFound:               "A apostila solicitada não foi encontrada.",
+	apperror.CodeHandoutTitleTaken:             "Já existe uma apostila com este título.",
+	apperror.CodeVideoLessonNotFound:           "A videoaula solicitada não foi encontrada.",
+	apperror.CodeVideoLessonTitleTaken:         "Já existe uma videoaula com este título.",
+	apperror.CodeOpenExerciseListNotFound:      "A lista de exercícios solicitada não foi encontrada.",
+	apperror.CodeOpenExerciseListTitleTaken:    "Já existe uma lista de exercícios com este título.",
+	apperror.CodeQuestionNotFound:              "A questão solicitada não foi encontrada.",
+	apperror.CodeInstitutionNotFound:           "A instituição solicitada não foi encontrada.",
+	apperror.CodeInstitutionNameTaken:          "Já existe uma instituição com este nome ou sigla.",
+	apperror.CodeExamNotFound:                  "A prova solicitada não foi encontrada.",
+	apperror.CodeExamDuplicate:                 "Já existe uma prova com esta instituição, ano e etapa.",
+	apperror.CodeActivityItemNotFound:          "O item da atividade solicitado não foi encontrado.",
+	apperror.CodeQuestionSubmissionNotFound:    "A resposta solicitada não foi encontrada.",
+	apperror.CodeActivitySubmissionNotFound:    "A entrega da atividade solicitada não foi encontrada.",
+	apperror.CodeActivityAlreadySubmitted:      "Você já enviou esta atividade.",
+	apperror.CodeActivitySubmissionNotPending:  "Esta entrega já foi avaliada e não pode ser editada.",
+	apperror.CodeActivitySubmissionNotReproved: "Esta entrega não foi reprovada e não pode ser reenviada.",
+	apperror.CodeCSRF:                          "O token CSRF está ausente ou é inválido.",
+	apperror.CodeAttemptLimitReached:           "Você atingiu o número máximo de tentativas para esta questão.",
+	apperror.CodeWeakPassword:                  "A senha não atende aos requisitos de segurança.",
+	apperror.CodeInvalidImage:                  "O arquivo não é uma imagem válida ou suas dimensões estão fora do permitido.",
+	apperror.CodeWebhookNotFound:               "O webhook solicitado não foi encontrado.",
+	apperror.CodeLeaderboardDisabled:           "O ranking está desativado para este grupo.",
+	apperror.CodeJoinCodeTaken:                 "Este código de acesso já está em uso.",
+	apperror.CodeInvalidIdempotencyKey:         "O cabeçalho Idempotency-Key deve ter entre 1 e 255 caracteres.",
+	apperror.CodeIdempotencyKeyInUse:           "Uma requisição com esta chave de idempotência ainda está sendo processada.",
+	apperror.CodeEmailNotFound:                 "O email solicitado não foi encontrado.",
+	apperror.CodeEmailNotFailed:                "Apenas emails com falha podem ser reenviados.",
+	apperror.CodeDueDateInPast:                 "A data de entrega deve estar no futuro.",
+	apperror.CodeActivitySubmissionNotReviewed: "Apenas entregas aprovadas ou reprovadas podem ser reabertas.",
+	apperror.CodeQuestionNotInActivity:         "Esta questão não faz parte da atividade.",
+	apperror.CodeContentInUse:                  "Este conteúdo está sendo usado por uma ou mais atividades.",
+	apperror.CodeImpersonationNotAllowed:       "Esta ação não é permitida ao visualizar como outro usuário.",
+	apperror.CodeNotImpersonating:              "Esta sessão não está visualizando como outro usuário.",
+	apperror.CodeRequestTooLarge:               "O corpo da requisição excede o tamanho máximo permitido.",
+	apperror.CodeLastAdmin:                     "Isso deixaria um grupo sem administrador. Promova outro administrador primeiro.",
+	apperror.CodeCannotRemoveSelf:              "Você não pode remover a si mesmo de um grupo. Saia do grupo em vez disso.",
+	apperror.CodeAttachmentNotAllowed:          "Apenas respostas dissertativas aceitam anexos.",
+	apperror.CodeSessionNotFound:               "A sessão solicitada não foi encontrada.",
+	apperror.CodeInvalidEmail:                  "O endereço de email não é válido.",
+	apperror.CodeQuestionArchived:              "Esta questão está arquivada e não pode ser adicionada a atividades.",
+	apperror.CodeInvalidTimeZone:               "O fuso horário deve ser um nome IANA, como America/Sao_Paulo.",
+	apperror.CodeRangeNotSatisfiable:           "O intervalo de bytes solicitado não está disponível.",
+	apperror.CodeAccountSuspended:              "Esta conta foi suspensa.",
+	apperror.CodeLastPlatformAdmin:             "Isso deixaria a plataforma sem um administrador ativo. Promova outro administrador primeiro.",
+	apperror.CodeNotFound:                      "O recurso solicitado não foi encontrado.",
+	apperror.CodeAnswerReviewed:                "Esta resposta já foi corrigida e não pode ser alterada.",
+	apperror.CodeActivityPastDue:               "O prazo desta atividade já terminou.",
+	apperror.CodeIdempotencyKeyMismatch:        "Esta chave de idempotência já foi usada com outro corpo de requisição.",
+}
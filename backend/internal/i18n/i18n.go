@@ -0,0 +1,108 @@
+// Package i18n selects the locale for a request or user and translates
+// apperror messages into it. English messages live in apperror itself; the
+// catalogs here hold the other locales, keyed by the stable error code.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+const (
+	PortugueseBR = "pt-BR"
+	English      = "en"
+
+	Default = PortugueseBR
+)
+
+var catalogs = map[string]map[apperror.Code]string{
+	PortugueseBR: ptBR,
+}
+
+type contextKey struct{}
+
+// Normalize maps a language tag such as "pt", "pt-br" or "en-US" to a
+// supported locale. ok is false when the language is not supported.
+func Normalize(tag string) (locale string, ok bool) {
+	lang, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	switch strings.ToLower(lang) {
+	case "pt":
+		return PortugueseBR, true
+	case "en":
+		return English, true
+	}
+	return "", false
+}
+
+// Resolve normalizes tag, falling back to Default for empty or unsupported
+// values.
+func Resolve(tag string) string {
+	if locale, ok := Normalize(tag); ok {
+		return locale
+	}
+	return Default
+}
+
+// FromAcceptLanguage picks the supported locale with the highest quality in an
+// Accept-Language header. A client that names no supported language gets
+// English, the language the API's own messages are written in.
+func FromAcceptLanguage(header string) string {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, ok := Normalize(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{locale: locale, q: q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return English
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the locale stored by WithLocale, or Default.
+func FromContext(ctx context.Context) string {
+	if v, ok := ctx.Value(contextKey{}).(string); ok && v != "" {
+		return v
+	}
+	return Default
+}
+
+// Message returns err's message in locale. Messages that were customised at
+// the call site, rather than the code's standard message, are returned as is.
+func Message(locale string, err *apperror.AppError) string {
+	if err.Message != apperror.DefaultMessage(err.Code) {
+		return err.Message
+	}
+	if msg, ok := catalogs[locale][err.Code]; ok {
+		return msg
+	}
+	return err.Message
+}
@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"net/http"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"pt-BR", PortugueseBR},
+		{"pt", PortugueseBR},
+		{"en-US,en;q=0.9", English},
+		{"en;q=0.5, pt-BR;q=0.8", PortugueseBR},
+		{"fr-FR, pt;q=0.3", PortugueseBR},
+		{"pt;q=0, en;q=0.1", English},
+		{"fr-FR, de", English},
+		{"", English},
+		{"pt;q=abc", English},
+	}
+
+	for _, tt := range tests {
+		if got := FromAcceptLanguage(tt.header); got != tt.want {
+			t.Errorf("FromAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	if got := Message(PortugueseBR, apperror.ErrGroupNotFound); got != ptBR[apperror.CodeGroupNotFound] {
+		t.Errorf("pt-BR message = %q, want the catalog entry", got)
+	}
+	if got := Message(English, apperror.ErrGroupNotFound); got != apperror.ErrGroupNotFound.Message {
+		t.Errorf("en message = %q, want %q", got, apperror.ErrGroupNotFound.Message)
+	}
+	if got := Message("fr", apperror.ErrGroupNotFound); got != apperror.ErrGroupNotFound.Message {
+		t.Errorf("unknown locale message = %q, want the English message", got)
+	}
+
+	custom := apperror.New(apperror.CodeGroupNotFound, "Group 42 is gone.", http.StatusNotFound)
+	if got := Message(PortugueseBR, custom); got != custom.Message {
+		t.Errorf("custom message = %q, want it returned as is", got)
+	}
+}
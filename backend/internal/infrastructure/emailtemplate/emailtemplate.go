@@ -0,0 +1,224 @@
+// Package emailtemplate renders the transactional emails sent by the
+// platform. Every email has an HTML and a plain-text version built from the
+// embedded templates, sharing a common layout, in each supported locale.
+package emailtemplate
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"proximos-passos/backend/internal/i18n"
+)
+
+//go:embed templates/*/*.html templates/*/*.txt
+var templateFS embed.FS
+
+// Message is a rendered email ready to be sent as multipart/alternative.
+type Message struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type VerificationData struct {
+	Name            string
+	VerificationURL string
+}
+
+type EmailChangeData struct {
+	Name            string
+	ConfirmationURL string
+}
+
+type ReviewResultData struct {
+	Name          string
+	ActivityTitle string
+	Approved      bool
+	Feedback      string
+	SubmissionURL string
+}
+
+type InvitationData struct {
+	Name          string
+	InviterName   string
+	GroupName     string
+	InvitationURL string
+}
+
+type SubmissionReopenedData struct {
+	Name          string
+	ActivityTitle string
+	Reason        string
+	ActivityURL   string
+}
+
+// AnswerReviewedData describes a reviewed question answer. ActivityTitle is
+// empty for answers given outside an activity.
+type AnswerReviewedData struct {
+	Name          string
+	ActivityTitle string
+	Passed        bool
+	Feedback      string
+	AnswerURL     string
+}
+
+type JoinRequestData struct {
+	Name          string
+	RequesterName string
+	GroupName     string
+	GroupURL      string
+}
+
+type ActivityReminderData struct {
+	Name          string
+	ActivityTitle string
+	GroupName     string
+	DueDate       time.Time // in the group's time zone, which the email names
+	ActivityURL   string
+}
+
+// view is the value every template is executed with.
+type view struct {
+	LogoURL     string
+	FrontendURL string
+	Year        int
+	Data        any
+}
+
+type buttonView struct {
+	URL   string
+	Label string
+}
+
+type templatePair struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var templateNames = []string{"verification", "email_change", "review_result", "invitation", "activity_reminder", "submission_reopened", "join_request", "answer_reviewed"}
+
+var locales = []string{i18n.PortugueseBR, i18n.English}
+
+var subjects = map[string]map[string]string{
+	i18n.PortugueseBR: {
+		"verification":        "Verifique seu email - Próximos Passos",
+		"email_change":        "Confirme seu novo email - Próximos Passos",
+		"review_result":       "Sua entrega foi avaliada - Próximos Passos",
+		"invitation":          "Você foi convidado para um grupo - Próximos Passos",
+		"activity_reminder":   "Lembrete de prazo de atividade - Próximos Passos",
+		"submission_reopened": "Sua entrega foi reaberta - Próximos Passos",
+		"join_request":        "Nova solicitação de entrada no grupo - Próximos Passos",
+		"answer_reviewed":     "Sua resposta foi avaliada - Próximos Passos",
+	},
+	i18n.English: {
+		"verification":        "Verify your email - Próximos Passos",
+		"email_change":        "Confirm your new email - Próximos Passos",
+		"review_result":       "Your submission has been reviewed - Próximos Passos",
+		"invitation":          "You have been invited to a group - Próximos Passos",
+		"activity_reminder":   "Activity due date reminder - Próximos Passos",
+		"submission_reopened": "Your submission has been reopened - Próximos Passos",
+		"join_request":        "New request to join your group - Próximos Passos",
+		"answer_reviewed":     "Your answer has been reviewed - Próximos Passos",
+	},
+}
+
+type Renderer struct {
+	logoURL     string
+	frontendURL string
+	// templates is keyed by locale, then by template name.
+	templates map[string]map[string]templatePair
+}
+
+// New parses the embedded templates. logoURL and frontendURL are injected
+// into every email.
+func New(logoURL, frontendURL string) (*Renderer, error) {
+	funcs := map[string]any{
+		"button": func(url, label string) buttonView { return buttonView{URL: url, Label: label} },
+	}
+
+	templates := make(map[string]map[string]templatePair, len(locales))
+	for _, locale := range locales {
+		templates[locale] = make(map[string]templatePair, len(templateNames))
+		dir := "templates/" + locale + "/"
+		for _, name := range templateNames {
+			html, err := htmltemplate.New(name).Funcs(funcs).ParseFS(templateFS, dir+"layout.html", dir+name+".html")
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s/%s html template: %w", locale, name, err)
+			}
+			text, err := texttemplate.New(name).ParseFS(templateFS, dir+"layout.txt", dir+name+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s/%s text template: %w", locale, name, err)
+			}
+			templates[locale][name] = templatePair{html: html, text: text}
+		}
+	}
+
+	return &Renderer{
+		logoURL:     logoURL,
+		frontendURL: frontendURL,
+		templates:   templates,
+	}, nil
+}
+
+func (r *Renderer) RenderVerification(locale string, data VerificationData) (Message, error) {
+	return r.render(locale, "verification", data)
+}
+
+func (r *Renderer) RenderEmailChange(locale string, data EmailChangeData) (Message, error) {
+	return r.render(locale, "email_change", data)
+}
+
+func (r *Renderer) RenderReviewResult(locale string, data ReviewResultData) (Message, error) {
+	return r.render(locale, "review_result", data)
+}
+
+func (r *Renderer) RenderInvitation(locale string, data InvitationData) (Message, error) {
+	return r.render(locale, "invitation", data)
+}
+
+func (r *Renderer) RenderActivityReminder(locale string, data ActivityReminderData) (Message, error) {
+	return r.render(locale, "activity_reminder", data)
+}
+
+func (r *Renderer) RenderSubmissionReopened(locale string, data SubmissionReopenedData) (Message, error) {
+	return r.render(locale, "submission_reopened", data)
+}
+
+func (r *Renderer) RenderJoinRequest(locale string, data JoinRequestData) (Message, error) {
+	return r.render(locale, "join_request", data)
+}
+
+func (r *Renderer) RenderAnswerReviewed(locale string, data AnswerReviewedData) (Message, error) {
+	return r.render(locale, "answer_reviewed", data)
+}
+
+// render executes the named template in locale, falling back to the default
+// locale when locale is empty or unsupported.
+func (r *Renderer) render(locale, name string, data any) (Message, error) {
+	locale = i18n.Resolve(locale)
+	pair, ok := r.templates[locale][name]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	v := view{
+		LogoURL:     r.logoURL,
+		FrontendURL: r.frontendURL,
+		Year:        time.Now().Year(),
+		Data:        data,
+	}
+
+	var html, text bytes.Buffer
+	if err := pair.html.ExecuteTemplate(&html, "layout", v); err != nil {
+		return Message{}, fmt.Errorf("rendering %s html: %w", name, err)
+	}
+	if err := pair.text.ExecuteTemplate(&text, "layout", v); err != nil {
+		return Message{}, fmt.Errorf("rendering %s text: %w", name, err)
+	}
+
+	return Message{Subject: subjects[locale][name], HTML: html.String(), Text: text.String()}, nil
+}
@@ -0,0 +1,56 @@
+package emailtemplate
+
+import (
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivityReminderUsesGroupTimeZone(t *testing.T) {
+	r, err := New("https://example.com/logo.png", "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saoPaulo := time.FixedZone("America/Sao_Paulo", -3*60*60)
+	due := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC).In(saoPaulo)
+
+	msg, err := r.RenderActivityReminder("pt-BR", ActivityReminderData{
+		Name:          "Ana",
+		ActivityTitle: "Lista 1",
+		GroupName:     "Turma A",
+		DueDate:       due,
+		ActivityURL:   "https://example.com/pt-BR/dashboard/activities/a1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "15/03/2026 às 09:00 (America/Sao_Paulo)"
+	for name, body := range map[string]string{"text": msg.Text, "html": msg.HTML} {
+		if !strings.Contains(body, want) {
+			t.Errorf("%s body does not contain %q:\n%s", name, want, body)
+		}
+	}
+}
+
+func TestEveryTemplateIsRendered(t *testing.T) {
+	for _, locale := range locales {
+		files, err := fs.Glob(templateFS, "templates/"+locale+"/*.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			name := strings.TrimSuffix(file[strings.LastIndex(file, "/")+1:], ".html")
+			if name != "layout" && !slices.Contains(templateNames, name) {
+				t.Errorf("%s is embedded but never rendered", file)
+			}
+		}
+		for _, name := range templateNames {
+			if subjects[locale][name] == "" {
+				t.Errorf("%s/%s has no subject", locale, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package imaging converts images by driving the cwebp command-line encoder
+// from libwebp, so the server binary itself stays free of cgo.
+package imaging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"proximos-passos/backend/internal/domain/service"
+)
+
+// cwebpInputs are the content types cwebp can read.
+var cwebpInputs = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+}
+
+// CWebPEncoder implements service.ImageEncoder with the cwebp binary.
+type CWebPEncoder struct {
+	path    string
+	quality int
+}
+
+// NewCWebPEncoder resolves binary on PATH and returns an encoder writing
+// lossy WebP at quality (0-100).
+func NewCWebPEncoder(binary string, quality int) (*CWebPEncoder, error) {
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, err
+	}
+	return &CWebPEncoder{path: path, quality: quality}, nil
+}
+
+func (e *CWebPEncoder) EncodeWebP(ctx context.Context, contentType string, src io.Reader) ([]byte, error) {
+	ext, ok := cwebpInputs[contentType]
+	if !ok {
+		return nil, service.ErrUnsupportedImage
+	}
+
+	dir, err := os.MkdirTemp("", "cwebp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "in"+ext)
+	out := filepath.Join(dir, "out.webp")
+
+	f, err := os.Create(in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.path, "-quiet", "-metadata", "none", "-q", strconv.Itoa(e.quality), in, "-o", out)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cwebp: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return os.ReadFile(out)
+}
@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"slices"
 	"time"
 
 	"proximos-passos/backend/internal/domain/entity"
@@ -10,11 +11,20 @@ import (
 
 const CookieName = "token"
 
+// leeway absorbs clock skew between the servers issuing and checking session
+// tokens.
+const leeway = 30 * time.Second
+
 type Claims struct {
 	UserPublicID string          `json:"sub"`
 	Name         string          `json:"name"`
 	Email        string          `json:"email"`
 	Role         entity.UserRole `json:"role"`
+	// ImpersonatedBy holds the public ID of the admin acting as this user,
+	// empty for regular sessions.
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
+	// RegisteredClaims.ID carries the public ID of the server-side session
+	// the token belongs to.
 	jwtlib.RegisteredClaims
 }
 
@@ -24,51 +34,145 @@ type VerificationClaims struct {
 	jwtlib.RegisteredClaims
 }
 
+type EmailChangeClaims struct {
+	UserPublicID string `json:"sub"`
+	Email        string `json:"email"`
+	Purpose      string `json:"purpose"`
+	jwtlib.RegisteredClaims
+}
+
 type Service struct {
 	secret     []byte
-	expiration time.Duration
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
 }
 
-func NewService(secret string, expiration time.Duration) *Service {
+// NewService returns a service issuing access tokens that live accessTTL,
+// for sessions that can be refreshed until refreshTTL after sign-in. There is
+// no separate refresh credential: an expired access token is itself what
+// refresh accepts, as long as its server-side session is live. Every
+// token names issuer and audience, and tokens naming others are rejected, so
+// environments that happen to share a secret cannot accept each other's
+// tokens.
+func NewService(secret, issuer, audience string, accessTTL, refreshTTL time.Duration) *Service {
 	return &Service{
 		secret:     []byte(secret),
-		expiration: expiration,
+		issuer:     issuer,
+		audience:   audience,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
+// registeredClaims returns the standard claims of a token issued now.
+func (s *Service) registeredClaims(id string, expiresAt time.Time) jwtlib.RegisteredClaims {
+	now := time.Now()
+	return jwtlib.RegisteredClaims{
+		ID:        id,
+		Issuer:    s.issuer,
+		Audience:  jwtlib.ClaimStrings{s.audience},
+		ExpiresAt: jwtlib.NewNumericDate(expiresAt),
+		IssuedAt:  jwtlib.NewNumericDate(now),
+		NotBefore: jwtlib.NewNumericDate(now),
 	}
 }
 
-func (s *Service) Generate(user *entity.User) (string, time.Time, error) {
-	expiresAt := time.Now().Add(s.expiration)
+// parserOptions validates the signing method and the standard claims, with
+// leeway on the time-based ones.
+func (s *Service) parserOptions() []jwtlib.ParserOption {
+	return []jwtlib.ParserOption{
+		jwtlib.WithValidMethods([]string{jwtlib.SigningMethodHS256.Alg()}),
+		jwtlib.WithIssuer(s.issuer),
+		jwtlib.WithAudience(s.audience),
+		jwtlib.WithLeeway(leeway),
+		jwtlib.WithExpirationRequired(),
+		jwtlib.WithIssuedAt(),
+	}
+}
 
+func (s *Service) key(*jwtlib.Token) (any, error) {
+	return s.secret, nil
+}
+
+// RefreshTTL returns the lifetime of regular sessions, over which their
+// access tokens can be refreshed.
+func (s *Service) RefreshTTL() time.Duration {
+	return s.refreshTTL
+}
+
+// AccessExpiry returns when an access token issued now for a session ending
+// at sessionExpiresAt expires.
+func (s *Service) AccessExpiry(sessionExpiresAt time.Time) time.Time {
+	expiresAt := time.Now().Add(s.accessTTL)
+	if sessionExpiresAt.Before(expiresAt) {
+		return sessionExpiresAt
+	}
+	return expiresAt
+}
+
+// Generate issues a token for user bound to the session sessionID.
+func (s *Service) Generate(user *entity.User, sessionID string, expiresAt time.Time) (string, error) {
+	return s.generate(user, "", sessionID, expiresAt)
+}
+
+// GenerateImpersonation issues a session token for user on behalf of the
+// admin identified by impersonatorPublicID.
+func (s *Service) GenerateImpersonation(user *entity.User, impersonatorPublicID, sessionID string, expiresAt time.Time) (string, error) {
+	return s.generate(user, impersonatorPublicID, sessionID, expiresAt)
+}
+
+func (s *Service) generate(user *entity.User, impersonatedBy, sessionID string, expiresAt time.Time) (string, error) {
 	claims := Claims{
-		UserPublicID: user.PublicID,
-		Name:         user.Name,
-		Email:        user.Email,
-		Role:         user.Role,
-		RegisteredClaims: jwtlib.RegisteredClaims{
-			ExpiresAt: jwtlib.NewNumericDate(expiresAt),
-			IssuedAt:  jwtlib.NewNumericDate(time.Now()),
-		},
+		UserPublicID:     user.PublicID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Role:             user.Role,
+		ImpersonatedBy:   impersonatedBy,
+		RegisteredClaims: s.registeredClaims(sessionID, expiresAt),
 	}
 
 	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, claims)
-	signed, err := token.SignedString(s.secret)
+	return token.SignedString(s.secret)
+}
+
+// Parse validates an access token. Besides its expiry, the lifetime between
+// iat and exp must fit the configured access TTL, so tokens minted under a
+// longer TTL stop working once it is lowered.
+func (s *Service) Parse(tokenStr string) (*Claims, error) {
+	token, err := jwtlib.ParseWithClaims(tokenStr, &Claims{}, s.key, s.parserOptions()...)
 	if err != nil {
-		return "", time.Time{}, err
+		return nil, err
 	}
 
-	return signed, expiresAt, nil
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.IssuedAt == nil {
+		return nil, jwtlib.ErrTokenInvalidClaims
+	}
+	if claims.ExpiresAt.Sub(claims.IssuedAt.Time) > s.accessTTL+leeway {
+		return nil, jwtlib.ErrTokenInvalidClaims
+	}
+
+	return claims, nil
 }
 
-func (s *Service) Parse(tokenStr string) (*Claims, error) {
-	token, err := jwtlib.ParseWithClaims(tokenStr, &Claims{}, func(t *jwtlib.Token) (any, error) {
-		return s.secret, nil
-	})
+// ParseSession checks the signature, issuer and audience of a session token
+// but not its timestamps, accepting expired ones. It serves refresh and
+// logout, which then look the session up server-side where its own expiry is
+// enforced.
+func (s *Service) ParseSession(tokenStr string) (*Claims, error) {
+	token, err := jwtlib.ParseWithClaims(tokenStr, &Claims{}, s.key,
+		jwtlib.WithValidMethods([]string{jwtlib.SigningMethodHS256.Alg()}), jwtlib.WithoutClaimsValidation())
 	if err != nil {
 		return nil, err
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.ID == "" {
+		return nil, jwtlib.ErrTokenInvalidClaims
+	}
+	if claims.Issuer != s.issuer || !slices.Contains(claims.Audience, s.audience) {
 		return nil, jwtlib.ErrTokenInvalidClaims
 	}
 
@@ -77,12 +181,9 @@ func (s *Service) Parse(tokenStr string) (*Claims, error) {
 
 func (s *Service) GenerateVerificationToken(userPublicID string, expiration time.Duration) (string, error) {
 	claims := VerificationClaims{
-		UserPublicID: userPublicID,
-		Purpose:      "email_verification",
-		RegisteredClaims: jwtlib.RegisteredClaims{
-			ExpiresAt: jwtlib.NewNumericDate(time.Now().Add(expiration)),
-			IssuedAt:  jwtlib.NewNumericDate(time.Now()),
-		},
+		UserPublicID:     userPublicID,
+		Purpose:          "email_verification",
+		RegisteredClaims: s.registeredClaims("", time.Now().Add(expiration)),
 	}
 
 	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, claims)
@@ -90,9 +191,7 @@ func (s *Service) GenerateVerificationToken(userPublicID string, expiration time
 }
 
 func (s *Service) ParseVerificationToken(tokenStr string) (*VerificationClaims, error) {
-	token, err := jwtlib.ParseWithClaims(tokenStr, &VerificationClaims{}, func(t *jwtlib.Token) (any, error) {
-		return s.secret, nil
-	})
+	token, err := jwtlib.ParseWithClaims(tokenStr, &VerificationClaims{}, s.key, s.parserOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -104,3 +203,29 @@ func (s *Service) ParseVerificationToken(tokenStr string) (*VerificationClaims,
 
 	return claims, nil
 }
+
+func (s *Service) GenerateEmailChangeToken(userPublicID, newEmail string, expiration time.Duration) (string, error) {
+	claims := EmailChangeClaims{
+		UserPublicID:     userPublicID,
+		Email:            newEmail,
+		Purpose:          "email_change",
+		RegisteredClaims: s.registeredClaims("", time.Now().Add(expiration)),
+	}
+
+	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, claims)
+	return token.SignedString(s.secret)
+}
+
+func (s *Service) ParseEmailChangeToken(tokenStr string) (*EmailChangeClaims, error) {
+	token, err := jwtlib.ParseWithClaims(tokenStr, &EmailChangeClaims{}, s.key, s.parserOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*EmailChangeClaims)
+	if !ok || !token.Valid || claims.Purpose != "email_change" {
+		return nil, jwtlib.ErrTokenInvalidClaims
+	}
+
+	return claims, nil
+}
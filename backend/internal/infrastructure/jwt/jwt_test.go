@@ -0,0 +1,116 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+func TestExpiredAccessTokenOnlyServesRefresh(t *testing.T) {
+	s := NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	user := &entity.User{PublicID: "user", Role: entity.UserRoleRegular}
+
+	token, err := s.Generate(user, "session-1", time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Parse(token); err == nil {
+		t.Error("an expired access token authenticated a request")
+	}
+	claims, err := s.ParseSession(token)
+	if err != nil {
+		t.Fatalf("ParseSession: %v", err)
+	}
+	if claims.ID != "session-1" {
+		t.Errorf("session = %q, want session-1", claims.ID)
+	}
+}
+
+func TestParseRejectsTokensLongerThanAccessTTL(t *testing.T) {
+	long := NewService("secret", "issuer", "audience", 24*time.Hour, 24*time.Hour)
+	user := &entity.User{PublicID: "user", Role: entity.UserRoleRegular}
+	token, err := long.Generate(user, "session-1", time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	short := NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	if _, err := short.Parse(token); err == nil {
+		t.Error("a token minted under a longer access TTL was accepted")
+	}
+}
+
+func TestAccessExpiryIsCappedBySession(t *testing.T) {
+	s := NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+
+	if got := s.AccessExpiry(time.Now().Add(time.Hour)); time.Until(got) > 15*time.Minute {
+		t.Errorf("access token expires in %v, want at most 15m", time.Until(got))
+	}
+	sessionEnd := time.Now().Add(5 * time.Minute)
+	if got := s.AccessExpiry(sessionEnd); !got.Equal(sessionEnd) {
+		t.Errorf("access expiry = %v, want the session end %v", got, sessionEnd)
+	}
+}
+
+func TestTokensFromOtherIssuersOrAudiencesAreRejected(t *testing.T) {
+	s := NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	user := &entity.User{PublicID: "user", Role: entity.UserRoleRegular}
+
+	tests := []struct {
+		name     string
+		issuer   string
+		audience string
+		wantOK   bool
+	}{
+		{"same issuer and audience", "issuer", "audience", true},
+		{"other audience", "issuer", "staging", false},
+		{"other issuer", "staging", "audience", false},
+		{"other issuer and audience", "staging", "staging", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := NewService("secret", tt.issuer, tt.audience, 15*time.Minute, 24*time.Hour)
+			token, err := other.Generate(user, "session-1", time.Now().Add(10*time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := s.Parse(token); (err == nil) != tt.wantOK {
+				t.Errorf("Parse err = %v, want accepted %v", err, tt.wantOK)
+			}
+			if _, err := s.ParseSession(token); (err == nil) != tt.wantOK {
+				t.Errorf("ParseSession err = %v, want accepted %v", err, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseAllowsLeewayOnExpiry(t *testing.T) {
+	s := NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	user := &entity.User{PublicID: "user", Role: entity.UserRoleRegular}
+
+	tests := []struct {
+		name      string
+		expiredBy time.Duration
+		wantOK    bool
+	}{
+		{"within leeway", 10 * time.Second, true},
+		{"just inside leeway", leeway - 5*time.Second, true},
+		{"past leeway", leeway + 5*time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := s.Generate(user, "session-1", time.Now().Add(-tt.expiredBy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Parse(token); (err == nil) != tt.wantOK {
+				t.Errorf("Parse err = %v, want accepted %v", err, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type ActivityReminderRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewActivityReminderRepository(pool *pgxpool.Pool) *ActivityReminderRepository {
+	return &ActivityReminderRepository{pool: pool}
+}
+
+func (r *ActivityReminderRepository) ListDue(ctx context.Context, until time.Time, limit int) ([]entity.ActivityReminder, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT a.id, a.public_id, a.title, a.due_date, g.name, g.time_zone,
+		        u.id, u.name, u.email, u.lang
+		 FROM activities a
+		 JOIN groups g ON g.id = a.group_id AND g.is_active = true
+		 JOIN group_members gm ON gm.group_id = a.group_id
+		      AND gm.is_active = true AND gm.accepted_by_id IS NOT NULL AND gm.role = 'member'
+		 JOIN users u ON u.id = gm.user_id AND u.is_active = true AND u.email_verified_at IS NOT NULL
+		      AND u.activity_reminders = true
+		 WHERE a.is_active = true
+		   AND a.due_date > NOW() AND a.due_date <= $1
+		   AND NOT EXISTS (
+		       SELECT 1 FROM activity_submissions s
+		       WHERE s.activity_id = a.id AND s.user_id = u.id AND s.is_active = true
+		         AND s.status <> 'created'
+		   )
+		   AND NOT EXISTS (
+		       SELECT 1 FROM activity_reminders ar
+		       WHERE ar.activity_id = a.id AND ar.user_id = u.id
+		   )
+		 ORDER BY a.due_date ASC, a.id, u.id
+		 LIMIT $2`,
+		until, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reminders []entity.ActivityReminder
+	for rows.Next() {
+		var rem entity.ActivityReminder
+		if err := rows.Scan(
+			&rem.ActivityID, &rem.ActivityPublicID, &rem.ActivityTitle, &rem.DueDate, &rem.GroupName, &rem.GroupTimeZone,
+			&rem.UserID, &rem.UserName, &rem.UserEmail, &rem.UserLang,
+		); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, rem)
+	}
+
+	return reminders, rows.Err()
+}
+
+func (r *ActivityReminderRepository) Claim(ctx context.Context, activityID, userID int) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`INSERT INTO activity_reminders (activity_id, user_id)
+		 VALUES ($1, $2)
+		 ON CONFLICT (activity_id, user_id) DO NOTHING`,
+		activityID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *ActivityReminderRepository) Release(ctx context.Context, activityID, userID int) error {
+	_, err := r.pool.Exec(ctx,
+		`DELETE FROM activity_reminders WHERE activity_id = $1 AND user_id = $2`,
+		activityID, userID,
+	)
+	return err
+}
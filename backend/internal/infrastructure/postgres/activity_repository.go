@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 
@@ -22,18 +23,19 @@ func NewActivityRepository(pool *pgxpool.Pool) *ActivityRepository {
 }
 
 func (r *ActivityRepository) Create(ctx context.Context, activity *entity.Activity) error {
-	return r.pool.QueryRow(ctx,
+	err := r.pool.QueryRow(ctx,
 		`INSERT INTO activities (group_id, title, description, due_date, created_by_id)
 		 VALUES ($1, $2, $3, $4, $5)
 		 RETURNING id, public_id, is_active, created_at, updated_at`,
-		activity.GroupID, activity.Title, activity.Description, activity.DueDate, activity.CreatedByID,
+		activity.GroupID, activity.Title, activity.Description, activity.DueDate.UTC(), activity.CreatedByID,
 	).Scan(&activity.ID, &activity.PublicID, &activity.IsActive, &activity.CreatedAt, &activity.UpdatedAt)
+	return mapConstraintError(err, apperror.ErrActivityTitleTaken, nil)
 }
 
 func (r *ActivityRepository) GetByPublicID(ctx context.Context, publicID string) (*entity.Activity, error) {
 	var a entity.Activity
 	err := r.pool.QueryRow(ctx,
-		`SELECT a.id, a.public_id, a.group_id, g.public_id, a.title, a.description, a.due_date,
+		`SELECT a.id, a.public_id, a.group_id, g.public_id, g.time_zone, a.title, a.description, a.due_date,
 		        a.is_active, a.created_by_id, a.created_at, a.updated_at,
 		        COALESCE((SELECT SUM(vl.duration_minutes) FROM activity_items ai JOIN video_lessons vl ON vl.id = ai.video_lesson_id WHERE ai.activity_id = a.id AND ai.type = 'video_lesson'), 0) as total_video_duration_minutes,
 		        (SELECT COUNT(*) FROM activity_items ai WHERE ai.activity_id = a.id AND ai.type = 'question') as total_questions_count,
@@ -42,7 +44,7 @@ func (r *ActivityRepository) GetByPublicID(ctx context.Context, publicID string)
 		 JOIN groups g ON g.id = a.group_id
 		 WHERE a.public_id = $1 AND a.is_active = true`,
 		publicID,
-	).Scan(&a.ID, &a.PublicID, &a.GroupID, &a.GroupPublicID, &a.Title, &a.Description, &a.DueDate,
+	).Scan(&a.ID, &a.PublicID, &a.GroupID, &a.GroupPublicID, &a.GroupTimeZone, &a.Title, &a.Description, &a.DueDate,
 		&a.IsActive, &a.CreatedByID, &a.CreatedAt, &a.UpdatedAt, &a.TotalVideoDurationMinutes, &a.TotalQuestionsCount, &a.TotalExerciseListsCount)
 
 	if err != nil {
@@ -57,7 +59,7 @@ func (r *ActivityRepository) GetByPublicID(ctx context.Context, publicID string)
 func (r *ActivityRepository) GetByID(ctx context.Context, id int) (*entity.Activity, error) {
 	var a entity.Activity
 	err := r.pool.QueryRow(ctx,
-		`SELECT a.id, a.public_id, a.group_id, g.public_id, a.title, a.description, a.due_date,
+		`SELECT a.id, a.public_id, a.group_id, g.public_id, g.time_zone, a.title, a.description, a.due_date,
 		        a.is_active, a.created_by_id, a.created_at, a.updated_at,
 		        COALESCE((SELECT SUM(vl.duration_minutes) FROM activity_items ai JOIN video_lessons vl ON vl.id = ai.video_lesson_id WHERE ai.activity_id = a.id AND ai.type = 'video_lesson'), 0) as total_video_duration_minutes,
 		        (SELECT COUNT(*) FROM activity_items ai WHERE ai.activity_id = a.id AND ai.type = 'question') as total_questions_count,
@@ -66,7 +68,7 @@ func (r *ActivityRepository) GetByID(ctx context.Context, id int) (*entity.Activ
 		 JOIN groups g ON g.id = a.group_id
 		 WHERE a.id = $1 AND a.is_active = true`,
 		id,
-	).Scan(&a.ID, &a.PublicID, &a.GroupID, &a.GroupPublicID, &a.Title, &a.Description, &a.DueDate,
+	).Scan(&a.ID, &a.PublicID, &a.GroupID, &a.GroupPublicID, &a.GroupTimeZone, &a.Title, &a.Description, &a.DueDate,
 		&a.IsActive, &a.CreatedByID, &a.CreatedAt, &a.UpdatedAt, &a.TotalVideoDurationMinutes, &a.TotalQuestionsCount, &a.TotalExerciseListsCount)
 
 	if err != nil {
@@ -83,9 +85,9 @@ func (r *ActivityRepository) Update(ctx context.Context, activity *entity.Activi
 		`UPDATE activities
 		 SET title = $1, description = $2, due_date = $3, updated_at = NOW()
 		 WHERE public_id = $4 AND is_active = true`,
-		activity.Title, activity.Description, activity.DueDate, activity.PublicID,
+		activity.Title, activity.Description, activity.DueDate.UTC(), activity.PublicID,
 	)
-	return err
+	return mapConstraintError(err, apperror.ErrActivityTitleTaken, nil)
 }
 
 func (r *ActivityRepository) Delete(ctx context.Context, publicID string) error {
@@ -107,6 +109,22 @@ func buildActivityFilterClause(filter repository.ActivityFilter, startParam int)
 		paramIdx++
 	}
 
+	if filter.MemberStatus != "" {
+		submission := fmt.Sprintf(
+			"SELECT 1 FROM activity_submissions s WHERE s.activity_id = a.id AND s.user_id = $%d AND s.is_active = true", paramIdx)
+		args = append(args, filter.MemberID)
+		paramIdx++
+
+		switch filter.MemberStatus {
+		case repository.ActivityMemberStatusTodo:
+			clauses = append(clauses, "NOT EXISTS ("+submission+" AND s.status IN ('pending', 'approved'))")
+		case repository.ActivityMemberStatusSubmitted:
+			clauses = append(clauses, "EXISTS ("+submission+" AND s.status = 'pending')")
+		case repository.ActivityMemberStatusApproved:
+			clauses = append(clauses, "EXISTS ("+submission+" AND s.status = 'approved')")
+		}
+	}
+
 	if len(clauses) == 0 {
 		return "", nil
 	}
@@ -117,7 +135,7 @@ func (r *ActivityRepository) ListUpcoming(ctx context.Context, groupID int, limi
 	filterClause, filterArgs := buildActivityFilterClause(filter, 4)
 
 	query := fmt.Sprintf(
-		`SELECT a.id, a.public_id, a.group_id, g.public_id, a.title, a.description, a.due_date,
+		`SELECT a.id, a.public_id, a.group_id, g.public_id, g.time_zone, a.title, a.description, a.due_date,
 		        a.is_active, a.created_by_id, a.created_at, a.updated_at,
 		        COALESCE((SELECT SUM(vl.duration_minutes) FROM activity_items ai JOIN video_lessons vl ON vl.id = ai.video_lesson_id WHERE ai.activity_id = a.id AND ai.type = 'video_lesson'), 0) as total_video_duration_minutes,
 		        (SELECT COUNT(*) FROM activity_items ai WHERE ai.activity_id = a.id AND ai.type = 'question') as total_questions_count,
@@ -125,7 +143,7 @@ func (r *ActivityRepository) ListUpcoming(ctx context.Context, groupID int, limi
 		 FROM activities a
 		 JOIN groups g ON g.id = a.group_id
 		 WHERE a.group_id = $1 AND a.is_active = true AND a.due_date >= NOW()%s
-		 ORDER BY a.due_date ASC
+		 ORDER BY a.due_date ASC, a.id ASC
 		 LIMIT $2 OFFSET $3`, filterClause)
 
 	args := append([]any{groupID, limit, offset}, filterArgs...)
@@ -155,7 +173,7 @@ func (r *ActivityRepository) ListPast(ctx context.Context, groupID int, limit, o
 	filterClause, filterArgs := buildActivityFilterClause(filter, 4)
 
 	query := fmt.Sprintf(
-		`SELECT a.id, a.public_id, a.group_id, g.public_id, a.title, a.description, a.due_date,
+		`SELECT a.id, a.public_id, a.group_id, g.public_id, g.time_zone, a.title, a.description, a.due_date,
 		        a.is_active, a.created_by_id, a.created_at, a.updated_at,
 		        COALESCE((SELECT SUM(vl.duration_minutes) FROM activity_items ai JOIN video_lessons vl ON vl.id = ai.video_lesson_id WHERE ai.activity_id = a.id AND ai.type = 'video_lesson'), 0) as total_video_duration_minutes,
 		        (SELECT COUNT(*) FROM activity_items ai WHERE ai.activity_id = a.id AND ai.type = 'question') as total_questions_count,
@@ -163,7 +181,7 @@ func (r *ActivityRepository) ListPast(ctx context.Context, groupID int, limit, o
 		 FROM activities a
 		 JOIN groups g ON g.id = a.group_id
 		 WHERE a.group_id = $1 AND a.is_active = true AND a.due_date < NOW()%s
-		 ORDER BY a.due_date DESC
+		 ORDER BY a.due_date DESC, a.id DESC
 		 LIMIT $2 OFFSET $3`, filterClause)
 
 	args := append([]any{groupID, limit, offset}, filterArgs...)
@@ -189,11 +207,70 @@ func (r *ActivityRepository) CountPast(ctx context.Context, groupID int, filter
 	return count, err
 }
 
+// ListByGroup returns every active activity of the group, oldest due date first.
+func (r *ActivityRepository) ListByGroup(ctx context.Context, groupID int) ([]entity.Activity, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT a.id, a.public_id, a.group_id, g.public_id, g.time_zone, a.title, a.description, a.due_date,
+		        a.is_active, a.created_by_id, a.created_at, a.updated_at,
+		        COALESCE((SELECT SUM(vl.duration_minutes) FROM activity_items ai JOIN video_lessons vl ON vl.id = ai.video_lesson_id WHERE ai.activity_id = a.id AND ai.type = 'video_lesson'), 0) as total_video_duration_minutes,
+		        (SELECT COUNT(*) FROM activity_items ai WHERE ai.activity_id = a.id AND ai.type = 'question') as total_questions_count,
+		        (SELECT COUNT(*) FROM activity_items ai WHERE ai.activity_id = a.id AND ai.type = 'open_exercise_list') as total_exercise_lists_count
+		 FROM activities a
+		 JOIN groups g ON g.id = a.group_id
+		 WHERE a.group_id = $1 AND a.is_active = true
+		 ORDER BY a.due_date ASC`,
+		groupID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanActivities(rows)
+}
+
+func (r *ActivityRepository) LoadSubmissionCounts(ctx context.Context, activities []entity.Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(activities))
+	byID := make(map[int]*entity.Activity, len(activities))
+	for i := range activities {
+		ids[i] = activities[i].ID
+		activities[i].SubmissionCounts = make(map[entity.ActivitySubmissionStatus]int)
+		byID[activities[i].ID] = &activities[i]
+	}
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT a.id, s.status, COUNT(*)
+		 FROM activities a
+		 JOIN activity_submissions s ON s.activity_id = a.id AND s.is_active = true
+		 WHERE a.id = ANY($1)
+		 GROUP BY a.id, s.status`,
+		ids,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var activityID, count int
+		var status entity.ActivitySubmissionStatus
+		if err := rows.Scan(&activityID, &status, &count); err != nil {
+			return err
+		}
+		byID[activityID].SubmissionCounts[status] = count
+	}
+	return rows.Err()
+}
+
 func scanActivities(rows pgx.Rows) ([]entity.Activity, error) {
 	var activities []entity.Activity
 	for rows.Next() {
 		var a entity.Activity
-		if err := rows.Scan(&a.ID, &a.PublicID, &a.GroupID, &a.GroupPublicID, &a.Title, &a.Description, &a.DueDate,
+		if err := rows.Scan(&a.ID, &a.PublicID, &a.GroupID, &a.GroupPublicID, &a.GroupTimeZone, &a.Title, &a.Description, &a.DueDate,
 			&a.IsActive, &a.CreatedByID, &a.CreatedAt, &a.UpdatedAt, &a.TotalVideoDurationMinutes, &a.TotalQuestionsCount, &a.TotalExerciseListsCount); err != nil {
 			return nil, err
 		}
@@ -212,10 +289,10 @@ func (r *ActivityRepository) CreateFile(ctx context.Context, file *entity.Activi
 	var createdAt interface{}
 
 	err := r.pool.QueryRow(ctx,
-		`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id)
-		 VALUES ($1, $2, $3, $4, $5)
+		`INSERT INTO files (key, filename, content_type, size_bytes, checksum, uploaded_by_id)
+		 VALUES ($1, $2, $3, $4, $5, $6)
 		 RETURNING id, public_id, created_at`,
-		file.Key, file.Filename, file.ContentType, file.SizeBytes, uploadedByID,
+		file.Key, file.Filename, file.ContentType, file.SizeBytes, file.Checksum, uploadedByID,
 	).Scan(&fileID, &filePublicID, &createdAt)
 	if err != nil {
 		return err
@@ -241,7 +318,7 @@ func (r *ActivityRepository) DeleteFile(ctx context.Context, fileID int) error {
 
 func (r *ActivityRepository) ListAttachments(ctx context.Context, activityID int) ([]entity.ActivityAttachment, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT aa.activity_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.created_at
+		`SELECT aa.activity_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.checksum, f.created_at
 		 FROM activity_attachments aa
 		 JOIN files f ON f.id = aa.file_id
 		 WHERE aa.activity_id = $1 AND f.is_active = true
@@ -257,7 +334,7 @@ func (r *ActivityRepository) ListAttachments(ctx context.Context, activityID int
 	for rows.Next() {
 		var a entity.ActivityAttachment
 		if err := rows.Scan(&a.ActivityID, &a.FileID, &a.FilePublicID, &a.Key, &a.Filename,
-			&a.ContentType, &a.SizeBytes, &a.CreatedAt); err != nil {
+			&a.ContentType, &a.SizeBytes, &a.Checksum, &a.CreatedAt); err != nil {
 			return nil, err
 		}
 		attachments = append(attachments, a)
@@ -268,13 +345,13 @@ func (r *ActivityRepository) ListAttachments(ctx context.Context, activityID int
 func (r *ActivityRepository) GetAttachment(ctx context.Context, activityID int, filePublicID string) (*entity.ActivityAttachment, error) {
 	var a entity.ActivityAttachment
 	err := r.pool.QueryRow(ctx,
-		`SELECT aa.activity_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.created_at
+		`SELECT aa.activity_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.checksum, f.created_at
 		 FROM activity_attachments aa
 		 JOIN files f ON f.id = aa.file_id
 		 WHERE aa.activity_id = $1 AND f.public_id = $2 AND f.is_active = true`,
 		activityID, filePublicID,
 	).Scan(&a.ActivityID, &a.FileID, &a.FilePublicID, &a.Key, &a.Filename,
-		&a.ContentType, &a.SizeBytes, &a.CreatedAt)
+		&a.ContentType, &a.SizeBytes, &a.Checksum, &a.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -352,7 +429,9 @@ func (r *ActivityRepository) DeleteItem(ctx context.Context, publicID string) er
 	return err
 }
 
-func (r *ActivityRepository) ListItems(ctx context.Context, activityID int) ([]entity.ActivityItem, error) {
+// ListItems returns the activity's items in order, optionally restricted to
+// a single item type.
+func (r *ActivityRepository) ListItems(ctx context.Context, activityID int, itemType *entity.ActivityItemType) ([]entity.ActivityItem, error) {
 	rows, err := r.pool.Query(ctx,
 		`SELECT ai.id, ai.public_id, ai.activity_id, ai.order_index, ai.title, ai.description, ai.type,
 		        ai.question_id, ai.video_lesson_id, ai.handout_id, ai.open_exercise_list_id, ai.simulated_exam_id,
@@ -380,9 +459,9 @@ func (r *ActivityRepository) ListItems(ctx context.Context, activityID int) ([]e
 		 LEFT JOIN handouts h ON h.id = ai.handout_id
 		 LEFT JOIN open_exercise_lists oel ON oel.id = ai.open_exercise_list_id
 		 LEFT JOIN simulated_exams se ON se.id = ai.simulated_exam_id
-		 WHERE ai.activity_id = $1
+		 WHERE ai.activity_id = $1 AND ($2::activity_item_type IS NULL OR ai.type = $2)
 		 ORDER BY ai.order_index ASC`,
-		activityID,
+		activityID, itemType,
 	)
 	if err != nil {
 		return nil, err
@@ -403,6 +482,41 @@ func (r *ActivityRepository) ListItems(ctx context.Context, activityID int) ([]e
 	return items, rows.Err()
 }
 
+func (r *ActivityRepository) HasQuestion(ctx context.Context, activityID, questionID int) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM activity_items ai
+		     LEFT JOIN simulated_exam_questions seq ON seq.simulated_exam_id = ai.simulated_exam_id
+		     WHERE ai.activity_id = $1 AND (ai.question_id = $2 OR seq.question_id = $2)
+		 )`,
+		activityID, questionID,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *ActivityRepository) CountItemsByType(ctx context.Context, activityID int) (map[entity.ActivityItemType]int, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT type, COUNT(*) FROM activity_items WHERE activity_id = $1 GROUP BY type`,
+		activityID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[entity.ActivityItemType]int)
+	for rows.Next() {
+		var itemType entity.ActivityItemType
+		var count int
+		if err := rows.Scan(&itemType, &count); err != nil {
+			return nil, err
+		}
+		counts[itemType] = count
+	}
+	return counts, rows.Err()
+}
+
 func (r *ActivityRepository) ReorderItems(ctx context.Context, activityID int, orderedIDs []string) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -431,3 +545,37 @@ func (r *ActivityRepository) ReorderItems(ctx context.Context, activityID int, o
 
 	return tx.Commit(ctx)
 }
+
+// activityItemContentID matches the single non-null content column of an
+// activity item.
+const activityItemContentID = `COALESCE(ai.question_id, ai.video_lesson_id, ai.handout_id, ai.open_exercise_list_id, ai.simulated_exam_id)`
+
+func (r *ActivityRepository) ListContentReferences(ctx context.Context, itemType entity.ActivityItemType, contentID int) ([]entity.ActivityRef, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT DISTINCT a.id, a.public_id, a.title, g.public_id, g.name
+		 FROM activity_items ai
+		 JOIN activities a ON a.id = ai.activity_id
+		 JOIN groups g ON g.id = a.group_id
+		 WHERE a.is_active = true
+		   AND ((ai.type = $1 AND `+activityItemContentID+` = $2)
+		     OR ($1 = 'question' AND ai.simulated_exam_id IN (
+		         SELECT simulated_exam_id FROM simulated_exam_questions WHERE question_id = $2
+		     )))
+		 ORDER BY g.name ASC, a.title ASC`,
+		itemType, contentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []entity.ActivityRef
+	for rows.Next() {
+		var ref entity.ActivityRef
+		if err := rows.Scan(&ref.ID, &ref.PublicID, &ref.Title, &ref.GroupPublicID, &ref.GroupName); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
@@ -22,10 +22,14 @@ const actSubSelectFields = `
 	asub.id, asub.public_id, asub.activity_id, asub.user_id,
 	asub.status, asub.notes, asub.feedback_notes,
 	asub.reviewed_at, asub.reviewed_by_id,
+	asub.reopen_reason, asub.reopened_at, asub.reopened_by_id,
 	asub.is_active, asub.submitted_at, asub.updated_at,
 	a.public_id, a.title,
 	u.public_id, u.name, u.avatar_url,
-	r.public_id, r.name
+	r.public_id, r.name,
+	(SELECT COUNT(*) FROM activity_submission_attachments asa
+	 JOIN files f ON f.id = asa.file_id
+	 WHERE asa.activity_submission_id = asub.id AND f.is_active = true)
 `
 
 const actSubFromJoins = `
@@ -35,23 +39,42 @@ const actSubFromJoins = `
 	LEFT JOIN users r ON r.id = asub.reviewed_by_id
 `
 
-func scanActivitySubmission(row pgx.Row) (*entity.ActivitySubmission, error) {
-	var s entity.ActivitySubmission
-	err := row.Scan(
+// actSubScanDest returns the scan destinations matching actSubSelectFields.
+func actSubScanDest(s *entity.ActivitySubmission) []any {
+	return []any{
 		&s.ID, &s.PublicID, &s.ActivityID, &s.UserID,
 		&s.Status, &s.Notes, &s.FeedbackNotes,
 		&s.ReviewedAt, &s.ReviewedByID,
+		&s.ReopenReason, &s.ReopenedAt, &s.ReopenedByID,
 		&s.IsActive, &s.SubmittedAt, &s.UpdatedAt,
 		&s.ActivityPublicID, &s.ActivityTitle,
 		&s.UserPublicID, &s.UserName, &s.UserAvatarURL,
 		&s.ReviewerPublicID, &s.ReviewerName,
-	)
-	if err != nil {
+		&s.AttachmentCount,
+	}
+}
+
+func scanActivitySubmission(row pgx.Row) (*entity.ActivitySubmission, error) {
+	var s entity.ActivitySubmission
+	if err := row.Scan(actSubScanDest(&s)...); err != nil {
 		return nil, err
 	}
 	return &s, nil
 }
 
+// reviewQueueFilter matches pending submissions to live activities of live
+// groups where $1 is an accepted admin or supervisor.
+const reviewQueueFilter = `
+	JOIN groups g ON g.id = a.group_id AND g.is_active = true
+	WHERE asub.status = 'pending' AND asub.is_active = true AND a.is_active = true
+	  AND EXISTS (
+	      SELECT 1 FROM group_members gm
+	      WHERE gm.group_id = a.group_id AND gm.user_id = $1
+	        AND gm.role IN ('admin', 'supervisor')
+	        AND gm.is_active = true AND gm.accepted_by_id IS NOT NULL
+	  )
+`
+
 func (r *ActivitySubmissionRepository) Create(ctx context.Context, s *entity.ActivitySubmission) error {
 	return r.pool.QueryRow(ctx,
 		`INSERT INTO activity_submissions (activity_id, user_id, notes)
@@ -75,6 +98,20 @@ func (r *ActivitySubmissionRepository) GetByPublicID(ctx context.Context, public
 	return s, nil
 }
 
+func (r *ActivitySubmissionRepository) GetByID(ctx context.Context, id int) (*entity.ActivitySubmission, error) {
+	row := r.pool.QueryRow(ctx,
+		`SELECT `+actSubSelectFields+actSubFromJoins+`
+		 WHERE asub.id = $1 AND asub.is_active = true`, id)
+	s, err := scanActivitySubmission(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
 func (r *ActivitySubmissionRepository) GetByActivityAndUser(ctx context.Context, activityID, userID int) (*entity.ActivitySubmission, error) {
 	row := r.pool.QueryRow(ctx,
 		`SELECT `+actSubSelectFields+actSubFromJoins+`
@@ -90,12 +127,26 @@ func (r *ActivitySubmissionRepository) GetByActivityAndUser(ctx context.Context,
 	return s, nil
 }
 
-func (r *ActivitySubmissionRepository) ListByActivity(ctx context.Context, activityID int, limit, offset int) ([]entity.ActivitySubmission, error) {
+// statusFilter converts statuses into a text[] parameter, or NULL when every
+// status should match.
+func statusFilter(statuses []entity.ActivitySubmissionStatus) []string {
+	if len(statuses) == 0 {
+		return nil
+	}
+	result := make([]string, len(statuses))
+	for i, s := range statuses {
+		result[i] = string(s)
+	}
+	return result
+}
+
+func (r *ActivitySubmissionRepository) ListByActivity(ctx context.Context, activityID int, limit, offset int, statuses []entity.ActivitySubmissionStatus) ([]entity.ActivitySubmission, error) {
 	rows, err := r.pool.Query(ctx,
 		`SELECT `+actSubSelectFields+actSubFromJoins+`
 		 WHERE asub.activity_id = $1 AND asub.is_active = true
-		 ORDER BY asub.submitted_at DESC
-		 LIMIT $2 OFFSET $3`, activityID, limit, offset)
+		   AND ($4::text[] IS NULL OR asub.status::text = ANY($4))
+		 ORDER BY asub.submitted_at DESC, asub.id DESC
+		 LIMIT $2 OFFSET $3`, activityID, limit, offset, statusFilter(statuses))
 	if err != nil {
 		return nil, err
 	}
@@ -112,20 +163,23 @@ func (r *ActivitySubmissionRepository) ListByActivity(ctx context.Context, activ
 	return result, rows.Err()
 }
 
-func (r *ActivitySubmissionRepository) CountByActivity(ctx context.Context, activityID int) (int, error) {
+func (r *ActivitySubmissionRepository) CountByActivity(ctx context.Context, activityID int, statuses []entity.ActivitySubmissionStatus) (int, error) {
 	var count int
 	err := r.pool.QueryRow(ctx,
-		`SELECT COUNT(*) FROM activity_submissions WHERE activity_id = $1 AND is_active = true`,
-		activityID).Scan(&count)
+		`SELECT COUNT(*) FROM activity_submissions
+		 WHERE activity_id = $1 AND is_active = true
+		   AND ($2::text[] IS NULL OR status::text = ANY($2))`,
+		activityID, statusFilter(statuses)).Scan(&count)
 	return count, err
 }
 
-func (r *ActivitySubmissionRepository) ListByUser(ctx context.Context, userID int, limit, offset int) ([]entity.ActivitySubmission, error) {
+func (r *ActivitySubmissionRepository) ListByUser(ctx context.Context, userID int, limit, offset int, statuses []entity.ActivitySubmissionStatus) ([]entity.ActivitySubmission, error) {
 	rows, err := r.pool.Query(ctx,
 		`SELECT `+actSubSelectFields+actSubFromJoins+`
 		 WHERE asub.user_id = $1 AND asub.is_active = true
-		 ORDER BY asub.submitted_at DESC
-		 LIMIT $2 OFFSET $3`, userID, limit, offset)
+		   AND ($4::text[] IS NULL OR asub.status::text = ANY($4))
+		 ORDER BY asub.submitted_at DESC, asub.id DESC
+		 LIMIT $2 OFFSET $3`, userID, limit, offset, statusFilter(statuses))
 	if err != nil {
 		return nil, err
 	}
@@ -142,10 +196,44 @@ func (r *ActivitySubmissionRepository) ListByUser(ctx context.Context, userID in
 	return result, rows.Err()
 }
 
-func (r *ActivitySubmissionRepository) CountByUser(ctx context.Context, userID int) (int, error) {
+func (r *ActivitySubmissionRepository) CountByUser(ctx context.Context, userID int, statuses []entity.ActivitySubmissionStatus) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM activity_submissions
+		 WHERE user_id = $1 AND is_active = true
+		   AND ($2::text[] IS NULL OR status::text = ANY($2))`,
+		userID, statusFilter(statuses)).Scan(&count)
+	return count, err
+}
+
+func (r *ActivitySubmissionRepository) ListReviewQueue(ctx context.Context, userID int, limit, offset int) ([]entity.ReviewQueueItem, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+actSubSelectFields+`, g.public_id, g.name, a.due_date`+actSubFromJoins+reviewQueueFilter+`
+		 ORDER BY asub.submitted_at ASC, asub.id ASC
+		 LIMIT $2 OFFSET $3`, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entity.ReviewQueueItem
+	for rows.Next() {
+		var item entity.ReviewQueueItem
+		dest := append(actSubScanDest(&item.Submission), &item.GroupPublicID, &item.GroupName, &item.ActivityDueDate)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, rows.Err()
+}
+
+func (r *ActivitySubmissionRepository) CountReviewQueue(ctx context.Context, userID int) (int, error) {
 	var count int
 	err := r.pool.QueryRow(ctx,
-		`SELECT COUNT(*) FROM activity_submissions WHERE user_id = $1 AND is_active = true`,
+		`SELECT COUNT(*)
+		 FROM activity_submissions asub
+		 JOIN activities a ON a.id = asub.activity_id`+reviewQueueFilter,
 		userID).Scan(&count)
 	return count, err
 }
@@ -159,6 +247,21 @@ func (r *ActivitySubmissionRepository) UpdateStatus(ctx context.Context, s *enti
 	return err
 }
 
+// Reopen moves a reviewed submission back to pending. It returns false when
+// the submission is no longer approved or reproved.
+func (r *ActivitySubmissionRepository) Reopen(ctx context.Context, id int, reason string, reopenedByID int) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE activity_submissions
+		 SET status = 'pending', reviewed_at = NULL, reviewed_by_id = NULL,
+		     reopen_reason = $2, reopened_at = NOW(), reopened_by_id = $3, updated_at = NOW()
+		 WHERE id = $1 AND status IN ('approved', 'reproved')`,
+		id, reason, reopenedByID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
 func (r *ActivitySubmissionRepository) UpdateNotes(ctx context.Context, id int, notes *string) error {
 	_, err := r.pool.Exec(ctx,
 		`UPDATE activity_submissions SET notes = $1, updated_at = NOW() WHERE id = $2`,
@@ -172,10 +275,10 @@ func (r *ActivitySubmissionRepository) CreateFile(ctx context.Context, file *ent
 	var createdAt interface{}
 
 	err := r.pool.QueryRow(ctx,
-		`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id)
-		 VALUES ($1, $2, $3, $4, $5)
+		`INSERT INTO files (key, filename, content_type, size_bytes, checksum, uploaded_by_id)
+		 VALUES ($1, $2, $3, $4, $5, $6)
 		 RETURNING id, public_id, created_at`,
-		file.Key, file.Filename, file.ContentType, file.SizeBytes, uploadedByID,
+		file.Key, file.Filename, file.ContentType, file.SizeBytes, file.Checksum, uploadedByID,
 	).Scan(&fileID, &filePublicID, &createdAt)
 	if err != nil {
 		return err
@@ -204,7 +307,7 @@ func (r *ActivitySubmissionRepository) DeleteFile(ctx context.Context, fileID in
 
 func (r *ActivitySubmissionRepository) ListAttachments(ctx context.Context, submissionID int) ([]entity.ActivitySubmissionAttachment, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT asa.activity_submission_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.created_at
+		`SELECT asa.activity_submission_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.checksum, f.created_at
 		 FROM activity_submission_attachments asa
 		 JOIN files f ON f.id = asa.file_id
 		 WHERE asa.activity_submission_id = $1 AND f.is_active = true
@@ -220,7 +323,7 @@ func (r *ActivitySubmissionRepository) ListAttachments(ctx context.Context, subm
 	for rows.Next() {
 		var a entity.ActivitySubmissionAttachment
 		if err := rows.Scan(&a.SubmissionID, &a.FileID, &a.FilePublicID, &a.Key, &a.Filename,
-			&a.ContentType, &a.SizeBytes, &a.CreatedAt); err != nil {
+			&a.ContentType, &a.SizeBytes, &a.Checksum, &a.CreatedAt); err != nil {
 			return nil, err
 		}
 		attachments = append(attachments, a)
@@ -231,13 +334,13 @@ func (r *ActivitySubmissionRepository) ListAttachments(ctx context.Context, subm
 func (r *ActivitySubmissionRepository) GetAttachment(ctx context.Context, submissionID int, filePublicID string) (*entity.ActivitySubmissionAttachment, error) {
 	var a entity.ActivitySubmissionAttachment
 	err := r.pool.QueryRow(ctx,
-		`SELECT asa.activity_submission_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.created_at
+		`SELECT asa.activity_submission_id, f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, f.checksum, f.created_at
 		 FROM activity_submission_attachments asa
 		 JOIN files f ON f.id = asa.file_id
 		 WHERE asa.activity_submission_id = $1 AND f.public_id = $2 AND f.is_active = true`,
 		submissionID, filePublicID,
 	).Scan(&a.SubmissionID, &a.FileID, &a.FilePublicID, &a.Key, &a.Filename,
-		&a.ContentType, &a.SizeBytes, &a.CreatedAt)
+		&a.ContentType, &a.SizeBytes, &a.Checksum, &a.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -3,16 +3,35 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Pool defaults used when the corresponding DB_POOL_* variable is unset.
+const (
+	defaultMaxConns          = 20
+	defaultMinConns          = 2
+	defaultMaxConnLifetime   = time.Hour
+	defaultMaxConnIdleTime   = 30 * time.Minute
+	defaultHealthCheckPeriod = time.Minute
+	defaultConnectTimeout    = 10 * time.Second
+	defaultStatementTimeout  = 30 * time.Second
+)
+
 func NewConnection(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
-	config, err := pgxpool.ParseConfig(databaseURL)
+	config, err := newPoolConfig(databaseURL, os.Getenv)
 	if err != nil {
-		return nil, fmt.Errorf("parsing database URL: %w", err)
+		return nil, err
 	}
 
+	log.Printf("Database pool: max_conns=%d min_conns=%d max_conn_lifetime=%s max_conn_idle_time=%s health_check_period=%s connect_timeout=%s statement_timeout=%sms",
+		config.MaxConns, config.MinConns, config.MaxConnLifetime, config.MaxConnIdleTime, config.HealthCheckPeriod,
+		config.ConnConfig.ConnectTimeout, config.ConnConfig.RuntimeParams["statement_timeout"])
+
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("creating connection pool: %w", err)
@@ -25,3 +44,75 @@ func NewConnection(ctx context.Context, databaseURL string) (*pgxpool.Pool, erro
 
 	return pool, nil
 }
+
+// newPoolConfig parses databaseURL and applies the DB_POOL_* and timeout
+// settings read through getenv. Durations use Go syntax, e.g. "30m" or "1h".
+func newPoolConfig(databaseURL string, getenv func(string) string) (*pgxpool.Config, error) {
+	config, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing database URL: %w", err)
+	}
+
+	if config.MaxConns, err = envInt32(getenv, "DB_POOL_MAX_CONNS", defaultMaxConns); err != nil {
+		return nil, err
+	}
+	if config.MinConns, err = envInt32(getenv, "DB_POOL_MIN_CONNS", defaultMinConns); err != nil {
+		return nil, err
+	}
+	if config.MaxConnLifetime, err = envDuration(getenv, "DB_POOL_MAX_CONN_LIFETIME", defaultMaxConnLifetime); err != nil {
+		return nil, err
+	}
+	if config.MaxConnIdleTime, err = envDuration(getenv, "DB_POOL_MAX_CONN_IDLE_TIME", defaultMaxConnIdleTime); err != nil {
+		return nil, err
+	}
+	if config.HealthCheckPeriod, err = envDuration(getenv, "DB_POOL_HEALTH_CHECK_PERIOD", defaultHealthCheckPeriod); err != nil {
+		return nil, err
+	}
+
+	// Queries inherit the request context for cancellation; these bound how
+	// long a single dial or statement may take even if the caller never gives up.
+	if config.ConnConfig.ConnectTimeout, err = envDuration(getenv, "DB_CONNECT_TIMEOUT", defaultConnectTimeout); err != nil {
+		return nil, err
+	}
+	statementTimeout, err := envDuration(getenv, "DB_STATEMENT_TIMEOUT", defaultStatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+	config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(statementTimeout.Milliseconds(), 10)
+
+	if config.MaxConns < 1 {
+		return nil, fmt.Errorf("DB_POOL_MAX_CONNS must be at least 1")
+	}
+	if config.MinConns < 0 || config.MinConns > config.MaxConns {
+		return nil, fmt.Errorf("DB_POOL_MIN_CONNS must be between 0 and DB_POOL_MAX_CONNS")
+	}
+
+	return config, nil
+}
+
+func envInt32(getenv func(string) string, name string, fallback int32) (int32, error) {
+	v := getenv(name)
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	return int32(n), nil
+}
+
+func envDuration(getenv func(string) string, name string, fallback time.Duration) (time.Duration, error) {
+	v := getenv(name)
+	if v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive", name)
+	}
+	return d, nil
+}
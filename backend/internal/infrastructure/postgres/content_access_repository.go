@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type ContentAccessRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewContentAccessRepository(pool *pgxpool.Pool) *ContentAccessRepository {
+	return &ContentAccessRepository{pool: pool}
+}
+
+func (r *ContentAccessRepository) Record(ctx context.Context, contentType entity.ActivityItemType, contentID int, userPublicID string, action entity.ContentAccessAction, window time.Duration) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO content_access_events (content_type, content_id, user_id, action)
+		 SELECT $1, $2, u.id, $4
+		 FROM users u
+		 WHERE u.public_id = $3
+		   AND NOT EXISTS (
+		       SELECT 1 FROM content_access_events e
+		       WHERE e.content_type = $1 AND e.content_id = $2 AND e.user_id = u.id AND e.action = $4
+		         AND e.accessed_at > NOW() - make_interval(secs => $5)
+		   )`,
+		contentType, contentID, userPublicID, action, window.Seconds(),
+	)
+	return err
+}
+
+func (r *ContentAccessRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx,
+		`DELETE FROM content_access_events WHERE accessed_at < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (r *ContentAccessRepository) ListStats(ctx context.Context, filter repository.ContentAccessFilter, limit int) ([]entity.ContentAccessStats, error) {
+	rows, err := r.pool.Query(ctx,
+		`WITH totals AS (
+		     SELECT content_type, content_id,
+		            COUNT(*) FILTER (WHERE action = 'view') AS views,
+		            COUNT(*) FILTER (WHERE action = 'download') AS downloads,
+		            COUNT(DISTINCT user_id) AS unique_users
+		     FROM content_access_events
+		     WHERE accessed_at >= $1 AND ($2 = '' OR content_type = $2)
+		     GROUP BY content_type, content_id
+		 )
+		 SELECT t.content_type,
+		        COALESCE(h.public_id, vl.public_id, oel.public_id),
+		        COALESCE(h.title, vl.title, oel.title),
+		        t.views, t.downloads, t.unique_users
+		 FROM totals t
+		 LEFT JOIN handouts h ON t.content_type = 'handout' AND h.id = t.content_id
+		 LEFT JOIN video_lessons vl ON t.content_type = 'video_lesson' AND vl.id = t.content_id
+		 LEFT JOIN open_exercise_lists oel ON t.content_type = 'open_exercise_list' AND oel.id = t.content_id
+		 WHERE COALESCE(h.is_active, vl.is_active, oel.is_active) = true
+		 ORDER BY t.views + t.downloads DESC, t.unique_users DESC
+		 LIMIT $3`,
+		filter.Since, string(filter.ContentType), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []entity.ContentAccessStats
+	for rows.Next() {
+		var s entity.ContentAccessStats
+		if err := rows.Scan(&s.ContentType, &s.PublicID, &s.Title, &s.Views, &s.Downloads, &s.UniqueUsers); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// softDeleteContent deactivates the row of table with the given public ID.
+// With removeItems set it also deletes, in the same transaction, the
+// activity items whose itemColumn points at it. table and itemColumn are
+// always constants.
+func softDeleteContent(ctx context.Context, pool *pgxpool.Pool, table, itemColumn, publicID string, removeItems bool) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	err = tx.QueryRow(ctx,
+		fmt.Sprintf(`UPDATE %s SET is_active = false, updated_at = NOW()
+		 WHERE public_id = $1 AND is_active = true
+		 RETURNING id`, table),
+		publicID,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if removeItems {
+		_, err = tx.Exec(ctx, fmt.Sprintf(`DELETE FROM activity_items WHERE %s = $1`, itemColumn), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
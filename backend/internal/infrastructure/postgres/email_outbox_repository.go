@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+const outboxEmailSelectFields = `id, public_id, recipient, subject, html, text, status, attempts,
+		 last_error, next_attempt_at, sent_at, created_at, updated_at`
+
+type EmailOutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEmailOutboxRepository(pool *pgxpool.Pool) *EmailOutboxRepository {
+	return &EmailOutboxRepository{pool: pool}
+}
+
+func scanOutboxEmail(row pgx.Row) (*entity.OutboxEmail, error) {
+	var e entity.OutboxEmail
+	err := row.Scan(&e.ID, &e.PublicID, &e.Recipient, &e.Subject, &e.HTML, &e.Text, &e.Status, &e.Attempts,
+		&e.LastError, &e.NextAttemptAt, &e.SentAt, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *EmailOutboxRepository) Enqueue(ctx context.Context, email *entity.OutboxEmail) error {
+	return r.pool.QueryRow(ctx,
+		`INSERT INTO emails_outbox (recipient, subject, html, text)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, public_id, status, attempts, next_attempt_at, created_at, updated_at`,
+		email.Recipient, email.Subject, email.HTML, email.Text,
+	).Scan(&email.ID, &email.PublicID, &email.Status, &email.Attempts, &email.NextAttemptAt, &email.CreatedAt, &email.UpdatedAt)
+}
+
+func (r *EmailOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.OutboxEmail, error) {
+	rows, err := r.pool.Query(ctx,
+		`UPDATE emails_outbox
+		 SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $2)
+		 WHERE id IN (
+		     SELECT id FROM emails_outbox
+		     WHERE status = 'pending' AND next_attempt_at <= NOW()
+		     ORDER BY next_attempt_at
+		     LIMIT $1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+outboxEmailSelectFields,
+		limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []entity.OutboxEmail
+	for rows.Next() {
+		e, err := scanOutboxEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, *e)
+	}
+	return emails, rows.Err()
+}
+
+func (r *EmailOutboxRepository) MarkSent(ctx context.Context, id int) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE emails_outbox SET status = 'sent', sent_at = NOW(), last_error = NULL WHERE id = $1`,
+		id,
+	)
+	return err
+}
+
+func (r *EmailOutboxRepository) ScheduleRetry(ctx context.Context, id int, lastError string, nextAttemptAt time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE emails_outbox SET last_error = $2, next_attempt_at = $3 WHERE id = $1 AND status = 'pending'`,
+		id, lastError, nextAttemptAt,
+	)
+	return err
+}
+
+func (r *EmailOutboxRepository) MarkFailed(ctx context.Context, id int, lastError string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE emails_outbox SET status = 'failed', last_error = $2 WHERE id = $1`,
+		id, lastError,
+	)
+	return err
+}
+
+func (r *EmailOutboxRepository) GetByPublicID(ctx context.Context, publicID string) (*entity.OutboxEmail, error) {
+	e, err := scanOutboxEmail(r.pool.QueryRow(ctx,
+		`SELECT `+outboxEmailSelectFields+`
+		 FROM emails_outbox
+		 WHERE public_id = $1`,
+		publicID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return e, err
+}
+
+func (r *EmailOutboxRepository) ListFailed(ctx context.Context, limit, offset int) ([]entity.OutboxEmail, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+outboxEmailSelectFields+`
+		 FROM emails_outbox
+		 WHERE status = 'failed'
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT $1 OFFSET $2`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	emails := []entity.OutboxEmail{}
+	for rows.Next() {
+		e, err := scanOutboxEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, *e)
+	}
+	return emails, rows.Err()
+}
+
+func (r *EmailOutboxRepository) CountFailed(ctx context.Context) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM emails_outbox WHERE status = 'failed'`,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *EmailOutboxRepository) Requeue(ctx context.Context, id int) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE emails_outbox
+		 SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		 WHERE id = $1 AND status = 'failed'`,
+		id,
+	)
+	return err
+}
+
+func (r *EmailOutboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx,
+		`DELETE FROM emails_outbox WHERE status = 'sent' AND sent_at < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+// PostgreSQL error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeUniqueViolation     = "23505"
+	codeForeignKeyViolation = "23503"
+	codeCheckViolation      = "23514"
+	codeInvalidText         = "22P02"
+)
+
+// IsUniqueViolation reports whether err was caused by a UNIQUE constraint.
+func IsUniqueViolation(err error) bool {
+	return hasCode(err, codeUniqueViolation)
+}
+
+// IsForeignKeyViolation reports whether err was caused by a FOREIGN KEY constraint.
+func IsForeignKeyViolation(err error) bool {
+	return hasCode(err, codeForeignKeyViolation)
+}
+
+// IsInvalidUUID reports whether err was caused by a malformed value for a
+// uuid column, such as a public ID taken from the URL.
+func IsInvalidUUID(err error) bool {
+	return hasCode(err, codeInvalidText)
+}
+
+// IsCheckViolation reports whether err was caused by a CHECK constraint.
+func IsCheckViolation(err error) bool {
+	return hasCode(err, codeCheckViolation)
+}
+
+// mapConstraintError translates a constraint violation in err into the
+// application error the caller expects for it. A nil unique or foreignKey
+// leaves that violation untouched; CHECK violations always become
+// ErrInvalidInput since they mean the input escaped validation.
+func mapConstraintError(err error, unique, foreignKey *apperror.AppError) error {
+	switch {
+	case unique != nil && IsUniqueViolation(err):
+		return unique
+	case foreignKey != nil && IsForeignKeyViolation(err):
+		return foreignKey
+	case IsCheckViolation(err):
+		return apperror.ErrInvalidInput
+	}
+	return err
+}
+
+func hasCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
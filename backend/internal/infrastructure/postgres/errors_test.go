@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func TestMapConstraintError(t *testing.T) {
+	other := errors.New("connection reset")
+	tests := []struct {
+		name       string
+		err        error
+		unique     *apperror.AppError
+		foreignKey *apperror.AppError
+		want       error
+	}{
+		{"nil", nil, apperror.ErrTopicNameTaken, apperror.ErrTopicNotFound, nil},
+		{"unique violation", &pgconn.PgError{Code: codeUniqueViolation}, apperror.ErrTopicNameTaken, apperror.ErrTopicNotFound, apperror.ErrTopicNameTaken},
+		{"wrapped unique violation", fmt.Errorf("insert: %w", &pgconn.PgError{Code: codeUniqueViolation}), apperror.ErrHandoutTitleTaken, nil, apperror.ErrHandoutTitleTaken},
+		{"foreign key violation", &pgconn.PgError{Code: codeForeignKeyViolation}, apperror.ErrTopicNameTaken, apperror.ErrTopicNotFound, apperror.ErrTopicNotFound},
+		{"check violation", &pgconn.PgError{Code: codeCheckViolation}, apperror.ErrTopicNameTaken, apperror.ErrTopicNotFound, apperror.ErrInvalidInput},
+		{"other error", other, apperror.ErrTopicNameTaken, apperror.ErrTopicNotFound, other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapConstraintError(tt.err, tt.unique, tt.foreignKey); got != tt.want {
+				t.Errorf("mapConstraintError = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("unmapped foreign key violation", func(t *testing.T) {
+		err := &pgconn.PgError{Code: codeForeignKeyViolation}
+		if got := mapConstraintError(err, apperror.ErrHandoutTitleTaken, nil); got != error(err) {
+			t.Errorf("mapConstraintError = %v, want the original error", got)
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 )
@@ -21,20 +22,28 @@ func NewExamRepository(pool *pgxpool.Pool) *ExamRepository {
 }
 
 const examSelectFields = `
-e.id, e.public_id, e.institution_id, e.title, e.description, e.year,
+e.id, e.public_id, e.institution_id, e.title, e.description, e.year, e.phase,
 e.is_active, e.created_by_id, e.created_at, e.updated_at,
-i.public_id, i.name, i.acronym`
+i.public_id, i.name, i.acronym,
+COALESCE(qc.question_count, 0)`
 
 const examFromJoin = `
 FROM exams e
-JOIN institutions i ON i.id = e.institution_id`
+JOIN institutions i ON i.id = e.institution_id
+LEFT JOIN (
+	SELECT exam_id, COUNT(*) AS question_count
+	FROM questions
+	WHERE is_active = true AND exam_id IS NOT NULL
+	GROUP BY exam_id
+) qc ON qc.exam_id = e.id`
 
 func scanExam(scanner interface{ Scan(dest ...any) error }) (*entity.Exam, error) {
 	var ex entity.Exam
 	err := scanner.Scan(
-		&ex.ID, &ex.PublicID, &ex.InstitutionID, &ex.Title, &ex.Description, &ex.Year,
+		&ex.ID, &ex.PublicID, &ex.InstitutionID, &ex.Title, &ex.Description, &ex.Year, &ex.Phase,
 		&ex.IsActive, &ex.CreatedByID, &ex.CreatedAt, &ex.UpdatedAt,
 		&ex.InstitutionPublicID, &ex.InstitutionName, &ex.InstitutionAcronym,
+		&ex.QuestionCount,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -46,12 +55,13 @@ func scanExam(scanner interface{ Scan(dest ...any) error }) (*entity.Exam, error
 }
 
 func (r *ExamRepository) Create(ctx context.Context, exam *entity.Exam) error {
-	return r.pool.QueryRow(ctx,
-		`INSERT INTO exams (institution_id, title, description, year, created_by_id)
- VALUES ($1, $2, $3, $4, $5)
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO exams (institution_id, title, description, year, phase, created_by_id)
+ VALUES ($1, $2, $3, $4, $5, $6)
  RETURNING id, public_id, is_active, created_at, updated_at`,
-		exam.InstitutionID, exam.Title, exam.Description, exam.Year, exam.CreatedByID,
+		exam.InstitutionID, exam.Title, exam.Description, exam.Year, exam.Phase, exam.CreatedByID,
 	).Scan(&exam.ID, &exam.PublicID, &exam.IsActive, &exam.CreatedAt, &exam.UpdatedAt)
+	return mapConstraintError(err, apperror.ErrExamDuplicate, nil)
 }
 
 func (r *ExamRepository) GetByPublicID(ctx context.Context, publicID string) (*entity.Exam, error) {
@@ -66,11 +76,11 @@ func (r *ExamRepository) GetByPublicID(ctx context.Context, publicID string) (*e
 func (r *ExamRepository) Update(ctx context.Context, exam *entity.Exam) error {
 	_, err := r.pool.Exec(ctx,
 		`UPDATE exams
- SET institution_id = $1, title = $2, description = $3, year = $4, updated_at = NOW()
- WHERE public_id = $5 AND is_active = true`,
-		exam.InstitutionID, exam.Title, exam.Description, exam.Year, exam.PublicID,
+ SET institution_id = $1, title = $2, description = $3, year = $4, phase = $5, updated_at = NOW()
+ WHERE public_id = $6 AND is_active = true`,
+		exam.InstitutionID, exam.Title, exam.Description, exam.Year, exam.Phase, exam.PublicID,
 	)
-	return err
+	return mapConstraintError(err, apperror.ErrExamDuplicate, nil)
 }
 
 func (r *ExamRepository) Delete(ctx context.Context, publicID string) error {
@@ -115,7 +125,7 @@ func (r *ExamRepository) List(ctx context.Context, limit, offset int, filter rep
 	query := fmt.Sprintf(
 		`SELECT %s %s
  WHERE e.is_active = true%s
- ORDER BY i.name ASC, e.year DESC, e.title ASC
+ ORDER BY i.name ASC, e.year DESC, e.title ASC, e.id ASC
  LIMIT $%d OFFSET $%d`,
 		examSelectFields, examFromJoin, filterClause, len(filterArgs)+1, len(filterArgs)+2,
 	)
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"proximos-passos/backend/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type FileRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewFileRepository(pool *pgxpool.Pool) *FileRepository {
+	return &FileRepository{pool: pool}
+}
+
+func (r *FileRepository) GetByChecksum(ctx context.Context, checksum string, sizeBytes int64) (*entity.File, error) {
+	var f entity.File
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, public_id, key, filename, content_type, size_bytes, checksum, is_active, created_at
+		 FROM files
+		 WHERE checksum = $1 AND size_bytes = $2 AND is_active = true
+		 ORDER BY created_at ASC
+		 LIMIT 1`,
+		checksum, sizeBytes,
+	).Scan(&f.ID, &f.PublicID, &f.Key, &f.Filename, &f.ContentType, &f.SizeBytes, &f.Checksum, &f.IsActive, &f.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *FileRepository) CountActiveByKey(ctx context.Context, key string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM files WHERE key = $1 AND is_active = true`,
+		key,
+	).Scan(&count)
+	return count, err
+}
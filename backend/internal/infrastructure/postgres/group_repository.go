@@ -11,7 +11,6 @@ import (
 	"proximos-passos/backend/internal/domain/repository"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -31,9 +30,9 @@ func (r *GroupRepository) Create(ctx context.Context, group *entity.Group) error
 	err := r.pool.QueryRow(ctx,
 		`INSERT INTO groups (name, description, access_type, visibility_type, thumbnail_url, created_by_id)
 		 VALUES ($1, $2, $3, $4, $5, $6)
-		 RETURNING id, public_id, is_active, created_at, updated_at`,
+		 RETURNING id, public_id, time_zone, is_active, created_at, updated_at`,
 		group.Name, group.Description, group.AccessType, group.VisibilityType, group.ThumbnailURL, group.CreatedByID,
-	).Scan(&group.ID, &group.PublicID, &group.IsActive, &group.CreatedAt, &group.UpdatedAt)
+	).Scan(&group.ID, &group.PublicID, &group.TimeZone, &group.IsActive, &group.CreatedAt, &group.UpdatedAt)
 
 	if err != nil {
 		return err
@@ -46,13 +45,15 @@ func (r *GroupRepository) GetByPublicID(ctx context.Context, publicID string) (*
 	var group entity.Group
 	err := r.pool.QueryRow(ctx,
 		`SELECT id, public_id, name, description, access_type, visibility_type,
-		        thumbnail_url, is_active, created_by_id, created_at, updated_at
+		        thumbnail_url, leaderboard_enabled, leaderboard_anonymous, allow_past_due, time_zone,
+		        submission_max_bytes, submission_allowed_types, join_code, is_active, created_by_id, created_at, updated_at
 		 FROM groups
 		 WHERE public_id = $1 AND is_active = true`,
 		publicID,
 	).Scan(
 		&group.ID, &group.PublicID, &group.Name, &group.Description,
-		&group.AccessType, &group.VisibilityType, &group.ThumbnailURL,
+		&group.AccessType, &group.VisibilityType, &group.ThumbnailURL, &group.LeaderboardEnabled, &group.LeaderboardAnonymous, &group.AllowPastDue, &group.TimeZone,
+		&group.SubmissionMaxBytes, &group.SubmissionAllowedTypes, &group.JoinCode,
 		&group.IsActive, &group.CreatedByID, &group.CreatedAt, &group.UpdatedAt,
 	)
 
@@ -60,8 +61,7 @@ func (r *GroupRepository) GetByPublicID(ctx context.Context, publicID string) (*
 		return nil, nil
 	}
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "22P02" {
+		if IsInvalidUUID(err) {
 			return nil, nil
 		}
 		return nil, err
@@ -97,15 +97,36 @@ func buildGroupFilterClause(filter repository.GroupFilter, startParam int) (stri
 	return " AND " + strings.Join(conditions, " AND "), args
 }
 
+var groupSortColumns = map[string]string{
+	"created_at": "g.created_at",
+	"updated_at": "g.updated_at",
+	"name":       "g.name",
+}
+
+// memberSortColumns ranks roles explicitly so staff lead an ascending role
+// sort whatever order the member_role enum values were added in.
+var memberSortColumns = map[string]string{
+	"joined_at": "gm.joined_at",
+	"name":      "u.name",
+	"email":     "u.email",
+	"role":      "CASE gm.role WHEN 'admin' THEN 0 WHEN 'supervisor' THEN 1 ELSE 2 END",
+}
+
 func (r *GroupRepository) List(ctx context.Context, limit, offset int, filter repository.GroupFilter) ([]entity.Group, error) {
+	orderBy, err := parseSort(filter.Sort, groupSortColumns, "g.created_at DESC", "g.id")
+	if err != nil {
+		return nil, err
+	}
+
 	filterClause, filterArgs := buildGroupFilterClause(filter, 3)
 	query := fmt.Sprintf(
 		`SELECT id, public_id, name, description, access_type, visibility_type,
-		        thumbnail_url, is_active, created_by_id, created_at, updated_at
+		        thumbnail_url, leaderboard_enabled, leaderboard_anonymous, allow_past_due, time_zone,
+		        submission_max_bytes, submission_allowed_types, join_code, is_active, created_by_id, created_at, updated_at
 		 FROM groups g
 		 WHERE g.is_active = true%s
-		 ORDER BY g.created_at DESC
-		 LIMIT $1 OFFSET $2`, filterClause)
+		 ORDER BY %s
+		 LIMIT $1 OFFSET $2`, filterClause, orderBy)
 
 	args := append([]any{limit, offset}, filterArgs...)
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -119,7 +140,8 @@ func (r *GroupRepository) List(ctx context.Context, limit, offset int, filter re
 		var g entity.Group
 		if err := rows.Scan(
 			&g.ID, &g.PublicID, &g.Name, &g.Description,
-			&g.AccessType, &g.VisibilityType, &g.ThumbnailURL,
+			&g.AccessType, &g.VisibilityType, &g.ThumbnailURL, &g.LeaderboardEnabled, &g.LeaderboardAnonymous, &g.AllowPastDue, &g.TimeZone,
+			&g.SubmissionMaxBytes, &g.SubmissionAllowedTypes, &g.JoinCode,
 			&g.IsActive, &g.CreatedByID, &g.CreatedAt, &g.UpdatedAt,
 		); err != nil {
 			return nil, err
@@ -140,14 +162,20 @@ func (r *GroupRepository) Count(ctx context.Context, filter repository.GroupFilt
 }
 
 func (r *GroupRepository) ListPublic(ctx context.Context, limit, offset int, filter repository.GroupFilter) ([]entity.Group, error) {
+	orderBy, err := parseSort(filter.Sort, groupSortColumns, "g.created_at DESC", "g.id")
+	if err != nil {
+		return nil, err
+	}
+
 	filterClause, filterArgs := buildGroupFilterClause(filter, 3)
 	query := fmt.Sprintf(
 		`SELECT id, public_id, name, description, access_type, visibility_type,
-		        thumbnail_url, is_active, created_by_id, created_at, updated_at
+		        thumbnail_url, leaderboard_enabled, leaderboard_anonymous, allow_past_due, time_zone,
+		        submission_max_bytes, submission_allowed_types, join_code, is_active, created_by_id, created_at, updated_at
 		 FROM groups g
 		 WHERE g.is_active = true AND g.visibility_type = 'public'%s
-		 ORDER BY g.created_at DESC
-		 LIMIT $1 OFFSET $2`, filterClause)
+		 ORDER BY %s
+		 LIMIT $1 OFFSET $2`, filterClause, orderBy)
 
 	args := append([]any{limit, offset}, filterArgs...)
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -161,7 +189,8 @@ func (r *GroupRepository) ListPublic(ctx context.Context, limit, offset int, fil
 		var g entity.Group
 		if err := rows.Scan(
 			&g.ID, &g.PublicID, &g.Name, &g.Description,
-			&g.AccessType, &g.VisibilityType, &g.ThumbnailURL,
+			&g.AccessType, &g.VisibilityType, &g.ThumbnailURL, &g.LeaderboardEnabled, &g.LeaderboardAnonymous, &g.AllowPastDue, &g.TimeZone,
+			&g.SubmissionMaxBytes, &g.SubmissionAllowedTypes, &g.JoinCode,
 			&g.IsActive, &g.CreatedByID, &g.CreatedAt, &g.UpdatedAt,
 		); err != nil {
 			return nil, err
@@ -182,15 +211,25 @@ func (r *GroupRepository) CountPublic(ctx context.Context, filter repository.Gro
 }
 
 func (r *GroupRepository) ListByUser(ctx context.Context, userID int, limit, offset int, filter repository.GroupFilter) ([]entity.Group, error) {
+	orderBy, err := parseSort(filter.Sort, groupSortColumns, "g.created_at DESC", "g.id")
+	if err != nil {
+		return nil, err
+	}
+
 	filterClause, filterArgs := buildGroupFilterClause(filter, 4)
+	if filter.Role != "" {
+		filterArgs = append(filterArgs, filter.Role)
+		filterClause += fmt.Sprintf(" AND gm.role = $%d", len(filterArgs)+3)
+	}
 	query := fmt.Sprintf(
 		`SELECT g.id, g.public_id, g.name, g.description, g.access_type, g.visibility_type,
-		        g.thumbnail_url, g.is_active, g.created_by_id, g.created_at, g.updated_at
+		        g.thumbnail_url, g.leaderboard_enabled, g.leaderboard_anonymous, g.allow_past_due, g.time_zone,
+		        g.submission_max_bytes, g.submission_allowed_types, g.join_code, g.is_active, g.created_by_id, g.created_at, g.updated_at
 		 FROM groups g
 		 JOIN group_members gm ON gm.group_id = g.id
 		 WHERE g.is_active = true AND gm.user_id = $1 AND gm.is_active = true AND gm.accepted_by_id IS NOT NULL%s
-		 ORDER BY g.created_at DESC
-		 LIMIT $2 OFFSET $3`, filterClause)
+		 ORDER BY %s
+		 LIMIT $2 OFFSET $3`, filterClause, orderBy)
 
 	args := append([]any{userID, limit, offset}, filterArgs...)
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -204,7 +243,8 @@ func (r *GroupRepository) ListByUser(ctx context.Context, userID int, limit, off
 		var g entity.Group
 		if err := rows.Scan(
 			&g.ID, &g.PublicID, &g.Name, &g.Description,
-			&g.AccessType, &g.VisibilityType, &g.ThumbnailURL,
+			&g.AccessType, &g.VisibilityType, &g.ThumbnailURL, &g.LeaderboardEnabled, &g.LeaderboardAnonymous, &g.AllowPastDue, &g.TimeZone,
+			&g.SubmissionMaxBytes, &g.SubmissionAllowedTypes, &g.JoinCode,
 			&g.IsActive, &g.CreatedByID, &g.CreatedAt, &g.UpdatedAt,
 		); err != nil {
 			return nil, err
@@ -217,6 +257,10 @@ func (r *GroupRepository) ListByUser(ctx context.Context, userID int, limit, off
 
 func (r *GroupRepository) CountByUser(ctx context.Context, userID int, filter repository.GroupFilter) (int, error) {
 	filterClause, filterArgs := buildGroupFilterClause(filter, 2)
+	if filter.Role != "" {
+		filterArgs = append(filterArgs, filter.Role)
+		filterClause += fmt.Sprintf(" AND gm.role = $%d", len(filterArgs)+1)
+	}
 	query := fmt.Sprintf(
 		`SELECT COUNT(*) FROM groups g
 		 JOIN group_members gm ON gm.group_id = g.id
@@ -231,9 +275,11 @@ func (r *GroupRepository) CountByUser(ctx context.Context, userID int, filter re
 func (r *GroupRepository) Update(ctx context.Context, group *entity.Group) error {
 	result, err := r.pool.Exec(ctx,
 		`UPDATE groups
-		 SET name = $1, description = $2, access_type = $3, visibility_type = $4
-		 WHERE public_id = $5 AND is_active = true`,
-		group.Name, group.Description, group.AccessType, group.VisibilityType, group.PublicID,
+		 SET name = $1, description = $2, access_type = $3, visibility_type = $4,
+		     leaderboard_enabled = $5, leaderboard_anonymous = $6, allow_past_due = $7, time_zone = $8
+		 WHERE public_id = $9 AND is_active = true`,
+		group.Name, group.Description, group.AccessType, group.VisibilityType,
+		group.LeaderboardEnabled, group.LeaderboardAnonymous, group.AllowPastDue, group.TimeZone, group.PublicID,
 	)
 	if err != nil {
 		return err
@@ -246,6 +292,49 @@ func (r *GroupRepository) Update(ctx context.Context, group *entity.Group) error
 	return nil
 }
 
+func (r *GroupRepository) GetByJoinCode(ctx context.Context, code string) (*entity.Group, error) {
+	var group entity.Group
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, public_id, name, description, access_type, visibility_type,
+		        thumbnail_url, leaderboard_enabled, leaderboard_anonymous, allow_past_due, time_zone,
+		        submission_max_bytes, submission_allowed_types, join_code, is_active, created_by_id, created_at, updated_at
+		 FROM groups
+		 WHERE join_code = $1 AND is_active = true`,
+		code,
+	).Scan(
+		&group.ID, &group.PublicID, &group.Name, &group.Description,
+		&group.AccessType, &group.VisibilityType, &group.ThumbnailURL, &group.LeaderboardEnabled, &group.LeaderboardAnonymous, &group.AllowPastDue, &group.TimeZone,
+		&group.SubmissionMaxBytes, &group.SubmissionAllowedTypes, &group.JoinCode,
+		&group.IsActive, &group.CreatedByID, &group.CreatedAt, &group.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (r *GroupRepository) UpdateJoinCode(ctx context.Context, groupID int, code *string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE groups SET join_code = $1 WHERE id = $2 AND is_active = true`,
+		code, groupID,
+	)
+	return mapConstraintError(err, apperror.ErrJoinCodeTaken, nil)
+}
+
+func (r *GroupRepository) UpdateUploadSettings(ctx context.Context, groupID int, maxBytes *int64, allowedTypes []string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE groups SET submission_max_bytes = $1, submission_allowed_types = $2
+		 WHERE id = $3 AND is_active = true`,
+		maxBytes, allowedTypes, groupID,
+	)
+	return err
+}
+
 func (r *GroupRepository) UpdateThumbnail(ctx context.Context, publicID string, thumbnailURL *string) error {
 	result, err := r.pool.Exec(ctx,
 		`UPDATE groups SET thumbnail_url = $1 WHERE public_id = $2 AND is_active = true`,
@@ -283,22 +372,24 @@ func (r *GroupRepository) Delete(ctx context.Context, publicID string) error {
 // ==========================================
 
 func (r *GroupRepository) AddMember(ctx context.Context, member *entity.GroupMember) error {
+	// The primary key serializes concurrent adds: the conflicting insert
+	// waits for the first and then either revives an inactive row or, when
+	// the WHERE rejects an active one, returns nothing.
 	err := r.pool.QueryRow(ctx,
 		`INSERT INTO group_members (group_id, user_id, role, accepted_by_id, created_by_id)
 		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (group_id, user_id) DO UPDATE
+		 SET is_active = true, role = EXCLUDED.role, accepted_by_id = EXCLUDED.accepted_by_id,
+		     created_by_id = EXCLUDED.created_by_id, joined_at = NOW(), updated_at = NOW()
+		 WHERE group_members.is_active = false
 		 RETURNING joined_at, updated_at`,
 		member.GroupID, member.UserID, member.Role, member.AcceptedByID, member.CreatedByID,
 	).Scan(&member.JoinedAt, &member.UpdatedAt)
 
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return apperror.ErrMemberAlreadyExists
-		}
-		return err
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperror.ErrMemberAlreadyExists
 	}
-
-	return nil
+	return err
 }
 
 func (r *GroupRepository) GetMember(ctx context.Context, groupID, userID int) (*entity.GroupMember, error) {
@@ -347,7 +438,42 @@ func (r *GroupRepository) GetFirstAdminMember(ctx context.Context, groupID int)
 	return &m, nil
 }
 
-func (r *GroupRepository) ListMembers(ctx context.Context, groupID int, limit, offset int, role string) ([]entity.GroupMember, error) {
+func (r *GroupRepository) ListAdmins(ctx context.Context, groupID int) ([]entity.User, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT u.id, u.public_id, u.role, u.name, u.email, u.lang
+		 FROM group_members gm
+		 JOIN users u ON u.id = gm.user_id AND u.is_active = true AND u.email_verified_at IS NOT NULL
+		 WHERE gm.group_id = $1 AND gm.role = 'admin' AND gm.is_active = true AND gm.accepted_by_id IS NOT NULL
+		 ORDER BY gm.joined_at ASC`,
+		groupID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var admins []entity.User
+	for rows.Next() {
+		var u entity.User
+		if err := rows.Scan(&u.ID, &u.PublicID, &u.Role, &u.Name, &u.Email, &u.Lang); err != nil {
+			return nil, err
+		}
+		admins = append(admins, u)
+	}
+	return admins, rows.Err()
+}
+
+func (r *GroupRepository) ListMembers(ctx context.Context, groupID int, limit, offset int, role, sort string) ([]entity.GroupMember, error) {
+	tieBreaker := "gm.user_id"
+	if strings.TrimPrefix(sort, "-") == "role" {
+		// Members sharing a role are listed by name.
+		tieBreaker = "u.name, gm.user_id"
+	}
+	orderBy, err := parseSort(sort, memberSortColumns, "gm.joined_at DESC", tieBreaker)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `SELECT gm.group_id, gm.user_id, u.public_id, gm.role, gm.accepted_by_id,
 		        gm.is_active, gm.created_by_id, gm.joined_at, gm.updated_at,
 		        u.name, u.email, u.avatar_url
@@ -362,7 +488,7 @@ func (r *GroupRepository) ListMembers(ctx context.Context, groupID int, limit, o
 	}
 
 	args = append(args, limit, offset)
-	query += fmt.Sprintf(" ORDER BY gm.joined_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", orderBy, len(args)-1, len(args))
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -423,7 +549,7 @@ func (r *GroupRepository) ListPendingMembers(ctx context.Context, groupID int, l
 		 FROM group_members gm
 		 JOIN users u ON u.id = gm.user_id
 		 WHERE gm.group_id = $1 AND gm.is_active = true AND gm.accepted_by_id IS NULL
-		 ORDER BY gm.joined_at ASC
+		 ORDER BY gm.joined_at ASC, gm.user_id ASC
 		 LIMIT $2 OFFSET $3`
 
 	rows, err := r.pool.Query(ctx, query, groupID, limit, offset)
@@ -473,21 +599,29 @@ func (r *GroupRepository) ApproveMember(ctx context.Context, groupID, userID, ap
 	return nil
 }
 
-func (r *GroupRepository) ReactivateMember(ctx context.Context, groupID, userID int, acceptedByID *int) error {
-	result, err := r.pool.Exec(ctx,
-		`UPDATE group_members SET is_active = true, accepted_by_id = $1, role = 'member'
-		 WHERE group_id = $2 AND user_id = $3 AND is_active = false`,
-		acceptedByID, groupID, userID,
+func (r *GroupRepository) ApprovePendingMembers(ctx context.Context, groupID int, userPublicIDs []string, approvedByID int) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`UPDATE group_members gm SET accepted_by_id = $1
+		 FROM users u
+		 WHERE u.id = gm.user_id AND gm.group_id = $2 AND gm.is_active = true AND gm.accepted_by_id IS NULL
+		   AND ($3::text[] IS NULL OR u.public_id::text = ANY($3))
+		 RETURNING u.public_id`,
+		approvedByID, groupID, userPublicIDs,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	if result.RowsAffected() == 0 {
-		return apperror.ErrMemberNotFound
+	var approved []string
+	for rows.Next() {
+		var publicID string
+		if err := rows.Scan(&publicID); err != nil {
+			return nil, err
+		}
+		approved = append(approved, publicID)
 	}
-
-	return nil
+	return approved, rows.Err()
 }
 
 func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, userID int) error {
@@ -505,3 +639,106 @@ func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, userID int)
 
 	return nil
 }
+
+func (r *GroupRepository) ListMembershipsByUser(ctx context.Context, userID int) ([]entity.GroupMembership, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT g.public_id, g.name, gm.role
+		 FROM group_members gm
+		 JOIN groups g ON g.id = gm.group_id
+		 WHERE gm.user_id = $1 AND gm.is_active = true AND gm.accepted_by_id IS NOT NULL AND g.is_active = true
+		 ORDER BY g.name ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships := []entity.GroupMembership{}
+	for rows.Next() {
+		var m entity.GroupMembership
+		if err := rows.Scan(&m.GroupPublicID, &m.GroupName, &m.Role); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, m)
+	}
+	return memberships, rows.Err()
+}
+
+func (r *GroupRepository) ListSoleAdminGroups(ctx context.Context, userID int) ([]entity.GroupMembership, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT g.public_id, g.name, gm.role
+		 FROM group_members gm
+		 JOIN groups g ON g.id = gm.group_id
+		 WHERE gm.user_id = $1 AND gm.role = 'admin' AND gm.is_active = true AND gm.accepted_by_id IS NOT NULL
+		   AND g.is_active = true
+		   AND NOT EXISTS (
+		       SELECT 1 FROM group_members other
+		       JOIN users u ON u.id = other.user_id AND u.is_active = true
+		       WHERE other.group_id = gm.group_id AND other.user_id <> gm.user_id
+		         AND other.role = 'admin' AND other.is_active = true AND other.accepted_by_id IS NOT NULL
+		   )
+		 ORDER BY g.name ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []entity.GroupMembership{}
+	for rows.Next() {
+		var m entity.GroupMembership
+		if err := rows.Scan(&m.GroupPublicID, &m.GroupName, &m.Role); err != nil {
+			return nil, err
+		}
+		groups = append(groups, m)
+	}
+	return groups, rows.Err()
+}
+
+func (r *GroupRepository) UpsertInvitation(ctx context.Context, invitation *entity.GroupInvitation) error {
+	return r.pool.QueryRow(ctx,
+		`INSERT INTO group_invitations (group_id, email, role, invited_by_id)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (group_id, email) DO UPDATE
+		 SET role = EXCLUDED.role, invited_by_id = EXCLUDED.invited_by_id, created_at = NOW()
+		 RETURNING created_at`,
+		invitation.GroupID, invitation.Email, invitation.Role, invitation.InvitedByID,
+	).Scan(&invitation.CreatedAt)
+}
+
+func (r *GroupRepository) AcceptInvitations(ctx context.Context, userID int, email string) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// An invitation revives a removed membership and accepts a pending join
+	// request, but leaves an active membership and its role alone.
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO group_members (group_id, user_id, role, accepted_by_id, created_by_id)
+		 SELECT i.group_id, $1, i.role, i.invited_by_id, i.invited_by_id
+		 FROM group_invitations i
+		 JOIN groups g ON g.id = i.group_id AND g.is_active = true
+		 WHERE i.email = $2
+		 ON CONFLICT (group_id, user_id) DO UPDATE
+		 SET is_active = true, role = EXCLUDED.role, accepted_by_id = EXCLUDED.accepted_by_id,
+		     joined_at = NOW(), updated_at = NOW()
+		 WHERE group_members.is_active = false OR group_members.accepted_by_id IS NULL`,
+		userID, email,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM group_invitations WHERE email = $1`, email); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 )
@@ -51,7 +52,7 @@ func (r *HandoutRepository) Create(ctx context.Context, handout *entity.Handout,
 		handout.Title, handout.Description, handout.FileID, handout.CreatedByID,
 	).Scan(&handout.ID, &handout.PublicID, &handout.IsActive, &handout.CreatedAt, &handout.UpdatedAt)
 	if err != nil {
-		return err
+		return mapConstraintError(err, apperror.ErrHandoutTitleTaken, nil)
 	}
 
 	// Insert topic associations
@@ -72,15 +73,21 @@ func (r *HandoutRepository) GetByPublicID(ctx context.Context, publicID string)
 	var h entity.Handout
 	err := r.pool.QueryRow(ctx,
 		`SELECT h.id, h.public_id, h.title, h.description,
-		        h.file_id, h.is_active, h.created_by_id, h.created_at, h.updated_at,
-		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		        h.file_id,
+		        (SELECT COUNT(*) FROM content_access_events e
+		         WHERE e.content_type = 'handout' AND e.content_id = h.id AND e.action = 'download'),
+		        h.is_active, h.created_by_id, h.created_at, h.updated_at,
+		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes,
+		        cu.public_id, cu.name
 		 FROM handouts h
 		 JOIN files f ON f.id = h.file_id
+		 JOIN users cu ON cu.id = h.created_by_id
 		 WHERE h.public_id = $1 AND h.is_active = true`,
 		publicID,
 	).Scan(&h.ID, &h.PublicID, &h.Title, &h.Description,
-		&h.FileID, &h.IsActive, &h.CreatedByID, &h.CreatedAt, &h.UpdatedAt,
-		&h.FilePublicID, &h.FileKey, &h.Filename, &h.ContentType, &h.SizeBytes)
+		&h.FileID, &h.DownloadCount, &h.IsActive, &h.CreatedByID, &h.CreatedAt, &h.UpdatedAt,
+		&h.FilePublicID, &h.FileKey, &h.Filename, &h.ContentType, &h.SizeBytes,
+		&h.CreatedByPublicID, &h.CreatedByName)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -105,7 +112,7 @@ func (r *HandoutRepository) Update(ctx context.Context, handout *entity.Handout)
 		 WHERE public_id = $3 AND is_active = true`,
 		handout.Title, handout.Description, handout.PublicID,
 	)
-	return err
+	return mapConstraintError(err, apperror.ErrHandoutTitleTaken, nil)
 }
 
 func (r *HandoutRepository) ReplaceFile(ctx context.Context, handoutID int, newFileID int) error {
@@ -170,13 +177,8 @@ func (r *HandoutRepository) SetTopics(ctx context.Context, handoutID int, topicI
 	return tx.Commit(ctx)
 }
 
-func (r *HandoutRepository) Delete(ctx context.Context, publicID string) error {
-	_, err := r.pool.Exec(ctx,
-		`UPDATE handouts SET is_active = false, updated_at = NOW()
-		 WHERE public_id = $1 AND is_active = true`,
-		publicID,
-	)
-	return err
+func (r *HandoutRepository) Delete(ctx context.Context, publicID string, removeItems bool) error {
+	return softDeleteContent(ctx, r.pool, "handouts", "handout_id", publicID, removeItems)
 }
 
 func (r *HandoutRepository) List(ctx context.Context, limit, offset int, filter repository.HandoutFilter) ([]entity.Handout, error) {
@@ -184,12 +186,17 @@ func (r *HandoutRepository) List(ctx context.Context, limit, offset int, filter
 
 	query := fmt.Sprintf(
 		`SELECT h.id, h.public_id, h.title, h.description,
-		        h.file_id, h.is_active, h.created_by_id, h.created_at, h.updated_at,
-		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		        h.file_id,
+		        (SELECT COUNT(*) FROM content_access_events e
+		         WHERE e.content_type = 'handout' AND e.content_id = h.id AND e.action = 'download'),
+		        h.is_active, h.created_by_id, h.created_at, h.updated_at,
+		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes,
+		        cu.public_id, cu.name
 		 FROM handouts h
 		 JOIN files f ON f.id = h.file_id
+		 JOIN users cu ON cu.id = h.created_by_id
 		 WHERE h.is_active = true%s
-		 ORDER BY h.created_at DESC
+		 ORDER BY h.created_at DESC, h.id DESC
 		 LIMIT $%d OFFSET $%d`,
 		filterClause, len(filterArgs)+1, len(filterArgs)+2,
 	)
@@ -205,8 +212,9 @@ func (r *HandoutRepository) List(ctx context.Context, limit, offset int, filter
 	for rows.Next() {
 		var h entity.Handout
 		if err := rows.Scan(&h.ID, &h.PublicID, &h.Title, &h.Description,
-			&h.FileID, &h.IsActive, &h.CreatedByID, &h.CreatedAt, &h.UpdatedAt,
-			&h.FilePublicID, &h.FileKey, &h.Filename, &h.ContentType, &h.SizeBytes); err != nil {
+			&h.FileID, &h.DownloadCount, &h.IsActive, &h.CreatedByID, &h.CreatedAt, &h.UpdatedAt,
+			&h.FilePublicID, &h.FileKey, &h.Filename, &h.ContentType, &h.SizeBytes,
+			&h.CreatedByPublicID, &h.CreatedByName); err != nil {
 			return nil, err
 		}
 		handouts = append(handouts, h)
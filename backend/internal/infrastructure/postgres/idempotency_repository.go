@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type IdempotencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(pool *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{pool: pool}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, userPublicID, key, route string, expiresAt time.Time) (bool, error) {
+	// An expired record is taken over as if it did not exist.
+	tag, err := r.pool.Exec(ctx,
+		`INSERT INTO idempotency_keys (user_id, idempotency_key, route, expires_at)
+		 SELECT id, $2, $3, $4 FROM users WHERE public_id = $1
+		 ON CONFLICT (user_id, idempotency_key, route) DO UPDATE
+		 SET request_hash = NULL, status_code = NULL, content_type = NULL, location = NULL, response_body = NULL,
+		     created_at = NOW(), expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at <= NOW()`,
+		userPublicID, key, route, expiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, userPublicID, key, route string) (*entity.IdempotencyRecord, error) {
+	var rec entity.IdempotencyRecord
+	var contentType, location *string
+	err := r.pool.QueryRow(ctx,
+		`SELECT ik.id, ik.user_id, ik.idempotency_key, ik.route, ik.request_hash, ik.status_code,
+		        ik.content_type, ik.location, ik.response_body, ik.created_at, ik.expires_at
+		 FROM idempotency_keys ik
+		 JOIN users u ON u.id = ik.user_id
+		 WHERE u.public_id = $1 AND ik.idempotency_key = $2 AND ik.route = $3
+		   AND ik.expires_at > NOW()`,
+		userPublicID, key, route,
+	).Scan(&rec.ID, &rec.UserID, &rec.Key, &rec.Route, &rec.RequestHash, &rec.StatusCode,
+		&contentType, &location, &rec.ResponseBody, &rec.CreatedAt, &rec.ExpiresAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if contentType != nil {
+		rec.ContentType = *contentType
+	}
+	if location != nil {
+		rec.Location = *location
+	}
+	return &rec, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, userPublicID, key, route string, requestHash []byte, statusCode int, contentType, location string, body []byte) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE idempotency_keys ik
+		 SET request_hash = $4, status_code = $5, content_type = $6, location = NULLIF($7, ''), response_body = $8
+		 FROM users u
+		 WHERE u.id = ik.user_id AND u.public_id = $1
+		   AND ik.idempotency_key = $2 AND ik.route = $3`,
+		userPublicID, key, route, requestHash, statusCode, contentType, location, body,
+	)
+	return err
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, userPublicID, key, route string) error {
+	_, err := r.pool.Exec(ctx,
+		`DELETE FROM idempotency_keys ik
+		 USING users u
+		 WHERE u.id = ik.user_id AND u.public_id = $1
+		   AND ik.idempotency_key = $2 AND ik.route = $3`,
+		userPublicID, key, route,
+	)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
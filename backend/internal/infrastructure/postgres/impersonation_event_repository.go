@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type ImpersonationEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImpersonationEventRepository(pool *pgxpool.Pool) *ImpersonationEventRepository {
+	return &ImpersonationEventRepository{pool: pool}
+}
+
+func (r *ImpersonationEventRepository) Create(ctx context.Context, e *entity.ImpersonationEvent) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO impersonation_events (admin_id, target_user_id, action, ip_address)
+		 VALUES ($1, $2, $3, NULLIF($4, ''))`,
+		e.AdminID, e.TargetUserID, e.Action, e.IPAddress)
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 )
@@ -21,22 +22,23 @@ func NewInstitutionRepository(pool *pgxpool.Pool) *InstitutionRepository {
 }
 
 func (r *InstitutionRepository) Create(ctx context.Context, institution *entity.Institution) error {
-	return r.pool.QueryRow(ctx,
+	err := r.pool.QueryRow(ctx,
 		`INSERT INTO institutions (name, acronym, created_by_id)
 		 VALUES ($1, $2, $3)
 		 RETURNING id, public_id, is_active, created_at, updated_at`,
 		institution.Name, institution.Acronym, institution.CreatedByID,
 	).Scan(&institution.ID, &institution.PublicID, &institution.IsActive, &institution.CreatedAt, &institution.UpdatedAt)
+	return mapConstraintError(err, apperror.ErrInstitutionNameTaken, nil)
 }
 
 func (r *InstitutionRepository) GetByPublicID(ctx context.Context, publicID string) (*entity.Institution, error) {
 	var i entity.Institution
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, public_id, name, acronym, is_active, created_by_id, created_at, updated_at
+		`SELECT id, public_id, name, acronym, logo_url, is_active, created_by_id, created_at, updated_at
 		 FROM institutions
 		 WHERE public_id = $1 AND is_active = true`,
 		publicID,
-	).Scan(&i.ID, &i.PublicID, &i.Name, &i.Acronym, &i.IsActive, &i.CreatedByID, &i.CreatedAt, &i.UpdatedAt)
+	).Scan(&i.ID, &i.PublicID, &i.Name, &i.Acronym, &i.LogoURL, &i.IsActive, &i.CreatedByID, &i.CreatedAt, &i.UpdatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -50,11 +52,11 @@ func (r *InstitutionRepository) GetByPublicID(ctx context.Context, publicID stri
 func (r *InstitutionRepository) GetByID(ctx context.Context, id int) (*entity.Institution, error) {
 	var i entity.Institution
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, public_id, name, acronym, is_active, created_by_id, created_at, updated_at
+		`SELECT id, public_id, name, acronym, logo_url, is_active, created_by_id, created_at, updated_at
 		 FROM institutions
 		 WHERE id = $1 AND is_active = true`,
 		id,
-	).Scan(&i.ID, &i.PublicID, &i.Name, &i.Acronym, &i.IsActive, &i.CreatedByID, &i.CreatedAt, &i.UpdatedAt)
+	).Scan(&i.ID, &i.PublicID, &i.Name, &i.Acronym, &i.LogoURL, &i.IsActive, &i.CreatedByID, &i.CreatedAt, &i.UpdatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -72,7 +74,23 @@ func (r *InstitutionRepository) Update(ctx context.Context, institution *entity.
 		 WHERE public_id = $3 AND is_active = true`,
 		institution.Name, institution.Acronym, institution.PublicID,
 	)
-	return err
+	return mapConstraintError(err, apperror.ErrInstitutionNameTaken, nil)
+}
+
+func (r *InstitutionRepository) UpdateLogo(ctx context.Context, publicID string, logoURL *string) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE institutions SET logo_url = $1, updated_at = NOW() WHERE public_id = $2 AND is_active = true`,
+		logoURL, publicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrInstitutionNotFound
+	}
+
+	return nil
 }
 
 func (r *InstitutionRepository) Delete(ctx context.Context, publicID string) error {
@@ -102,10 +120,10 @@ func (r *InstitutionRepository) List(ctx context.Context, limit, offset int, fil
 	filterClause, filterArgs := buildInstitutionFilterClause(filter)
 
 	query := fmt.Sprintf(
-		`SELECT id, public_id, name, acronym, is_active, created_by_id, created_at, updated_at
+		`SELECT id, public_id, name, acronym, logo_url, is_active, created_by_id, created_at, updated_at
 		 FROM institutions i
 		 WHERE i.is_active = true%s
-		 ORDER BY i.name ASC
+		 ORDER BY i.name ASC, i.id ASC
 		 LIMIT $%d OFFSET $%d`,
 		filterClause, len(filterArgs)+1, len(filterArgs)+2,
 	)
@@ -120,7 +138,7 @@ func (r *InstitutionRepository) List(ctx context.Context, limit, offset int, fil
 	var institutions []entity.Institution
 	for rows.Next() {
 		var i entity.Institution
-		if err := rows.Scan(&i.ID, &i.PublicID, &i.Name, &i.Acronym, &i.IsActive, &i.CreatedByID, &i.CreatedAt, &i.UpdatedAt); err != nil {
+		if err := rows.Scan(&i.ID, &i.PublicID, &i.Name, &i.Acronym, &i.LogoURL, &i.IsActive, &i.CreatedByID, &i.CreatedAt, &i.UpdatedAt); err != nil {
 			return nil, err
 		}
 		institutions = append(institutions, i)
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 )
@@ -52,7 +53,7 @@ func (r *OpenExerciseListRepository) Create(ctx context.Context, oel *entity.Ope
 		oel.Title, oel.Description, oel.FileID, oel.FileURL, oel.CreatedByID,
 	).Scan(&oel.ID, &oel.PublicID, &oel.IsActive, &oel.CreatedAt, &oel.UpdatedAt)
 	if err != nil {
-		return err
+		return mapConstraintError(err, apperror.ErrOpenExerciseListTitleTaken, nil)
 	}
 
 	// Insert topic associations
@@ -76,16 +77,21 @@ func (r *OpenExerciseListRepository) GetByPublicID(ctx context.Context, publicID
 	err := r.pool.QueryRow(ctx,
 		`SELECT oel.id, oel.public_id, oel.title, oel.description,
 		        oel.file_id, oel.file_url,
+		        (SELECT COUNT(*) FROM content_access_events e
+		         WHERE e.content_type = 'open_exercise_list' AND e.content_id = oel.id AND e.action = 'download'),
 		        oel.is_active, oel.created_by_id, oel.created_at, oel.updated_at,
-		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes,
+		        cu.public_id, cu.name
 		 FROM open_exercise_lists oel
 		 LEFT JOIN files f ON f.id = oel.file_id
+		 JOIN users cu ON cu.id = oel.created_by_id
 		 WHERE oel.public_id = $1 AND oel.is_active = true`,
 		publicID,
 	).Scan(&oel.ID, &oel.PublicID, &oel.Title, &oel.Description,
-		&oel.FileID, &oel.FileURL,
+		&oel.FileID, &oel.FileURL, &oel.DownloadCount,
 		&oel.IsActive, &oel.CreatedByID, &oel.CreatedAt, &oel.UpdatedAt,
-		&filePublicID, &fileKey, &filename, &contentType, &sizeBytes)
+		&filePublicID, &fileKey, &filename, &contentType, &sizeBytes,
+		&oel.CreatedByPublicID, &oel.CreatedByName)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -118,7 +124,7 @@ func (r *OpenExerciseListRepository) Update(ctx context.Context, oel *entity.Ope
 		 WHERE public_id = $4 AND is_active = true`,
 		oel.Title, oel.Description, oel.FileURL, oel.PublicID,
 	)
-	return err
+	return mapConstraintError(err, apperror.ErrOpenExerciseListTitleTaken, nil)
 }
 
 func (r *OpenExerciseListRepository) ReplaceFile(ctx context.Context, oelID int, newFileID int) error {
@@ -183,13 +189,8 @@ func (r *OpenExerciseListRepository) SetTopics(ctx context.Context, oelID int, t
 	return tx.Commit(ctx)
 }
 
-func (r *OpenExerciseListRepository) Delete(ctx context.Context, publicID string) error {
-	_, err := r.pool.Exec(ctx,
-		`UPDATE open_exercise_lists SET is_active = false, updated_at = NOW()
-		 WHERE public_id = $1 AND is_active = true`,
-		publicID,
-	)
-	return err
+func (r *OpenExerciseListRepository) Delete(ctx context.Context, publicID string, removeItems bool) error {
+	return softDeleteContent(ctx, r.pool, "open_exercise_lists", "open_exercise_list_id", publicID, removeItems)
 }
 
 func (r *OpenExerciseListRepository) List(ctx context.Context, limit, offset int, filter repository.OpenExerciseListFilter) ([]entity.OpenExerciseList, error) {
@@ -198,12 +199,16 @@ func (r *OpenExerciseListRepository) List(ctx context.Context, limit, offset int
 	query := fmt.Sprintf(
 		`SELECT oel.id, oel.public_id, oel.title, oel.description,
 		        oel.file_id, oel.file_url,
+		        (SELECT COUNT(*) FROM content_access_events e
+		         WHERE e.content_type = 'open_exercise_list' AND e.content_id = oel.id AND e.action = 'download'),
 		        oel.is_active, oel.created_by_id, oel.created_at, oel.updated_at,
-		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes,
+		        cu.public_id, cu.name
 		 FROM open_exercise_lists oel
 		 LEFT JOIN files f ON f.id = oel.file_id
+		 JOIN users cu ON cu.id = oel.created_by_id
 		 WHERE oel.is_active = true%s
-		 ORDER BY oel.created_at DESC
+		 ORDER BY oel.created_at DESC, oel.id DESC
 		 LIMIT $%d OFFSET $%d`,
 		filterClause, len(filterArgs)+1, len(filterArgs)+2,
 	)
@@ -221,9 +226,10 @@ func (r *OpenExerciseListRepository) List(ctx context.Context, limit, offset int
 		var filePublicID, fileKey, filename, contentType *string
 		var sizeBytes *int64
 		if err := rows.Scan(&oel.ID, &oel.PublicID, &oel.Title, &oel.Description,
-			&oel.FileID, &oel.FileURL,
+			&oel.FileID, &oel.FileURL, &oel.DownloadCount,
 			&oel.IsActive, &oel.CreatedByID, &oel.CreatedAt, &oel.UpdatedAt,
-			&filePublicID, &fileKey, &filename, &contentType, &sizeBytes); err != nil {
+			&filePublicID, &fileKey, &filename, &contentType, &sizeBytes,
+			&oel.CreatedByPublicID, &oel.CreatedByName); err != nil {
 			return nil, err
 		}
 		if filePublicID != nil {
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"io"
+
+	"proximos-passos/backend/internal/infrastructure/metrics"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolCollector reports connection pool saturation from pgxpool.Stat.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	return &PoolCollector{pool: pool}
+}
+
+func (c *PoolCollector) Collect(w io.Writer) {
+	stat := c.pool.Stat()
+
+	metrics.WriteGauge(w, "pgxpool_acquired_conns", "Connections currently checked out of the pool.", float64(stat.AcquiredConns()))
+	metrics.WriteGauge(w, "pgxpool_idle_conns", "Idle connections in the pool.", float64(stat.IdleConns()))
+	metrics.WriteGauge(w, "pgxpool_total_conns", "Total connections owned by the pool.", float64(stat.TotalConns()))
+	metrics.WriteGauge(w, "pgxpool_max_conns", "Maximum size of the pool.", float64(stat.MaxConns()))
+	metrics.WriteCounter(w, "pgxpool_acquire_count_total", "Successful connection acquisitions.", float64(stat.AcquireCount()))
+	metrics.WriteCounter(w, "pgxpool_empty_acquire_count_total", "Acquisitions that had to wait because the pool was empty.", float64(stat.EmptyAcquireCount()))
+	metrics.WriteCounter(w, "pgxpool_canceled_acquire_count_total", "Acquisitions canceled by their context.", float64(stat.CanceledAcquireCount()))
+	metrics.WriteCounter(w, "pgxpool_acquire_duration_seconds_total", "Total time spent acquiring connections.", stat.AcquireDuration().Seconds())
+}
@@ -37,10 +37,10 @@ func (r *QuestionRepository) Create(ctx context.Context, q *entity.Question, top
 		var fileID int
 		var filePublicID string
 		err = tx.QueryRow(ctx,
-			`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id)
-			 VALUES ($1, $2, $3, $4, $5)
+			`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id, webp_key)
+			 VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
 			 RETURNING id, public_id`,
-			img.FileKey, img.Filename, img.ContentType, img.SizeBytes, q.CreatedByID,
+			img.FileKey, img.Filename, img.ContentType, img.SizeBytes, q.CreatedByID, img.WebPKey,
 		).Scan(&fileID, &filePublicID)
 		if err != nil {
 			return err
@@ -52,10 +52,10 @@ func (r *QuestionRepository) Create(ctx context.Context, q *entity.Question, top
 
 	// Insert the question record
 	err = tx.QueryRow(ctx,
-		`INSERT INTO questions (type, statement, expected_answer_text, passing_score, exam_id, created_by_id)
-		 VALUES ($1, $2, $3, $4, $5, $6)
+		`INSERT INTO questions (type, statement, expected_answer_text, expected_keywords, passing_score, max_attempts, difficulty, exam_id, created_by_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		 RETURNING id, public_id, is_active, created_at, updated_at`,
-		q.Type, q.Statement, q.ExpectedAnswerText, q.PassingScore, q.ExamID, q.CreatedByID,
+		q.Type, q.Statement, q.ExpectedAnswerText, keywordsOrEmpty(q.ExpectedKeywords), q.PassingScore, q.MaxAttempts, q.Difficulty, q.ExamID, q.CreatedByID,
 	).Scan(&q.ID, &q.PublicID, &q.IsActive, &q.CreatedAt, &q.UpdatedAt)
 	if err != nil {
 		return err
@@ -83,6 +83,17 @@ func (r *QuestionRepository) Create(ctx context.Context, q *entity.Question, top
 		}
 	}
 
+	// Insert tags
+	for _, tag := range q.Tags {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO question_tags (question_id, tag) VALUES ($1, $2)`,
+			q.ID, tag,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Insert options
 	for i := range q.Options {
 		opt := &q.Options[i]
@@ -106,10 +117,10 @@ func (r *QuestionRepository) Create(ctx context.Context, q *entity.Question, top
 			var fid int
 			var fpid string
 			err = tx.QueryRow(ctx,
-				`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id)
-				 VALUES ($1, $2, $3, $4, $5)
+				`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id, webp_key)
+				 VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
 				 RETURNING id, public_id`,
-				img.FileKey, img.Filename, img.ContentType, img.SizeBytes, q.CreatedByID,
+				img.FileKey, img.Filename, img.ContentType, img.SizeBytes, q.CreatedByID, img.WebPKey,
 			).Scan(&fid, &fpid)
 			if err != nil {
 				return err
@@ -136,8 +147,9 @@ func (r *QuestionRepository) GetByPublicID(ctx context.Context, publicID string)
 	var examYear *int
 	err := r.pool.QueryRow(ctx,
 		`SELECT q.id, q.public_id, q.type, q.statement,
-		        q.expected_answer_text, q.passing_score, q.exam_id,
+		        q.expected_answer_text, q.expected_keywords, q.passing_score, q.max_attempts, q.difficulty, q.exam_id, q.archived_at,
 		        q.is_active, q.created_by_id, q.created_at, q.updated_at,
+		        cu.public_id, cu.name,
 		        e.public_id, e.title, e.year, i.name, i.acronym,
 				(
 					SELECT PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY (qf.difficulty_logic + qf.difficulty_labor + qf.difficulty_theory)/3.0)
@@ -156,13 +168,15 @@ func (r *QuestionRepository) GetByPublicID(ctx context.Context, publicID string)
 					FROM question_feedbacks qf WHERE qf.question_id = q.id AND qf.is_active = true
 				) as median_theory
 		 FROM questions q
+		 JOIN users cu ON cu.id = q.created_by_id
 		 LEFT JOIN exams e ON e.id = q.exam_id AND e.is_active = true
 		 LEFT JOIN institutions i ON i.id = e.institution_id AND i.is_active = true
 		 WHERE q.public_id = $1 AND q.is_active = true`,
 		publicID,
 	).Scan(&q.ID, &q.PublicID, &q.Type, &q.Statement,
-		&q.ExpectedAnswerText, &q.PassingScore, &q.ExamID,
+		&q.ExpectedAnswerText, &q.ExpectedKeywords, &q.PassingScore, &q.MaxAttempts, &q.Difficulty, &q.ExamID, &q.ArchivedAt,
 		&q.IsActive, &q.CreatedByID, &q.CreatedAt, &q.UpdatedAt,
+		&q.CreatedByPublicID, &q.CreatedByName,
 		&examPublicID, &examTitle, &examYear, &examInstitution, &examInstitutionAcronym, &q.MedianDifficulty, &q.MedianLogic, &q.MedianLabor, &q.MedianTheory)
 
 	if examPublicID != nil {
@@ -200,6 +214,12 @@ func (r *QuestionRepository) GetByPublicID(ctx context.Context, publicID string)
 	}
 	q.Topics = topics
 
+	tags, err := r.loadTags(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	q.Tags = tags
+
 	options, err := r.loadOptions(ctx, q.ID)
 	if err != nil {
 		return nil, err
@@ -209,16 +229,163 @@ func (r *QuestionRepository) GetByPublicID(ctx context.Context, publicID string)
 	return &q, nil
 }
 
-func (r *QuestionRepository) Update(ctx context.Context, q *entity.Question) error {
-	_, err := r.pool.Exec(ctx,
+func (r *QuestionRepository) Update(ctx context.Context, q *entity.Question, changes repository.QuestionChanges) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx,
 		`UPDATE questions
-		 SET type = $1, statement = $2, expected_answer_text = $3, passing_score = $4, exam_id = $5, updated_at = NOW()
-		 WHERE public_id = $6 AND is_active = true`,
-		q.Type, q.Statement, q.ExpectedAnswerText, q.PassingScore, q.ExamID, q.PublicID,
+		 SET type = $1, statement = $2, expected_answer_text = $3, expected_keywords = $4, passing_score = $5, max_attempts = $6, difficulty = $7, exam_id = $8, updated_at = NOW()
+		 WHERE public_id = $9 AND is_active = true`,
+		q.Type, q.Statement, q.ExpectedAnswerText, keywordsOrEmpty(q.ExpectedKeywords), q.PassingScore, q.MaxAttempts, q.Difficulty, q.ExamID, q.PublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if changes.TopicIDs != nil {
+		if err := replaceQuestionTopics(ctx, tx, q.ID, changes.TopicIDs); err != nil {
+			return err
+		}
+	}
+	if changes.Tags != nil {
+		if err := replaceQuestionTags(ctx, tx, q.ID, changes.Tags); err != nil {
+			return err
+		}
+	}
+	if changes.Options != nil {
+		if err := replaceQuestionOptions(ctx, tx, q.ID, changes.Options, q.CreatedByID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func replaceQuestionTopics(ctx context.Context, tx pgx.Tx, questionID int, topicIDs []int) error {
+	_, err := tx.Exec(ctx, `DELETE FROM question_topics WHERE question_id = $1`, questionID)
+	if err != nil {
+		return err
+	}
+
+	for _, topicID := range topicIDs {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO question_topics (question_id, topic_id) VALUES ($1, $2)`,
+			questionID, topicID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceQuestionTags(ctx context.Context, tx pgx.Tx, questionID int, tags []string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM question_tags WHERE question_id = $1`, questionID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO question_tags (question_id, tag) VALUES ($1, $2)`,
+			questionID, tag,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceQuestionOptions(ctx context.Context, tx pgx.Tx, questionID int, options []entity.QuestionOption, createdByID int) error {
+	var previousFileIDs []int
+	err := tx.QueryRow(ctx,
+		`SELECT COALESCE(array_agg(qoi.image_file_id), '{}')
+		 FROM question_option_images qoi
+		 JOIN question_options qo ON qo.id = qoi.question_option_id
+		 WHERE qo.question_id = $1`,
+		questionID,
+	).Scan(&previousFileIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM question_options WHERE question_id = $1`, questionID)
+	if err != nil {
+		return err
+	}
+
+	for i := range options {
+		opt := &options[i]
+
+		var optID int
+		err = tx.QueryRow(ctx,
+			`INSERT INTO question_options (question_id, original_order, text, is_correct, created_by_id)
+			 VALUES ($1, $2, $3, $4, $5)
+			 RETURNING id`,
+			questionID, opt.OriginalOrder, opt.Text, opt.IsCorrect, createdByID,
+		).Scan(&optID)
+		if err != nil {
+			return err
+		}
+		opt.ID = optID
+
+		// Insert option images
+		for j := range opt.Images {
+			img := &opt.Images[j]
+
+			var fileID int
+			if img.FileKey != "" {
+				// New upload — create file record
+				err = tx.QueryRow(ctx,
+					`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id, webp_key)
+					 VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+					 RETURNING id`,
+					img.FileKey, img.Filename, img.ContentType, img.SizeBytes, createdByID, img.WebPKey,
+				).Scan(&fileID)
+				if err != nil {
+					return err
+				}
+			} else if img.FileID > 0 {
+				// Existing image — re-link
+				fileID = img.FileID
+			} else {
+				continue
+			}
+
+			_, err = tx.Exec(ctx,
+				`INSERT INTO question_option_images (question_option_id, image_file_id) VALUES ($1, $2)`,
+				optID, fileID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Image files no option links to any more are retired; the caller
+	// deletes their objects once the transaction commits.
+	_, err = tx.Exec(ctx,
+		`UPDATE files SET is_active = false, updated_at = NOW()
+		 WHERE id = ANY($1) AND is_active = true
+		   AND NOT EXISTS (SELECT 1 FROM question_option_images qoi WHERE qoi.image_file_id = files.id)`,
+		previousFileIDs,
 	)
 	return err
 }
 
+// keywordsOrEmpty avoids writing NULL into the NOT NULL expected_keywords array.
+func keywordsOrEmpty(keywords []string) []string {
+	if keywords == nil {
+		return []string{}
+	}
+	return keywords
+}
+
 func (r *QuestionRepository) AddImages(ctx context.Context, questionID int, q *entity.Question, uploadedByID int) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -233,10 +400,10 @@ func (r *QuestionRepository) AddImages(ctx context.Context, questionID int, q *e
 		}
 		var fileID int
 		err = tx.QueryRow(ctx,
-			`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id)
-			 VALUES ($1, $2, $3, $4, $5)
+			`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id, webp_key)
+			 VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
 			 RETURNING id`,
-			img.FileKey, img.Filename, img.ContentType, img.SizeBytes, uploadedByID,
+			img.FileKey, img.Filename, img.ContentType, img.SizeBytes, uploadedByID, img.WebPKey,
 		).Scan(&fileID)
 		if err != nil {
 			return err
@@ -272,47 +439,66 @@ func (r *QuestionRepository) RemoveImage(ctx context.Context, questionID int, fi
 	return err
 }
 
-func (r *QuestionRepository) SetTopics(ctx context.Context, questionID int, topicIDs []int) error {
+func (r *QuestionRepository) DeleteMany(ctx context.Context, questionIDs []int, removeItems bool) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback(ctx)
 
-	_, err = tx.Exec(ctx, `DELETE FROM question_topics WHERE question_id = $1`, questionID)
-	if err != nil {
-		return err
-	}
-
-	for _, topicID := range topicIDs {
-		_, err = tx.Exec(ctx,
-			`INSERT INTO question_topics (question_id, topic_id) VALUES ($1, $2)`,
-			questionID, topicID,
-		)
+	if removeItems {
+		_, err = tx.Exec(ctx, `DELETE FROM activity_items WHERE question_id = ANY($1)`, questionIDs)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM simulated_exam_questions WHERE question_id = ANY($1)`, questionIDs)
 		if err != nil {
 			return err
 		}
 	}
 
+	_, err = tx.Exec(ctx,
+		`UPDATE questions SET is_active = false, updated_at = NOW()
+		 WHERE id = ANY($1) AND is_active = true`,
+		questionIDs,
+	)
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
-func (r *QuestionRepository) Delete(ctx context.Context, publicID string) error {
+func (r *QuestionRepository) SetArchived(ctx context.Context, questionID int, archived bool) error {
 	_, err := r.pool.Exec(ctx,
-		`UPDATE questions SET is_active = false, updated_at = NOW()
-		 WHERE public_id = $1 AND is_active = true`,
-		publicID,
+		`UPDATE questions
+		 SET archived_at = CASE WHEN $2 THEN COALESCE(archived_at, NOW()) END, updated_at = NOW()
+		 WHERE id = $1 AND is_active = true`,
+		questionID, archived,
 	)
 	return err
 }
 
+var questionSortColumns = map[string]string{
+	"created_at": "q.created_at",
+	"updated_at": "q.updated_at",
+	"statement":  "q.statement",
+	"type":       "q.type",
+}
+
 func (r *QuestionRepository) List(ctx context.Context, limit, offset int, filter repository.QuestionFilter) ([]entity.Question, error) {
+	orderBy, err := parseSort(filter.Sort, questionSortColumns, "q.created_at DESC", "q.id")
+	if err != nil {
+		return nil, err
+	}
+
 	filterClause, filterArgs := buildQuestionFilterClause(filter)
 
 	query := fmt.Sprintf(
 		`SELECT q.id, q.public_id, q.type, q.statement,
-		        q.expected_answer_text, q.passing_score, q.exam_id,
+		        q.expected_answer_text, q.expected_keywords, q.passing_score, q.max_attempts, q.difficulty, q.exam_id, q.archived_at,
 		        q.is_active, q.created_by_id, q.created_at, q.updated_at,
+		        cu.public_id, cu.name,
 		        e.public_id, e.title, e.year, i.name, i.acronym,
 				(
 					SELECT PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY (qf.difficulty_logic + qf.difficulty_labor + qf.difficulty_theory)/3.0)
@@ -331,12 +517,13 @@ func (r *QuestionRepository) List(ctx context.Context, limit, offset int, filter
 					FROM question_feedbacks qf WHERE qf.question_id = q.id AND qf.is_active = true
 				) as median_theory
 		 FROM questions q
+		 JOIN users cu ON cu.id = q.created_by_id
 		 LEFT JOIN exams e ON e.id = q.exam_id AND e.is_active = true
 		 LEFT JOIN institutions i ON i.id = e.institution_id AND i.is_active = true
 		 WHERE q.is_active = true%s
-		 ORDER BY q.created_at DESC
+		 ORDER BY %s
 		 LIMIT $%d OFFSET $%d`,
-		filterClause, len(filterArgs)+1, len(filterArgs)+2,
+		filterClause, orderBy, len(filterArgs)+1, len(filterArgs)+2,
 	)
 
 	args := append(filterArgs, limit, offset)
@@ -352,8 +539,9 @@ func (r *QuestionRepository) List(ctx context.Context, limit, offset int, filter
 		var examPublicID, examTitle, examInstitution, examInstitutionAcronym *string
 		var examYear *int
 		if err := rows.Scan(&q.ID, &q.PublicID, &q.Type, &q.Statement,
-			&q.ExpectedAnswerText, &q.PassingScore, &q.ExamID,
+			&q.ExpectedAnswerText, &q.ExpectedKeywords, &q.PassingScore, &q.MaxAttempts, &q.Difficulty, &q.ExamID, &q.ArchivedAt,
 			&q.IsActive, &q.CreatedByID, &q.CreatedAt, &q.UpdatedAt,
+			&q.CreatedByPublicID, &q.CreatedByName,
 			&examPublicID, &examTitle, &examYear, &examInstitution, &examInstitutionAcronym, &q.MedianDifficulty, &q.MedianLogic, &q.MedianLabor, &q.MedianTheory); err != nil {
 			return nil, err
 		}
@@ -386,6 +574,12 @@ func (r *QuestionRepository) List(ctx context.Context, limit, offset int, filter
 		}
 		questions[i].Topics = topics
 
+		tags, err := r.loadTags(ctx, questions[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		questions[i].Tags = tags
+
 		images, err := r.loadImages(ctx, questions[i].ID)
 		if err != nil {
 			return nil, err
@@ -402,6 +596,35 @@ func (r *QuestionRepository) List(ctx context.Context, limit, offset int, filter
 	return questions, nil
 }
 
+// FindSimilar returns active questions whose normalized statement equals
+// normalizedStatement or reaches minSimilarity under pg_trgm, best match first.
+func (r *QuestionRepository) FindSimilar(ctx context.Context, normalizedStatement string, minSimilarity float64, limit int) ([]entity.QuestionDuplicate, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT public_id, statement, similarity(normalize_statement(statement), $1) AS sim
+		 FROM questions
+		 WHERE is_active = true
+		   AND (normalize_statement(statement) = $1
+		        OR (normalize_statement(statement) % $1 AND similarity(normalize_statement(statement), $1) >= $2))
+		 ORDER BY sim DESC
+		 LIMIT $3`,
+		normalizedStatement, minSimilarity, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entity.QuestionDuplicate
+	for rows.Next() {
+		var d entity.QuestionDuplicate
+		if err := rows.Scan(&d.PublicID, &d.Statement, &d.Similarity); err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, rows.Err()
+}
+
 func (r *QuestionRepository) Count(ctx context.Context, filter repository.QuestionFilter) (int, error) {
 	filterClause, filterArgs := buildQuestionFilterClause(filter)
 
@@ -418,7 +641,10 @@ func (r *QuestionRepository) Count(ctx context.Context, filter repository.Questi
 }
 
 func buildQuestionFilterClause(filter repository.QuestionFilter) (string, []any) {
-	clause := ""
+	clause := " AND q.archived_at IS NULL"
+	if filter.Archived {
+		clause = " AND q.archived_at IS NOT NULL"
+	}
 	args := []any{}
 	argIdx := 1
 
@@ -440,6 +666,18 @@ func buildQuestionFilterClause(filter repository.QuestionFilter) (string, []any)
 		argIdx++
 	}
 
+	if filter.Difficulty != "" {
+		clause += fmt.Sprintf(" AND q.difficulty = $%d", argIdx)
+		args = append(args, filter.Difficulty)
+		argIdx++
+	}
+
+	if filter.Tag != "" {
+		clause += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM question_tags qtg WHERE qtg.question_id = q.id AND qtg.tag = $%d)", argIdx)
+		args = append(args, filter.Tag)
+		argIdx++
+	}
+
 	if filter.ExamID != nil {
 		clause += fmt.Sprintf(" AND q.exam_id = $%d", argIdx)
 		args = append(args, *filter.ExamID)
@@ -480,75 +718,25 @@ func (r *QuestionRepository) loadTopics(ctx context.Context, questionID int) ([]
 	return topics, rows.Err()
 }
 
-func (r *QuestionRepository) SetOptions(ctx context.Context, questionID int, options []entity.QuestionOption, createdByID int) error {
-	tx, err := r.pool.Begin(ctx)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	_, err = tx.Exec(ctx, `DELETE FROM question_options WHERE question_id = $1`, questionID)
-	if err != nil {
-		return err
-	}
-
-	for i := range options {
-		opt := &options[i]
-
-		var optID int
-		err = tx.QueryRow(ctx,
-			`INSERT INTO question_options (question_id, original_order, text, is_correct, created_by_id)
-			 VALUES ($1, $2, $3, $4, $5)
-			 RETURNING id`,
-			questionID, opt.OriginalOrder, opt.Text, opt.IsCorrect, createdByID,
-		).Scan(&optID)
-		if err != nil {
-			return err
-		}
-		opt.ID = optID
-
-		// Insert option images
-		for j := range opt.Images {
-			img := &opt.Images[j]
-
-			var fileID int
-			if img.FileKey != "" {
-				// New upload — create file record
-				err = tx.QueryRow(ctx,
-					`INSERT INTO files (key, filename, content_type, size_bytes, uploaded_by_id)
-					 VALUES ($1, $2, $3, $4, $5)
-					 RETURNING id`,
-					img.FileKey, img.Filename, img.ContentType, img.SizeBytes, createdByID,
-				).Scan(&fileID)
-				if err != nil {
-					return err
-				}
-			} else if img.FileID > 0 {
-				// Existing image — re-link
-				fileID = img.FileID
-			} else {
-				continue
-			}
-
-			_, err = tx.Exec(ctx,
-				`INSERT INTO question_option_images (question_option_id, image_file_id) VALUES ($1, $2)`,
-				optID, fileID,
-			)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	_, err = tx.Exec(ctx,
-		`UPDATE questions SET updated_at = NOW() WHERE id = $1`,
+func (r *QuestionRepository) loadTags(ctx context.Context, questionID int) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT tag FROM question_tags WHERE question_id = $1 ORDER BY tag ASC`,
 		questionID,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return tx.Commit(ctx)
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
 }
 
 func (r *QuestionRepository) loadOptions(ctx context.Context, questionID int) ([]entity.QuestionOption, error) {
@@ -592,7 +780,7 @@ func (r *QuestionRepository) loadOptions(ctx context.Context, questionID int) ([
 
 func (r *QuestionRepository) loadOptionImages(ctx context.Context, optionID int) ([]entity.QuestionImage, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		`SELECT f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, COALESCE(f.webp_key, '')
 		 FROM question_option_images qoi
 		 JOIN files f ON f.id = qoi.image_file_id
 		 WHERE qoi.question_option_id = $1 AND f.is_active = true
@@ -607,7 +795,7 @@ func (r *QuestionRepository) loadOptionImages(ctx context.Context, optionID int)
 	var images []entity.QuestionImage
 	for rows.Next() {
 		var img entity.QuestionImage
-		if err := rows.Scan(&img.FileID, &img.FilePublicID, &img.FileKey, &img.Filename, &img.ContentType, &img.SizeBytes); err != nil {
+		if err := rows.Scan(&img.FileID, &img.FilePublicID, &img.FileKey, &img.Filename, &img.ContentType, &img.SizeBytes, &img.WebPKey); err != nil {
 			return nil, err
 		}
 		images = append(images, img)
@@ -617,7 +805,7 @@ func (r *QuestionRepository) loadOptionImages(ctx context.Context, optionID int)
 
 func (r *QuestionRepository) loadImages(ctx context.Context, questionID int) ([]entity.QuestionImage, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		`SELECT f.id, f.public_id, f.key, f.filename, f.content_type, f.size_bytes, COALESCE(f.webp_key, '')
 		 FROM question_images qi
 		 JOIN files f ON f.id = qi.image_file_id
 		 WHERE qi.question_id = $1 AND f.is_active = true
@@ -632,7 +820,7 @@ func (r *QuestionRepository) loadImages(ctx context.Context, questionID int) ([]
 	var images []entity.QuestionImage
 	for rows.Next() {
 		var img entity.QuestionImage
-		if err := rows.Scan(&img.FileID, &img.FilePublicID, &img.FileKey, &img.Filename, &img.ContentType, &img.SizeBytes); err != nil {
+		if err := rows.Scan(&img.FileID, &img.FilePublicID, &img.FileKey, &img.Filename, &img.ContentType, &img.SizeBytes, &img.WebPKey); err != nil {
 			return nil, err
 		}
 		images = append(images, img)
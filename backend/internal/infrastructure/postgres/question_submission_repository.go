@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
 )
 
 type QuestionSubmissionRepository struct {
@@ -19,25 +22,87 @@ func NewQuestionSubmissionRepository(pool *pgxpool.Pool) *QuestionSubmissionRepo
 	return &QuestionSubmissionRepository{pool: pool}
 }
 
-func (r *QuestionSubmissionRepository) Create(ctx context.Context, s *entity.QuestionSubmission) error {
-	return r.pool.QueryRow(ctx,
+func (r *QuestionSubmissionRepository) Create(ctx context.Context, s *entity.QuestionSubmission, maxAttempts *int) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if s.ActivitySubmissionID != nil {
+		// Serializes attempts within the activity submission until commit.
+		if _, err := tx.Exec(ctx,
+			`SELECT 1 FROM activity_submissions WHERE id = $1 FOR UPDATE`,
+			*s.ActivitySubmissionID,
+		); err != nil {
+			return err
+		}
+
+		if maxAttempts != nil {
+			var attempts int
+			var passed bool
+			err := tx.QueryRow(ctx,
+				`SELECT COUNT(*), COALESCE(BOOL_OR(passed), false)
+				 FROM question_submissions
+				 WHERE activity_submission_id = $1 AND question_id = $2 AND is_active = true`,
+				*s.ActivitySubmissionID, s.QuestionID,
+			).Scan(&attempts, &passed)
+			if err != nil {
+				return err
+			}
+			if entity.AttemptLimitReached(maxAttempts, attempts, passed) {
+				return apperror.ErrAttemptLimitReached
+			}
+		}
+	}
+
+	// The start is consumed inside the transaction so a failed insert keeps
+	// it for the retry. NOW() is the transaction time, which also becomes
+	// submitted_at.
+	var startedAt, submittedAt time.Time
+	err = tx.QueryRow(ctx,
+		`DELETE FROM question_attempt_starts
+		 WHERE user_id = $1 AND question_id = $2
+		 RETURNING started_at, NOW()`,
+		s.UserID, s.QuestionID,
+	).Scan(&startedAt, &submittedAt)
+	switch {
+	case err == nil:
+		duration := entity.AnswerDurationSeconds(startedAt, submittedAt)
+		s.StartedAt = &startedAt
+		s.DurationSeconds = &duration
+	case !errors.Is(err, pgx.ErrNoRows):
+		return err
+	}
+
+	err = tx.QueryRow(ctx,
 		`INSERT INTO question_submissions
-			(question_id, user_id, activity_submission_id, simulated_exam_id, question_option_id, answer_text, score, answer_feedback, passed)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			(question_id, user_id, activity_submission_id, simulated_exam_id, question_option_id, answer_text, score, answer_feedback, passed,
+			 started_at, duration_seconds)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		 RETURNING id, public_id, is_active, submitted_at, updated_at`,
 		s.QuestionID, s.UserID, s.ActivitySubmissionID, s.SimulatedExamID,
 		s.QuestionOptionID, s.AnswerText, s.Score, s.AnswerFeedback, s.Passed,
+		s.StartedAt, s.DurationSeconds,
 	).Scan(&s.ID, &s.PublicID, &s.IsActive, &s.SubmittedAt, &s.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 const submissionSelectFields = `
 	qs.id, qs.public_id, qs.question_id, qs.user_id,
 	qs.activity_submission_id, qs.simulated_exam_id,
 	qs.question_option_id, qs.answer_text, qs.score, qs.answer_feedback,
-	qs.passed, qs.is_active, qs.submitted_at, qs.updated_at,
-	q.public_id, q.type, q.statement,
+	qs.passed, qs.started_at, qs.duration_seconds, qs.attachment_file_id,
+	qs.reviewed_at, qs.reviewed_by_id,
+	qs.is_active, qs.submitted_at, qs.updated_at,
+	q.public_id, q.type, q.statement, q.max_attempts, q.expected_keywords,
 	u.public_id, u.name,
-	COALESCE(qo.public_id::text, ''), COALESCE(qo.text, ''), COALESCE(qo.is_correct, false)
+	COALESCE(qo.public_id::text, ''), COALESCE(qo.text, ''), COALESCE(qo.is_correct, false),
+	af.public_id, af.key, af.filename, af.content_type, af.size_bytes, af.checksum
 `
 
 const submissionFromJoins = `
@@ -45,19 +110,26 @@ const submissionFromJoins = `
 	JOIN questions q ON q.id = qs.question_id
 	JOIN users u ON u.id = qs.user_id
 	LEFT JOIN question_options qo ON qo.id = qs.question_option_id
+	LEFT JOIN files af ON af.id = qs.attachment_file_id AND af.is_active = true
 `
 
 func scanSubmission(row pgx.Row) (*entity.QuestionSubmission, error) {
 	var s entity.QuestionSubmission
 	var optText string
+	var filePublicID, fileKey, filename, contentType *string
+	var sizeBytes *int64
+	var checksum *string
 	err := row.Scan(
 		&s.ID, &s.PublicID, &s.QuestionID, &s.UserID,
 		&s.ActivitySubmissionID, &s.SimulatedExamID,
 		&s.QuestionOptionID, &s.AnswerText, &s.Score, &s.AnswerFeedback,
-		&s.Passed, &s.IsActive, &s.SubmittedAt, &s.UpdatedAt,
-		&s.QuestionPublicID, &s.QuestionType, &s.QuestionStatement,
+		&s.Passed, &s.StartedAt, &s.DurationSeconds, &s.AttachmentFileID,
+		&s.ReviewedAt, &s.ReviewedByID,
+		&s.IsActive, &s.SubmittedAt, &s.UpdatedAt,
+		&s.QuestionPublicID, &s.QuestionType, &s.QuestionStatement, &s.QuestionMaxAttempts, &s.QuestionKeywords,
 		&s.UserPublicID, &s.UserName,
 		&s.OptionPublicID, &optText, &s.OptionIsCorrect,
+		&filePublicID, &fileKey, &filename, &contentType, &sizeBytes, &checksum,
 	)
 	if err != nil {
 		return nil, err
@@ -65,6 +137,17 @@ func scanSubmission(row pgx.Row) (*entity.QuestionSubmission, error) {
 	if optText != "" {
 		s.OptionText = &optText
 	}
+	if s.AttachmentFileID != nil && filePublicID != nil {
+		s.Attachment = &entity.QuestionSubmissionAttachment{
+			FileID:       *s.AttachmentFileID,
+			FilePublicID: *filePublicID,
+			Key:          *fileKey,
+			Filename:     *filename,
+			ContentType:  *contentType,
+			SizeBytes:    *sizeBytes,
+			Checksum:     checksum,
+		}
+	}
 	return &s, nil
 }
 
@@ -94,7 +177,7 @@ func (r *QuestionSubmissionRepository) ListByUser(ctx context.Context, userID in
 		argIdx++
 	}
 
-	query += fmt.Sprintf(` ORDER BY qs.submitted_at DESC LIMIT $%d OFFSET $%d`, argIdx, argIdx+1)
+	query += fmt.Sprintf(` ORDER BY qs.submitted_at DESC, qs.id DESC LIMIT $%d OFFSET $%d`, argIdx, argIdx+1)
 	args = append(args, limit, offset)
 
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -133,7 +216,7 @@ func (r *QuestionSubmissionRepository) ListByQuestion(ctx context.Context, quest
 	rows, err := r.pool.Query(ctx,
 		`SELECT `+submissionSelectFields+submissionFromJoins+`
 		 WHERE qs.question_id = $1 AND qs.is_active = true
-		 ORDER BY qs.submitted_at DESC
+		 ORDER BY qs.submitted_at DESC, qs.id DESC
 		 LIMIT $2 OFFSET $3`, questionID, limit, offset)
 	if err != nil {
 		return nil, err
@@ -158,11 +241,96 @@ func (r *QuestionSubmissionRepository) CountByQuestion(ctx context.Context, ques
 	return count, err
 }
 
-func (r *QuestionSubmissionRepository) ListByActivitySubmission(ctx context.Context, activitySubmissionID int) ([]entity.QuestionSubmission, error) {
+func (r *QuestionSubmissionRepository) ListByActivitySubmission(ctx context.Context, activitySubmissionID int, limit, offset int, filter repository.QuestionSubmissionFilter) ([]entity.QuestionSubmission, error) {
+	filterClause, args := buildQuestionSubmissionFilterClause(activitySubmissionID, filter)
+	query := fmt.Sprintf(
+		`SELECT `+submissionSelectFields+submissionFromJoins+`
+		 WHERE qs.activity_submission_id = $1 AND qs.is_active = true%s
+		 ORDER BY qs.submitted_at DESC, qs.id DESC
+		 LIMIT $%d OFFSET $%d`,
+		filterClause, len(args)+1, len(args)+2,
+	)
+
+	rows, err := r.pool.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entity.QuestionSubmission
+	for rows.Next() {
+		s, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *s)
+	}
+	return result, rows.Err()
+}
+
+func (r *QuestionSubmissionRepository) CountByActivitySubmission(ctx context.Context, activitySubmissionID int, filter repository.QuestionSubmissionFilter) (int, error) {
+	filterClause, args := buildQuestionSubmissionFilterClause(activitySubmissionID, filter)
+	query := fmt.Sprintf(
+		`SELECT COUNT(*)
+		 FROM question_submissions qs
+		 JOIN questions q ON q.id = qs.question_id
+		 WHERE qs.activity_submission_id = $1 AND qs.is_active = true%s`,
+		filterClause,
+	)
+
+	var count int
+	err := r.pool.QueryRow(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// buildQuestionSubmissionFilterClause returns the filter conditions for
+// attempts under an activity submission; $1 is always the submission ID.
+func buildQuestionSubmissionFilterClause(activitySubmissionID int, filter repository.QuestionSubmissionFilter) (string, []any) {
+	clause := ""
+	args := []any{activitySubmissionID}
+	argIdx := 2
+
+	if filter.QuestionPublicID != "" {
+		clause += fmt.Sprintf(" AND q.public_id::text = $%d", argIdx)
+		args = append(args, filter.QuestionPublicID)
+		argIdx++
+	}
+
+	if filter.Passed != nil {
+		clause += fmt.Sprintf(" AND qs.passed = $%d", argIdx)
+		args = append(args, *filter.Passed)
+		argIdx++
+	}
+
+	return clause, args
+}
+
+func (r *QuestionSubmissionRepository) ListAllByActivitySubmission(ctx context.Context, activitySubmissionID int) ([]entity.QuestionSubmission, error) {
 	rows, err := r.pool.Query(ctx,
 		`SELECT `+submissionSelectFields+submissionFromJoins+`
 		 WHERE qs.activity_submission_id = $1 AND qs.is_active = true
-		 ORDER BY qs.submitted_at DESC`, activitySubmissionID)
+		 ORDER BY qs.submitted_at DESC, qs.id DESC`, activitySubmissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entity.QuestionSubmission
+	for rows.Next() {
+		s, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *s)
+	}
+	return result, rows.Err()
+}
+
+func (r *QuestionSubmissionRepository) ListByActivitySubmissionAndQuestion(ctx context.Context, activitySubmissionID, questionID int) ([]entity.QuestionSubmission, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+submissionSelectFields+submissionFromJoins+`
+		 WHERE qs.activity_submission_id = $1 AND qs.question_id = $2 AND qs.is_active = true
+		 ORDER BY qs.submitted_at DESC, qs.id DESC`, activitySubmissionID, questionID)
 	if err != nil {
 		return nil, err
 	}
@@ -178,3 +346,81 @@ func (r *QuestionSubmissionRepository) ListByActivitySubmission(ctx context.Cont
 	}
 	return result, rows.Err()
 }
+
+func (r *QuestionSubmissionRepository) StartAttempt(ctx context.Context, userID, questionID int) (time.Time, error) {
+	var startedAt time.Time
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO question_attempt_starts (user_id, question_id)
+		 VALUES ($1, $2)
+		 ON CONFLICT (user_id, question_id) DO UPDATE SET started_at = NOW()
+		 RETURNING started_at`,
+		userID, questionID,
+	).Scan(&startedAt)
+	return startedAt, err
+}
+
+func (r *QuestionSubmissionRepository) GetTimeStats(ctx context.Context, questionID int) (*entity.QuestionTimeStats, error) {
+	stats := entity.QuestionTimeStats{QuestionID: questionID}
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(duration_seconds), AVG(duration_seconds)::float8
+		 FROM question_submissions
+		 WHERE question_id = $1 AND is_active = true`,
+		questionID,
+	).Scan(&stats.TimedAttempts, &stats.AverageDurationSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *QuestionSubmissionRepository) SetAttachment(ctx context.Context, submissionID int, attachment *entity.QuestionSubmissionAttachment, uploadedByID int) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO files (key, filename, content_type, size_bytes, checksum, uploaded_by_id)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id, public_id`,
+		attachment.Key, attachment.Filename, attachment.ContentType, attachment.SizeBytes, attachment.Checksum, uploadedByID,
+	).Scan(&attachment.FileID, &attachment.FilePublicID)
+	if err != nil {
+		return err
+	}
+
+	var previousFileID *int
+	err = tx.QueryRow(ctx,
+		`UPDATE question_submissions qs
+		 SET attachment_file_id = $2, updated_at = NOW()
+		 FROM question_submissions prev
+		 WHERE prev.id = qs.id AND qs.id = $1
+		 RETURNING prev.attachment_file_id`,
+		submissionID, attachment.FileID,
+	).Scan(&previousFileID)
+	if err != nil {
+		return err
+	}
+
+	if previousFileID != nil {
+		if _, err := tx.Exec(ctx,
+			`UPDATE files SET is_active = false, updated_at = NOW() WHERE id = $1`, *previousFileID,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *QuestionSubmissionRepository) Review(ctx context.Context, s *entity.QuestionSubmission) error {
+	return r.pool.QueryRow(ctx,
+		`UPDATE question_submissions
+		 SET score = $2, passed = $3, answer_feedback = $4, reviewed_by_id = $5,
+		     reviewed_at = NOW(), updated_at = NOW()
+		 WHERE id = $1 AND is_active = true
+		 RETURNING reviewed_at, updated_at`,
+		s.ID, s.Score, s.Passed, s.AnswerFeedback, s.ReviewedByID,
+	).Scan(&s.ReviewedAt, &s.UpdatedAt)
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type RecommendationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRecommendationRepository(pool *pgxpool.Pool) *RecommendationRepository {
+	return &RecommendationRepository{pool: pool}
+}
+
+func (r *RecommendationRepository) ListForTopic(ctx context.Context, userID int, topicPublicID string, limit int) ([]entity.Recommendation, error) {
+	rows, err := r.pool.Query(ctx,
+		`WITH RECURSIVE tree AS (
+		     SELECT id FROM topics WHERE public_id = $1 AND is_active = true
+		     UNION ALL
+		     SELECT t.id FROM topics t JOIN tree ON t.parent_id = tree.id
+		     WHERE t.is_active = true
+		 )
+		 (SELECT 'question', q.public_id, left(q.statement, 200), q.created_at
+		  FROM questions q
+		  WHERE q.is_active = true AND q.archived_at IS NULL
+		    AND EXISTS (SELECT 1 FROM question_topics qt WHERE qt.question_id = q.id AND qt.topic_id IN (SELECT id FROM tree))
+		    AND NOT EXISTS (SELECT 1 FROM question_submissions qs
+		                    WHERE qs.question_id = q.id AND qs.user_id = $2 AND qs.is_active = true AND qs.passed)
+		  UNION ALL
+		  SELECT 'video_lesson', vl.public_id, vl.title, vl.created_at
+		  FROM video_lessons vl
+		  WHERE vl.is_active = true
+		    AND EXISTS (SELECT 1 FROM video_lesson_topics vlt WHERE vlt.video_lesson_id = vl.id AND vlt.topic_id IN (SELECT id FROM tree))
+		    AND NOT EXISTS (SELECT 1 FROM content_access_events e
+		                    WHERE e.content_type = 'video_lesson' AND e.content_id = vl.id AND e.user_id = $2)
+		  UNION ALL
+		  SELECT 'handout', h.public_id, h.title, h.created_at
+		  FROM handouts h
+		  WHERE h.is_active = true
+		    AND EXISTS (SELECT 1 FROM handout_topics ht WHERE ht.handout_id = h.id AND ht.topic_id IN (SELECT id FROM tree))
+		    AND NOT EXISTS (SELECT 1 FROM content_access_events e
+		                    WHERE e.content_type = 'handout' AND e.content_id = h.id AND e.user_id = $2))
+		 ORDER BY 4 DESC, 2 ASC
+		 LIMIT $3`,
+		topicPublicID, userID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entity.Recommendation
+	for rows.Next() {
+		var rec entity.Recommendation
+		if err := rows.Scan(&rec.ContentType, &rec.PublicID, &rec.Title, &rec.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, rec)
+	}
+	return result, rows.Err()
+}
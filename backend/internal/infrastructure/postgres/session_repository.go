@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type SessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSessionRepository(pool *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{pool: pool}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, s *entity.Session) error {
+	return r.pool.QueryRow(ctx,
+		`INSERT INTO sessions (user_id, impersonator_session_id, user_agent, ip_address, expires_at)
+		 VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5)
+		 RETURNING id, public_id, created_at, last_seen_at`,
+		s.UserID, s.ImpersonatorSessionID, s.UserAgent, s.IPAddress, s.ExpiresAt,
+	).Scan(&s.ID, &s.PublicID, &s.CreatedAt, &s.LastSeenAt)
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, publicID string) (bool, error) {
+	var active bool
+	err := r.pool.QueryRow(ctx,
+		`WITH touched AS (
+		     UPDATE sessions SET last_seen_at = NOW()
+		     WHERE public_id::text = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		       AND last_seen_at < NOW() - INTERVAL '1 minute'
+		 )
+		 SELECT EXISTS (
+		     SELECT 1 FROM sessions
+		     WHERE public_id::text = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		 )`,
+		publicID,
+	).Scan(&active)
+	return active, err
+}
+
+func (r *SessionRepository) GetActive(ctx context.Context, publicID string) (*entity.Session, error) {
+	return r.getActive(ctx, `public_id::text = $1`, publicID)
+}
+
+func (r *SessionRepository) GetActiveByID(ctx context.Context, id int) (*entity.Session, error) {
+	return r.getActive(ctx, `id = $1`, id)
+}
+
+func (r *SessionRepository) getActive(ctx context.Context, where string, arg any) (*entity.Session, error) {
+	var s entity.Session
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, public_id, user_id, impersonator_session_id, COALESCE(user_agent, ''), COALESCE(ip_address, ''),
+		        created_at, last_seen_at, expires_at, revoked_at
+		 FROM sessions
+		 WHERE `+where+` AND revoked_at IS NULL AND expires_at > NOW()`,
+		arg,
+	).Scan(&s.ID, &s.PublicID, &s.UserID, &s.ImpersonatorSessionID, &s.UserAgent, &s.IPAddress,
+		&s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SessionRepository) ListActiveByUser(ctx context.Context, userID int) ([]entity.Session, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, public_id, user_id, COALESCE(user_agent, ''), COALESCE(ip_address, ''),
+		        created_at, last_seen_at, expires_at, revoked_at
+		 FROM sessions
+		 WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		 ORDER BY last_seen_at DESC, id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []entity.Session
+	for rows.Next() {
+		var s entity.Session
+		if err := rows.Scan(&s.ID, &s.PublicID, &s.UserID, &s.UserAgent, &s.IPAddress,
+			&s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, publicID string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW()
+		 WHERE public_id::text = $1 AND revoked_at IS NULL`,
+		publicID,
+	)
+	return err
+}
+
+func (r *SessionRepository) RevokeForUser(ctx context.Context, userID int, publicID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW()
+		 WHERE user_id = $1 AND public_id::text = $2
+		   AND revoked_at IS NULL AND expires_at > NOW()`,
+		userID, publicID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *SessionRepository) RevokeAllByUser(ctx context.Context, userID int) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW()
+		 WHERE user_id = $1 AND revoked_at IS NULL`,
+		userID,
+	)
+	return err
+}
+
+func (r *SessionRepository) RevokeOthersByUser(ctx context.Context, userID int, keepPublicID string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW()
+		 WHERE user_id = $1 AND public_id::text <> $2 AND revoked_at IS NULL`,
+		userID, keepPublicID,
+	)
+	return err
+}
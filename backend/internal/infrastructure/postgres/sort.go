@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"strings"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+// parseSort maps a client-supplied sort key such as "name" or "-created_at" to
+// an ORDER BY expression. Only columns present in allowed are ever written into
+// the query; an empty key yields fallback and unknown keys are rejected.
+// tieBreaker names a unique column appended in the same direction, so rows
+// sharing a sort value keep a stable order across pages.
+func parseSort(sort string, allowed map[string]string, fallback, tieBreaker string) (string, error) {
+	if sort == "" {
+		direction := "ASC"
+		if strings.HasSuffix(fallback, " DESC") {
+			direction = "DESC"
+		}
+		return fallback + ", " + tieBreaker + " " + direction, nil
+	}
+
+	direction := "ASC"
+	key := sort
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		key = sort[1:]
+	}
+
+	column, ok := allowed[key]
+	if !ok {
+		return "", apperror.ErrInvalidInput
+	}
+	return column + " " + direction + ", " + tieBreaker + " " + direction, nil
+}
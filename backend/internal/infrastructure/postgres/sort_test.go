@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func TestParseSort(t *testing.T) {
+	allowed := map[string]string{
+		"title":      "q.title",
+		"created_at": "q.created_at",
+	}
+	tests := []struct {
+		name     string
+		sort     string
+		fallback string
+		want     string
+		wantErr  error
+	}{
+		{"ascending", "title", "q.created_at DESC", "q.title ASC, q.id ASC", nil},
+		{"descending", "-title", "q.created_at DESC", "q.title DESC, q.id DESC", nil},
+		{"fallback descending", "", "q.created_at DESC", "q.created_at DESC, q.id DESC", nil},
+		{"fallback ascending", "", "q.title", "q.title, q.id ASC", nil},
+		{"unknown key", "difficulty", "q.created_at DESC", "", apperror.ErrInvalidInput},
+		{"raw column name", "q.title", "q.created_at DESC", "", apperror.ErrInvalidInput},
+		{"injection attempt", "title; DROP TABLE questions", "q.created_at DESC", "", apperror.ErrInvalidInput},
+		{"descending injection attempt", "-title\"; DROP TABLE questions; --", "q.created_at DESC", "", apperror.ErrInvalidInput},
+		{"bare minus", "-", "q.created_at DESC", "", apperror.ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSort(tt.sort, allowed, tt.fallback, "q.id")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSort(%q) = %q, want %q", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type StatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStatsRepository(pool *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{pool: pool}
+}
+
+func (r *StatsRepository) GroupStats(ctx context.Context, groupID int) (*entity.GroupStats, error) {
+	stats := &entity.GroupStats{
+		MembersByRole: map[entity.MemberRole]int{
+			entity.MemberRoleAdmin:      0,
+			entity.MemberRoleSupervisor: 0,
+			entity.MemberRoleMember:     0,
+		},
+		SubmissionsByStatus: map[entity.ActivitySubmissionStatus]int{
+			entity.ActivitySubmissionStatusCreated:  0,
+			entity.ActivitySubmissionStatusPending:  0,
+			entity.ActivitySubmissionStatusApproved: 0,
+			entity.ActivitySubmissionStatusReproved: 0,
+		},
+	}
+
+	// Accepted members per role; requests awaiting approval are counted apart.
+	rows, err := r.pool.Query(ctx,
+		`SELECT role,
+		        COUNT(*) FILTER (WHERE accepted_by_id IS NOT NULL),
+		        COUNT(*) FILTER (WHERE accepted_by_id IS NULL)
+		 FROM group_members
+		 WHERE group_id = $1 AND is_active = true
+		 GROUP BY role`,
+		groupID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var role entity.MemberRole
+		var accepted, pending int
+		if err := rows.Scan(&role, &accepted, &pending); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		stats.MembersByRole[role] = accepted
+		stats.PendingRequests += pending
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FILTER (WHERE due_date >= NOW()),
+		        COUNT(*) FILTER (WHERE due_date < NOW())
+		 FROM activities
+		 WHERE group_id = $1 AND is_active = true`,
+		groupID,
+	).Scan(&stats.ActiveActivities, &stats.PastActivities)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = r.pool.Query(ctx,
+		`SELECT asub.status, COUNT(*)
+		 FROM activity_submissions asub
+		 JOIN activities a ON a.id = asub.activity_id
+		 WHERE a.group_id = $1 AND a.is_active = true AND asub.is_active = true
+		 GROUP BY asub.status`,
+		groupID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var status entity.ActivitySubmissionStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		stats.SubmissionsByStatus[status] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = r.pool.QueryRow(ctx,
+		`SELECT AVG(EXTRACT(EPOCH FROM (asub.reviewed_at - asub.submitted_at)))::float8
+		 FROM activity_submissions asub
+		 JOIN activities a ON a.id = asub.activity_id
+		 WHERE a.group_id = $1 AND a.is_active = true AND asub.is_active = true
+		   AND asub.reviewed_at IS NOT NULL`,
+		groupID,
+	).Scan(&stats.AvgReviewTurnaroundSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+var leaderboardMetricColumns = map[string]string{
+	"passed": "passed_questions",
+	"score":  "total_score",
+}
+
+func (r *StatsRepository) GroupLeaderboard(ctx context.Context, groupID int, since *time.Time, metric string) ([]entity.LeaderboardEntry, error) {
+	column, ok := leaderboardMetricColumns[metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard metric %q", metric)
+	}
+
+	// Each question counts once per member: passed if any attempt passed,
+	// scored by the best attempt.
+	query := fmt.Sprintf(
+		`WITH per_question AS (
+		     SELECT qs.user_id, qs.question_id, bool_or(qs.passed) AS passed, MAX(qs.score) AS best_score
+		     FROM question_submissions qs
+		     JOIN activity_submissions asub ON asub.id = qs.activity_submission_id
+		     JOIN activities a ON a.id = asub.activity_id
+		     WHERE a.group_id = $1 AND a.is_active = true AND qs.is_active = true
+		       AND ($2::timestamptz IS NULL OR qs.submitted_at >= $2)
+		     GROUP BY qs.user_id, qs.question_id
+		 ), totals AS (
+		     SELECT user_id,
+		            COUNT(*) FILTER (WHERE passed) AS passed_questions,
+		            COALESCE(SUM(best_score), 0) AS total_score
+		     FROM per_question
+		     GROUP BY user_id
+		 )
+		 SELECT RANK() OVER (ORDER BY COALESCE(t.%[1]s, 0) DESC),
+		        u.id, u.public_id, u.name, u.avatar_url,
+		        COALESCE(t.passed_questions, 0), COALESCE(t.total_score, 0)
+		 FROM group_members gm
+		 JOIN users u ON u.id = gm.user_id
+		 LEFT JOIN totals t ON t.user_id = gm.user_id
+		 WHERE gm.group_id = $1 AND gm.is_active = true AND gm.accepted_by_id IS NOT NULL
+		   AND gm.role = 'member'
+		 ORDER BY COALESCE(t.%[1]s, 0) DESC, u.name ASC, u.id ASC`,
+		column,
+	)
+
+	rows, err := r.pool.Query(ctx, query, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []entity.LeaderboardEntry
+	for rows.Next() {
+		var e entity.LeaderboardEntry
+		if err := rows.Scan(&e.Rank, &e.UserID, &e.UserPublicID, &e.UserName, &e.UserAvatarURL, &e.PassedQuestions, &e.TotalScore); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *StatsRepository) TopicMastery(ctx context.Context, userID int) ([]entity.TopicMastery, error) {
+	// UNION rather than UNION ALL: a submission whose question sits in two
+	// sibling topics counts once for their shared parent.
+	rows, err := r.pool.Query(ctx,
+		`WITH RECURSIVE lineage AS (
+		     SELECT qs.id AS submission_id, qt.topic_id
+		     FROM question_submissions qs
+		     JOIN question_topics qt ON qt.question_id = qs.question_id
+		     WHERE qs.user_id = $1 AND qs.is_active = true
+		     UNION
+		     SELECT l.submission_id, t.parent_id
+		     FROM lineage l
+		     JOIN topics t ON t.id = l.topic_id
+		     WHERE t.parent_id IS NOT NULL
+		 )
+		 SELECT t.public_id, t.name, p.public_id,
+		        COUNT(*), COUNT(*) FILTER (WHERE qs.passed), AVG(qs.score)::float8
+		 FROM lineage l
+		 JOIN question_submissions qs ON qs.id = l.submission_id
+		 JOIN topics t ON t.id = l.topic_id AND t.is_active = true
+		 LEFT JOIN topics p ON p.id = t.parent_id
+		 GROUP BY t.id, t.public_id, t.name, p.public_id
+		 ORDER BY t.name ASC, t.id ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entity.TopicMastery
+	for rows.Next() {
+		var m entity.TopicMastery
+		if err := rows.Scan(&m.TopicPublicID, &m.TopicName, &m.ParentPublicID, &m.Attempts, &m.Passed, &m.AverageScore); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
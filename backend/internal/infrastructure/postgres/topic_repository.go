@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 )
@@ -48,12 +49,13 @@ func NewTopicRepository(pool *pgxpool.Pool) *TopicRepository {
 }
 
 func (r *TopicRepository) Create(ctx context.Context, topic *entity.Topic) error {
-	return r.pool.QueryRow(ctx,
+	err := r.pool.QueryRow(ctx,
 		`INSERT INTO topics (parent_id, name, description, created_by_id)
 		 VALUES ($1, $2, $3, $4)
 		 RETURNING id, public_id, is_active, created_at, updated_at`,
 		topic.ParentID, topic.Name, topic.Description, topic.CreatedByID,
 	).Scan(&topic.ID, &topic.PublicID, &topic.IsActive, &topic.CreatedAt, &topic.UpdatedAt)
+	return mapConstraintError(err, apperror.ErrTopicNameTaken, apperror.ErrTopicNotFound)
 }
 
 func (r *TopicRepository) GetByPublicID(ctx context.Context, publicID string) (*entity.Topic, error) {
@@ -111,7 +113,7 @@ func (r *TopicRepository) Update(ctx context.Context, topic *entity.Topic) error
 		 WHERE public_id = $4 AND is_active = true`,
 		topic.ParentID, topic.Name, topic.Description, topic.PublicID,
 	)
-	return err
+	return mapConstraintError(err, apperror.ErrTopicNameTaken, nil)
 }
 
 func (r *TopicRepository) Delete(ctx context.Context, publicID string) error {
@@ -187,7 +189,7 @@ func (r *TopicRepository) List(ctx context.Context, limit, offset int, filter re
 		 LEFT JOIN topics p ON p.id = t.parent_id
 		 %s
 		 WHERE t.is_active = true%s
-		 ORDER BY t.name ASC
+		 ORDER BY t.name ASC, t.id ASC
 		 LIMIT $%d OFFSET $%d`,
 		topicCountsSelect, parentJoin, filterClause, len(filterArgs)+1, len(filterArgs)+2,
 	)
@@ -234,3 +236,48 @@ func (r *TopicRepository) Count(ctx context.Context, filter repository.TopicFilt
 	err := r.pool.QueryRow(ctx, query, filterArgs...).Scan(&count)
 	return count, err
 }
+
+func (r *TopicRepository) ListBreadcrumbs(ctx context.Context, publicIDs []string) ([]entity.TopicBreadcrumb, error) {
+	rows, err := r.pool.Query(ctx,
+		`WITH RECURSIVE chain AS (
+			SELECT t.public_id AS origin, t.id, t.public_id, t.name, t.parent_id, 0 AS depth
+			FROM topics t
+			WHERE t.public_id = ANY($1::uuid[]) AND t.is_active = true
+			UNION ALL
+			SELECT c.origin, p.id, p.public_id, p.name, p.parent_id, c.depth + 1
+			FROM chain c
+			JOIN topics p ON p.id = c.parent_id
+		)
+		SELECT origin, id, public_id, name, depth
+		FROM chain
+		ORDER BY origin, depth DESC`,
+		publicIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Rows arrive per origin from the root down, ending with the topic itself.
+	var breadcrumbs []entity.TopicBreadcrumb
+	var ancestors []entity.TopicRef
+	for rows.Next() {
+		var origin string
+		var ref entity.TopicRef
+		var depth int
+		if err := rows.Scan(&origin, &ref.ID, &ref.PublicID, &ref.Name, &depth); err != nil {
+			return nil, err
+		}
+		if depth > 0 {
+			ancestors = append(ancestors, ref)
+			continue
+		}
+		breadcrumbs = append(breadcrumbs, entity.TopicBreadcrumb{
+			PublicID:  ref.PublicID,
+			Name:      ref.Name,
+			Ancestors: ancestors,
+		})
+		ancestors = nil
+	}
+	return breadcrumbs, rows.Err()
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"proximos-passos/backend/internal/domain/apperror"
@@ -23,11 +24,11 @@ func NewUserRepository(pool *pgxpool.Pool) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 	err := r.pool.QueryRow(ctx,
-		`INSERT INTO users (name, email, password_hash, role)
-		 VALUES ($1, $2, $3, $4)
-		 RETURNING id, public_id, is_active, created_at, updated_at`,
-		user.Name, user.Email, user.PasswordHash, user.Role,
-	).Scan(&user.ID, &user.PublicID, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
+		`INSERT INTO users (name, email, password_hash, role, email_verified_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, public_id, activity_reminders, status, is_active, created_at, updated_at`,
+		user.Name, user.Email, user.PasswordHash, user.Role, user.EmailVerifiedAt,
+	).Scan(&user.ID, &user.PublicID, &user.ActivityReminders, &user.Status, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -44,24 +45,23 @@ func (r *UserRepository) GetByPublicID(ctx context.Context, publicID string) (*e
 	var user entity.User
 	err := r.pool.QueryRow(ctx,
 		`SELECT id, public_id, role, name, email, email_verified_at,
-		        last_verification_token_sent_at, password_hash, avatar_url,
-		        is_active, created_at, updated_at
+		        last_verification_token_sent_at, password_hash, avatar_url, lang, activity_reminders,
+		        status, is_active, created_at, updated_at
 		 FROM users
 		 WHERE public_id = $1 AND is_active = true`,
 		publicID,
 	).Scan(
 		&user.ID, &user.PublicID, &user.Role, &user.Name, &user.Email,
 		&user.EmailVerifiedAt, &user.LastVerificationTokenSentAt,
-		&user.PasswordHash, &user.AvatarURL,
-		&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.PasswordHash, &user.AvatarURL, &user.Lang, &user.ActivityReminders,
+		&user.Status, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "22P02" {
+		if IsInvalidUUID(err) {
 			return nil, nil
 		}
 		return nil, err
@@ -74,24 +74,23 @@ func (r *UserRepository) GetByPublicIDUnfiltered(ctx context.Context, publicID s
 	var user entity.User
 	err := r.pool.QueryRow(ctx,
 		`SELECT id, public_id, role, name, email, email_verified_at,
-		        last_verification_token_sent_at, password_hash, avatar_url,
-		        is_active, created_at, updated_at
+		        last_verification_token_sent_at, password_hash, avatar_url, lang, activity_reminders,
+		        status, is_active, created_at, updated_at
 		 FROM users
 		 WHERE public_id = $1`,
 		publicID,
 	).Scan(
 		&user.ID, &user.PublicID, &user.Role, &user.Name, &user.Email,
 		&user.EmailVerifiedAt, &user.LastVerificationTokenSentAt,
-		&user.PasswordHash, &user.AvatarURL,
-		&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.PasswordHash, &user.AvatarURL, &user.Lang, &user.ActivityReminders,
+		&user.Status, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "22P02" {
+		if IsInvalidUUID(err) {
 			return nil, nil
 		}
 		return nil, err
@@ -100,20 +99,51 @@ func (r *UserRepository) GetByPublicIDUnfiltered(ctx context.Context, publicID s
 	return &user, nil
 }
 
+func (r *UserRepository) GetManyByPublicID(ctx context.Context, publicIDs []string) (map[string]*entity.User, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, public_id, role, name, email, email_verified_at,
+		        last_verification_token_sent_at, password_hash, avatar_url, lang, activity_reminders,
+		        status, is_active, created_at, updated_at
+		 FROM users
+		 WHERE public_id = ANY($1::uuid[]) AND is_active = true`,
+		publicIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make(map[string]*entity.User, len(publicIDs))
+	for rows.Next() {
+		var u entity.User
+		if err := rows.Scan(
+			&u.ID, &u.PublicID, &u.Role, &u.Name, &u.Email,
+			&u.EmailVerifiedAt, &u.LastVerificationTokenSentAt,
+			&u.PasswordHash, &u.AvatarURL, &u.Lang, &u.ActivityReminders,
+			&u.Status, &u.IsActive, &u.CreatedAt, &u.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		users[u.PublicID] = &u
+	}
+
+	return users, rows.Err()
+}
+
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
 	err := r.pool.QueryRow(ctx,
 		`SELECT id, public_id, role, name, email, email_verified_at,
-		        last_verification_token_sent_at, password_hash, avatar_url,
-		        is_active, created_at, updated_at
+		        last_verification_token_sent_at, password_hash, avatar_url, lang, activity_reminders,
+		        status, is_active, created_at, updated_at
 		 FROM users
 		 WHERE email = $1 AND is_active = true`,
 		email,
 	).Scan(
 		&user.ID, &user.PublicID, &user.Role, &user.Name, &user.Email,
 		&user.EmailVerifiedAt, &user.LastVerificationTokenSentAt,
-		&user.PasswordHash, &user.AvatarURL,
-		&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.PasswordHash, &user.AvatarURL, &user.Lang, &user.ActivityReminders,
+		&user.Status, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -126,15 +156,28 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entity.
 	return &user, nil
 }
 
-func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]entity.User, error) {
-	rows, err := r.pool.Query(ctx,
+var userSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"name":       "name",
+	"email":      "email",
+	"role":       "role",
+}
+
+func (r *UserRepository) List(ctx context.Context, limit, offset int, sort string) ([]entity.User, error) {
+	orderBy, err := parseSort(sort, userSortColumns, "created_at DESC", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(
 		`SELECT id, public_id, role, name, email, email_verified_at,
-		        last_verification_token_sent_at, password_hash, avatar_url,
-		        is_active, created_at, updated_at
+		        last_verification_token_sent_at, password_hash, avatar_url, lang, activity_reminders,
+		        status, is_active, created_at, updated_at
 		 FROM users
 		 WHERE is_active = true
-		 ORDER BY created_at DESC
-		 LIMIT $1 OFFSET $2`,
+		 ORDER BY %s
+		 LIMIT $1 OFFSET $2`, orderBy),
 		limit, offset,
 	)
 	if err != nil {
@@ -148,8 +191,8 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]entity.
 		if err := rows.Scan(
 			&u.ID, &u.PublicID, &u.Role, &u.Name, &u.Email,
 			&u.EmailVerifiedAt, &u.LastVerificationTokenSentAt,
-			&u.PasswordHash, &u.AvatarURL,
-			&u.IsActive, &u.CreatedAt, &u.UpdatedAt,
+			&u.PasswordHash, &u.AvatarURL, &u.Lang, &u.ActivityReminders,
+			&u.Status, &u.IsActive, &u.CreatedAt, &u.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -159,14 +202,19 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]entity.
 	return users, rows.Err()
 }
 
-func (r *UserRepository) ListAll(ctx context.Context, limit, offset int) ([]entity.User, error) {
-	rows, err := r.pool.Query(ctx,
+func (r *UserRepository) ListAll(ctx context.Context, limit, offset int, sort string) ([]entity.User, error) {
+	orderBy, err := parseSort(sort, userSortColumns, "created_at DESC", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(
 		`SELECT id, public_id, role, name, email, email_verified_at,
-		        last_verification_token_sent_at, password_hash, avatar_url,
-		        is_active, created_at, updated_at
+		        last_verification_token_sent_at, password_hash, avatar_url, lang, activity_reminders,
+		        status, is_active, created_at, updated_at
 		 FROM users
-		 ORDER BY created_at DESC
-		 LIMIT $1 OFFSET $2`,
+		 ORDER BY %s
+		 LIMIT $1 OFFSET $2`, orderBy),
 		limit, offset,
 	)
 	if err != nil {
@@ -180,8 +228,8 @@ func (r *UserRepository) ListAll(ctx context.Context, limit, offset int) ([]enti
 		if err := rows.Scan(
 			&u.ID, &u.PublicID, &u.Role, &u.Name, &u.Email,
 			&u.EmailVerifiedAt, &u.LastVerificationTokenSentAt,
-			&u.PasswordHash, &u.AvatarURL,
-			&u.IsActive, &u.CreatedAt, &u.UpdatedAt,
+			&u.PasswordHash, &u.AvatarURL, &u.Lang, &u.ActivityReminders,
+			&u.Status, &u.IsActive, &u.CreatedAt, &u.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -203,12 +251,24 @@ func (r *UserRepository) CountAll(ctx context.Context) (int, error) {
 	return count, err
 }
 
+func (r *UserRepository) CountActiveAdminsExcept(ctx context.Context, userID int) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM users
+		 WHERE role = 'admin' AND is_active = true AND status = 'active' AND id <> $1`,
+		userID,
+	).Scan(&count)
+	return count, err
+}
+
 func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 	result, err := r.pool.Exec(ctx,
 		`UPDATE users
-		 SET name = $1, email = $2, avatar_url = $3, role = $4, is_active = $5
-		 WHERE public_id = $6`,
-		user.Name, user.Email, user.AvatarURL, user.Role, user.IsActive, user.PublicID,
+		 SET name = $1, email = $2, avatar_url = $3, role = $4, is_active = $5, lang = $6,
+		     activity_reminders = $7
+		 WHERE public_id = $8`,
+		user.Name, user.Email, user.AvatarURL, user.Role, user.IsActive, user.Lang,
+		user.ActivityReminders, user.PublicID,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -226,18 +286,45 @@ func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 }
 
 func (r *UserRepository) Delete(ctx context.Context, publicID string) error {
-	result, err := r.pool.Exec(ctx,
-		`UPDATE users SET is_active = false WHERE public_id = $1 AND is_active = true`,
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int
+	err = tx.QueryRow(ctx,
+		`UPDATE users SET is_active = false, status = 'deleted' WHERE public_id = $1 AND is_active = true RETURNING id`,
 		publicID,
-	)
+	).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperror.ErrUserNotFound
+	}
 	if err != nil {
 		return err
 	}
 
+	if _, err := tx.Exec(ctx,
+		`UPDATE group_members SET is_active = false WHERE user_id = $1 AND is_active = true`,
+		userID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *UserRepository) SetStatus(ctx context.Context, userID int, status entity.UserStatus) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE users SET status = $1 WHERE id = $2 AND is_active = true`,
+		status, userID,
+	)
+	if err != nil {
+		return err
+	}
 	if result.RowsAffected() == 0 {
 		return apperror.ErrUserNotFound
 	}
-
 	return nil
 }
 
@@ -273,6 +360,22 @@ func (r *UserRepository) UpdateAvatar(ctx context.Context, publicID string, avat
 	return nil
 }
 
+func (r *UserRepository) UpdatePassword(ctx context.Context, publicID string, passwordHash string) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE users SET password_hash = $1 WHERE public_id = $2 AND is_active = true`,
+		passwordHash, publicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (r *UserRepository) UpdateLastVerificationSent(ctx context.Context, publicID string) error {
 	_, err := r.pool.Exec(ctx,
 		`UPDATE users SET last_verification_token_sent_at = NOW() WHERE public_id = $1 AND is_active = true`,
@@ -280,3 +383,43 @@ func (r *UserRepository) UpdateLastVerificationSent(ctx context.Context, publicI
 	)
 	return err
 }
+
+func (r *UserRepository) SetPendingEmail(ctx context.Context, publicID string, email string) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE users SET pending_email = $1 WHERE public_id = $2 AND is_active = true`,
+		email, publicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ConfirmPendingEmail swaps in the pending email only if it still matches the
+// one the confirmation token was issued for, and marks it verified.
+func (r *UserRepository) ConfirmPendingEmail(ctx context.Context, publicID string, email string) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE users
+		 SET email = pending_email, pending_email = NULL, email_verified_at = NOW()
+		 WHERE public_id = $1 AND pending_email = $2 AND is_active = true`,
+		publicID, email,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, "email") {
+			return apperror.ErrEmailTaken
+		}
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrInvalidToken
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 )
@@ -52,7 +53,7 @@ func (r *VideoLessonRepository) Create(ctx context.Context, vl *entity.VideoLess
 		vl.Title, vl.Description, vl.FileID, vl.FileURL, vl.DurationMinutes, vl.CreatedByID,
 	).Scan(&vl.ID, &vl.PublicID, &vl.IsActive, &vl.CreatedAt, &vl.UpdatedAt)
 	if err != nil {
-		return err
+		return mapConstraintError(err, apperror.ErrVideoLessonTitleTaken, nil)
 	}
 
 	// Insert topic associations
@@ -77,15 +78,18 @@ func (r *VideoLessonRepository) GetByPublicID(ctx context.Context, publicID stri
 		`SELECT vl.id, vl.public_id, vl.title, vl.description,
 		        vl.file_id, vl.file_url, vl.duration_minutes,
 		        vl.is_active, vl.created_by_id, vl.created_at, vl.updated_at,
-		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes,
+		        cu.public_id, cu.name
 		 FROM video_lessons vl
 		 LEFT JOIN files f ON f.id = vl.file_id
+		 JOIN users cu ON cu.id = vl.created_by_id
 		 WHERE vl.public_id = $1 AND vl.is_active = true`,
 		publicID,
 	).Scan(&vl.ID, &vl.PublicID, &vl.Title, &vl.Description,
 		&vl.FileID, &vl.FileURL, &vl.DurationMinutes,
 		&vl.IsActive, &vl.CreatedByID, &vl.CreatedAt, &vl.UpdatedAt,
-		&filePublicID, &fileKey, &filename, &contentType, &sizeBytes)
+		&filePublicID, &fileKey, &filename, &contentType, &sizeBytes,
+		&vl.CreatedByPublicID, &vl.CreatedByName)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -118,7 +122,7 @@ func (r *VideoLessonRepository) Update(ctx context.Context, vl *entity.VideoLess
 		 WHERE public_id = $5 AND is_active = true`,
 		vl.Title, vl.Description, vl.FileURL, vl.DurationMinutes, vl.PublicID,
 	)
-	return err
+	return mapConstraintError(err, apperror.ErrVideoLessonTitleTaken, nil)
 }
 
 func (r *VideoLessonRepository) ReplaceFile(ctx context.Context, vlID int, newFileID int) error {
@@ -183,13 +187,8 @@ func (r *VideoLessonRepository) SetTopics(ctx context.Context, vlID int, topicID
 	return tx.Commit(ctx)
 }
 
-func (r *VideoLessonRepository) Delete(ctx context.Context, publicID string) error {
-	_, err := r.pool.Exec(ctx,
-		`UPDATE video_lessons SET is_active = false, updated_at = NOW()
-		 WHERE public_id = $1 AND is_active = true`,
-		publicID,
-	)
-	return err
+func (r *VideoLessonRepository) Delete(ctx context.Context, publicID string, removeItems bool) error {
+	return softDeleteContent(ctx, r.pool, "video_lessons", "video_lesson_id", publicID, removeItems)
 }
 
 func (r *VideoLessonRepository) List(ctx context.Context, limit, offset int, filter repository.VideoLessonFilter) ([]entity.VideoLesson, error) {
@@ -199,11 +198,13 @@ func (r *VideoLessonRepository) List(ctx context.Context, limit, offset int, fil
 		`SELECT vl.id, vl.public_id, vl.title, vl.description,
 		        vl.file_id, vl.file_url, vl.duration_minutes,
 		        vl.is_active, vl.created_by_id, vl.created_at, vl.updated_at,
-		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes
+		        f.public_id, f.key, f.filename, f.content_type, f.size_bytes,
+		        cu.public_id, cu.name
 		 FROM video_lessons vl
 		 LEFT JOIN files f ON f.id = vl.file_id
+		 JOIN users cu ON cu.id = vl.created_by_id
 		 WHERE vl.is_active = true%s
-		 ORDER BY vl.created_at DESC
+		 ORDER BY vl.created_at DESC, vl.id DESC
 		 LIMIT $%d OFFSET $%d`,
 		filterClause, len(filterArgs)+1, len(filterArgs)+2,
 	)
@@ -223,7 +224,8 @@ func (r *VideoLessonRepository) List(ctx context.Context, limit, offset int, fil
 		if err := rows.Scan(&vl.ID, &vl.PublicID, &vl.Title, &vl.Description,
 			&vl.FileID, &vl.FileURL, &vl.DurationMinutes,
 			&vl.IsActive, &vl.CreatedByID, &vl.CreatedAt, &vl.UpdatedAt,
-			&filePublicID, &fileKey, &filename, &contentType, &sizeBytes); err != nil {
+			&filePublicID, &fileKey, &filename, &contentType, &sizeBytes,
+			&vl.CreatedByPublicID, &vl.CreatedByName); err != nil {
 			return nil, err
 		}
 		if filePublicID != nil {
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	return r.pool.QueryRow(ctx,
+		`INSERT INTO webhooks (url, secret, created_by_id)
+		 VALUES ($1, $2, $3)
+		 RETURNING id, public_id, is_active, created_at, updated_at`,
+		webhook.URL, webhook.Secret, webhook.CreatedByID,
+	).Scan(&webhook.ID, &webhook.PublicID, &webhook.IsActive, &webhook.CreatedAt, &webhook.UpdatedAt)
+}
+
+func (r *WebhookRepository) GetByPublicID(ctx context.Context, publicID string) (*entity.Webhook, error) {
+	var w entity.Webhook
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, public_id, url, secret, is_active, created_by_id, created_at, updated_at
+		 FROM webhooks
+		 WHERE public_id = $1 AND is_active = true`,
+		publicID,
+	).Scan(&w.ID, &w.PublicID, &w.URL, &w.Secret, &w.IsActive, &w.CreatedByID, &w.CreatedAt, &w.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, publicID string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE webhooks SET is_active = false, updated_at = NOW()
+		 WHERE public_id = $1 AND is_active = true`,
+		publicID,
+	)
+	return err
+}
+
+func (r *WebhookRepository) List(ctx context.Context, limit, offset int) ([]entity.Webhook, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, public_id, url, secret, is_active, created_by_id, created_at, updated_at
+		 FROM webhooks
+		 WHERE is_active = true
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT $1 OFFSET $2`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhooks(rows)
+}
+
+func (r *WebhookRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM webhooks WHERE is_active = true`).Scan(&count)
+	return count, err
+}
+
+func (r *WebhookRepository) ListActive(ctx context.Context) ([]entity.Webhook, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, public_id, url, secret, is_active, created_by_id, created_at, updated_at
+		 FROM webhooks
+		 WHERE is_active = true`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhooks(rows)
+}
+
+func scanWebhooks(rows pgx.Rows) ([]entity.Webhook, error) {
+	defer rows.Close()
+
+	var webhooks []entity.Webhook
+	for rows.Next() {
+		var w entity.Webhook
+		if err := rows.Scan(&w.ID, &w.PublicID, &w.URL, &w.Secret, &w.IsActive, &w.CreatedByID, &w.CreatedAt, &w.UpdatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
@@ -2,22 +2,31 @@ package r2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/service"
 )
 
 type StorageService struct {
 	client    *s3.Client
+	presigner *s3.PresignClient
 	bucket    string
 	publicURL string
+	timeout   time.Duration
 }
 
-func NewStorageService(accountID, accessKeyID, accessKeySecret, bucket, publicURL string) (*StorageService, error) {
+// NewStorageService creates an R2 client. Every Upload and Delete is bounded
+// by timeout on top of the caller's context.
+func NewStorageService(accountID, accessKeyID, accessKeySecret, bucket, publicURL string, timeout time.Duration) (*StorageService, error) {
 	endpoint := fmt.Sprintf("https://%s.r2.cloudflarestorage.com", accountID)
 
 	cfg, err := config.LoadDefaultConfig(context.Background(),
@@ -34,12 +43,17 @@ func NewStorageService(accountID, accessKeyID, accessKeySecret, bucket, publicUR
 
 	return &StorageService{
 		client:    client,
+		presigner: s3.NewPresignClient(client),
 		bucket:    bucket,
 		publicURL: publicURL,
+		timeout:   timeout,
 	}, nil
 }
 
 func (s *StorageService) Upload(ctx context.Context, key, contentType string, body io.Reader) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
 	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(s.bucket),
 		Key:         aws.String(key),
@@ -54,6 +68,9 @@ func (s *StorageService) Upload(ctx context.Context, key, contentType string, bo
 }
 
 func (s *StorageService) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(key),
@@ -68,3 +85,48 @@ func (s *StorageService) Delete(ctx context.Context, key string) error {
 func (s *StorageService) GetPublicURL(key string) string {
 	return fmt.Sprintf("%s/%s", s.publicURL, key)
 }
+
+// GetSignedURL presigns a GetObject request for key, valid for expiry. Signing
+// happens locally and does not call R2.
+func (s *StorageService) GetSignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	req, err := s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign R2 object: %w", err)
+	}
+
+	return req.URL, nil
+}
+
+// Open starts a GetObject for key. Unlike Upload and Delete it is not bounded
+// by the service timeout, since the body is streamed for as long as the
+// client keeps reading; ctx should be the client's request context.
+func (s *StorageService) Open(ctx context.Context, key, byteRange string) (*service.StorageObject, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if byteRange != "" {
+		input.Range = aws.String(byteRange)
+	}
+
+	out, err := s.client.GetObject(ctx, input)
+	if err != nil {
+		var coded interface{ ErrorCode() string }
+		if errors.As(err, &coded) && coded.ErrorCode() == "InvalidRange" {
+			return nil, apperror.ErrRangeNotSatisfiable
+		}
+		return nil, fmt.Errorf("failed to read from R2: %w", err)
+	}
+
+	return &service.StorageObject{
+		Body:          out.Body,
+		ContentType:   aws.ToString(out.ContentType),
+		ContentLength: aws.ToInt64(out.ContentLength),
+		ContentRange:  aws.ToString(out.ContentRange),
+		ETag:          aws.ToString(out.ETag),
+		LastModified:  aws.ToTime(out.LastModified),
+	}, nil
+}
@@ -2,73 +2,149 @@ package resend
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	resendlib "github.com/resend/resend-go/v2"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/infrastructure/emailtemplate"
 )
 
 type EmailService struct {
-	client      *resendlib.Client
-	from        string
-	logoFullURL string
+	client    *resendlib.Client
+	from      string
+	templates *emailtemplate.Renderer
+	timeout   time.Duration
+	outbox    repository.EmailOutboxRepository
 }
 
-func NewEmailService(apiKey, fromEmail, logoFullURL string) *EmailService {
+// NewEmailService creates a Resend-backed email service. Emails are rendered
+// with templates and queued in outbox; an OutboxWorker delivers them through
+// Deliver, each call bounded by timeout on top of the caller's context.
+func NewEmailService(apiKey, fromEmail string, templates *emailtemplate.Renderer, timeout time.Duration, outbox repository.EmailOutboxRepository) *EmailService {
 	return &EmailService{
-		client:      resendlib.NewClient(apiKey),
-		from:        fromEmail,
-		logoFullURL: logoFullURL,
+		client:    resendlib.NewClient(apiKey),
+		from:      fromEmail,
+		templates: templates,
+		timeout:   timeout,
+		outbox:    outbox,
+	}
+}
+
+func (s *EmailService) SendVerificationEmail(ctx context.Context, to, name, locale, verificationURL string) error {
+	msg, err := s.templates.RenderVerification(locale, emailtemplate.VerificationData{
+		Name:            name,
+		VerificationURL: verificationURL,
+	})
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, to, msg)
+}
+
+func (s *EmailService) SendEmailChangeEmail(ctx context.Context, to, name, locale, confirmationURL string) error {
+	msg, err := s.templates.RenderEmailChange(locale, emailtemplate.EmailChangeData{
+		Name:            name,
+		ConfirmationURL: confirmationURL,
+	})
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, to, msg)
+}
+
+func (s *EmailService) SendSubmissionReopenedEmail(ctx context.Context, to, name, locale, activityTitle, reason, activityURL string) error {
+	msg, err := s.templates.RenderSubmissionReopened(locale, emailtemplate.SubmissionReopenedData{
+		Name:          name,
+		ActivityTitle: activityTitle,
+		Reason:        reason,
+		ActivityURL:   activityURL,
+	})
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, to, msg)
+}
+
+// SendInvitationEmail invites an address without an account, so the email
+// greets the address itself.
+func (s *EmailService) SendInvitationEmail(ctx context.Context, to, locale, inviterName, groupName, invitationURL string) error {
+	msg, err := s.templates.RenderInvitation(locale, emailtemplate.InvitationData{
+		Name:          to,
+		InviterName:   inviterName,
+		GroupName:     groupName,
+		InvitationURL: invitationURL,
+	})
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, to, msg)
+}
+
+func (s *EmailService) SendJoinRequestEmail(ctx context.Context, to, name, locale, requesterName, groupName, groupURL string) error {
+	msg, err := s.templates.RenderJoinRequest(locale, emailtemplate.JoinRequestData{
+		Name:          name,
+		RequesterName: requesterName,
+		GroupName:     groupName,
+		GroupURL:      groupURL,
+	})
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, to, msg)
+}
+
+func (s *EmailService) SendAnswerReviewedEmail(ctx context.Context, to, name, locale, activityTitle string, passed bool, feedback, answerURL string) error {
+	msg, err := s.templates.RenderAnswerReviewed(locale, emailtemplate.AnswerReviewedData{
+		Name:          name,
+		ActivityTitle: activityTitle,
+		Passed:        passed,
+		Feedback:      feedback,
+		AnswerURL:     answerURL,
+	})
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, to, msg)
+}
+
+func (s *EmailService) SendActivityReminderEmail(ctx context.Context, to, name, locale, activityTitle, groupName string, dueDate time.Time, activityURL string) error {
+	msg, err := s.templates.RenderActivityReminder(locale, emailtemplate.ActivityReminderData{
+		Name:          name,
+		ActivityTitle: activityTitle,
+		GroupName:     groupName,
+		DueDate:       dueDate,
+		ActivityURL:   activityURL,
+	})
+	if err != nil {
+		return err
 	}
+	return s.Send(ctx, to, msg)
+}
+
+// Send queues a rendered email for delivery instead of calling Resend inline,
+// so a transient provider failure is retried rather than lost.
+func (s *EmailService) Send(ctx context.Context, to string, msg emailtemplate.Message) error {
+	return s.outbox.Enqueue(ctx, &entity.OutboxEmail{
+		Recipient: to,
+		Subject:   msg.Subject,
+		HTML:      msg.HTML,
+		Text:      msg.Text,
+	})
 }
 
-func (s *EmailService) SendVerificationEmail(ctx context.Context, to, name, verificationURL string) error {
-	subject := "Verifique seu email - Próximos Passos"
-
-	html := fmt.Sprintf(`<!DOCTYPE html>
-<html lang="pt-BR">
-<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"></head>
-<body style="margin: 0; padding: 0; background-color: #f6f9f6; font-family: 'Helvetica Neue', Helvetica, Arial, sans-serif;">
-<div style="width: 100%%; max-width: 600px; margin: 32px auto; background-color: #ffffff; border-radius: 8px; box-shadow: 0 4px 6px rgba(0,0,0,0.05); overflow: hidden;">
-
-  <div style="background-color: #ffffff; padding: 32px 0; text-align: center; border-bottom: 3px solid #cfa156;">
-    <img src="%s" alt="Próximos Passos" width="200" style="display: block; margin: 0 auto; border: 0;" />
-  </div>
-
-  <div style="padding: 40px 32px;">
-    <h1 style="font-family: Georgia, 'Times New Roman', serif; color: #0f2e2e; font-size: 24px; margin: 0 0 16px 0;">
-      Olá, %s!
-    </h1>
-    <p style="color: #333333; font-size: 16px; line-height: 1.6; margin: 0 0 16px 0;">
-      Sua conta foi criada na plataforma <strong style="color: #0f2e2e;">Próximos Passos</strong>.
-    </p>
-    <p style="color: #333333; font-size: 16px; line-height: 1.6; margin: 0 0 32px 0;">
-      Clique no botão abaixo para verificar seu email:
-    </p>
-    <div style="text-align: center; margin: 0 0 32px 0;">
-      <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: #cfa156; color: #ffffff; text-decoration: none; border-radius: 4px; font-weight: bold; font-size: 16px;">
-        Verificar Email
-      </a>
-    </div>
-    <p style="color: #333333; font-size: 14px; line-height: 1.6; margin: 0;">
-      Se você não reconhece esta ação, ignore este email.
-    </p>
-  </div>
-
-  <div style="background-color: #0f2e2e; padding: 24px 32px; text-align: center;">
-    <p style="color: #88aaaa; font-size: 13px; line-height: 1.5; margin: 0;">
-      &copy; 2026 Próximos Passos. Todos os direitos reservados.
-    </p>
-  </div>
-
-</div>
-</body>
-</html>`, s.logoFullURL, name, verificationURL)
+// Deliver sends a queued email through Resend.
+func (s *EmailService) Deliver(ctx context.Context, email *entity.OutboxEmail) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
 
 	_, err := s.client.Emails.SendWithContext(ctx, &resendlib.SendEmailRequest{
 		From:    s.from,
-		To:      []string{to},
-		Subject: subject,
-		Html:    html,
+		To:      []string{email.Recipient},
+		Subject: email.Subject,
+		Html:    email.HTML,
+		Text:    email.Text,
 	})
 
 	return err
@@ -0,0 +1,137 @@
+package resend
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+const (
+	outboxBatchSize  = 20
+	outboxMaxBackoff = time.Hour
+	// outboxPurgeInterval spaces out the deletion of expired sent emails.
+	outboxPurgeInterval = time.Hour
+)
+
+// Deliverer sends a single queued email.
+type Deliverer interface {
+	Deliver(ctx context.Context, email *entity.OutboxEmail) error
+}
+
+// OutboxWorker polls emails_outbox and delivers due emails, retrying failures
+// with exponential backoff until maxAttempts, after which the email is marked
+// failed for inspection. Pending rows survive restarts and are picked up on
+// the next poll; delivery order is not guaranteed. Sent emails are kept for
+// retention and then deleted; failed ones stay until requeued.
+type OutboxWorker struct {
+	repo         repository.EmailOutboxRepository
+	deliverer    Deliverer
+	maxAttempts  int
+	baseBackoff  time.Duration
+	pollInterval time.Duration
+	lease        time.Duration
+	retention    time.Duration
+	lastPurge    time.Time
+}
+
+func NewOutboxWorker(repo repository.EmailOutboxRepository, deliverer Deliverer, maxAttempts int, baseBackoff, pollInterval, lease, retention time.Duration) *OutboxWorker {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &OutboxWorker{
+		repo:         repo,
+		deliverer:    deliverer,
+		maxAttempts:  maxAttempts,
+		baseBackoff:  baseBackoff,
+		pollInterval: pollInterval,
+		lease:        lease,
+		retention:    retention,
+	}
+}
+
+// Run processes the outbox until ctx is cancelled.
+func (w *OutboxWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.ProcessDue(ctx)
+		if time.Since(w.lastPurge) >= outboxPurgeInterval {
+			w.PurgeSent(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue claims and delivers every email that is currently due.
+func (w *OutboxWorker) ProcessDue(ctx context.Context) {
+	for {
+		emails, err := w.repo.ClaimDue(ctx, outboxBatchSize, w.lease)
+		if err != nil {
+			log.Printf("failed to claim outbox emails: %v", err)
+			return
+		}
+
+		for i := range emails {
+			w.process(ctx, &emails[i])
+		}
+
+		if len(emails) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// PurgeSent deletes emails delivered more than retention ago.
+func (w *OutboxWorker) PurgeSent(ctx context.Context) {
+	w.lastPurge = time.Now()
+	deleted, err := w.repo.DeleteSentBefore(ctx, w.lastPurge.Add(-w.retention))
+	if err != nil {
+		log.Printf("failed to purge sent outbox emails: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("purged %d sent outbox emails", deleted)
+	}
+}
+
+func (w *OutboxWorker) process(ctx context.Context, email *entity.OutboxEmail) {
+	err := w.deliverer.Deliver(ctx, email)
+	if err == nil {
+		if err := w.repo.MarkSent(ctx, email.ID); err != nil {
+			log.Printf("failed to mark email %s as sent: %v", email.PublicID, err)
+		}
+		return
+	}
+
+	// Attempts already includes the current one, counted when it was claimed.
+	if email.Attempts >= w.maxAttempts {
+		log.Printf("email %s to %s failed permanently after %d attempts: %v", email.PublicID, email.Recipient, email.Attempts, err)
+		if err := w.repo.MarkFailed(ctx, email.ID, err.Error()); err != nil {
+			log.Printf("failed to mark email %s as failed: %v", email.PublicID, err)
+		}
+		return
+	}
+
+	next := time.Now().Add(w.backoff(email.Attempts))
+	if err := w.repo.ScheduleRetry(ctx, email.ID, err.Error(), next); err != nil {
+		log.Printf("failed to schedule retry for email %s: %v", email.PublicID, err)
+	}
+}
+
+// backoff doubles the delay after every attempt, capped at outboxMaxBackoff.
+func (w *OutboxWorker) backoff(attempts int) time.Duration {
+	d := w.baseBackoff
+	for i := 1; i < attempts && d < outboxMaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, outboxMaxBackoff)
+}
@@ -0,0 +1,34 @@
+package resend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type fakeOutboxRepo struct {
+	repository.EmailOutboxRepository
+	purgedBefore time.Time
+}
+
+func (f *fakeOutboxRepo) DeleteSentBefore(_ context.Context, before time.Time) (int64, error) {
+	f.purgedBefore = before
+	return 0, nil
+}
+
+func TestPurgeSentKeepsEmailsWithinRetention(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	w := NewOutboxWorker(repo, nil, 8, time.Second, time.Second, time.Minute, 72*time.Hour)
+
+	w.PurgeSent(context.Background())
+
+	cutoff := time.Since(repo.purgedBefore)
+	if cutoff < 72*time.Hour || cutoff > 72*time.Hour+time.Minute {
+		t.Fatalf("purged emails sent before %v ago, want 72h", cutoff)
+	}
+	if w.lastPurge.IsZero() {
+		t.Error("the purge time was not recorded")
+	}
+}
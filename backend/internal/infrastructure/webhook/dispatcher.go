@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+)
+
+// HTTPClient is the subset of *http.Client used by the dispatcher.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Dispatcher struct {
+	repo        repository.WebhookRepository
+	client      HTTPClient
+	maxAttempts int
+	baseBackoff time.Duration
+}
+
+func NewDispatcher(repo repository.WebhookRepository, client HTTPClient, maxAttempts int, baseBackoff time.Duration) *Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Dispatcher{
+		repo:        repo,
+		client:      client,
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+	}
+}
+
+type payload struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of "timestamp.body" keyed with
+// secret, prefixed with the algorithm name as sent in the signature header.
+// timestamp is the Unix time sent in the timestamp header; covering it lets
+// subscribers reject replayed deliveries.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Emit delivers the event to every active subscriber in the background. The
+// request context is detached so deliveries outlive the originating request.
+func (d *Dispatcher) Emit(ctx context.Context, eventType string, data any) {
+	ctx = context.WithoutCancel(ctx)
+
+	body, err := json.Marshal(payload{
+		ID:         newDeliveryID(),
+		Event:      eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		log.Printf("failed to encode webhook event %s: %v", eventType, err)
+		return
+	}
+
+	go func() {
+		webhooks, err := d.repo.ListActive(ctx)
+		if err != nil {
+			log.Printf("failed to list webhooks for event %s: %v", eventType, err)
+			return
+		}
+		for _, w := range webhooks {
+			go d.Deliver(ctx, w, eventType, body)
+		}
+	}()
+}
+
+// Deliver posts body to the subscriber, retrying network errors, 429 and 5xx
+// responses with exponential backoff until maxAttempts is reached. It returns
+// the number of attempts made and the last error, if any. Waiting between
+// attempts stops early when ctx is cancelled.
+func (d *Dispatcher) Deliver(ctx context.Context, w entity.Webhook, eventType string, body []byte) (int, error) {
+	backoff := d.baseBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := d.send(ctx, w, eventType, body)
+		if err == nil {
+			return attempt, nil
+		}
+		if !retry || attempt >= d.maxAttempts {
+			log.Printf("giving up on webhook %s for event %s after %d attempt(s): %v", w.PublicID, eventType, attempt, err)
+			return attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, w entity.Webhook, eventType string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	timestamp := time.Now().Unix()
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+}
+
+func newDeliveryID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+type fakeClient struct {
+	requests []*http.Request
+	status   int
+	onSend   func()
+}
+
+func (c *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	if c.onSend != nil {
+		c.onSend()
+	}
+	return &http.Response{StatusCode: c.status, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestDeliverSignsTimestampAndBody(t *testing.T) {
+	client := &fakeClient{status: http.StatusOK}
+	d := NewDispatcher(nil, client, 1, time.Millisecond)
+	body := []byte(`{"event":"activity.created"}`)
+
+	if _, err := d.Deliver(context.Background(), entity.Webhook{URL: "https://example.com/hook", Secret: "s3cret"}, "activity.created", body); err != nil {
+		t.Fatal(err)
+	}
+
+	req := client.requests[0]
+	timestamp, err := strconv.ParseInt(req.Header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("timestamp header %q: %v", req.Header.Get(TimestampHeader), err)
+	}
+	if time.Since(time.Unix(timestamp, 0)) > time.Minute {
+		t.Errorf("timestamp %d is not the send time", timestamp)
+	}
+	if got, want := req.Header.Get(SignatureHeader), Sign("s3cret", timestamp, body); got != want {
+		t.Errorf("signature = %s, want %s", got, want)
+	}
+	if Sign("s3cret", timestamp+1, body) == Sign("s3cret", timestamp, body) {
+		t.Error("the signature does not cover the timestamp")
+	}
+}
+
+func TestDeliverStopsBackoffWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &fakeClient{status: http.StatusServiceUnavailable, onSend: cancel}
+	d := NewDispatcher(nil, client, 5, time.Hour)
+
+	start := time.Now()
+	attempts, err := d.Deliver(ctx, entity.Webhook{URL: "https://example.com/hook"}, "activity.created", []byte(`{}`))
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if attempts != 1 || len(client.requests) != 1 {
+		t.Errorf("made %d attempt(s), want 1", attempts)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Deliver waited out the backoff after cancellation")
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/permissions"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+// Access denials follow one rule: a caller who cannot see a resource gets its
+// not-found error, so a response never confirms that a private group,
+// activity or submission exists. ErrForbidden is reserved for callers who can
+// already see the resource but lack the role an action needs.
+
+// denyGroup returns the error for a user lacking a permission on a group.
+// Public groups, and private groups the user belongs to or asked to join,
+// are visible to them.
+func denyGroup(ctx context.Context, groupRepo repository.GroupRepository, group *entity.Group, user *entity.User) error {
+	if group.VisibilityType == entity.GroupVisibilityPublic || user.Role == entity.UserRoleAdmin {
+		return apperror.ErrForbidden
+	}
+
+	member, err := groupRepo.GetMember(ctx, group.ID, user.ID)
+	if err != nil {
+		return err
+	}
+	if member != nil && member.IsActive {
+		return apperror.ErrForbidden
+	}
+	return apperror.ErrGroupNotFound
+}
+
+// denyActivity returns the error for a user lacking a permission on an
+// activity. Activities are visible to the accepted members of their group.
+func denyActivity(ctx context.Context, groupRepo repository.GroupRepository, groupID int, user *entity.User) error {
+	if user.Role == entity.UserRoleAdmin {
+		return apperror.ErrForbidden
+	}
+
+	member, err := groupRepo.GetMember(ctx, groupID, user.ID)
+	if err != nil {
+		return err
+	}
+	if member != nil && member.IsActive && member.AcceptedByID != nil {
+		return apperror.ErrForbidden
+	}
+	return apperror.ErrActivityNotFound
+}
+
+// loadRequester returns the user with their membership in the group, for
+// reporting capabilities. An unknown user gets no capabilities.
+func loadRequester(ctx context.Context, userRepo repository.UserRepository, groupRepo repository.GroupRepository, groupID int, userPublicID string) (permissions.Requester, error) {
+	user, err := userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil || user == nil {
+		return permissions.Requester{}, err
+	}
+
+	member, err := groupRepo.GetMember(ctx, groupID, user.ID)
+	if err != nil {
+		return permissions.Requester{}, err
+	}
+	return permissions.Requester{Role: user.Role, Member: member}, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/i18n"
+)
+
+const activityReminderBatchSize = 100
+
+// ActivityReminderUseCase emails group members about activities that are due
+// within window and that they have not submitted yet. Each member is reminded
+// at most once per activity.
+type ActivityReminderUseCase struct {
+	reminderRepo repository.ActivityReminderRepository
+	emailSvc     service.EmailService
+	frontendURL  string
+	window       time.Duration
+}
+
+func NewActivityReminderUseCase(reminderRepo repository.ActivityReminderRepository, emailSvc service.EmailService, frontendURL string, window time.Duration) *ActivityReminderUseCase {
+	return &ActivityReminderUseCase{
+		reminderRepo: reminderRepo,
+		emailSvc:     emailSvc,
+		frontendURL:  frontendURL,
+		window:       window,
+	}
+}
+
+// Run sends due reminders every interval until ctx is cancelled.
+func (uc *ActivityReminderUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.SendDue(ctx); err != nil {
+			log.Printf("failed to send activity reminders: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue queues a reminder email for every member who still needs one and
+// returns how many were queued.
+func (uc *ActivityReminderUseCase) SendDue(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		reminders, err := uc.reminderRepo.ListDue(ctx, time.Now().Add(uc.window), activityReminderBatchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		batchSent := 0
+		for _, rem := range reminders {
+			// Claiming first keeps concurrent instances from emailing the same
+			// member twice.
+			claimed, err := uc.reminderRepo.Claim(ctx, rem.ActivityID, rem.UserID)
+			if err != nil {
+				return sent, err
+			}
+			if !claimed {
+				continue
+			}
+
+			locale := ""
+			if rem.UserLang != nil {
+				locale = *rem.UserLang
+			}
+			activityURL := fmt.Sprintf("%s/%s/dashboard/activities/%s", uc.frontendURL, i18n.Resolve(locale), rem.ActivityPublicID)
+
+			dueDate := rem.DueDate.In(entity.TimeZoneLocation(rem.GroupTimeZone))
+
+			if err := uc.emailSvc.SendActivityReminderEmail(ctx, rem.UserEmail, rem.UserName, locale, rem.ActivityTitle, rem.GroupName, dueDate, activityURL); err != nil {
+				log.Printf("failed to queue activity reminder for user %d on activity %d: %v", rem.UserID, rem.ActivityID, err)
+				if err := uc.reminderRepo.Release(ctx, rem.ActivityID, rem.UserID); err != nil {
+					return sent, err
+				}
+				continue
+			}
+			batchSent++
+		}
+		sent += batchSent
+
+		// A short batch means everything due has been listed; a batch where
+		// nothing could be sent would otherwise be listed again forever.
+		if len(reminders) < activityReminderBatchSize || batchSent == 0 {
+			return sent, nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/service"
+)
+
+type fakeReminderRepo struct {
+	repository.ActivityReminderRepository
+	due []entity.ActivityReminder
+}
+
+func (f *fakeReminderRepo) ListDue(_ context.Context, _ time.Time, _ int) ([]entity.ActivityReminder, error) {
+	due := f.due
+	f.due = nil
+	return due, nil
+}
+
+func (f *fakeReminderRepo) Claim(_ context.Context, _, _ int) (bool, error) {
+	return true, nil
+}
+
+type fakeReminderSender struct {
+	service.EmailService
+	dueDates []time.Time
+}
+
+func (f *fakeReminderSender) SendActivityReminderEmail(_ context.Context, _, _, _, _, _ string, dueDate time.Time, _ string) error {
+	f.dueDates = append(f.dueDates, dueDate)
+	return nil
+}
+
+func TestSendDueFormatsDueDateInGroupTimeZone(t *testing.T) {
+	due := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	repo := &fakeReminderRepo{due: []entity.ActivityReminder{
+		{ActivityID: 1, UserID: 1, DueDate: due, GroupTimeZone: "America/Sao_Paulo"},
+		{ActivityID: 1, UserID: 2, DueDate: due, GroupTimeZone: "Not/AZone"},
+	}}
+	sender := &fakeReminderSender{}
+	uc := NewActivityReminderUseCase(repo, sender, "https://example.com", 24*time.Hour)
+
+	sent, err := uc.SendDue(context.Background())
+	if err != nil || sent != 2 {
+		t.Fatalf("SendDue = %d, %v; want 2, nil", sent, err)
+	}
+	for i, zone := range []string{"America/Sao_Paulo", "UTC"} {
+		got := sender.dueDates[i]
+		if !got.Equal(due) || got.Location().String() != zone {
+			t.Errorf("reminder %d due date = %v, want %v in %s", i, got, due, zone)
+		}
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
+	"log"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+	"proximos-passos/backend/internal/i18n"
 )
 
 type ActivitySubmissionUseCase struct {
@@ -20,7 +23,12 @@ type ActivitySubmissionUseCase struct {
 	groupRepo    repository.GroupRepository
 	userRepo     repository.UserRepository
 	qSubRepo     repository.QuestionSubmissionRepository
+	fileRepo     repository.FileRepository
 	storageSvc   service.StorageService
+	events       service.EventEmitter
+	emailSvc     service.EmailService
+	frontendURL  string
+	uploadLimits uploadlimits.Limits
 }
 
 func NewActivitySubmissionUseCase(
@@ -29,7 +37,12 @@ func NewActivitySubmissionUseCase(
 	groupRepo repository.GroupRepository,
 	userRepo repository.UserRepository,
 	qSubRepo repository.QuestionSubmissionRepository,
+	fileRepo repository.FileRepository,
 	storageSvc service.StorageService,
+	events service.EventEmitter,
+	emailSvc service.EmailService,
+	frontendURL string,
+	uploadLimits uploadlimits.Limits,
 ) *ActivitySubmissionUseCase {
 	return &ActivitySubmissionUseCase{
 		subRepo:      subRepo,
@@ -37,7 +50,12 @@ func NewActivitySubmissionUseCase(
 		groupRepo:    groupRepo,
 		userRepo:     userRepo,
 		qSubRepo:     qSubRepo,
+		fileRepo:     fileRepo,
 		storageSvc:   storageSvc,
+		events:       events,
+		emailSvc:     emailSvc,
+		frontendURL:  frontendURL,
+		uploadLimits: uploadLimits,
 	}
 }
 
@@ -74,6 +92,36 @@ func (uc *ActivitySubmissionUseCase) isGroupAdminOrSupervisor(ctx context.Contex
 	return member.Role == entity.MemberRoleAdmin || member.Role == entity.MemberRoleSupervisor, nil
 }
 
+// canSeeSubmission reports whether the user may see a submission: its owner,
+// platform admins and the admins or supervisors of the activity's group.
+func (uc *ActivitySubmissionUseCase) canSeeSubmission(ctx context.Context, sub *entity.ActivitySubmission, user *entity.User) (bool, error) {
+	if sub.UserID == user.ID || user.Role == entity.UserRoleAdmin {
+		return true, nil
+	}
+
+	activity, err := uc.activityRepo.GetByID(ctx, sub.ActivityID)
+	if err != nil {
+		return false, err
+	}
+	if activity == nil {
+		return false, nil
+	}
+	return uc.isGroupAdminOrSupervisor(ctx, activity.GroupID, user.ID)
+}
+
+// denySubmission returns the error for a user lacking a permission on a
+// submission, hiding it from users who cannot see it (see access.go).
+func (uc *ActivitySubmissionUseCase) denySubmission(ctx context.Context, sub *entity.ActivitySubmission, user *entity.User) error {
+	visible, err := uc.canSeeSubmission(ctx, sub, user)
+	if err != nil {
+		return err
+	}
+	if visible {
+		return apperror.ErrForbidden
+	}
+	return apperror.ErrActivitySubmissionNotFound
+}
+
 type SubmitActivityInput struct {
 	ActivityPublicID string
 	UserPublicID     string
@@ -102,7 +150,7 @@ func (uc *ActivitySubmissionUseCase) Submit(ctx context.Context, input SubmitAct
 		return nil, err
 	}
 	if !isMember {
-		return nil, apperror.ErrForbidden
+		return nil, apperror.ErrActivityNotFound
 	}
 
 	// Check if user already submitted
@@ -140,6 +188,8 @@ func (uc *ActivitySubmissionUseCase) Submit(ctx context.Context, input SubmitAct
 	return full, nil
 }
 
+// GetByPublicID returns a submission to its owner, platform admins and the
+// group's staff; anyone else gets ErrActivitySubmissionNotFound.
 func (uc *ActivitySubmissionUseCase) GetByPublicID(ctx context.Context, publicID string, requesterPublicID string) (*entity.ActivitySubmission, error) {
 	sub, err := uc.subRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
@@ -148,6 +198,22 @@ func (uc *ActivitySubmissionUseCase) GetByPublicID(ctx context.Context, publicID
 	if sub == nil {
 		return nil, apperror.ErrActivitySubmissionNotFound
 	}
+
+	requester, err := uc.userRepo.GetByPublicID(ctx, requesterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if requester == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	visible, err := uc.canSeeSubmission(ctx, sub, requester)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, apperror.ErrActivitySubmissionNotFound
+	}
 	return sub, nil
 }
 
@@ -200,7 +266,7 @@ func (uc *ActivitySubmissionUseCase) GetOrCreateSubmission(ctx context.Context,
 		return nil, err
 	}
 	if !isMember {
-		return nil, apperror.ErrForbidden
+		return nil, apperror.ErrActivityNotFound
 	}
 
 	existing, err := uc.subRepo.GetByActivityAndUser(ctx, activity.ID, user.ID)
@@ -227,7 +293,45 @@ func (uc *ActivitySubmissionUseCase) GetOrCreateSubmission(ctx context.Context,
 	return full, nil
 }
 
-func (uc *ActivitySubmissionUseCase) ListByActivity(ctx context.Context, activityPublicID, requesterPublicID string, requesterRole entity.UserRole, page, size int) ([]entity.ActivitySubmission, int, error) {
+// reviewableStatuses are the statuses group staff see by default: drafts a
+// student has not sent yet are left out.
+var reviewableStatuses = []entity.ActivitySubmissionStatus{
+	entity.ActivitySubmissionStatusPending,
+	entity.ActivitySubmissionStatusApproved,
+	entity.ActivitySubmissionStatusReproved,
+}
+
+// parseSubmissionStatuses parses a comma-separated list of statuses such as
+// "pending,approved". An empty string yields nil.
+func parseSubmissionStatuses(raw string) ([]entity.ActivitySubmissionStatus, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var statuses []entity.ActivitySubmissionStatus
+	for _, part := range strings.Split(raw, ",") {
+		s := entity.ActivitySubmissionStatus(strings.TrimSpace(part))
+		if !slices.Contains(entity.ActivitySubmissionStatuses, s) {
+			return nil, apperror.ErrInvalidInput
+		}
+		if !slices.Contains(statuses, s) {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses, nil
+}
+
+// ListByActivity lists the submissions for an activity in the given
+// comma-separated statuses. When status is empty, drafts are excluded.
+func (uc *ActivitySubmissionUseCase) ListByActivity(ctx context.Context, activityPublicID, requesterPublicID string, requesterRole entity.UserRole, limit, offset int, status string) ([]entity.ActivitySubmission, int, error) {
+	statuses, err := parseSubmissionStatuses(status)
+	if err != nil {
+		return nil, 0, err
+	}
+	if statuses == nil {
+		statuses = reviewableStatuses
+	}
+
 	activity, err := uc.activityRepo.GetByPublicID(ctx, activityPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -251,22 +355,16 @@ func (uc *ActivitySubmissionUseCase) ListByActivity(ctx context.Context, activit
 			return nil, 0, err
 		}
 		if !isAuth {
-			return nil, 0, apperror.ErrForbidden
+			return nil, 0, denyActivity(ctx, uc.groupRepo, activity.GroupID, user)
 		}
 	}
 
-	total, err := uc.subRepo.CountByActivity(ctx, activity.ID)
+	total, err := uc.subRepo.CountByActivity(ctx, activity.ID, statuses)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
-	if page > totalPages && totalPages > 0 {
-		page = totalPages
-	}
-	offset := (page - 1) * size
-
-	subs, err := uc.subRepo.ListByActivity(ctx, activity.ID, size, offset)
+	subs, err := uc.subRepo.ListByActivity(ctx, activity.ID, limit, offset, statuses)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -318,7 +416,7 @@ func (uc *ActivitySubmissionUseCase) Review(ctx context.Context, input ReviewAct
 			return nil, err
 		}
 		if !isAdmin {
-			return nil, apperror.ErrForbidden
+			return nil, uc.denySubmission(ctx, sub, reviewer)
 		}
 	}
 
@@ -342,10 +440,121 @@ func (uc *ActivitySubmissionUseCase) Review(ctx context.Context, input ReviewAct
 	if err != nil {
 		return nil, err
 	}
+
+	uc.events.Emit(ctx, service.EventSubmissionReviewed, map[string]any{
+		"submission_id": full.PublicID,
+		"activity_id":   full.ActivityPublicID,
+		"user_id":       full.UserPublicID,
+		"reviewer_id":   reviewer.PublicID,
+		"status":        full.Status,
+		"reviewed_at":   full.ReviewedAt,
+	})
+
 	return full, nil
 }
 
-func (uc *ActivitySubmissionUseCase) ListMySubmissions(ctx context.Context, userPublicID string, page, size int) ([]entity.ActivitySubmission, int, error) {
+// ListMySubmissions lists the user's own submissions, including drafts, in the
+// given comma-separated statuses (every status when empty).
+type ReopenActivitySubmissionInput struct {
+	SubmissionPublicID string
+	ReviewerPublicID   string
+	ReviewerRole       entity.UserRole
+	Reason             string
+}
+
+// Reopen sends an approved or reproved submission back to pending so it can
+// be reviewed again, recording why, and notifies the student.
+func (uc *ActivitySubmissionUseCase) Reopen(ctx context.Context, input ReopenActivitySubmissionInput) (*entity.ActivitySubmission, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		v := apperror.NewValidationError()
+		v.Add("reason", "Reason is required.")
+		return nil, v.Err()
+	}
+
+	sub, err := uc.subRepo.GetByPublicID(ctx, input.SubmissionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, apperror.ErrActivitySubmissionNotFound
+	}
+
+	reviewer, err := uc.userRepo.GetByPublicID(ctx, input.ReviewerPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewer == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	activity, err := uc.activityRepo.GetByID(ctx, sub.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+	if activity == nil {
+		return nil, apperror.ErrActivityNotFound
+	}
+
+	if input.ReviewerRole != entity.UserRoleAdmin {
+		isAdmin, err := uc.isGroupAdmin(ctx, activity.GroupID, reviewer.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			return nil, uc.denySubmission(ctx, sub, reviewer)
+		}
+	}
+
+	reopened, err := uc.subRepo.Reopen(ctx, sub.ID, reason, reviewer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !reopened {
+		return nil, apperror.ErrActivitySubmissionNotReviewed
+	}
+
+	full, err := uc.subRepo.GetByPublicID(ctx, sub.PublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.events.Emit(ctx, service.EventSubmissionReopened, map[string]any{
+		"submission_id": full.PublicID,
+		"activity_id":   full.ActivityPublicID,
+		"user_id":       full.UserPublicID,
+		"reviewer_id":   reviewer.PublicID,
+		"reason":        reason,
+		"reopened_at":   full.ReopenedAt,
+	})
+
+	uc.notifyReopened(ctx, full)
+
+	return full, nil
+}
+
+// notifyReopened emails the student about a reopened submission. Failures are
+// logged rather than returned since the reopen itself already succeeded.
+func (uc *ActivitySubmissionUseCase) notifyReopened(ctx context.Context, sub *entity.ActivitySubmission) {
+	student, err := uc.userRepo.GetByPublicID(ctx, sub.UserPublicID)
+	if err != nil || student == nil {
+		log.Printf("failed to load student %s to notify about reopened submission %s: %v", sub.UserPublicID, sub.PublicID, err)
+		return
+	}
+
+	locale := userLocale(student)
+	activityURL := fmt.Sprintf("%s/%s/dashboard/activities/%s", uc.frontendURL, i18n.Resolve(locale), sub.ActivityPublicID)
+	if err := uc.emailSvc.SendSubmissionReopenedEmail(ctx, student.Email, student.Name, locale, sub.ActivityTitle, *sub.ReopenReason, activityURL); err != nil {
+		log.Printf("failed to send reopened submission email to %s: %v", student.Email, err)
+	}
+}
+
+func (uc *ActivitySubmissionUseCase) ListMySubmissions(ctx context.Context, userPublicID string, limit, offset int, status string) ([]entity.ActivitySubmission, int, error) {
+	statuses, err := parseSubmissionStatuses(status)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -354,18 +563,12 @@ func (uc *ActivitySubmissionUseCase) ListMySubmissions(ctx context.Context, user
 		return nil, 0, apperror.ErrUserNotFound
 	}
 
-	total, err := uc.subRepo.CountByUser(ctx, user.ID)
+	total, err := uc.subRepo.CountByUser(ctx, user.ID, statuses)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
-	if page > totalPages && totalPages > 0 {
-		page = totalPages
-	}
-	offset := (page - 1) * size
-
-	subs, err := uc.subRepo.ListByUser(ctx, user.ID, size, offset)
+	subs, err := uc.subRepo.ListByUser(ctx, user.ID, limit, offset, statuses)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -391,7 +594,7 @@ func (uc *ActivitySubmissionUseCase) SendSubmission(ctx context.Context, submiss
 	}
 
 	if sub.UserID != user.ID {
-		return nil, apperror.ErrForbidden
+		return nil, uc.denySubmission(ctx, sub, user)
 	}
 
 	// Allow sending/resending from any status.
@@ -430,7 +633,7 @@ func (uc *ActivitySubmissionUseCase) UpdateNotes(ctx context.Context, submission
 	}
 
 	if sub.UserID != user.ID {
-		return nil, apperror.ErrForbidden
+		return nil, uc.denySubmission(ctx, sub, user)
 	}
 
 	// No status restrictions.
@@ -476,7 +679,7 @@ func (uc *ActivitySubmissionUseCase) Resubmit(ctx context.Context, submissionPub
 	}
 
 	if sub.UserID != user.ID {
-		return nil, apperror.ErrForbidden
+		return nil, uc.denySubmission(ctx, sub, user)
 	}
 
 	// No status restrictions for resubmitting.
@@ -501,13 +704,49 @@ func (uc *ActivitySubmissionUseCase) Resubmit(ctx context.Context, submissionPub
 // ==========================================
 
 type QuestionStatus struct {
-	QuestionPublicID string
-	Passed           bool
-	Attempts         int
-	LastScore        *int
+	QuestionPublicID  string
+	Passed            bool
+	Attempts          int
+	AttemptsRemaining *int // nil when the question has no attempt limit or was already passed
+	LastScore         *int
 }
 
 func (uc *ActivitySubmissionUseCase) GetQuestionStatuses(ctx context.Context, activityPublicID, userPublicID string) ([]QuestionStatus, error) {
+	sub, err := uc.findOwnSubmission(ctx, activityPublicID, userPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, nil
+	}
+
+	return uc.questionStatusesForSubmission(ctx, sub.ID)
+}
+
+// ensureQuestionInActivity rejects answers to questions that are not items of
+// the activity, so unrelated questions cannot count towards it.
+func (uc *ActivitySubmissionUseCase) ensureQuestionInActivity(ctx context.Context, activityPublicID string, questionID int) error {
+	activity, err := uc.activityRepo.GetByPublicID(ctx, activityPublicID)
+	if err != nil {
+		return err
+	}
+	if activity == nil {
+		return apperror.ErrActivityNotFound
+	}
+
+	inActivity, err := uc.activityRepo.HasQuestion(ctx, activity.ID, questionID)
+	if err != nil {
+		return err
+	}
+	if !inActivity {
+		return apperror.ErrQuestionNotInActivity
+	}
+	return nil
+}
+
+// findOwnSubmission returns the user's submission for the activity, or nil
+// when the user has not started one.
+func (uc *ActivitySubmissionUseCase) findOwnSubmission(ctx context.Context, activityPublicID, userPublicID string) (*entity.ActivitySubmission, error) {
 	activity, err := uc.activityRepo.GetByPublicID(ctx, activityPublicID)
 	if err != nil {
 		return nil, err
@@ -524,21 +763,20 @@ func (uc *ActivitySubmissionUseCase) GetQuestionStatuses(ctx context.Context, ac
 		return nil, apperror.ErrUserNotFound
 	}
 
-	sub, err := uc.subRepo.GetByActivityAndUser(ctx, activity.ID, user.ID)
-	if err != nil {
-		return nil, err
-	}
-	if sub == nil {
-		return nil, nil
-	}
+	return uc.subRepo.GetByActivityAndUser(ctx, activity.ID, user.ID)
+}
 
-	qSubs, err := uc.qSubRepo.ListByActivitySubmission(ctx, sub.ID)
+// questionStatusesForSubmission aggregates the question attempts recorded
+// under an activity submission into one status per question.
+func (uc *ActivitySubmissionUseCase) questionStatusesForSubmission(ctx context.Context, submissionID int) ([]QuestionStatus, error) {
+	qSubs, err := uc.qSubRepo.ListAllByActivitySubmission(ctx, submissionID)
 	if err != nil {
 		return nil, err
 	}
 
 	type info struct {
 		questionPublicID string
+		maxAttempts      *int
 		passed           bool
 		attempts         int
 		lastScore        *int
@@ -547,7 +785,7 @@ func (uc *ActivitySubmissionUseCase) GetQuestionStatuses(ctx context.Context, ac
 	for _, qs := range qSubs {
 		i, ok := byQuestion[qs.QuestionID]
 		if !ok {
-			i = &info{questionPublicID: qs.QuestionPublicID}
+			i = &info{questionPublicID: qs.QuestionPublicID, maxAttempts: qs.QuestionMaxAttempts}
 			byQuestion[qs.QuestionID] = i
 		}
 		i.attempts++
@@ -561,11 +799,17 @@ func (uc *ActivitySubmissionUseCase) GetQuestionStatuses(ctx context.Context, ac
 
 	result := make([]QuestionStatus, 0, len(byQuestion))
 	for _, i := range byQuestion {
+		var remaining *int
+		if i.maxAttempts != nil && !i.passed {
+			r := max(*i.maxAttempts-i.attempts, 0)
+			remaining = &r
+		}
 		result = append(result, QuestionStatus{
-			QuestionPublicID: i.questionPublicID,
-			Passed:           i.passed,
-			Attempts:         i.attempts,
-			LastScore:        i.lastScore,
+			QuestionPublicID:  i.questionPublicID,
+			Passed:            i.passed,
+			Attempts:          i.attempts,
+			AttemptsRemaining: remaining,
+			LastScore:         i.lastScore,
 		})
 	}
 	return result, nil
@@ -575,16 +819,6 @@ func (uc *ActivitySubmissionUseCase) GetQuestionStatuses(ctx context.Context, ac
 // Submission Attachments
 // ==========================================
 
-var allowedSubmissionAttachmentTypes = map[string]bool{
-	"image/jpeg":      true,
-	"image/png":       true,
-	"image/webp":      true,
-	"image/gif":       true,
-	"application/pdf": true,
-}
-
-const maxSubmissionAttachmentSize = 10 * 1024 * 1024 // 10 MB
-
 func (uc *ActivitySubmissionUseCase) UploadAttachment(ctx context.Context, submissionPublicID, userPublicID, filename, contentType string, size int64, body io.Reader) (*entity.ActivitySubmissionAttachment, error) {
 	sub, err := uc.subRepo.GetByPublicID(ctx, submissionPublicID)
 	if err != nil {
@@ -603,42 +837,68 @@ func (uc *ActivitySubmissionUseCase) UploadAttachment(ctx context.Context, submi
 	}
 
 	if sub.UserID != user.ID {
-		return nil, apperror.ErrForbidden
+		return nil, uc.denySubmission(ctx, sub, user)
 	}
 	// No status restrictions for attachments.
 
-	if !allowedSubmissionAttachmentTypes[contentType] {
+	limits, err := uc.uploadSettingsForActivity(ctx, sub.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(limits.AllowedTypes, contentType) {
 		return nil, apperror.ErrInvalidFileType
 	}
-	if size > maxSubmissionAttachmentSize {
+	if size > limits.MaxSizeBytes {
 		return nil, apperror.ErrFileTooLarge
 	}
 
 	ext := filepath.Ext(filename)
 	key := fmt.Sprintf("activity-submissions/%s%s", newUUID(), ext)
 
-	url, err := uc.storageSvc.Upload(ctx, key, contentType, body)
+	stored, err := uploadDeduplicated(ctx, uc.storageSvc, uc.fileRepo, key, contentType, size, body)
 	if err != nil {
 		return nil, apperror.ErrUploadFailed
 	}
 
 	attachment := &entity.ActivitySubmissionAttachment{
 		SubmissionID: sub.ID,
-		Key:          key,
+		Key:          stored.Key,
 		Filename:     filename,
 		ContentType:  contentType,
 		SizeBytes:    size,
-		URL:          url,
+		Checksum:     &stored.Checksum,
 	}
 
 	if err := uc.subRepo.CreateFile(ctx, attachment, user.ID); err != nil {
-		_ = uc.storageSvc.Delete(ctx, key)
+		releaseStoredObject(ctx, uc.storageSvc, uc.fileRepo, stored.Key)
 		return nil, err
 	}
 
 	return attachment, nil
 }
 
+// uploadSettingsForActivity returns the attachment limits of the group that
+// owns the activity.
+func (uc *ActivitySubmissionUseCase) uploadSettingsForActivity(ctx context.Context, activityID int) (SubmissionUploadSettings, error) {
+	activity, err := uc.activityRepo.GetByID(ctx, activityID)
+	if err != nil {
+		return SubmissionUploadSettings{}, err
+	}
+	if activity == nil {
+		return SubmissionUploadSettings{}, apperror.ErrActivityNotFound
+	}
+
+	group, err := uc.groupRepo.GetByPublicID(ctx, activity.GroupPublicID)
+	if err != nil {
+		return SubmissionUploadSettings{}, err
+	}
+	if group == nil {
+		return SubmissionUploadSettings{}, apperror.ErrGroupNotFound
+	}
+
+	return submissionUploadSettings(group, uc.uploadLimits.SubmissionAttachment), nil
+}
+
 func (uc *ActivitySubmissionUseCase) DeleteAttachment(ctx context.Context, submissionPublicID, filePublicID, userPublicID string) error {
 	sub, err := uc.subRepo.GetByPublicID(ctx, submissionPublicID)
 	if err != nil {
@@ -657,7 +917,7 @@ func (uc *ActivitySubmissionUseCase) DeleteAttachment(ctx context.Context, submi
 	}
 
 	if sub.UserID != user.ID {
-		return apperror.ErrForbidden
+		return uc.denySubmission(ctx, sub, user)
 	}
 	// No status restrictions for attachments.
 
@@ -669,69 +929,130 @@ func (uc *ActivitySubmissionUseCase) DeleteAttachment(ctx context.Context, submi
 		return apperror.ErrAttachmentNotFound
 	}
 
-	_ = uc.storageSvc.Delete(ctx, attachment.Key)
-	return uc.subRepo.DeleteFile(ctx, attachment.FileID)
+	if err := uc.subRepo.DeleteFile(ctx, attachment.FileID); err != nil {
+		return err
+	}
+
+	releaseStoredObject(ctx, uc.storageSvc, uc.fileRepo, attachment.Key)
+	return nil
 }
 
 // ==========================================
 // Question Attempts for a Submission (admin)
 // ==========================================
 
-func (uc *ActivitySubmissionUseCase) GetSubmissionQuestionAttempts(ctx context.Context, submissionPublicID, requesterPublicID string, requesterRole entity.UserRole) ([]entity.QuestionSubmission, error) {
+// GetSubmissionQuestionAttempts lists a page of the question attempts recorded
+// under a submission, newest first, optionally narrowed to one question or to
+// passed or failed attempts.
+func (uc *ActivitySubmissionUseCase) GetSubmissionQuestionAttempts(ctx context.Context, submissionPublicID, requesterPublicID string, requesterRole entity.UserRole, limit, offset int, filter repository.QuestionSubmissionFilter) ([]entity.QuestionSubmission, int, error) {
 	sub, err := uc.subRepo.GetByPublicID(ctx, submissionPublicID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if sub == nil {
-		return nil, apperror.ErrActivitySubmissionNotFound
+		return nil, 0, apperror.ErrActivitySubmissionNotFound
 	}
 
 	user, err := uc.userRepo.GetByPublicID(ctx, requesterPublicID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if user == nil {
-		return nil, apperror.ErrUserNotFound
+		return nil, 0, apperror.ErrUserNotFound
 	}
 
 	// Allow if platform admin, group admin, or supervisor
 	if requesterRole != entity.UserRoleAdmin {
 		activity, err := uc.activityRepo.GetByID(ctx, sub.ActivityID)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		if activity == nil {
-			return nil, apperror.ErrActivityNotFound
+			return nil, 0, apperror.ErrActivityNotFound
 		}
 		isAuth, err := uc.isGroupAdminOrSupervisor(ctx, activity.GroupID, user.ID)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		if !isAuth {
-			return nil, apperror.ErrForbidden
+			return nil, 0, uc.denySubmission(ctx, sub, user)
 		}
 	}
 
-	return uc.qSubRepo.ListByActivitySubmission(ctx, sub.ID)
+	total, err := uc.qSubRepo.CountByActivitySubmission(ctx, sub.ID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	attempts, err := uc.qSubRepo.ListByActivitySubmission(ctx, sub.ID, limit, offset, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return attempts, total, nil
 }
 
 func (uc *ActivitySubmissionUseCase) ListAttachments(ctx context.Context, submissionPublicID, userPublicID string) ([]entity.ActivitySubmissionAttachment, error) {
-	sub, err := uc.subRepo.GetByPublicID(ctx, submissionPublicID)
+	sub, err := uc.GetByPublicID(ctx, submissionPublicID, userPublicID)
 	if err != nil {
 		return nil, err
 	}
-	if sub == nil {
-		return nil, apperror.ErrActivitySubmissionNotFound
+
+	return uc.subRepo.ListAttachments(ctx, sub.ID)
+}
+
+// GetAttachmentDownloadURL returns a short-lived signed URL for a submission
+// attachment, to whoever may see the submission.
+func (uc *ActivitySubmissionUseCase) GetAttachmentDownloadURL(ctx context.Context, submissionPublicID, filePublicID, userPublicID string) (string, error) {
+	sub, err := uc.GetByPublicID(ctx, submissionPublicID, userPublicID)
+	if err != nil {
+		return "", err
 	}
 
-	attachments, err := uc.subRepo.ListAttachments(ctx, sub.ID)
+	attachment, err := uc.subRepo.GetAttachment(ctx, sub.ID, filePublicID)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	if attachment == nil {
+		return "", apperror.ErrAttachmentNotFound
+	}
+
+	return uc.storageSvc.GetSignedURL(ctx, attachment.Key, attachmentURLExpiry)
+}
+
+// CountReviewQueue returns how many submissions await review in the groups
+// the user staffs. Users who staff no group get zero.
+func (uc *ActivitySubmissionUseCase) CountReviewQueue(ctx context.Context, userPublicID string) (int, error) {
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, apperror.ErrUserNotFound
 	}
 
-	for i := range attachments {
-		attachments[i].URL = uc.storageSvc.GetPublicURL(attachments[i].Key)
+	return uc.subRepo.CountReviewQueue(ctx, user.ID)
+}
+
+// ListReviewQueue lists the submissions counted by CountReviewQueue, oldest
+// first.
+func (uc *ActivitySubmissionUseCase) ListReviewQueue(ctx context.Context, userPublicID string, limit, offset int) ([]entity.ReviewQueueItem, int, error) {
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if user == nil {
+		return nil, 0, apperror.ErrUserNotFound
+	}
+
+	total, err := uc.subRepo.CountReviewQueue(ctx, user.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items, err := uc.subRepo.ListReviewQueue(ctx, user.ID, limit, offset)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return attachments, nil
+	return items, total, nil
 }
@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/permissions"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/text"
+	"proximos-passos/backend/internal/domain/uploadlimits"
 )
 
 type ActivityUseCase struct {
@@ -23,7 +27,10 @@ type ActivityUseCase struct {
 	videoLessonRepo  repository.VideoLessonRepository
 	handoutRepo      repository.HandoutRepository
 	exerciseListRepo repository.OpenExerciseListRepository
+	fileRepo         repository.FileRepository
 	storageSvc       service.StorageService
+	events           service.EventEmitter
+	uploadLimits     uploadlimits.Limits
 }
 
 func NewActivityUseCase(
@@ -34,7 +41,10 @@ func NewActivityUseCase(
 	videoLessonRepo repository.VideoLessonRepository,
 	handoutRepo repository.HandoutRepository,
 	exerciseListRepo repository.OpenExerciseListRepository,
+	fileRepo repository.FileRepository,
 	storageSvc service.StorageService,
+	events service.EventEmitter,
+	uploadLimits uploadlimits.Limits,
 ) *ActivityUseCase {
 	return &ActivityUseCase{
 		activityRepo:     activityRepo,
@@ -44,20 +54,82 @@ func NewActivityUseCase(
 		videoLessonRepo:  videoLessonRepo,
 		handoutRepo:      handoutRepo,
 		exerciseListRepo: exerciseListRepo,
+		fileRepo:         fileRepo,
 		storageSvc:       storageSvc,
+		events:           events,
+		uploadLimits:     uploadLimits,
 	}
 }
 
+const (
+	maxActivityTitleLength       = 255
+	maxActivityDescriptionLength = 5000
+)
+
 type CreateActivityInput struct {
 	Title       string
 	Description *string
-	DueDate     time.Time
+	DueDate     string // RFC3339, or a local date-time read in the group's time zone
 }
 
 type UpdateActivityInput struct {
 	Title       *string
 	Description *string
-	DueDate     *time.Time
+	DueDate     *string
+}
+
+// cleanActivityTitle strips control characters from a title and checks what
+// is left, recording failures on v.
+func cleanActivityTitle(v *apperror.ValidationError, raw string) string {
+	title := text.SingleLine(raw)
+	if title == "" {
+		v.Add("title", "Title is required.")
+	} else if utf8.RuneCountInString(title) > maxActivityTitleLength {
+		v.Add("title", fmt.Sprintf("Title must be at most %d characters long.", maxActivityTitleLength))
+	}
+	return title
+}
+
+// cleanActivityDescription cleans an optional description; blank input
+// yields nil.
+func cleanActivityDescription(v *apperror.ValidationError, raw string) *string {
+	description := text.MultiLine(raw)
+	if description == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(description) > maxActivityDescriptionLength {
+		v.Add("description", fmt.Sprintf("Description must be at most %d characters long.", maxActivityDescriptionLength))
+	}
+	return &description
+}
+
+const dueDateFormatMessage = "Due date must be RFC3339 (e.g., 2025-12-31T23:59:59-03:00) or a local date-time (e.g., 2025-12-31T23:59)."
+
+// localDueDateLayouts are the zone-less forms a due date may take. They are
+// read as wall-clock time in the group's time zone.
+var localDueDateLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// parseDueDate reads a due date and returns it in UTC. A value with an
+// explicit offset keeps it; a zone-less value is placed in timeZone.
+func parseDueDate(value string, timeZone string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	loc := entity.TimeZoneLocation(timeZone)
+	var lastErr error
+	for _, layout := range localDueDateLayouts {
+		t, err := time.ParseInLocation(layout, value, loc)
+		if err == nil {
+			return t.UTC(), nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
 }
 
 func (uc *ActivityUseCase) isGroupAdmin(ctx context.Context, groupID int, userPublicID string) (bool, *entity.User, error) {
@@ -114,38 +186,55 @@ func (uc *ActivityUseCase) Create(ctx context.Context, groupPublicID string, req
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyGroup(ctx, uc.groupRepo, group, user)
 	}
 
-	title := strings.TrimSpace(input.Title)
-	if title == "" {
-		return nil, apperror.ErrInvalidInput
-	}
+	v := apperror.NewValidationError()
 
+	title := cleanActivityTitle(v, input.Title)
 	var desc *string
 	if input.Description != nil {
-		d := strings.TrimSpace(*input.Description)
-		if d != "" {
-			desc = &d
-		}
+		desc = cleanActivityDescription(v, *input.Description)
+	}
+
+	var dueDate time.Time
+	if strings.TrimSpace(input.DueDate) == "" {
+		v.Add("due_date", "Due date is required.")
+	} else if parsed, err := parseDueDate(input.DueDate, group.TimeZone); err != nil {
+		v.Add("due_date", dueDateFormatMessage)
+	} else {
+		dueDate = parsed
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if !group.AllowPastDue && !dueDate.After(time.Now()) {
+		return nil, apperror.ErrDueDateInPast
 	}
 
 	activity := &entity.Activity{
 		GroupID:       group.ID,
 		GroupPublicID: group.PublicID,
+		GroupTimeZone: group.TimeZone,
 		Title:         title,
 		Description:   desc,
-		DueDate:       input.DueDate,
+		DueDate:       dueDate,
 		CreatedByID:   user.ID,
 	}
 
 	if err := uc.activityRepo.Create(ctx, activity); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrActivityTitleTaken
-		}
 		return nil, err
 	}
 
+	uc.events.Emit(ctx, service.EventActivityCreated, map[string]any{
+		"activity_id": activity.PublicID,
+		"group_id":    group.PublicID,
+		"title":       activity.Title,
+		"due_date":    activity.DueDate,
+	})
+
 	return activity, nil
 }
 
@@ -164,7 +253,7 @@ func (uc *ActivityUseCase) GetByPublicID(ctx context.Context, activityPublicID s
 			return nil, nil, err
 		}
 		if !isMember {
-			return nil, nil, apperror.ErrForbidden
+			return nil, nil, apperror.ErrActivityNotFound
 		}
 	}
 
@@ -173,13 +262,14 @@ func (uc *ActivityUseCase) GetByPublicID(ctx context.Context, activityPublicID s
 		return nil, nil, err
 	}
 
-	for i := range attachments {
-		attachments[i].URL = uc.storageSvc.GetPublicURL(attachments[i].Key)
-	}
-
 	return activity, attachments, nil
 }
 
+// Permissions returns what the requester may do with the activity.
+func (uc *ActivityUseCase) Permissions(ctx context.Context, activity *entity.Activity, requesterPublicID string) (permissions.Requester, error) {
+	return loadRequester(ctx, uc.userRepo, uc.groupRepo, activity.GroupID, requesterPublicID)
+}
+
 func (uc *ActivityUseCase) Update(ctx context.Context, activityPublicID string, requesterPublicID string, input UpdateActivityInput) (*entity.Activity, error) {
 	activity, err := uc.activityRepo.GetByPublicID(ctx, activityPublicID)
 	if err != nil {
@@ -189,39 +279,33 @@ func (uc *ActivityUseCase) Update(ctx context.Context, activityPublicID string,
 		return nil, apperror.ErrActivityNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
 	if err != nil {
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyActivity(ctx, uc.groupRepo, activity.GroupID, requester)
 	}
 
+	v := apperror.NewValidationError()
 	if input.Title != nil {
-		t := strings.TrimSpace(*input.Title)
-		if t == "" {
-			return nil, apperror.ErrInvalidInput
-		}
-		activity.Title = t
+		activity.Title = cleanActivityTitle(v, *input.Title)
 	}
-
 	if input.Description != nil {
-		d := strings.TrimSpace(*input.Description)
-		if d == "" {
-			activity.Description = nil
-		} else {
-			activity.Description = &d
-		}
+		activity.Description = cleanActivityDescription(v, *input.Description)
 	}
-
 	if input.DueDate != nil {
-		activity.DueDate = *input.DueDate
+		dueDate, err := parseDueDate(*input.DueDate, activity.GroupTimeZone)
+		if err != nil {
+			v.Add("due_date", dueDateFormatMessage)
+		}
+		activity.DueDate = dueDate
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	if err := uc.activityRepo.Update(ctx, activity); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrActivityTitleTaken
-		}
 		return nil, err
 	}
 
@@ -242,18 +326,67 @@ func (uc *ActivityUseCase) Delete(ctx context.Context, activityPublicID string,
 		return apperror.ErrActivityNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
 	if err != nil {
 		return err
 	}
 	if !isAdmin {
-		return apperror.ErrForbidden
+		return denyActivity(ctx, uc.groupRepo, activity.GroupID, requester)
 	}
 
 	return uc.activityRepo.Delete(ctx, activityPublicID)
 }
 
-func (uc *ActivityUseCase) ListUpcoming(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole, pageNumber, pageSize int, filter repository.ActivityFilter) ([]entity.Activity, int, error) {
+// resolveMemberStatusFilter validates filter.MemberStatus and points it at
+// the requester's own submissions.
+func (uc *ActivityUseCase) resolveMemberStatusFilter(ctx context.Context, requesterPublicID string, filter *repository.ActivityFilter) error {
+	switch filter.MemberStatus {
+	case "":
+		return nil
+	case repository.ActivityMemberStatusTodo, repository.ActivityMemberStatusSubmitted, repository.ActivityMemberStatusApproved:
+	default:
+		return apperror.ErrInvalidInput
+	}
+
+	user, err := uc.userRepo.GetByPublicID(ctx, requesterPublicID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+	filter.MemberID = user.ID
+	return nil
+}
+
+// loadSubmissionCountsForStaff attaches per-status submission counts to the
+// activities when the requester is a platform admin or a group admin or
+// supervisor. Members get the listing without them.
+func (uc *ActivityUseCase) loadSubmissionCountsForStaff(ctx context.Context, groupID int, requesterPublicID string, requesterRole entity.UserRole, activities []entity.Activity) error {
+	if requesterRole != entity.UserRoleAdmin {
+		user, err := uc.userRepo.GetByPublicID(ctx, requesterPublicID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return apperror.ErrUserNotFound
+		}
+		member, err := uc.groupRepo.GetMember(ctx, groupID, user.ID)
+		if err != nil {
+			return err
+		}
+		if member == nil || !member.IsActive || member.AcceptedByID == nil {
+			return nil
+		}
+		if member.Role != entity.MemberRoleAdmin && member.Role != entity.MemberRoleSupervisor {
+			return nil
+		}
+	}
+
+	return uc.activityRepo.LoadSubmissionCounts(ctx, activities)
+}
+
+func (uc *ActivityUseCase) ListUpcoming(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole, limit, offset int, filter repository.ActivityFilter) ([]entity.Activity, int, error) {
 	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -263,24 +396,20 @@ func (uc *ActivityUseCase) ListUpcoming(ctx context.Context, groupPublicID strin
 	}
 
 	if requesterRole != entity.UserRoleAdmin {
-		isMember, _, err := uc.isMember(ctx, group.ID, requesterPublicID)
+		isMember, requester, err := uc.isMember(ctx, group.ID, requesterPublicID)
 		if err != nil {
 			return nil, 0, err
 		}
 		if !isMember {
-			return nil, 0, apperror.ErrForbidden
+			return nil, 0, denyGroup(ctx, uc.groupRepo, group, requester)
 		}
 	}
 
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
+	if err := uc.resolveMemberStatusFilter(ctx, requesterPublicID, &filter); err != nil {
+		return nil, 0, err
 	}
-	offset := (pageNumber - 1) * pageSize
 
-	activities, err := uc.activityRepo.ListUpcoming(ctx, group.ID, pageSize, offset, filter)
+	activities, err := uc.activityRepo.ListUpcoming(ctx, group.ID, limit, offset, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -290,10 +419,14 @@ func (uc *ActivityUseCase) ListUpcoming(ctx context.Context, groupPublicID strin
 		return nil, 0, err
 	}
 
+	if err := uc.loadSubmissionCountsForStaff(ctx, group.ID, requesterPublicID, requesterRole, activities); err != nil {
+		return nil, 0, err
+	}
+
 	return activities, total, nil
 }
 
-func (uc *ActivityUseCase) ListPast(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole, pageNumber, pageSize int, filter repository.ActivityFilter) ([]entity.Activity, int, error) {
+func (uc *ActivityUseCase) ListPast(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole, limit, offset int, filter repository.ActivityFilter) ([]entity.Activity, int, error) {
 	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -303,24 +436,20 @@ func (uc *ActivityUseCase) ListPast(ctx context.Context, groupPublicID string, r
 	}
 
 	if requesterRole != entity.UserRoleAdmin {
-		isMember, _, err := uc.isMember(ctx, group.ID, requesterPublicID)
+		isMember, requester, err := uc.isMember(ctx, group.ID, requesterPublicID)
 		if err != nil {
 			return nil, 0, err
 		}
 		if !isMember {
-			return nil, 0, apperror.ErrForbidden
+			return nil, 0, denyGroup(ctx, uc.groupRepo, group, requester)
 		}
 	}
 
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
+	if err := uc.resolveMemberStatusFilter(ctx, requesterPublicID, &filter); err != nil {
+		return nil, 0, err
 	}
-	offset := (pageNumber - 1) * pageSize
 
-	activities, err := uc.activityRepo.ListPast(ctx, group.ID, pageSize, offset, filter)
+	activities, err := uc.activityRepo.ListPast(ctx, group.ID, limit, offset, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -330,11 +459,11 @@ func (uc *ActivityUseCase) ListPast(ctx context.Context, groupPublicID string, r
 		return nil, 0, err
 	}
 
-	return activities, total, nil
-}
+	if err := uc.loadSubmissionCountsForStaff(ctx, group.ID, requesterPublicID, requesterRole, activities); err != nil {
+		return nil, 0, err
+	}
 
-func (uc *ActivityUseCase) TotalPages(total, pageSize int) int {
-	return int(math.Ceil(float64(total) / float64(pageSize)))
+	return activities, total, nil
 }
 
 // ==========================================
@@ -349,8 +478,6 @@ var allowedAttachmentTypes = map[string]bool{
 	"application/pdf": true,
 }
 
-const maxAttachmentSize = 10 << 20 // 10MB
-
 func (uc *ActivityUseCase) UploadAttachment(ctx context.Context, activityPublicID string, requesterPublicID string, filename string, contentType string, size int64, body io.Reader) (*entity.ActivityAttachment, error) {
 	activity, err := uc.activityRepo.GetByPublicID(ctx, activityPublicID)
 	if err != nil {
@@ -365,35 +492,35 @@ func (uc *ActivityUseCase) UploadAttachment(ctx context.Context, activityPublicI
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyActivity(ctx, uc.groupRepo, activity.GroupID, user)
 	}
 
 	if !allowedAttachmentTypes[contentType] {
 		return nil, apperror.ErrInvalidFileType
 	}
-	if size > maxAttachmentSize {
+	if size > uc.uploadLimits.ActivityAttachment {
 		return nil, apperror.ErrFileTooLarge
 	}
 
 	ext := filepath.Ext(filename)
 	key := fmt.Sprintf("activities/%s%s", newUUID(), ext)
 
-	url, err := uc.storageSvc.Upload(ctx, key, contentType, body)
+	stored, err := uploadDeduplicated(ctx, uc.storageSvc, uc.fileRepo, key, contentType, size, body)
 	if err != nil {
 		return nil, apperror.ErrUploadFailed
 	}
 
 	attachment := &entity.ActivityAttachment{
 		ActivityID:  activity.ID,
-		Key:         key,
+		Key:         stored.Key,
 		Filename:    filename,
 		ContentType: contentType,
 		SizeBytes:   size,
-		URL:         url,
+		Checksum:    &stored.Checksum,
 	}
 
 	if err := uc.activityRepo.CreateFile(ctx, attachment, user.ID); err != nil {
-		_ = uc.storageSvc.Delete(ctx, key)
+		releaseStoredObject(ctx, uc.storageSvc, uc.fileRepo, stored.Key)
 		return nil, err
 	}
 
@@ -409,12 +536,12 @@ func (uc *ActivityUseCase) DeleteAttachment(ctx context.Context, activityPublicI
 		return apperror.ErrActivityNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
 	if err != nil {
 		return err
 	}
 	if !isAdmin {
-		return apperror.ErrForbidden
+		return denyActivity(ctx, uc.groupRepo, activity.GroupID, requester)
 	}
 
 	attachment, err := uc.activityRepo.GetAttachment(ctx, activity.ID, filePublicID)
@@ -425,9 +552,49 @@ func (uc *ActivityUseCase) DeleteAttachment(ctx context.Context, activityPublicI
 		return apperror.ErrAttachmentNotFound
 	}
 
-	_ = uc.storageSvc.Delete(ctx, attachment.Key)
+	if err := uc.activityRepo.DeleteFile(ctx, attachment.FileID); err != nil {
+		return err
+	}
+
+	releaseStoredObject(ctx, uc.storageSvc, uc.fileRepo, attachment.Key)
+	return nil
+}
+
+// attachmentURLExpiry bounds how long a download link handed to a group member
+// stays valid.
+const attachmentURLExpiry = 5 * time.Minute
+
+// GetAttachmentDownloadURL returns a short-lived signed URL for an activity
+// attachment. Only members of the activity's group (or platform admins) may
+// download it.
+func (uc *ActivityUseCase) GetAttachmentDownloadURL(ctx context.Context, activityPublicID string, filePublicID string, requesterPublicID string, requesterRole entity.UserRole) (string, error) {
+	activity, err := uc.activityRepo.GetByPublicID(ctx, activityPublicID)
+	if err != nil {
+		return "", err
+	}
+	if activity == nil {
+		return "", apperror.ErrActivityNotFound
+	}
+
+	if requesterRole != entity.UserRoleAdmin {
+		isMember, _, err := uc.isMember(ctx, activity.GroupID, requesterPublicID)
+		if err != nil {
+			return "", err
+		}
+		if !isMember {
+			return "", apperror.ErrActivityNotFound
+		}
+	}
+
+	attachment, err := uc.activityRepo.GetAttachment(ctx, activity.ID, filePublicID)
+	if err != nil {
+		return "", err
+	}
+	if attachment == nil {
+		return "", apperror.ErrAttachmentNotFound
+	}
 
-	return uc.activityRepo.DeleteFile(ctx, attachment.FileID)
+	return uc.storageSvc.GetSignedURL(ctx, attachment.Key, attachmentURLExpiry)
 }
 
 // ==========================================
@@ -458,12 +625,12 @@ func (uc *ActivityUseCase) CreateItem(ctx context.Context, activityPublicID stri
 		return nil, apperror.ErrActivityNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
 	if err != nil {
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyActivity(ctx, uc.groupRepo, activity.GroupID, requester)
 	}
 
 	title := strings.TrimSpace(input.Title)
@@ -496,6 +663,9 @@ func (uc *ActivityUseCase) CreateItem(ctx context.Context, activityPublicID stri
 		if q == nil {
 			return nil, apperror.ErrQuestionNotFound
 		}
+		if q.ArchivedAt != nil {
+			return nil, apperror.ErrQuestionArchived
+		}
 		item.QuestionID = &q.ID
 		count++
 	}
@@ -569,12 +739,12 @@ func (uc *ActivityUseCase) UpdateItem(ctx context.Context, itemPublicID string,
 		return nil, apperror.ErrActivityNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
 	if err != nil {
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyActivity(ctx, uc.groupRepo, activity.GroupID, requester)
 	}
 
 	if input.Title != nil {
@@ -618,37 +788,109 @@ func (uc *ActivityUseCase) DeleteItem(ctx context.Context, itemPublicID string,
 		return apperror.ErrActivityNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
 	if err != nil {
 		return err
 	}
 	if !isAdmin {
-		return apperror.ErrForbidden
+		return denyActivity(ctx, uc.groupRepo, activity.GroupID, requester)
 	}
 
 	return uc.activityRepo.DeleteItem(ctx, itemPublicID)
 }
 
-func (uc *ActivityUseCase) ListItems(ctx context.Context, activityPublicID string, requesterPublicID string, requesterRole entity.UserRole) ([]entity.ActivityItem, error) {
+// ListItems returns the activity's items, restricted to itemType when it is
+// not empty, along with the number of items of each type in the whole
+// activity.
+func (uc *ActivityUseCase) ListItems(ctx context.Context, activityPublicID string, requesterPublicID string, requesterRole entity.UserRole, itemType string) ([]entity.ActivityItem, map[entity.ActivityItemType]int, error) {
+	var typeFilter *entity.ActivityItemType
+	if itemType != "" {
+		t := entity.ActivityItemType(itemType)
+		if !slices.Contains(entity.ActivityItemTypes, t) {
+			return nil, nil, apperror.ErrInvalidInput
+		}
+		typeFilter = &t
+	}
+
 	activity, err := uc.activityRepo.GetByPublicID(ctx, activityPublicID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if activity == nil {
-		return nil, apperror.ErrActivityNotFound
+		return nil, nil, apperror.ErrActivityNotFound
 	}
 
 	if requesterRole != entity.UserRoleAdmin {
 		isMember, _, err := uc.isMember(ctx, activity.GroupID, requesterPublicID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if !isMember {
+			return nil, nil, apperror.ErrActivityNotFound
+		}
+	}
+
+	items, err := uc.activityRepo.ListItems(ctx, activity.ID, typeFilter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts, err := uc.activityRepo.CountItemsByType(ctx, activity.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, counts, nil
+}
+
+// GetItemDetail returns an activity item with its question fully loaded
+// (options and images). The boolean reports whether the requester may see
+// the answer key: platform admins and group admins can, other members cannot.
+func (uc *ActivityUseCase) GetItemDetail(ctx context.Context, itemPublicID string, requesterPublicID string, requesterRole entity.UserRole) (*entity.ActivityItem, bool, error) {
+	item, err := uc.activityRepo.GetItemByPublicID(ctx, itemPublicID)
+	if err != nil {
+		return nil, false, err
+	}
+	if item == nil {
+		return nil, false, apperror.ErrActivityItemNotFound
+	}
+
+	activity, err := uc.activityRepo.GetByID(ctx, item.ActivityID)
+	if err != nil {
+		return nil, false, err
+	}
+	if activity == nil {
+		return nil, false, apperror.ErrActivityNotFound
+	}
+
+	showAnswers := requesterRole == entity.UserRoleAdmin
+	if !showAnswers {
+		isMember, _, err := uc.isMember(ctx, activity.GroupID, requesterPublicID)
+		if err != nil {
+			return nil, false, err
 		}
 		if !isMember {
-			return nil, apperror.ErrForbidden
+			return nil, false, apperror.ErrActivityNotFound
+		}
+
+		showAnswers, _, err = uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	if item.Type == entity.ActivityItemTypeQuestion && item.QuestionPublicID != nil {
+		q, err := uc.questionRepo.GetByPublicID(ctx, *item.QuestionPublicID)
+		if err != nil {
+			return nil, false, err
+		}
+		if q != nil {
+			resolveQuestionImageURLs(uc.storageSvc, q)
+			item.Question = q
 		}
 	}
 
-	return uc.activityRepo.ListItems(ctx, activity.ID)
+	return item, showAnswers, nil
 }
 
 func (uc *ActivityUseCase) ReorderItems(ctx context.Context, activityPublicID string, requesterPublicID string, orderedIDs []string) error {
@@ -660,12 +902,12 @@ func (uc *ActivityUseCase) ReorderItems(ctx context.Context, activityPublicID st
 		return apperror.ErrActivityNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, activity.GroupID, requesterPublicID)
 	if err != nil {
 		return err
 	}
 	if !isAdmin {
-		return apperror.ErrForbidden
+		return denyActivity(ctx, uc.groupRepo, activity.GroupID, requester)
 	}
 
 	return uc.activityRepo.ReorderItems(ctx, activity.ID, orderedIDs)
@@ -3,8 +3,11 @@ package usecase
 import (
 	"context"
 	"strings"
+	"time"
 
 	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/email"
+	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/infrastructure/jwt"
 
@@ -12,33 +15,52 @@ import (
 )
 
 type AuthUseCase struct {
-	repo       repository.UserRepository
-	jwtService *jwt.Service
+	repo        repository.UserRepository
+	sessionRepo repository.SessionRepository
+	eventRepo   repository.ImpersonationEventRepository
+	jwtService  *jwt.Service
 }
 
-func NewAuthUseCase(repo repository.UserRepository, jwtService *jwt.Service) *AuthUseCase {
-	return &AuthUseCase{repo: repo, jwtService: jwtService}
+func NewAuthUseCase(repo repository.UserRepository, sessionRepo repository.SessionRepository, eventRepo repository.ImpersonationEventRepository, jwtService *jwt.Service) *AuthUseCase {
+	return &AuthUseCase{repo: repo, sessionRepo: sessionRepo, eventRepo: eventRepo, jwtService: jwtService}
 }
 
 type LoginInput struct {
 	Email    string
 	Password string
+	Client   ClientInfo
 }
 
+// ClientInfo describes the device a session is started from, shown to the
+// user when they review their sessions.
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// maxUserAgentLength matches the sessions.user_agent column limit.
+const maxUserAgentLength = 512
+
 type LoginOutput struct {
-	Token     string
-	ExpiresAt int64
+	Token            string
+	ExpiresAt        int64 // access token expiry
+	SessionExpiresAt int64 // until when the token can be refreshed
 }
 
 func (uc *AuthUseCase) Login(ctx context.Context, input LoginInput) (*LoginOutput, error) {
-	email := strings.TrimSpace(input.Email)
 	password := strings.TrimSpace(input.Password)
 
-	if email == "" || password == "" {
+	if strings.TrimSpace(input.Email) == "" || password == "" {
 		return nil, apperror.ErrInvalidInput
 	}
 
-	user, err := uc.repo.GetByEmail(ctx, email)
+	// A malformed address cannot belong to any account.
+	address, err := email.Normalize(input.Email)
+	if err != nil {
+		return nil, apperror.ErrInvalidCredentials
+	}
+
+	user, err := uc.repo.GetByEmail(ctx, address)
 	if err != nil {
 		return nil, err
 	}
@@ -53,14 +75,214 @@ func (uc *AuthUseCase) Login(ctx context.Context, input LoginInput) (*LoginOutpu
 	if user.EmailVerifiedAt == nil {
 		return nil, apperror.ErrEmailNotVerified
 	}
+	if user.Status == entity.UserStatusSuspended {
+		return nil, apperror.ErrAccountSuspended
+	}
+
+	return uc.startSession(ctx, user, uc.jwtService.RefreshTTL(), input.Client)
+}
+
+// startSession records a new session for user and issues its token.
+func (uc *AuthUseCase) startSession(ctx context.Context, user *entity.User, ttl time.Duration, client ClientInfo) (*LoginOutput, error) {
+	session := newSession(user, ttl, client)
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
 
-	token, expiresAt, err := uc.jwtService.Generate(user)
+	return uc.issueToken(user, "", session)
+}
+
+func newSession(user *entity.User, ttl time.Duration, client ClientInfo) *entity.Session {
+	userAgent := []rune(client.UserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return &entity.Session{
+		UserID:    user.ID,
+		UserAgent: string(userAgent),
+		IPAddress: client.IPAddress,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// issueToken signs an access token for session, capped at the session's own
+// expiry.
+func (uc *AuthUseCase) issueToken(user *entity.User, impersonatorPublicID string, session *entity.Session) (*LoginOutput, error) {
+	expiresAt := uc.jwtService.AccessExpiry(session.ExpiresAt)
+
+	var token string
+	var err error
+	if impersonatorPublicID == "" {
+		token, err = uc.jwtService.Generate(user, session.PublicID, expiresAt)
+	} else {
+		token, err = uc.jwtService.GenerateImpersonation(user, impersonatorPublicID, session.PublicID, expiresAt)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	return &LoginOutput{
-		Token:     token,
-		ExpiresAt: expiresAt.Unix(),
+		Token:            token,
+		ExpiresAt:        expiresAt.Unix(),
+		SessionExpiresAt: session.ExpiresAt.Unix(),
 	}, nil
 }
+
+// Refresh issues a new access token for the live session behind token, which
+// may itself have expired. Impersonation sessions stay impersonated.
+func (uc *AuthUseCase) Refresh(ctx context.Context, token string) (*LoginOutput, error) {
+	claims, err := uc.jwtService.ParseSession(token)
+	if err != nil {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	session, err := uc.sessionRepo.GetActive(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	user, err := uc.repo.GetByPublicID(ctx, claims.UserPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.ID != session.UserID {
+		return nil, apperror.ErrUnauthorized
+	}
+	if user.Status == entity.UserStatusSuspended {
+		return nil, apperror.ErrAccountSuspended
+	}
+
+	return uc.issueToken(user, claims.ImpersonatedBy, session)
+}
+
+// Logout revokes the session behind token, even once the token itself has
+// expired. Tokens that do not parse have nothing left to revoke, so they are
+// ignored.
+func (uc *AuthUseCase) Logout(ctx context.Context, token string) error {
+	claims, err := uc.jwtService.ParseSession(token)
+	if err != nil {
+		return nil
+	}
+	return uc.sessionRepo.Revoke(ctx, claims.ID)
+}
+
+// LogoutAll revokes every session of the user, on all devices.
+func (uc *AuthUseCase) LogoutAll(ctx context.Context, userPublicID string) error {
+	user, err := uc.repo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+	return uc.sessionRepo.RevokeAllByUser(ctx, user.ID)
+}
+
+// impersonationTTL bounds how long an admin can act as another user before
+// having to start over.
+const impersonationTTL = 30 * time.Minute
+
+// Impersonate issues a short-lived session for the target user that carries
+// the admin's public ID and remembers the admin's own session, so stopping
+// can return to it. Admins cannot impersonate themselves or other admins.
+func (uc *AuthUseCase) Impersonate(ctx context.Context, adminPublicID, adminSessionPublicID, targetPublicID string, client ClientInfo) (*LoginOutput, error) {
+	if adminPublicID == targetPublicID {
+		return nil, apperror.ErrImpersonationNotAllowed
+	}
+
+	admin, err := uc.repo.GetByPublicID(ctx, adminPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, apperror.ErrUnauthorized
+	}
+	adminSession, err := uc.sessionRepo.GetActive(ctx, adminSessionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if adminSession == nil || adminSession.UserID != admin.ID || adminSession.ImpersonatorSessionID != nil {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	target, err := uc.repo.GetByPublicID(ctx, targetPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+	if target.Role == entity.UserRoleAdmin {
+		return nil, apperror.ErrImpersonationNotAllowed
+	}
+
+	if err := uc.eventRepo.Create(ctx, &entity.ImpersonationEvent{
+		AdminID:      admin.ID,
+		TargetUserID: target.ID,
+		Action:       entity.ImpersonationStart,
+		IPAddress:    client.IPAddress,
+	}); err != nil {
+		return nil, err
+	}
+
+	session := newSession(target, impersonationTTL, client)
+	session.ImpersonatorSessionID = &adminSession.ID
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return uc.issueToken(target, admin.PublicID, session)
+}
+
+// StopImpersonation ends an impersonated session and hands back a token for
+// the admin session it was started from. No new admin session is created: if
+// the original one has ended, the admin has to log in again.
+func (uc *AuthUseCase) StopImpersonation(ctx context.Context, impersonatorPublicID, sessionPublicID string, client ClientInfo) (*LoginOutput, error) {
+	if impersonatorPublicID == "" {
+		return nil, apperror.ErrNotImpersonating
+	}
+
+	session, err := uc.sessionRepo.GetActive(ctx, sessionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.ImpersonatorSessionID == nil {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	admin, err := uc.repo.GetByPublicID(ctx, impersonatorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	if err := uc.eventRepo.Create(ctx, &entity.ImpersonationEvent{
+		AdminID:      admin.ID,
+		TargetUserID: session.UserID,
+		Action:       entity.ImpersonationStop,
+		IPAddress:    client.IPAddress,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := uc.sessionRepo.Revoke(ctx, sessionPublicID); err != nil {
+		return nil, err
+	}
+
+	original, err := uc.sessionRepo.GetActiveByID(ctx, *session.ImpersonatorSessionID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil || original.UserID != admin.ID ||
+		admin.Role != entity.UserRoleAdmin || admin.Status == entity.UserStatusSuspended {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	return uc.issueToken(admin, "", original)
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/infrastructure/jwt"
+)
+
+type fakeAuthUserRepo struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (f *fakeAuthUserRepo) GetByPublicID(_ context.Context, publicID string) (*entity.User, error) {
+	return f.users[publicID], nil
+}
+
+type fakeSessionRepo struct {
+	repository.SessionRepository
+	sessions []*entity.Session
+}
+
+func (f *fakeSessionRepo) Create(_ context.Context, s *entity.Session) error {
+	s.ID = len(f.sessions) + 1
+	s.PublicID = fmt.Sprintf("session-%d", s.ID)
+	f.sessions = append(f.sessions, s)
+	return nil
+}
+
+func (f *fakeSessionRepo) GetActive(_ context.Context, publicID string) (*entity.Session, error) {
+	for _, s := range f.sessions {
+		if s.PublicID == publicID && s.RevokedAt == nil && s.ExpiresAt.After(time.Now()) {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSessionRepo) GetActiveByID(ctx context.Context, id int) (*entity.Session, error) {
+	if id < 1 || id > len(f.sessions) {
+		return nil, nil
+	}
+	return f.GetActive(ctx, f.sessions[id-1].PublicID)
+}
+
+func (f *fakeSessionRepo) Revoke(_ context.Context, publicID string) error {
+	now := time.Now()
+	for _, s := range f.sessions {
+		if s.PublicID == publicID {
+			s.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeImpersonationEventRepo struct {
+	events []entity.ImpersonationEvent
+}
+
+func (f *fakeImpersonationEventRepo) Create(_ context.Context, e *entity.ImpersonationEvent) error {
+	f.events = append(f.events, *e)
+	return nil
+}
+
+func newImpersonationFixture(t *testing.T) (*AuthUseCase, *fakeSessionRepo, *fakeImpersonationEventRepo, *jwt.Service, *entity.Session) {
+	t.Helper()
+	users := &fakeAuthUserRepo{users: map[string]*entity.User{
+		"admin":   {ID: 1, PublicID: "admin", Role: entity.UserRoleAdmin, Status: entity.UserStatusActive},
+		"student": {ID: 2, PublicID: "student", Role: entity.UserRoleRegular, Status: entity.UserStatusActive},
+	}}
+	sessions := &fakeSessionRepo{}
+	events := &fakeImpersonationEventRepo{}
+	jwtService := jwt.NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	uc := NewAuthUseCase(users, sessions, events, jwtService)
+
+	adminSession := &entity.Session{UserID: 1, ExpiresAt: time.Now().Add(24 * time.Hour)}
+	if err := sessions.Create(context.Background(), adminSession); err != nil {
+		t.Fatal(err)
+	}
+	return uc, sessions, events, jwtService, adminSession
+}
+
+func TestStopImpersonationRestoresOriginalSession(t *testing.T) {
+	ctx := context.Background()
+	uc, sessions, events, jwtService, adminSession := newImpersonationFixture(t)
+
+	out, err := uc.Impersonate(ctx, "admin", adminSession.PublicID, "student", ClientInfo{IPAddress: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("Impersonate: %v", err)
+	}
+	claims, err := jwtService.Parse(out.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserPublicID != "student" || claims.ImpersonatedBy != "admin" {
+		t.Fatalf("impersonation claims = %q by %q", claims.UserPublicID, claims.ImpersonatedBy)
+	}
+
+	out, err = uc.StopImpersonation(ctx, "admin", claims.ID, ClientInfo{IPAddress: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("StopImpersonation: %v", err)
+	}
+	claims, err = jwtService.Parse(out.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserPublicID != "admin" || claims.ImpersonatedBy != "" {
+		t.Fatalf("restored claims = %q by %q", claims.UserPublicID, claims.ImpersonatedBy)
+	}
+	if claims.ID != adminSession.PublicID {
+		t.Errorf("restored session = %q, want the original %q", claims.ID, adminSession.PublicID)
+	}
+	if out.SessionExpiresAt != adminSession.ExpiresAt.Unix() {
+		t.Errorf("SessionExpiresAt = %d, want the original session's %d", out.SessionExpiresAt, adminSession.ExpiresAt.Unix())
+	}
+	if len(sessions.sessions) != 2 {
+		t.Errorf("sessions created = %d, want 2 (admin + impersonation)", len(sessions.sessions))
+	}
+	if sessions.sessions[1].RevokedAt == nil {
+		t.Error("impersonation session was not revoked")
+	}
+
+	if len(events.events) != 2 {
+		t.Fatalf("events = %d, want 2", len(events.events))
+	}
+	for i, want := range []entity.ImpersonationAction{entity.ImpersonationStart, entity.ImpersonationStop} {
+		e := events.events[i]
+		if e.Action != want || e.AdminID != 1 || e.TargetUserID != 2 || e.IPAddress != "10.0.0.1" {
+			t.Errorf("event %d = %+v, want %s by admin 1 on user 2", i, e, want)
+		}
+	}
+}
+
+func TestStopImpersonationRequiresLiveOriginalSession(t *testing.T) {
+	ctx := context.Background()
+	uc, sessions, _, jwtService, adminSession := newImpersonationFixture(t)
+
+	out, err := uc.Impersonate(ctx, "admin", adminSession.PublicID, "student", ClientInfo{})
+	if err != nil {
+		t.Fatalf("Impersonate: %v", err)
+	}
+	claims, err := jwtService.Parse(out.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The admin logged out elsewhere while impersonating.
+	if err := sessions.Revoke(ctx, adminSession.PublicID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = uc.StopImpersonation(ctx, "admin", claims.ID, ClientInfo{})
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+	if len(sessions.sessions) != 2 {
+		t.Errorf("sessions created = %d, want no new admin session", len(sessions.sessions))
+	}
+	if sessions.sessions[1].RevokedAt == nil {
+		t.Error("impersonation session was not revoked")
+	}
+}
+
+func TestStopImpersonationRejectsRegularSession(t *testing.T) {
+	uc, _, events, _, adminSession := newImpersonationFixture(t)
+
+	_, err := uc.StopImpersonation(context.Background(), "admin", adminSession.PublicID, ClientInfo{})
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("events = %d, want none", len(events.events))
+	}
+}
+
+func (f *fakeSessionRepo) RevokeOthersByUser(_ context.Context, userID int, keepPublicID string) error {
+	now := time.Now()
+	for _, s := range f.sessions {
+		if s.UserID == userID && s.PublicID != keepPublicID && s.RevokedAt == nil {
+			s.RevokedAt = &now
+		}
+	}
+	return nil
+}
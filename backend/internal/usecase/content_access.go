@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+const (
+	// contentAccessWindow is how long repeat views or downloads of the same
+	// content by the same user are ignored, so refreshes do not inflate counts.
+	contentAccessWindow = 10 * time.Minute
+	// contentAccessQueueSize bounds how many accesses wait to be stored.
+	// When the database falls behind, further accesses are dropped instead of
+	// slowing down the requests that produced them.
+	contentAccessQueueSize = 1024
+	// contentAccessPurgeInterval spaces out the deletion of expired accesses.
+	contentAccessPurgeInterval = time.Hour
+)
+
+type contentAccess struct {
+	contentType  entity.ActivityItemType
+	contentID    int
+	userPublicID string
+	action       entity.ContentAccessAction
+}
+
+// ContentAccessRecorder stores content views and downloads off the request
+// path: Record queues an access and Run writes the queue one access at a
+// time, so the dedupe window sees every earlier access. Run also deletes
+// accesses older than retention, which bounds both the table and how far
+// back download counts and content stats reach.
+type ContentAccessRecorder struct {
+	repo      repository.ContentAccessRepository
+	retention time.Duration
+	queue     chan contentAccess
+}
+
+func NewContentAccessRecorder(repo repository.ContentAccessRepository, retention time.Duration) *ContentAccessRecorder {
+	return &ContentAccessRecorder{
+		repo:      repo,
+		retention: retention,
+		queue:     make(chan contentAccess, contentAccessQueueSize),
+	}
+}
+
+// Record queues an access to content by the user without blocking.
+func (r *ContentAccessRecorder) Record(contentType entity.ActivityItemType, contentID int, userPublicID string, action entity.ContentAccessAction) {
+	select {
+	case r.queue <- contentAccess{contentType: contentType, contentID: contentID, userPublicID: userPublicID, action: action}:
+	default:
+		log.Printf("content access queue is full, dropping %s of %s %d", action, contentType, contentID)
+	}
+}
+
+// Run stores queued accesses and purges expired ones until ctx is cancelled.
+func (r *ContentAccessRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(contentAccessPurgeInterval)
+	defer ticker.Stop()
+
+	r.Purge(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case access := <-r.queue:
+			if err := r.repo.Record(ctx, access.contentType, access.contentID, access.userPublicID, access.action, contentAccessWindow); err != nil {
+				log.Printf("failed to record %s of %s %d: %v", access.action, access.contentType, access.contentID, err)
+			}
+		case <-ticker.C:
+			r.Purge(ctx)
+		}
+	}
+}
+
+// Purge deletes accesses recorded more than retention ago.
+func (r *ContentAccessRecorder) Purge(ctx context.Context) {
+	deleted, err := r.repo.DeleteBefore(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		log.Printf("failed to purge content accesses: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("purged %d content accesses", deleted)
+	}
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type fakeContentAccessRepo struct {
+	repository.ContentAccessRepository
+	mu           sync.Mutex
+	recorded     []contentAccess
+	deleteBefore time.Time
+	done         chan struct{}
+}
+
+func (r *fakeContentAccessRepo) Record(_ context.Context, contentType entity.ActivityItemType, contentID int, userPublicID string, action entity.ContentAccessAction, window time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recorded = append(r.recorded, contentAccess{contentType, contentID, userPublicID, action})
+	if len(r.recorded) == 2 {
+		close(r.done)
+	}
+	return nil
+}
+
+func (r *fakeContentAccessRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deleteBefore = before
+	return 0, nil
+}
+
+func TestContentAccessRecorderStoresQueuedAccessesInOrder(t *testing.T) {
+	repo := &fakeContentAccessRepo{done: make(chan struct{})}
+	recorder := NewContentAccessRecorder(repo, 24*time.Hour)
+
+	recorder.Record(entity.ActivityItemTypeHandout, 1, "user-1", entity.ContentAccessView)
+	recorder.Record(entity.ActivityItemTypeHandout, 1, "user-1", entity.ContentAccessDownload)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go recorder.Run(ctx)
+
+	select {
+	case <-repo.done:
+	case <-time.After(time.Second):
+		t.Fatal("queued accesses were not stored")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if repo.recorded[0].action != entity.ContentAccessView || repo.recorded[1].action != entity.ContentAccessDownload {
+		t.Errorf("recorded %+v, want the view before the download", repo.recorded)
+	}
+	if age := time.Since(repo.deleteBefore); age < 24*time.Hour || age > 25*time.Hour {
+		t.Errorf("purged accesses older than %v, want the 24h retention", age)
+	}
+}
+
+func TestContentAccessRecorderDropsWhenQueueIsFull(t *testing.T) {
+	recorder := NewContentAccessRecorder(&fakeContentAccessRepo{}, time.Hour)
+
+	finished := make(chan struct{})
+	go func() {
+		for i := 0; i <= contentAccessQueueSize; i++ {
+			recorder.Record(entity.ActivityItemTypeVideoLesson, i, "user-1", entity.ContentAccessView)
+		}
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("Record blocked on a full queue")
+	}
+	if len(recorder.queue) != contentAccessQueueSize {
+		t.Errorf("queue holds %d accesses, want %d", len(recorder.queue), contentAccessQueueSize)
+	}
+}
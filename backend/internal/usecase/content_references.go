@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+// ensureContentUnused rejects deleting content still used by an active
+// activity with ErrContentInUse, listing those activities. A forced delete
+// skips this check and has the content repository remove the referencing
+// items in the same transaction as the content.
+func ensureContentUnused(ctx context.Context, activityRepo repository.ActivityRepository, itemType entity.ActivityItemType, contentID int) error {
+	refs, err := activityRepo.ListContentReferences(ctx, itemType, contentID)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	activities := make([]map[string]string, len(refs))
+	for i, ref := range refs {
+		activities[i] = map[string]string{"id": ref.PublicID, "title": ref.Title}
+	}
+	return apperror.WithDetails(
+		apperror.ErrContentInUse.Code,
+		apperror.ErrContentInUse.Message,
+		apperror.ErrContentInUse.HTTPStatus,
+		map[string]any{"activities": activities},
+	)
+}
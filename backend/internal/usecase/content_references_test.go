@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type fakeContentRefRepo struct {
+	repository.ActivityRepository
+	refs []entity.ActivityRef
+}
+
+func (f *fakeContentRefRepo) ListContentReferences(_ context.Context, _ entity.ActivityItemType, _ int) ([]entity.ActivityRef, error) {
+	return f.refs, nil
+}
+
+type fakeDeleteHandoutRepo struct {
+	repository.HandoutRepository
+	deleted     bool
+	removeItems bool
+}
+
+func (f *fakeDeleteHandoutRepo) GetByPublicID(_ context.Context, publicID string) (*entity.Handout, error) {
+	return &entity.Handout{ID: 3, PublicID: publicID}, nil
+}
+
+func (f *fakeDeleteHandoutRepo) Delete(_ context.Context, _ string, removeItems bool) error {
+	f.deleted, f.removeItems = true, removeItems
+	return nil
+}
+
+func TestDeleteContentInUse(t *testing.T) {
+	ctx := context.Background()
+	refs := []entity.ActivityRef{{PublicID: "a1", Title: "Lista 1"}}
+
+	t.Run("rejects without force", func(t *testing.T) {
+		handouts := &fakeDeleteHandoutRepo{}
+		uc := &HandoutUseCase{handoutRepo: handouts, activityRepo: &fakeContentRefRepo{refs: refs}}
+
+		err := uc.Delete(ctx, "h1", false)
+		var appErr *apperror.AppError
+		if !errors.As(err, &appErr) || appErr.Code != apperror.CodeContentInUse {
+			t.Fatalf("err = %v, want CONTENT_IN_USE", err)
+		}
+		if handouts.deleted {
+			t.Error("content in use was deleted")
+		}
+	})
+
+	t.Run("force removes items with the content", func(t *testing.T) {
+		handouts := &fakeDeleteHandoutRepo{}
+		activities := &fakeContentRefRepo{refs: refs}
+		uc := &HandoutUseCase{handoutRepo: handouts, activityRepo: activities}
+
+		if err := uc.Delete(ctx, "h1", true); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if !handouts.deleted || !handouts.removeItems {
+			t.Errorf("deleted = %v, removeItems = %v; want both in one repository call", handouts.deleted, handouts.removeItems)
+		}
+	})
+}
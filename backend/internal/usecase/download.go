@@ -0,0 +1,10 @@
+package usecase
+
+import "strings"
+
+// countsAsDownload reports whether a request for byteRange starts a download.
+// Players and download managers fetch files in several ranged requests, so
+// only whole-file requests and those starting at byte zero are counted.
+func countsAsDownload(byteRange string) bool {
+	return byteRange == "" || strings.HasPrefix(strings.TrimSpace(byteRange), "bytes=0-")
+}
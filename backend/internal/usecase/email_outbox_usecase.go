@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type EmailOutboxUseCase struct {
+	outboxRepo repository.EmailOutboxRepository
+}
+
+func NewEmailOutboxUseCase(outboxRepo repository.EmailOutboxRepository) *EmailOutboxUseCase {
+	return &EmailOutboxUseCase{outboxRepo: outboxRepo}
+}
+
+// ListFailed returns the dead-lettered emails that exhausted their retries.
+func (uc *EmailOutboxUseCase) ListFailed(ctx context.Context, limit, offset int) ([]entity.OutboxEmail, int, error) {
+	emails, err := uc.outboxRepo.ListFailed(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := uc.outboxRepo.CountFailed(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return emails, total, nil
+}
+
+// Retry puts a failed email back in the queue with a fresh attempt budget.
+func (uc *EmailOutboxUseCase) Retry(ctx context.Context, publicID string) error {
+	email, err := uc.outboxRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return err
+	}
+	if email == nil {
+		return apperror.ErrEmailNotFound
+	}
+	if email.Status != entity.EmailStatusFailed {
+		return apperror.ErrEmailNotFailed
+	}
+	return uc.outboxRepo.Requeue(ctx, email.ID)
+}
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"math"
 	"strings"
 
 	"proximos-passos/backend/internal/domain/apperror"
@@ -25,6 +24,7 @@ type CreateExamInput struct {
 	Title         string
 	Description   *string
 	Year          int
+	Phase         *int
 }
 
 type UpdateExamInput struct {
@@ -32,6 +32,7 @@ type UpdateExamInput struct {
 	Title         *string
 	Description   *string
 	Year          *int
+	Phase         *int // 0 clears the phase
 }
 
 func (uc *ExamUseCase) Create(ctx context.Context, createdByPublicID string, input CreateExamInput) (*entity.Exam, error) {
@@ -68,18 +69,20 @@ func (uc *ExamUseCase) Create(ctx context.Context, createdByPublicID string, inp
 		return nil, apperror.ErrInvalidInput
 	}
 
+	if input.Phase != nil && *input.Phase < 1 {
+		return nil, apperror.ErrInvalidInput
+	}
+
 	exam := &entity.Exam{
 		InstitutionID: institution.ID,
 		Title:         title,
 		Description:   description,
 		Year:          input.Year,
+		Phase:         input.Phase,
 		CreatedByID:   user.ID,
 	}
 
 	if err := uc.examRepo.Create(ctx, exam); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrExamDuplicate
-		}
 		return nil, err
 	}
 
@@ -145,10 +148,19 @@ func (uc *ExamUseCase) Update(ctx context.Context, publicID string, input Update
 		exam.Year = *input.Year
 	}
 
-	if err := uc.examRepo.Update(ctx, exam); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrExamDuplicate
+	if input.Phase != nil {
+		switch {
+		case *input.Phase == 0:
+			exam.Phase = nil
+		case *input.Phase < 0:
+			return nil, apperror.ErrInvalidInput
+		default:
+			phase := *input.Phase
+			exam.Phase = &phase
 		}
+	}
+
+	if err := uc.examRepo.Update(ctx, exam); err != nil {
 		return nil, err
 	}
 
@@ -170,27 +182,17 @@ func (uc *ExamUseCase) Delete(ctx context.Context, publicID string) error {
 	return uc.examRepo.Delete(ctx, publicID)
 }
 
-func (uc *ExamUseCase) List(ctx context.Context, page, pageSize int, filter repository.ExamFilter) ([]entity.Exam, int, int, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	offset := (page - 1) * pageSize
+func (uc *ExamUseCase) List(ctx context.Context, limit, offset int, filter repository.ExamFilter) ([]entity.Exam, int, error) {
 
-	exams, err := uc.examRepo.List(ctx, pageSize, offset, filter)
+	exams, err := uc.examRepo.List(ctx, limit, offset, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
 	total, err := uc.examRepo.Count(ctx, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
-
-	return exams, total, totalPages, nil
+	return exams, total, nil
 }
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/service"
+)
+
+type storedObject struct {
+	Key      string
+	URL      string
+	Checksum string
+}
+
+// uploadDeduplicated streams body to storage under key while computing its
+// SHA-256. When an active file with the same checksum and size already exists,
+// the fresh object is removed and the existing one is returned instead, so
+// identical uploads share a single stored object.
+func uploadDeduplicated(ctx context.Context, storageSvc service.StorageService, fileRepo repository.FileRepository, key, contentType string, size int64, body io.Reader) (*storedObject, error) {
+	hasher := sha256.New()
+	url, err := storageSvc.Upload(ctx, key, contentType, io.TeeReader(body, hasher))
+	if err != nil {
+		return nil, err
+	}
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+
+	existing, err := fileRepo.GetByChecksum(ctx, checksum, size)
+	if err != nil {
+		_ = storageSvc.Delete(ctx, key)
+		return nil, err
+	}
+	if existing != nil && existing.Key != key {
+		_ = storageSvc.Delete(ctx, key)
+		return &storedObject{
+			Key:      existing.Key,
+			URL:      storageSvc.GetPublicURL(existing.Key),
+			Checksum: checksum,
+		}, nil
+	}
+
+	return &storedObject{Key: key, URL: url, Checksum: checksum}, nil
+}
+
+// releaseStoredObject deletes key from storage once no active file row
+// references it anymore. Call it after the file row has been deactivated.
+func releaseStoredObject(ctx context.Context, storageSvc service.StorageService, fileRepo repository.FileRepository, key string) {
+	count, err := fileRepo.CountActiveByKey(ctx, key)
+	if err != nil || count > 0 {
+		return
+	}
+	_ = storageSvc.Delete(ctx, key)
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/email"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/i18n"
+)
+
+const maxMemberImportRows = 1000
+
+// Outcomes reported for each imported row.
+const (
+	MemberImportAdded         = "added"
+	MemberImportApproved      = "approved"
+	MemberImportAlreadyMember = "already_member"
+	MemberImportRoleUpdated   = "role_updated"
+	MemberImportInvited       = "invited"
+	MemberImportFailed        = "failed"
+)
+
+type MemberImportResult struct {
+	Row    int // 1-based line in the CSV
+	Email  string
+	Role   entity.MemberRole
+	Status string
+	Reason apperror.Code // set when Status is MemberImportFailed
+}
+
+// ImportMembers adds the users listed in a CSV of "email,role" rows to the
+// group. A header row and an empty role (meaning member) are accepted. Rows
+// are processed independently: malformed rows, unknown roles and repeated
+// emails are reported as failed without aborting the import. Pending join
+// requests are approved, previously removed members are reactivated and
+// emails without an account are invited to sign up.
+func (uc *GroupUseCase) ImportMembers(ctx context.Context, groupPublicID string, creatorPublicID string, data io.Reader) ([]MemberImportResult, error) {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, apperror.ErrGroupNotFound
+	}
+
+	creator, err := uc.userRepo.GetByPublicID(ctx, creatorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if creator == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var results []MemberImportResult
+	seen := make(map[string]bool)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if row > maxMemberImportRows {
+			return nil, apperror.ErrInvalidInput
+		}
+		if err != nil {
+			results = append(results, MemberImportResult{Row: row, Status: MemberImportFailed, Reason: apperror.CodeInvalidInput})
+			continue
+		}
+
+		address := strings.TrimSpace(record[0])
+		if row == 1 && strings.EqualFold(address, "email") {
+			continue
+		}
+
+		result := MemberImportResult{Row: row, Email: address, Role: entity.MemberRoleMember}
+		if len(record) > 1 {
+			if r := strings.ToLower(strings.TrimSpace(record[1])); r != "" {
+				result.Role = entity.MemberRole(r)
+			}
+		}
+
+		normalized, emailErr := email.Normalize(address)
+
+		switch {
+		case address == "" || len(record) > 2:
+			result.Status, result.Reason = MemberImportFailed, apperror.CodeInvalidInput
+		case emailErr != nil:
+			result.Status, result.Reason = MemberImportFailed, apperror.CodeInvalidEmail
+		case result.Role != entity.MemberRoleMember && result.Role != entity.MemberRoleSupervisor && result.Role != entity.MemberRoleAdmin:
+			result.Status, result.Reason = MemberImportFailed, apperror.CodeInvalidInput
+		case seen[normalized]:
+			result.Status, result.Reason = MemberImportFailed, apperror.CodeDuplicateRow
+		default:
+			seen[normalized] = true
+			result.Status, err = uc.importMember(ctx, group, creator, normalized, result.Role)
+			if err != nil {
+				var appErr *apperror.AppError
+				if !errors.As(err, &appErr) {
+					return nil, err
+				}
+				result.Status, result.Reason = MemberImportFailed, appErr.Code
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (uc *GroupUseCase) importMember(ctx context.Context, group *entity.Group, creator *entity.User, email string, role entity.MemberRole) (string, error) {
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return MemberImportInvited, uc.invite(ctx, group, creator, email, role)
+	}
+
+	existing, err := uc.groupRepo.GetMember(ctx, group.ID, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	// GetMember only sees active memberships; AddMember revives an inactive
+	// one with the imported role.
+	if existing == nil {
+		member := &entity.GroupMember{
+			GroupID:      group.ID,
+			UserID:       user.ID,
+			Role:         role,
+			AcceptedByID: &creator.ID,
+			IsActive:     true,
+			CreatedByID:  creator.ID,
+		}
+		return MemberImportAdded, uc.groupRepo.AddMember(ctx, member)
+	}
+
+	status := MemberImportAlreadyMember
+	if existing.AcceptedByID == nil {
+		if err := uc.groupRepo.ApproveMember(ctx, group.ID, user.ID, creator.ID); err != nil {
+			return "", err
+		}
+		status = MemberImportApproved
+	}
+
+	if existing.Role != role {
+		if existing.Role == entity.MemberRoleAdmin {
+			if err := uc.ensureAnotherAdmin(ctx, group.ID, user.ID); err != nil {
+				return "", err
+			}
+		}
+		if err := uc.groupRepo.UpdateMemberRole(ctx, group.ID, user.ID, role); err != nil {
+			return "", err
+		}
+		if status == MemberImportAlreadyMember {
+			status = MemberImportRoleUpdated
+		}
+	}
+
+	return status, nil
+}
+
+// invite records an invitation for an email without an account and emails a
+// sign-up link. The membership is created when the account verifies that
+// email.
+func (uc *GroupUseCase) invite(ctx context.Context, group *entity.Group, inviter *entity.User, address string, role entity.MemberRole) error {
+	invitation := &entity.GroupInvitation{
+		GroupID:     group.ID,
+		Email:       address,
+		Role:        role,
+		InvitedByID: inviter.ID,
+	}
+	if err := uc.groupRepo.UpsertInvitation(ctx, invitation); err != nil {
+		return err
+	}
+
+	// The invitee has no language preference yet; the inviter's is the best
+	// guess.
+	locale := userLocale(inviter)
+	invitationURL := fmt.Sprintf("%s/%s/register?email=%s", uc.frontendURL, i18n.Resolve(locale), url.QueryEscape(address))
+	if err := uc.emailSvc.SendInvitationEmail(ctx, address, locale, inviter.Name, group.Name, invitationURL); err != nil {
+		return apperror.ErrEmailSendFailed
+	}
+	return nil
+}
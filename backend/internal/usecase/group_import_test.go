@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+)
+
+// fakeGroupRepo keeps the active memberships of a single group.
+type fakeGroupRepo struct {
+	repository.GroupRepository
+	group       *entity.Group
+	members     map[int]*entity.GroupMember
+	invitations map[string]*entity.GroupInvitation
+	accepted    map[int]string
+}
+
+func (f *fakeGroupRepo) GetByPublicID(_ context.Context, publicID string) (*entity.Group, error) {
+	if f.group == nil || f.group.PublicID != publicID {
+		return nil, nil
+	}
+	return f.group, nil
+}
+
+func (f *fakeGroupRepo) GetMember(_ context.Context, _, userID int) (*entity.GroupMember, error) {
+	return f.members[userID], nil
+}
+
+func (f *fakeGroupRepo) AddMember(_ context.Context, m *entity.GroupMember) error {
+	f.members[m.UserID] = m
+	return nil
+}
+
+func (f *fakeGroupRepo) UpdateMemberRole(_ context.Context, _, userID int, role entity.MemberRole) error {
+	f.members[userID].Role = role
+	return nil
+}
+
+func (f *fakeGroupRepo) CountMembers(_ context.Context, _ int, role string) (int, error) {
+	count := 0
+	for _, m := range f.members {
+		if m.AcceptedByID != nil && string(m.Role) == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeGroupRepo) UpsertInvitation(_ context.Context, inv *entity.GroupInvitation) error {
+	f.invitations[inv.Email] = inv
+	return nil
+}
+
+func (f *fakeGroupRepo) AcceptInvitations(_ context.Context, userID int, email string) (int, error) {
+	if f.accepted == nil {
+		f.accepted = map[int]string{}
+	}
+	f.accepted[userID] = email
+	return 0, nil
+}
+
+type fakeUserLookupRepo struct {
+	repository.UserRepository
+	users []*entity.User
+}
+
+func (f *fakeUserLookupRepo) GetByPublicID(_ context.Context, publicID string) (*entity.User, error) {
+	for _, u := range f.users {
+		if u.PublicID == publicID {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeUserLookupRepo) GetByEmail(_ context.Context, address string) (*entity.User, error) {
+	for _, u := range f.users {
+		if u.Email == address {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeInvitationSender struct {
+	service.EmailService
+	invited map[string]string
+}
+
+func (f *fakeInvitationSender) SendInvitationEmail(_ context.Context, to, _, _, _, invitationURL string) error {
+	f.invited[to] = invitationURL
+	return nil
+}
+
+func TestImportMembers(t *testing.T) {
+	adminID := 1
+	groups := &fakeGroupRepo{
+		group: &entity.Group{ID: 10, PublicID: "g1", Name: "Turma A"},
+		members: map[int]*entity.GroupMember{
+			1: {GroupID: 10, UserID: 1, Role: entity.MemberRoleAdmin, AcceptedByID: &adminID},
+		},
+		invitations: map[string]*entity.GroupInvitation{},
+	}
+	users := &fakeUserLookupRepo{users: []*entity.User{
+		{ID: 1, PublicID: "admin", Name: "Admin", Email: "admin@example.com"},
+		{ID: 2, PublicID: "ana", Name: "Ana", Email: "ana@example.com"},
+	}}
+	sender := &fakeInvitationSender{invited: map[string]string{}}
+	uc := NewGroupUseCase(groups, users, nil, nil, sender, "https://example.com", uploadlimits.Limits{})
+
+	csv := "email,role\n" +
+		"ana@example.com,member\n" +
+		"New@Example.com,\n" +
+		"not-an-email,member\n" +
+		"admin@example.com,member\n"
+	results, err := uc.ImportMembers(context.Background(), "g1", "admin", strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ImportMembers: %v", err)
+	}
+
+	want := []struct {
+		status string
+		reason apperror.Code
+	}{
+		{MemberImportAdded, ""},
+		{MemberImportInvited, ""},
+		{MemberImportFailed, apperror.CodeInvalidEmail},
+		{MemberImportFailed, apperror.CodeLastAdmin},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, w := range want {
+		if results[i].Status != w.status || results[i].Reason != w.reason {
+			t.Errorf("row %d = %s/%s, want %s/%s", results[i].Row, results[i].Status, results[i].Reason, w.status, w.reason)
+		}
+	}
+
+	if groups.members[2] == nil {
+		t.Error("the existing user was not added")
+	}
+	if inv := groups.invitations["new@example.com"]; inv == nil || inv.Role != entity.MemberRoleMember {
+		t.Errorf("invitation = %+v, want a member invitation", inv)
+	}
+	if url := sender.invited["new@example.com"]; !strings.Contains(url, "/register?email=new%40example.com") {
+		t.Errorf("invitation link = %q, want the sign-up page for the address", url)
+	}
+	if groups.members[1].Role != entity.MemberRoleAdmin {
+		t.Error("the last admin was demoted")
+	}
+}
@@ -2,19 +2,31 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"path"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/permissions"
+	"proximos-passos/backend/internal/domain/publicid"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/text"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+	"proximos-passos/backend/internal/i18n"
 )
 
-const maxThumbnailSize = 5 << 20 // 5MB
+const (
+	maxGroupNameLength        = 120
+	maxGroupDescriptionLength = 2000
+)
 
 var allowedThumbnailTypes = map[string]bool{
 	"image/jpeg": true,
@@ -24,16 +36,24 @@ var allowedThumbnailTypes = map[string]bool{
 }
 
 type GroupUseCase struct {
-	groupRepo  repository.GroupRepository
-	userRepo   repository.UserRepository
-	storageSvc service.StorageService
+	groupRepo    repository.GroupRepository
+	userRepo     repository.UserRepository
+	storageSvc   service.StorageService
+	events       service.EventEmitter
+	emailSvc     service.EmailService
+	frontendURL  string
+	uploadLimits uploadlimits.Limits
 }
 
-func NewGroupUseCase(groupRepo repository.GroupRepository, userRepo repository.UserRepository, storageSvc service.StorageService) *GroupUseCase {
+func NewGroupUseCase(groupRepo repository.GroupRepository, userRepo repository.UserRepository, storageSvc service.StorageService, events service.EventEmitter, emailSvc service.EmailService, frontendURL string, uploadLimits uploadlimits.Limits) *GroupUseCase {
 	return &GroupUseCase{
-		groupRepo:  groupRepo,
-		userRepo:   userRepo,
-		storageSvc: storageSvc,
+		groupRepo:    groupRepo,
+		userRepo:     userRepo,
+		storageSvc:   storageSvc,
+		events:       events,
+		emailSvc:     emailSvc,
+		frontendURL:  frontendURL,
+		uploadLimits: uploadLimits,
 	}
 }
 
@@ -50,10 +70,14 @@ type CreateGroupInput struct {
 }
 
 type UpdateGroupInput struct {
-	Name           *string
-	Description    *string
-	AccessType     *entity.GroupAccessType
-	VisibilityType *entity.GroupVisibilityType
+	Name                 *string
+	Description          *string
+	AccessType           *entity.GroupAccessType
+	VisibilityType       *entity.GroupVisibilityType
+	LeaderboardEnabled   *bool
+	LeaderboardAnonymous *bool
+	AllowPastDue         *bool
+	TimeZone             *string
 }
 
 // ==========================================
@@ -74,10 +98,40 @@ type UpdateMemberRoleInput struct {
 // Group Operations
 // ==========================================
 
-func (uc *GroupUseCase) Create(ctx context.Context, input CreateGroupInput) (*entity.Group, error) {
-	name := strings.TrimSpace(input.Name)
+// cleanGroupName strips control characters from a group name and checks
+// what is left, recording failures on v.
+func cleanGroupName(v *apperror.ValidationError, raw string) string {
+	name := text.SingleLine(raw)
 	if name == "" {
-		return nil, apperror.ErrInvalidInput
+		v.Add("name", "Name is required.")
+	} else if utf8.RuneCountInString(name) > maxGroupNameLength {
+		v.Add("name", fmt.Sprintf("Name must be at most %d characters long.", maxGroupNameLength))
+	}
+	return name
+}
+
+// cleanGroupDescription is cleanGroupName for descriptions, which are
+// optional: blank input yields nil.
+func cleanGroupDescription(v *apperror.ValidationError, raw string) *string {
+	description := text.MultiLine(raw)
+	if description == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(description) > maxGroupDescriptionLength {
+		v.Add("description", fmt.Sprintf("Description must be at most %d characters long.", maxGroupDescriptionLength))
+	}
+	return &description
+}
+
+func (uc *GroupUseCase) Create(ctx context.Context, input CreateGroupInput) (*entity.Group, error) {
+	v := apperror.NewValidationError()
+	name := cleanGroupName(v, input.Name)
+	var description *string
+	if input.Description != nil {
+		description = cleanGroupDescription(v, *input.Description)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	creator, err := uc.userRepo.GetByPublicID(ctx, input.CreatorPublicID)
@@ -98,14 +152,6 @@ func (uc *GroupUseCase) Create(ctx context.Context, input CreateGroupInput) (*en
 		visibilityType = entity.GroupVisibilityPrivate
 	}
 
-	var description *string
-	if input.Description != nil {
-		trimmed := strings.TrimSpace(*input.Description)
-		if trimmed != "" {
-			description = &trimmed
-		}
-	}
-
 	group := &entity.Group{
 		Name:           name,
 		Description:    description,
@@ -165,7 +211,20 @@ func (uc *GroupUseCase) GetByPublicID(ctx context.Context, publicID string, user
 	return group, nil
 }
 
-func (uc *GroupUseCase) GetPreview(ctx context.Context, publicID string) (*entity.Group, error) {
+// GroupPreview is what a prospective member sees on a group's join page.
+type GroupPreview struct {
+	Group            *entity.Group
+	MemberCount      int
+	AdminCount       int
+	MembershipStatus string
+	MembershipRole   string
+}
+
+// GetPreview returns the join page view of a group for the viewer. Private
+// groups are only previewed by platform admins, users who already have a
+// membership or request, and callers presenting the group's join code;
+// everyone else gets ErrGroupNotFound.
+func (uc *GroupUseCase) GetPreview(ctx context.Context, publicID, viewerPublicID, code string) (*GroupPreview, error) {
 	group, err := uc.groupRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
 		return nil, err
@@ -174,34 +233,59 @@ func (uc *GroupUseCase) GetPreview(ctx context.Context, publicID string) (*entit
 		return nil, apperror.ErrGroupNotFound
 	}
 
-	return group, nil
-}
+	viewer, err := uc.userRepo.GetByPublicID(ctx, viewerPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if viewer == nil {
+		return nil, apperror.ErrUserNotFound
+	}
 
-func (uc *GroupUseCase) List(ctx context.Context, pageNumber, pageSize int, userRole entity.UserRole, filter repository.GroupFilter) ([]entity.Group, int, error) {
-	if pageSize <= 0 {
-		pageSize = 10
+	member, err := uc.groupRepo.GetMember(ctx, group.ID, viewer.ID)
+	if err != nil {
+		return nil, err
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	if group.VisibilityType == entity.GroupVisibilityPrivate && viewer.Role != entity.UserRoleAdmin && member == nil {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" || group.JoinCode == nil || *group.JoinCode != code {
+			return nil, apperror.ErrGroupNotFound
+		}
 	}
-	if pageNumber < 1 {
-		pageNumber = 1
+
+	memberCount, err := uc.groupRepo.CountMembers(ctx, group.ID, "")
+	if err != nil {
+		return nil, err
 	}
 
-	offset := (pageNumber - 1) * pageSize
+	adminCount, err := uc.groupRepo.CountMembers(ctx, group.ID, string(entity.MemberRoleAdmin))
+	if err != nil {
+		return nil, err
+	}
 
+	status, role := membershipStatus(member)
+	return &GroupPreview{
+		Group:            group,
+		MemberCount:      memberCount,
+		AdminCount:       adminCount,
+		MembershipStatus: status,
+		MembershipRole:   role,
+	}, nil
+}
+
+func (uc *GroupUseCase) List(ctx context.Context, limit, offset int, userRole entity.UserRole, filter repository.GroupFilter) ([]entity.Group, int, error) {
 	var groups []entity.Group
 	var total int
 	var err error
 
 	if userRole == entity.UserRoleAdmin {
-		groups, err = uc.groupRepo.List(ctx, pageSize, offset, filter)
+		groups, err = uc.groupRepo.List(ctx, limit, offset, filter)
 		if err != nil {
 			return nil, 0, err
 		}
 		total, err = uc.groupRepo.Count(ctx, filter)
 	} else {
-		groups, err = uc.groupRepo.ListPublic(ctx, pageSize, offset, filter)
+		groups, err = uc.groupRepo.ListPublic(ctx, limit, offset, filter)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -214,7 +298,13 @@ func (uc *GroupUseCase) List(ctx context.Context, pageNumber, pageSize int, user
 	return groups, total, nil
 }
 
-func (uc *GroupUseCase) ListMyGroups(ctx context.Context, userPublicID string, pageNumber, pageSize int, filter repository.GroupFilter) ([]entity.Group, int, error) {
+func (uc *GroupUseCase) ListMyGroups(ctx context.Context, userPublicID string, limit, offset int, filter repository.GroupFilter) ([]entity.Group, int, error) {
+	switch entity.MemberRole(filter.Role) {
+	case "", entity.MemberRoleAdmin, entity.MemberRoleSupervisor, entity.MemberRoleMember:
+	default:
+		return nil, 0, apperror.ErrInvalidInput
+	}
+
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -223,19 +313,7 @@ func (uc *GroupUseCase) ListMyGroups(ctx context.Context, userPublicID string, p
 		return nil, 0, apperror.ErrUserNotFound
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	offset := (pageNumber - 1) * pageSize
-
-	groups, err := uc.groupRepo.ListByUser(ctx, user.ID, pageSize, offset, filter)
+	groups, err := uc.groupRepo.ListByUser(ctx, user.ID, limit, offset, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -257,21 +335,15 @@ func (uc *GroupUseCase) Update(ctx context.Context, publicID string, input Updat
 		return nil, apperror.ErrGroupNotFound
 	}
 
+	v := apperror.NewValidationError()
 	if input.Name != nil {
-		name := strings.TrimSpace(*input.Name)
-		if name == "" {
-			return nil, apperror.ErrInvalidInput
-		}
-		group.Name = name
+		group.Name = cleanGroupName(v, *input.Name)
 	}
-
 	if input.Description != nil {
-		trimmed := strings.TrimSpace(*input.Description)
-		if trimmed == "" {
-			group.Description = nil
-		} else {
-			group.Description = &trimmed
-		}
+		group.Description = cleanGroupDescription(v, *input.Description)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	if input.AccessType != nil {
@@ -282,6 +354,31 @@ func (uc *GroupUseCase) Update(ctx context.Context, publicID string, input Updat
 		group.VisibilityType = *input.VisibilityType
 	}
 
+	if input.LeaderboardEnabled != nil {
+		group.LeaderboardEnabled = *input.LeaderboardEnabled
+	}
+
+	if input.LeaderboardAnonymous != nil {
+		group.LeaderboardAnonymous = *input.LeaderboardAnonymous
+	}
+
+	if input.AllowPastDue != nil {
+		group.AllowPastDue = *input.AllowPastDue
+	}
+
+	if input.TimeZone != nil {
+		tz := strings.TrimSpace(*input.TimeZone)
+		// LoadLocation maps "" to UTC and "Local" to the host zone; neither
+		// is a name clients should store.
+		if tz == "" || tz == "Local" {
+			return nil, apperror.ErrInvalidTimeZone
+		}
+		if _, err := time.LoadLocation(tz); err != nil {
+			return nil, apperror.ErrInvalidTimeZone
+		}
+		group.TimeZone = tz
+	}
+
 	if err := uc.groupRepo.Update(ctx, group); err != nil {
 		return nil, err
 	}
@@ -302,7 +399,7 @@ func (uc *GroupUseCase) Delete(ctx context.Context, publicID string) error {
 }
 
 func (uc *GroupUseCase) UploadThumbnail(ctx context.Context, publicID string, filename string, contentType string, size int64, body io.Reader) (*entity.Group, error) {
-	if size > maxThumbnailSize {
+	if size > uc.uploadLimits.Thumbnail {
 		return nil, apperror.ErrFileTooLarge
 	}
 
@@ -310,6 +407,11 @@ func (uc *GroupUseCase) UploadThumbnail(ctx context.Context, publicID string, fi
 		return nil, apperror.ErrInvalidFileType
 	}
 
+	body, err := validateImage(body)
+	if err != nil {
+		return nil, err
+	}
+
 	group, err := uc.groupRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
 		return nil, err
@@ -377,6 +479,8 @@ func extractThumbnailKeyFromURL(url string) string {
 // Member Operations
 // ==========================================
 
+// JoinGroup requests membership of a public group. Private groups can only
+// be joined with their join code, see JoinGroupByCode.
 func (uc *GroupUseCase) JoinGroup(ctx context.Context, groupPublicID string, userPublicID string) (*entity.GroupMember, error) {
 	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
 	if err != nil {
@@ -385,7 +489,46 @@ func (uc *GroupUseCase) JoinGroup(ctx context.Context, groupPublicID string, use
 	if group == nil {
 		return nil, apperror.ErrGroupNotFound
 	}
+	if group.VisibilityType == entity.GroupVisibilityPrivate {
+		user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, apperror.ErrUserNotFound
+		}
+		return nil, denyGroup(ctx, uc.groupRepo, group, user)
+	}
+
+	return uc.join(ctx, group, userPublicID)
+}
+
+// JoinGroupByCode requests membership of the group holding the given join
+// code, regardless of its visibility.
+func (uc *GroupUseCase) JoinGroupByCode(ctx context.Context, code string, userPublicID string) (*entity.Group, *entity.GroupMember, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, nil, apperror.ErrGroupNotFound
+	}
+
+	group, err := uc.groupRepo.GetByJoinCode(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if group == nil {
+		return nil, nil, apperror.ErrGroupNotFound
+	}
+
+	member, err := uc.join(ctx, group, userPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, member, nil
+}
 
+// join adds the user to the group, auto-accepting them in open groups and
+// leaving a pending request in closed ones.
+func (uc *GroupUseCase) join(ctx context.Context, group *entity.Group, userPublicID string) (*entity.GroupMember, error) {
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
 	if err != nil {
 		return nil, err
@@ -419,20 +562,37 @@ func (uc *GroupUseCase) JoinGroup(ctx context.Context, groupPublicID string, use
 		CreatedByID:  user.ID,
 	}
 
+	// A previously rejected or removed membership is revived in place.
 	if err := uc.groupRepo.AddMember(ctx, member); err != nil {
-		// If member record exists (was previously rejected/removed), reactivate it
-		if errors.Is(err, apperror.ErrMemberAlreadyExists) {
-			if reactivateErr := uc.groupRepo.ReactivateMember(ctx, group.ID, user.ID, acceptedByID); reactivateErr != nil {
-				return nil, reactivateErr
-			}
-			return member, nil
-		}
 		return nil, err
 	}
 
+	if acceptedByID == nil {
+		uc.notifyJoinRequest(ctx, group, user)
+	}
+
 	return member, nil
 }
 
+// notifyJoinRequest emails every group admin about a new pending request.
+// Failures are only logged so they never block the join itself.
+func (uc *GroupUseCase) notifyJoinRequest(ctx context.Context, group *entity.Group, requester *entity.User) {
+	admins, err := uc.groupRepo.ListAdmins(ctx, group.ID)
+	if err != nil {
+		log.Printf("failed to list admins of group %s to notify about join request: %v", group.PublicID, err)
+		return
+	}
+
+	for i := range admins {
+		admin := &admins[i]
+		locale := userLocale(admin)
+		groupURL := fmt.Sprintf("%s/%s/dashboard/groups/%s", uc.frontendURL, i18n.Resolve(locale), group.PublicID)
+		if err := uc.emailSvc.SendJoinRequestEmail(ctx, admin.Email, admin.Name, locale, requester.Name, group.Name, groupURL); err != nil {
+			log.Printf("failed to send join request email to %s: %v", admin.Email, err)
+		}
+	}
+}
+
 func (uc *GroupUseCase) AddMember(ctx context.Context, groupPublicID string, input AddMemberInput) (*entity.GroupMember, error) {
 	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
 	if err != nil {
@@ -442,19 +602,18 @@ func (uc *GroupUseCase) AddMember(ctx context.Context, groupPublicID string, inp
 		return nil, apperror.ErrGroupNotFound
 	}
 
-	user, err := uc.userRepo.GetByPublicID(ctx, input.UserPublicID)
-	if err != nil {
-		return nil, err
-	}
-	if user == nil {
+	userPublicID := strings.ToLower(input.UserPublicID)
+	creatorPublicID := strings.ToLower(input.CreatorPublicID)
+	if !publicid.Valid(userPublicID) || !publicid.Valid(creatorPublicID) {
 		return nil, apperror.ErrUserNotFound
 	}
 
-	creator, err := uc.userRepo.GetByPublicID(ctx, input.CreatorPublicID)
+	users, err := uc.userRepo.GetManyByPublicID(ctx, []string{userPublicID, creatorPublicID})
 	if err != nil {
 		return nil, err
 	}
-	if creator == nil {
+	user, creator := users[userPublicID], users[creatorPublicID]
+	if user == nil || creator == nil {
 		return nil, apperror.ErrUserNotFound
 	}
 
@@ -486,7 +645,7 @@ func (uc *GroupUseCase) AddMember(ctx context.Context, groupPublicID string, inp
 	return member, nil
 }
 
-func (uc *GroupUseCase) ListMembers(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole, pageNumber, pageSize int, role string) ([]entity.GroupMember, int, error) {
+func (uc *GroupUseCase) ListMembers(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole, limit, offset int, role, sort string) ([]entity.GroupMember, int, error) {
 	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -513,23 +672,11 @@ func (uc *GroupUseCase) ListMembers(ctx context.Context, groupPublicID string, r
 			return nil, 0, err
 		}
 		if member == nil {
-			return nil, 0, apperror.ErrForbidden
+			return nil, 0, apperror.ErrGroupNotFound
 		}
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	offset := (pageNumber - 1) * pageSize
-
-	members, err := uc.groupRepo.ListMembers(ctx, group.ID, pageSize, offset, role)
+	members, err := uc.groupRepo.ListMembers(ctx, group.ID, limit, offset, role, sort)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -559,6 +706,12 @@ func (uc *GroupUseCase) UpdateMemberRole(ctx context.Context, groupPublicID, use
 		return apperror.ErrUserNotFound
 	}
 
+	if input.Role != entity.MemberRoleAdmin {
+		if err := uc.ensureAnotherAdmin(ctx, group.ID, user.ID); err != nil {
+			return err
+		}
+	}
+
 	return uc.groupRepo.UpdateMemberRole(ctx, group.ID, user.ID, input.Role)
 }
 
@@ -579,9 +732,60 @@ func (uc *GroupUseCase) RemoveMember(ctx context.Context, groupPublicID, userPub
 		return apperror.ErrUserNotFound
 	}
 
+	if err := uc.ensureAnotherAdmin(ctx, group.ID, user.ID); err != nil {
+		return err
+	}
+
 	return uc.groupRepo.RemoveMember(ctx, group.ID, user.ID)
 }
 
+// Leave removes the user's own membership. It is the only way to remove
+// oneself; the member-removal endpoints reject self-removal.
+func (uc *GroupUseCase) Leave(ctx context.Context, groupPublicID, userPublicID string) error {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return apperror.ErrGroupNotFound
+	}
+
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+
+	if err := uc.ensureAnotherAdmin(ctx, group.ID, user.ID); err != nil {
+		return err
+	}
+
+	return uc.groupRepo.RemoveMember(ctx, group.ID, user.ID)
+}
+
+// ensureAnotherAdmin returns ErrLastAdmin when the user is the group's only
+// active admin, so demoting or removing them would leave it unmanaged.
+func (uc *GroupUseCase) ensureAnotherAdmin(ctx context.Context, groupID, userID int) error {
+	member, err := uc.groupRepo.GetMember(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil || member.AcceptedByID == nil || member.Role != entity.MemberRoleAdmin {
+		return nil
+	}
+
+	adminCount, err := uc.groupRepo.CountMembers(ctx, groupID, string(entity.MemberRoleAdmin))
+	if err != nil {
+		return err
+	}
+	if adminCount <= 1 {
+		return apperror.ErrLastAdmin
+	}
+	return nil
+}
+
 func (uc *GroupUseCase) GetUserByPublicID(ctx context.Context, publicID string) (*entity.User, error) {
 	return uc.userRepo.GetByPublicID(ctx, publicID)
 }
@@ -607,13 +811,21 @@ func (uc *GroupUseCase) CheckMembership(ctx context.Context, groupPublicID strin
 	if err != nil {
 		return "", "", err
 	}
+
+	status, role := membershipStatus(member)
+	return status, role, nil
+}
+
+// membershipStatus reports "none", "pending" or "member" for member, along
+// with its role when there is one.
+func membershipStatus(member *entity.GroupMember) (string, string) {
 	if member == nil {
-		return "none", "", nil
+		return "none", ""
 	}
 	if member.AcceptedByID == nil {
-		return "pending", string(member.Role), nil
+		return "pending", string(member.Role)
 	}
-	return "member", string(member.Role), nil
+	return "member", string(member.Role)
 }
 
 // isGroupAdmin checks whether the given user is an active admin member of the group.
@@ -671,12 +883,12 @@ func (uc *GroupUseCase) UpdateAsGroupAdmin(ctx context.Context, publicID string,
 		return nil, apperror.ErrGroupNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
 	if err != nil {
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyGroup(ctx, uc.groupRepo, group, requester)
 	}
 
 	return uc.Update(ctx, publicID, input)
@@ -691,12 +903,12 @@ func (uc *GroupUseCase) UploadThumbnailAsGroupAdmin(ctx context.Context, publicI
 		return nil, apperror.ErrGroupNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
 	if err != nil {
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyGroup(ctx, uc.groupRepo, group, requester)
 	}
 
 	return uc.UploadThumbnail(ctx, publicID, filename, contentType, size, body)
@@ -711,18 +923,18 @@ func (uc *GroupUseCase) DeleteThumbnailAsGroupAdmin(ctx context.Context, publicI
 		return nil, apperror.ErrGroupNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
 	if err != nil {
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, apperror.ErrForbidden
+		return nil, denyGroup(ctx, uc.groupRepo, group, requester)
 	}
 
 	return uc.DeleteThumbnail(ctx, publicID)
 }
 
-func (uc *GroupUseCase) ListPendingMembers(ctx context.Context, groupPublicID string, requesterPublicID string, pageNumber, pageSize int) ([]entity.GroupMember, int, error) {
+func (uc *GroupUseCase) ListPendingMembers(ctx context.Context, groupPublicID string, requesterPublicID string, limit, offset int) ([]entity.GroupMember, int, error) {
 	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -731,26 +943,15 @@ func (uc *GroupUseCase) ListPendingMembers(ctx context.Context, groupPublicID st
 		return nil, 0, apperror.ErrGroupNotFound
 	}
 
-	isAdminOrSupervisor, _, err := uc.isGroupAdminOrSupervisor(ctx, group.ID, requesterPublicID)
+	isAdminOrSupervisor, requester, err := uc.isGroupAdminOrSupervisor(ctx, group.ID, requesterPublicID)
 	if err != nil {
 		return nil, 0, err
 	}
-	if !isAdminOrSupervisor {
-		return nil, 0, apperror.ErrForbidden
+	if !isAdminOrSupervisor && requester.Role != entity.UserRoleAdmin {
+		return nil, 0, denyGroup(ctx, uc.groupRepo, group, requester)
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-	offset := (pageNumber - 1) * pageSize
-
-	members, err := uc.groupRepo.ListPendingMembers(ctx, group.ID, pageSize, offset)
+	members, err := uc.groupRepo.ListPendingMembers(ctx, group.ID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -776,8 +977,8 @@ func (uc *GroupUseCase) ApproveMember(ctx context.Context, groupPublicID, userPu
 	if err != nil {
 		return err
 	}
-	if !isAdmin {
-		return apperror.ErrForbidden
+	if !isAdmin && approver.Role != entity.UserRoleAdmin {
+		return denyGroup(ctx, uc.groupRepo, group, approver)
 	}
 
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
@@ -788,7 +989,91 @@ func (uc *GroupUseCase) ApproveMember(ctx context.Context, groupPublicID, userPu
 		return apperror.ErrUserNotFound
 	}
 
-	return uc.groupRepo.ApproveMember(ctx, group.ID, user.ID, approver.ID)
+	if err := uc.groupRepo.ApproveMember(ctx, group.ID, user.ID, approver.ID); err != nil {
+		return err
+	}
+
+	uc.events.Emit(ctx, service.EventMemberApproved, map[string]any{
+		"group_id":    group.PublicID,
+		"user_id":     user.PublicID,
+		"approver_id": approver.PublicID,
+	})
+
+	return nil
+}
+
+// maxBulkApprove caps how many users ApproveMany accepts in one call.
+const maxBulkApprove = 500
+
+// BulkApproveResult reports how many requested approvals went through.
+// Skipped covers users without a pending request, including requests
+// withdrawn while the approval was running.
+type BulkApproveResult struct {
+	Approved int
+	Skipped  int
+}
+
+// ApproveAllPending approves every pending join request of the group.
+func (uc *GroupUseCase) ApproveAllPending(ctx context.Context, groupPublicID, approverPublicID string) (*BulkApproveResult, error) {
+	return uc.approvePending(ctx, groupPublicID, nil, approverPublicID)
+}
+
+// ApproveMany approves the pending join requests of the given users.
+func (uc *GroupUseCase) ApproveMany(ctx context.Context, groupPublicID string, userPublicIDs []string, approverPublicID string) (*BulkApproveResult, error) {
+	seen := make(map[string]bool, len(userPublicIDs))
+	ids := make([]string, 0, len(userPublicIDs))
+	for _, id := range userPublicIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 || len(ids) > maxBulkApprove {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	return uc.approvePending(ctx, groupPublicID, ids, approverPublicID)
+}
+
+// approvePending approves the requests in a single statement, so a request
+// withdrawn concurrently is either approved before it goes or skipped.
+func (uc *GroupUseCase) approvePending(ctx context.Context, groupPublicID string, userPublicIDs []string, approverPublicID string) (*BulkApproveResult, error) {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, apperror.ErrGroupNotFound
+	}
+
+	isAdmin, approver, err := uc.isGroupAdmin(ctx, group.ID, approverPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin && approver.Role != entity.UserRoleAdmin {
+		return nil, denyGroup(ctx, uc.groupRepo, group, approver)
+	}
+
+	approved, err := uc.groupRepo.ApprovePendingMembers(ctx, group.ID, userPublicIDs, approver.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userPublicID := range approved {
+		uc.events.Emit(ctx, service.EventMemberApproved, map[string]any{
+			"group_id":    group.PublicID,
+			"user_id":     userPublicID,
+			"approver_id": approver.PublicID,
+		})
+	}
+
+	result := &BulkApproveResult{Approved: len(approved)}
+	if userPublicIDs != nil {
+		result.Skipped = len(userPublicIDs) - len(approved)
+	}
+	return result, nil
 }
 
 func (uc *GroupUseCase) RejectMember(ctx context.Context, groupPublicID, userPublicID, requesterPublicID string) error {
@@ -800,12 +1085,12 @@ func (uc *GroupUseCase) RejectMember(ctx context.Context, groupPublicID, userPub
 		return apperror.ErrGroupNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
 	if err != nil {
 		return err
 	}
-	if !isAdmin {
-		return apperror.ErrForbidden
+	if !isAdmin && requester.Role != entity.UserRoleAdmin {
+		return denyGroup(ctx, uc.groupRepo, group, requester)
 	}
 
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
@@ -816,9 +1101,149 @@ func (uc *GroupUseCase) RejectMember(ctx context.Context, groupPublicID, userPub
 		return apperror.ErrUserNotFound
 	}
 
+	// Only pending requests can be rejected; accepted members are removed instead.
+	member, err := uc.groupRepo.GetMember(ctx, group.ID, user.ID)
+	if err != nil {
+		return err
+	}
+	if member == nil || !member.IsActive || member.AcceptedByID != nil {
+		return apperror.ErrMemberNotFound
+	}
+
 	return uc.groupRepo.RemoveMember(ctx, group.ID, user.ID)
 }
 
+const joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// RotateJoinCode generates a new join code for the group, invalidating the
+// previous one. Only group admins and platform admins may do so.
+// GetUploadSettings returns the effective submission upload limits of a group
+// (group or platform admins).
+func (uc *GroupUseCase) GetUploadSettings(ctx context.Context, groupPublicID string, requesterPublicID string) (*SubmissionUploadSettings, error) {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, apperror.ErrGroupNotFound
+	}
+
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin && requester.Role != entity.UserRoleAdmin {
+		return nil, denyGroup(ctx, uc.groupRepo, group, requester)
+	}
+
+	settings := submissionUploadSettings(group, uc.uploadLimits.SubmissionAttachment)
+	return &settings, nil
+}
+
+// UpdateUploadSettings replaces the submission upload overrides of a group
+// (group or platform admins).
+func (uc *GroupUseCase) UpdateUploadSettings(ctx context.Context, groupPublicID string, requesterPublicID string, input UpdateSubmissionUploadSettingsInput) (*SubmissionUploadSettings, error) {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, apperror.ErrGroupNotFound
+	}
+
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin && requester.Role != entity.UserRoleAdmin {
+		return nil, denyGroup(ctx, uc.groupRepo, group, requester)
+	}
+
+	maxBytes, allowedTypes, err := input.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.groupRepo.UpdateUploadSettings(ctx, group.ID, maxBytes, allowedTypes); err != nil {
+		return nil, err
+	}
+
+	group.SubmissionMaxBytes = maxBytes
+	group.SubmissionAllowedTypes = allowedTypes
+	settings := submissionUploadSettings(group, uc.uploadLimits.SubmissionAttachment)
+	return &settings, nil
+}
+
+func (uc *GroupUseCase) RotateJoinCode(ctx context.Context, groupPublicID string, requesterPublicID string) (string, error) {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return "", err
+	}
+	if group == nil {
+		return "", apperror.ErrGroupNotFound
+	}
+
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	if err != nil {
+		return "", err
+	}
+	if !isAdmin && requester.Role != entity.UserRoleAdmin {
+		return "", denyGroup(ctx, uc.groupRepo, group, requester)
+	}
+
+	// Retry on the unlikely collision with another group's code.
+	for range 3 {
+		code, err := newJoinCode()
+		if err != nil {
+			return "", err
+		}
+		err = uc.groupRepo.UpdateJoinCode(ctx, group.ID, &code)
+		if err == nil {
+			return code, nil
+		}
+		if !errors.Is(err, apperror.ErrJoinCodeTaken) {
+			return "", err
+		}
+	}
+	return "", apperror.ErrInternalError
+}
+
+func newJoinCode() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = joinCodeAlphabet[int(b[i])%len(joinCodeAlphabet)]
+	}
+	return string(b[:]), nil
+}
+
+// PendingMemberCount returns the number of join requests awaiting approval,
+// or nil when the requester is neither a platform admin nor group staff.
+func (uc *GroupUseCase) PendingMemberCount(ctx context.Context, group *entity.Group, requesterPublicID string, requesterRole entity.UserRole) (*int, error) {
+	if requesterRole != entity.UserRoleAdmin {
+		isAdminOrSupervisor, _, err := uc.isGroupAdminOrSupervisor(ctx, group.ID, requesterPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdminOrSupervisor {
+			return nil, nil
+		}
+	}
+
+	count, err := uc.groupRepo.CountPendingMembers(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+// Permissions returns what the requester may do in the group.
+func (uc *GroupUseCase) Permissions(ctx context.Context, group *entity.Group, requesterPublicID string) (permissions.Requester, error) {
+	return loadRequester(ctx, uc.userRepo, uc.groupRepo, group.ID, requesterPublicID)
+}
+
 func (uc *GroupUseCase) RemoveMemberAsGroupAdmin(ctx context.Context, groupPublicID, userPublicID, requesterPublicID string) error {
 	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
 	if err != nil {
@@ -828,12 +1253,12 @@ func (uc *GroupUseCase) RemoveMemberAsGroupAdmin(ctx context.Context, groupPubli
 		return apperror.ErrGroupNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
 	if err != nil {
 		return err
 	}
 	if !isAdmin {
-		return apperror.ErrForbidden
+		return denyGroup(ctx, uc.groupRepo, group, requester)
 	}
 
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
@@ -843,6 +1268,13 @@ func (uc *GroupUseCase) RemoveMemberAsGroupAdmin(ctx context.Context, groupPubli
 	if user == nil {
 		return apperror.ErrUserNotFound
 	}
+	if user.PublicID == requesterPublicID {
+		return apperror.ErrCannotRemoveSelf
+	}
+
+	if err := uc.ensureAnotherAdmin(ctx, group.ID, user.ID); err != nil {
+		return err
+	}
 
 	return uc.groupRepo.RemoveMember(ctx, group.ID, user.ID)
 }
@@ -856,12 +1288,12 @@ func (uc *GroupUseCase) UpdateMemberRoleAsGroupAdmin(ctx context.Context, groupP
 		return apperror.ErrGroupNotFound
 	}
 
-	isAdmin, _, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
+	isAdmin, requester, err := uc.isGroupAdmin(ctx, group.ID, requesterPublicID)
 	if err != nil {
 		return err
 	}
 	if !isAdmin {
-		return apperror.ErrForbidden
+		return denyGroup(ctx, uc.groupRepo, group, requester)
 	}
 
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
@@ -872,5 +1304,11 @@ func (uc *GroupUseCase) UpdateMemberRoleAsGroupAdmin(ctx context.Context, groupP
 		return apperror.ErrUserNotFound
 	}
 
+	if input.Role != entity.MemberRoleAdmin {
+		if err := uc.ensureAnotherAdmin(ctx, group.ID, user.ID); err != nil {
+			return err
+		}
+	}
+
 	return uc.groupRepo.UpdateMemberRole(ctx, group.ID, user.ID, input.Role)
 }
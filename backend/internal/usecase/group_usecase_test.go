@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func TestTextLengthLimits(t *testing.T) {
+	// "é" is two bytes, so these also check that limits count characters.
+	clean := map[string]func(*apperror.ValidationError, string) string{
+		"group name": cleanGroupName,
+		"group description": func(v *apperror.ValidationError, raw string) string {
+			if d := cleanGroupDescription(v, raw); d != nil {
+				return *d
+			}
+			return ""
+		},
+		"activity title": cleanActivityTitle,
+		"activity description": func(v *apperror.ValidationError, raw string) string {
+			if d := cleanActivityDescription(v, raw); d != nil {
+				return *d
+			}
+			return ""
+		},
+	}
+	limits := map[string]struct {
+		field    string
+		max      int
+		required bool
+	}{
+		"group name":           {"name", maxGroupNameLength, true},
+		"group description":    {"description", maxGroupDescriptionLength, false},
+		"activity title":       {"title", maxActivityTitleLength, true},
+		"activity description": {"description", maxActivityDescriptionLength, false},
+	}
+
+	for name, fn := range clean {
+		limit := limits[name]
+		t.Run(name, func(t *testing.T) {
+			tests := []struct {
+				name    string
+				raw     string
+				want    string
+				wantErr bool
+			}{
+				{"at the limit", strings.Repeat("é", limit.max), strings.Repeat("é", limit.max), false},
+				{"at the limit after trimming", "  " + strings.Repeat("é", limit.max) + "\n", strings.Repeat("é", limit.max), false},
+				{"one over the limit", strings.Repeat("é", limit.max+1), "", true},
+				{"control characters do not count", strings.Repeat("é", limit.max) + "\x00\u202e", strings.Repeat("é", limit.max), false},
+				{"whitespace only", " \t\n ", "", limit.required},
+			}
+
+			for _, tt := range tests {
+				v := apperror.NewValidationError()
+				got := fn(v, tt.raw)
+				err := v.Err()
+				if tt.wantErr {
+					if err == nil || err.(*apperror.AppError).Errors[limit.field] == "" {
+						t.Errorf("%s: err = %v, want a %q field error", tt.name, err, limit.field)
+					}
+					continue
+				}
+				if err != nil {
+					t.Errorf("%s: err = %v, want none", tt.name, err)
+				}
+				if got != tt.want {
+					t.Errorf("%s: cleaned to %d characters, want %d", tt.name, len([]rune(got)), len([]rune(tt.want)))
+				}
+			}
+		})
+	}
+}
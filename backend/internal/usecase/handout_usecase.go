@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 	"path/filepath"
 	"strings"
 
@@ -12,13 +11,17 @@ import (
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
 )
 
 type HandoutUseCase struct {
-	handoutRepo repository.HandoutRepository
-	topicRepo   repository.TopicRepository
-	userRepo    repository.UserRepository
-	storageSvc  service.StorageService
+	handoutRepo    repository.HandoutRepository
+	topicRepo      repository.TopicRepository
+	userRepo       repository.UserRepository
+	storageSvc     service.StorageService
+	activityRepo   repository.ActivityRepository
+	accessRecorder *ContentAccessRecorder
+	uploadLimits   uploadlimits.Limits
 }
 
 func NewHandoutUseCase(
@@ -26,17 +29,21 @@ func NewHandoutUseCase(
 	topicRepo repository.TopicRepository,
 	userRepo repository.UserRepository,
 	storageSvc service.StorageService,
+	activityRepo repository.ActivityRepository,
+	accessRecorder *ContentAccessRecorder,
+	uploadLimits uploadlimits.Limits,
 ) *HandoutUseCase {
 	return &HandoutUseCase{
-		handoutRepo: handoutRepo,
-		topicRepo:   topicRepo,
-		userRepo:    userRepo,
-		storageSvc:  storageSvc,
+		handoutRepo:    handoutRepo,
+		topicRepo:      topicRepo,
+		userRepo:       userRepo,
+		storageSvc:     storageSvc,
+		activityRepo:   activityRepo,
+		accessRecorder: accessRecorder,
+		uploadLimits:   uploadLimits,
 	}
 }
 
-const maxHandoutFileSize = 50 << 20 // 50MB
-
 type UpdateHandoutInput struct {
 	Title       *string
 	Description *string
@@ -78,7 +85,7 @@ func (uc *HandoutUseCase) Create(
 	if contentType != "application/pdf" {
 		return nil, apperror.ErrInvalidFileType
 	}
-	if size > maxHandoutFileSize {
+	if size > uc.uploadLimits.Handout {
 		return nil, apperror.ErrFileTooLarge
 	}
 
@@ -108,9 +115,6 @@ func (uc *HandoutUseCase) Create(
 
 	if err := uc.handoutRepo.Create(ctx, handout, topicIDs); err != nil {
 		_ = uc.storageSvc.Delete(ctx, key)
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrHandoutTitleTaken
-		}
 		return nil, err
 	}
 
@@ -120,11 +124,11 @@ func (uc *HandoutUseCase) Create(
 		return nil, err
 	}
 
-	created.FileURL = uc.storageSvc.GetPublicURL(created.FileKey)
 	return created, nil
 }
 
-func (uc *HandoutUseCase) GetByPublicID(ctx context.Context, publicID string) (*entity.Handout, error) {
+// GetByPublicID returns the handout and counts a view by viewerPublicID.
+func (uc *HandoutUseCase) GetByPublicID(ctx context.Context, publicID, viewerPublicID string) (*entity.Handout, error) {
 	handout, err := uc.handoutRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
 		return nil, err
@@ -133,10 +137,34 @@ func (uc *HandoutUseCase) GetByPublicID(ctx context.Context, publicID string) (*
 		return nil, apperror.ErrHandoutNotFound
 	}
 
-	handout.FileURL = uc.storageSvc.GetPublicURL(handout.FileKey)
+	uc.accessRecorder.Record(entity.ActivityItemTypeHandout, handout.ID, viewerPublicID, entity.ContentAccessView)
+
 	return handout, nil
 }
 
+// OpenDownload streams the handout's file and counts the download by
+// requesterPublicID. The caller must close the returned object's body.
+func (uc *HandoutUseCase) OpenDownload(ctx context.Context, publicID, requesterPublicID, byteRange string) (*entity.Handout, *service.StorageObject, error) {
+	handout, err := uc.handoutRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if handout == nil {
+		return nil, nil, apperror.ErrHandoutNotFound
+	}
+
+	obj, err := uc.storageSvc.Open(ctx, handout.FileKey, byteRange)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if countsAsDownload(byteRange) {
+		uc.accessRecorder.Record(entity.ActivityItemTypeHandout, handout.ID, requesterPublicID, entity.ContentAccessDownload)
+	}
+
+	return handout, obj, nil
+}
+
 func (uc *HandoutUseCase) Update(ctx context.Context, publicID string, input UpdateHandoutInput) (*entity.Handout, error) {
 	handout, err := uc.handoutRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
@@ -164,9 +192,6 @@ func (uc *HandoutUseCase) Update(ctx context.Context, publicID string, input Upd
 	}
 
 	if err := uc.handoutRepo.Update(ctx, handout); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrHandoutTitleTaken
-		}
 		return nil, err
 	}
 
@@ -185,7 +210,6 @@ func (uc *HandoutUseCase) Update(ctx context.Context, publicID string, input Upd
 		return nil, err
 	}
 
-	updated.FileURL = uc.storageSvc.GetPublicURL(updated.FileKey)
 	return updated, nil
 }
 
@@ -217,7 +241,7 @@ func (uc *HandoutUseCase) ReplaceFile(
 	if contentType != "application/pdf" {
 		return nil, apperror.ErrInvalidFileType
 	}
-	if size > maxHandoutFileSize {
+	if size > uc.uploadLimits.Handout {
 		return nil, apperror.ErrFileTooLarge
 	}
 
@@ -250,52 +274,52 @@ func (uc *HandoutUseCase) ReplaceFile(
 	if err != nil {
 		return nil, err
 	}
-	updated.FileURL = uc.storageSvc.GetPublicURL(updated.FileKey)
 	return updated, nil
 }
 
-func (uc *HandoutUseCase) Delete(ctx context.Context, publicID string) error {
+// ListUsages returns the activities whose items reference the handout.
+func (uc *HandoutUseCase) ListUsages(ctx context.Context, publicID string) ([]entity.ActivityRef, error) {
 	handout, err := uc.handoutRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if handout == nil {
-		return apperror.ErrHandoutNotFound
+		return nil, apperror.ErrHandoutNotFound
 	}
 
-	return uc.handoutRepo.Delete(ctx, publicID)
+	return uc.activityRepo.ListContentReferences(ctx, entity.ActivityItemTypeHandout, handout.ID)
 }
 
-func (uc *HandoutUseCase) List(ctx context.Context, pageNumber, pageSize int, filter repository.HandoutFilter) ([]entity.Handout, int, int, error) {
-	if pageNumber < 1 {
-		pageNumber = 1
+func (uc *HandoutUseCase) Delete(ctx context.Context, publicID string, force bool) error {
+	handout, err := uc.handoutRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return err
 	}
-	if pageSize < 1 {
-		pageSize = 10
+	if handout == nil {
+		return apperror.ErrHandoutNotFound
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	if !force {
+		if err := ensureContentUnused(ctx, uc.activityRepo, entity.ActivityItemTypeHandout, handout.ID); err != nil {
+			return err
+		}
 	}
-	offset := (pageNumber - 1) * pageSize
 
-	handouts, err := uc.handoutRepo.List(ctx, pageSize, offset, filter)
+	return uc.handoutRepo.Delete(ctx, publicID, force)
+}
+
+func (uc *HandoutUseCase) List(ctx context.Context, limit, offset int, filter repository.HandoutFilter) ([]entity.Handout, int, error) {
+	handouts, err := uc.handoutRepo.List(ctx, limit, offset, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
 	total, err := uc.handoutRepo.Count(ctx, filter)
 	if err != nil {
-		return nil, 0, 0, err
-	}
-
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
-
-	// Set file URLs
-	for i := range handouts {
-		handouts[i].FileURL = uc.storageSvc.GetPublicURL(handouts[i].FileKey)
+		return nil, 0, err
 	}
 
-	return handouts, total, totalPages, nil
+	return handouts, total, nil
 }
 
 func (uc *HandoutUseCase) ResolveTopicIDs(ctx context.Context, publicIDs []string) ([]int, error) {
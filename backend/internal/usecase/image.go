@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	_ "golang.org/x/image/webp"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+const maxImageDimension = 8000
+
+// validateImage confirms body is a fully decodable image whose dimensions are
+// within bounds, so files whose headers lie about the content (or declare huge
+// canvases) are rejected before upload. The dimensions are checked from the
+// header before the pixel data is decoded, which keeps decompression bombs from
+// allocating a full canvas. The returned reader replays the whole body.
+func validateImage(body io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxImageDimension || cfg.Height > maxImageDimension {
+		return nil, apperror.ErrInvalidImage
+	}
+
+	if _, _, err := image.Decode(bytes.NewReader(data)); err != nil {
+		return nil, apperror.ErrInvalidImage
+	}
+
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateImage(t *testing.T) {
+	valid := encodePNG(t, 4, 3)
+	// A 1x1 lossless WebP.
+	webp, _ := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+
+	tests := []struct {
+		name    string
+		body    []byte
+		wantErr bool
+	}{
+		{"png", valid, false},
+		{"webp", webp, false},
+		{"truncated pixel data", valid[:len(valid)-16], true},
+		{"oversized canvas", encodePNG(t, maxImageDimension+1, 1), true},
+		{"not an image", []byte("GIF89a but not really"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateImage(bytes.NewReader(tt.body))
+			if tt.wantErr {
+				if !errors.Is(err, apperror.ErrInvalidImage) {
+					t.Fatalf("err = %v, want ErrInvalidImage", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			replayed, _ := io.ReadAll(got)
+			if !bytes.Equal(replayed, tt.body) {
+				t.Error("the returned reader does not replay the whole body")
+			}
+		})
+	}
+}
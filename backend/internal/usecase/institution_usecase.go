@@ -2,21 +2,27 @@ package usecase
 
 import (
 	"context"
-	"math"
+	"fmt"
+	"io"
+	"path"
 	"strings"
 
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
 )
 
 type InstitutionUseCase struct {
 	institutionRepo repository.InstitutionRepository
 	userRepo        repository.UserRepository
+	storageSvc      service.StorageService
+	uploadLimits    uploadlimits.Limits
 }
 
-func NewInstitutionUseCase(institutionRepo repository.InstitutionRepository, userRepo repository.UserRepository) *InstitutionUseCase {
-	return &InstitutionUseCase{institutionRepo: institutionRepo, userRepo: userRepo}
+func NewInstitutionUseCase(institutionRepo repository.InstitutionRepository, userRepo repository.UserRepository, storageSvc service.StorageService, uploadLimits uploadlimits.Limits) *InstitutionUseCase {
+	return &InstitutionUseCase{institutionRepo: institutionRepo, userRepo: userRepo, storageSvc: storageSvc, uploadLimits: uploadLimits}
 }
 
 type CreateInstitutionInput struct {
@@ -55,9 +61,6 @@ func (uc *InstitutionUseCase) Create(ctx context.Context, createdByPublicID stri
 	}
 
 	if err := uc.institutionRepo.Create(ctx, institution); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrInstitutionNameTaken
-		}
 		return nil, err
 	}
 
@@ -101,9 +104,6 @@ func (uc *InstitutionUseCase) Update(ctx context.Context, publicID string, input
 	}
 
 	if err := uc.institutionRepo.Update(ctx, institution); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrInstitutionNameTaken
-		}
 		return nil, err
 	}
 
@@ -125,27 +125,88 @@ func (uc *InstitutionUseCase) Delete(ctx context.Context, publicID string) error
 	return uc.institutionRepo.Delete(ctx, publicID)
 }
 
-func (uc *InstitutionUseCase) List(ctx context.Context, page, pageSize int, filter repository.InstitutionFilter) ([]entity.Institution, int, int, error) {
-	if page < 1 {
-		page = 1
+// UploadLogo stores a new logo for the institution, replacing the previous
+// one. Logos follow the same size and type limits as group thumbnails.
+func (uc *InstitutionUseCase) UploadLogo(ctx context.Context, publicID string, filename string, contentType string, size int64, body io.Reader) (*entity.Institution, error) {
+	if size > uc.uploadLimits.Thumbnail {
+		return nil, apperror.ErrFileTooLarge
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+
+	if !allowedThumbnailTypes[contentType] {
+		return nil, apperror.ErrInvalidFileType
 	}
 
-	offset := (page - 1) * pageSize
+	body, err := validateImage(body)
+	if err != nil {
+		return nil, err
+	}
 
-	institutions, err := uc.institutionRepo.List(ctx, pageSize, offset, filter)
+	institution, err := uc.institutionRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, err
+	}
+	if institution == nil {
+		return nil, apperror.ErrInstitutionNotFound
 	}
 
-	total, err := uc.institutionRepo.Count(ctx, filter)
+	if institution.LogoURL != nil {
+		oldKey := extractThumbnailKeyFromURL(*institution.LogoURL)
+		if oldKey != "" {
+			_ = uc.storageSvc.Delete(ctx, oldKey)
+		}
+	}
+
+	ext := path.Ext(filename)
+	key := fmt.Sprintf("logos/institutions/%s/%s%s", institution.PublicID, newUUID(), ext)
+
+	url, err := uc.storageSvc.Upload(ctx, key, contentType, body)
+	if err != nil {
+		return nil, apperror.ErrUploadFailed
+	}
+
+	if err := uc.institutionRepo.UpdateLogo(ctx, publicID, &url); err != nil {
+		return nil, err
+	}
+
+	institution.LogoURL = &url
+	return institution, nil
+}
+
+func (uc *InstitutionUseCase) DeleteLogo(ctx context.Context, publicID string) (*entity.Institution, error) {
+	institution, err := uc.institutionRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, err
+	}
+	if institution == nil {
+		return nil, apperror.ErrInstitutionNotFound
+	}
+
+	if institution.LogoURL != nil {
+		oldKey := extractThumbnailKeyFromURL(*institution.LogoURL)
+		if oldKey != "" {
+			_ = uc.storageSvc.Delete(ctx, oldKey)
+		}
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+	if err := uc.institutionRepo.UpdateLogo(ctx, publicID, nil); err != nil {
+		return nil, err
+	}
+
+	institution.LogoURL = nil
+	return institution, nil
+}
+
+func (uc *InstitutionUseCase) List(ctx context.Context, limit, offset int, filter repository.InstitutionFilter) ([]entity.Institution, int, error) {
+
+	institutions, err := uc.institutionRepo.List(ctx, limit, offset, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := uc.institutionRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return institutions, total, totalPages, nil
+	return institutions, total, nil
 }
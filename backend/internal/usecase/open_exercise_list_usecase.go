@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 	"path/filepath"
 	"strings"
 
@@ -12,13 +11,17 @@ import (
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
 )
 
 type OpenExerciseListUseCase struct {
-	oelRepo    repository.OpenExerciseListRepository
-	topicRepo  repository.TopicRepository
-	userRepo   repository.UserRepository
-	storageSvc service.StorageService
+	oelRepo        repository.OpenExerciseListRepository
+	topicRepo      repository.TopicRepository
+	userRepo       repository.UserRepository
+	storageSvc     service.StorageService
+	activityRepo   repository.ActivityRepository
+	accessRecorder *ContentAccessRecorder
+	uploadLimits   uploadlimits.Limits
 }
 
 func NewOpenExerciseListUseCase(
@@ -26,17 +29,21 @@ func NewOpenExerciseListUseCase(
 	topicRepo repository.TopicRepository,
 	userRepo repository.UserRepository,
 	storageSvc service.StorageService,
+	activityRepo repository.ActivityRepository,
+	accessRecorder *ContentAccessRecorder,
+	uploadLimits uploadlimits.Limits,
 ) *OpenExerciseListUseCase {
 	return &OpenExerciseListUseCase{
-		oelRepo:    oelRepo,
-		topicRepo:  topicRepo,
-		userRepo:   userRepo,
-		storageSvc: storageSvc,
+		oelRepo:        oelRepo,
+		topicRepo:      topicRepo,
+		userRepo:       userRepo,
+		storageSvc:     storageSvc,
+		activityRepo:   activityRepo,
+		accessRecorder: accessRecorder,
+		uploadLimits:   uploadLimits,
 	}
 }
 
-const maxExerciseListFileSize = 50 << 20 // 50MB
-
 var allowedExerciseListTypes = map[string]bool{
 	"application/pdf": true,
 }
@@ -112,7 +119,7 @@ func (uc *OpenExerciseListUseCase) Create(
 		if !allowedExerciseListTypes[contentType] {
 			return nil, apperror.ErrInvalidFileType
 		}
-		if size > maxExerciseListFileSize {
+		if size > uc.uploadLimits.ExerciseList {
 			return nil, apperror.ErrFileTooLarge
 		}
 
@@ -134,9 +141,6 @@ func (uc *OpenExerciseListUseCase) Create(
 		if oel.FileKey != "" {
 			_ = uc.storageSvc.Delete(ctx, oel.FileKey)
 		}
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrOpenExerciseListTitleTaken
-		}
 		return nil, err
 	}
 
@@ -150,7 +154,8 @@ func (uc *OpenExerciseListUseCase) Create(
 	return created, nil
 }
 
-func (uc *OpenExerciseListUseCase) GetByPublicID(ctx context.Context, publicID string) (*entity.OpenExerciseList, error) {
+// GetByPublicID returns the list and counts a view by viewerPublicID.
+func (uc *OpenExerciseListUseCase) GetByPublicID(ctx context.Context, publicID, viewerPublicID string) (*entity.OpenExerciseList, error) {
 	oel, err := uc.oelRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
 		return nil, err
@@ -159,10 +164,44 @@ func (uc *OpenExerciseListUseCase) GetByPublicID(ctx context.Context, publicID s
 		return nil, apperror.ErrOpenExerciseListNotFound
 	}
 
+	uc.accessRecorder.Record(entity.ActivityItemTypeOpenExerciseList, oel.ID, viewerPublicID, entity.ContentAccessView)
+
 	uc.resolveURL(oel)
 	return oel, nil
 }
 
+// OpenDownload streams the list's uploaded file and counts the download by
+// requesterPublicID.
+// Lists that only link to an external URL return a nil object and the URL in
+// ResolvedURL for the caller to redirect to. The caller must close a returned
+// object's body.
+func (uc *OpenExerciseListUseCase) OpenDownload(ctx context.Context, publicID, requesterPublicID, byteRange string) (*entity.OpenExerciseList, *service.StorageObject, error) {
+	oel, err := uc.oelRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if oel == nil {
+		return nil, nil, apperror.ErrOpenExerciseListNotFound
+	}
+	uc.resolveURL(oel)
+
+	var obj *service.StorageObject
+	if oel.FileID != nil && oel.FileKey != "" {
+		obj, err = uc.storageSvc.Open(ctx, oel.FileKey, byteRange)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if oel.ResolvedURL == "" {
+		return nil, nil, apperror.ErrOpenExerciseListNotFound
+	}
+
+	if countsAsDownload(byteRange) {
+		uc.accessRecorder.Record(entity.ActivityItemTypeOpenExerciseList, oel.ID, requesterPublicID, entity.ContentAccessDownload)
+	}
+
+	return oel, obj, nil
+}
+
 func (uc *OpenExerciseListUseCase) Update(ctx context.Context, publicID string, input UpdateOpenExerciseListInput) (*entity.OpenExerciseList, error) {
 	oel, err := uc.oelRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
@@ -199,9 +238,6 @@ func (uc *OpenExerciseListUseCase) Update(ctx context.Context, publicID string,
 	}
 
 	if err := uc.oelRepo.Update(ctx, oel); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrOpenExerciseListTitleTaken
-		}
 		return nil, err
 	}
 
@@ -252,7 +288,7 @@ func (uc *OpenExerciseListUseCase) ReplaceFile(
 	if !allowedExerciseListTypes[contentType] {
 		return nil, apperror.ErrInvalidFileType
 	}
-	if size > maxExerciseListFileSize {
+	if size > uc.uploadLimits.ExerciseList {
 		return nil, apperror.ErrFileTooLarge
 	}
 
@@ -290,48 +326,54 @@ func (uc *OpenExerciseListUseCase) ReplaceFile(
 	return updated, nil
 }
 
-func (uc *OpenExerciseListUseCase) Delete(ctx context.Context, publicID string) error {
+// ListUsages returns the activities whose items reference the exercise list.
+func (uc *OpenExerciseListUseCase) ListUsages(ctx context.Context, publicID string) ([]entity.ActivityRef, error) {
 	oel, err := uc.oelRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if oel == nil {
-		return apperror.ErrOpenExerciseListNotFound
+		return nil, apperror.ErrOpenExerciseListNotFound
 	}
 
-	return uc.oelRepo.Delete(ctx, publicID)
+	return uc.activityRepo.ListContentReferences(ctx, entity.ActivityItemTypeOpenExerciseList, oel.ID)
 }
 
-func (uc *OpenExerciseListUseCase) List(ctx context.Context, pageNumber, pageSize int, filter repository.OpenExerciseListFilter) ([]entity.OpenExerciseList, int, int, error) {
-	if pageNumber < 1 {
-		pageNumber = 1
+func (uc *OpenExerciseListUseCase) Delete(ctx context.Context, publicID string, force bool) error {
+	oel, err := uc.oelRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return err
 	}
-	if pageSize < 1 {
-		pageSize = 10
+	if oel == nil {
+		return apperror.ErrOpenExerciseListNotFound
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	if !force {
+		if err := ensureContentUnused(ctx, uc.activityRepo, entity.ActivityItemTypeOpenExerciseList, oel.ID); err != nil {
+			return err
+		}
 	}
-	offset := (pageNumber - 1) * pageSize
 
-	lists, err := uc.oelRepo.List(ctx, pageSize, offset, filter)
+	return uc.oelRepo.Delete(ctx, publicID, force)
+}
+
+func (uc *OpenExerciseListUseCase) List(ctx context.Context, limit, offset int, filter repository.OpenExerciseListFilter) ([]entity.OpenExerciseList, int, error) {
+	lists, err := uc.oelRepo.List(ctx, limit, offset, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
 	total, err := uc.oelRepo.Count(ctx, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
-
 	// Set file URLs
 	for i := range lists {
 		uc.resolveURL(&lists[i])
 	}
 
-	return lists, total, totalPages, nil
+	return lists, total, nil
 }
 
 func (uc *OpenExerciseListUseCase) ResolveTopicIDs(ctx context.Context, publicIDs []string) ([]int, error) {
@@ -357,10 +399,10 @@ func (uc *OpenExerciseListUseCase) resolveTopicIDs(ctx context.Context, publicID
 	return ids, nil
 }
 
+// resolveURL sets ResolvedURL for lists that link to an external file.
+// Uploaded files are only served through OpenDownload.
 func (uc *OpenExerciseListUseCase) resolveURL(oel *entity.OpenExerciseList) {
-	if oel.FileID != nil && oel.FileKey != "" {
-		oel.ResolvedURL = uc.storageSvc.GetPublicURL(oel.FileKey)
-	} else if oel.FileURL != nil {
+	if oel.FileID == nil && oel.FileURL != nil {
 		oel.ResolvedURL = *oel.FileURL
 	}
 }
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type ProgressUseCase struct {
+	groupRepo    repository.GroupRepository
+	userRepo     repository.UserRepository
+	activityRepo repository.ActivityRepository
+	subRepo      repository.ActivitySubmissionRepository
+	subUC        *ActivitySubmissionUseCase
+}
+
+func NewProgressUseCase(
+	groupRepo repository.GroupRepository,
+	userRepo repository.UserRepository,
+	activityRepo repository.ActivityRepository,
+	subRepo repository.ActivitySubmissionRepository,
+	subUC *ActivitySubmissionUseCase,
+) *ProgressUseCase {
+	return &ProgressUseCase{
+		groupRepo:    groupRepo,
+		userRepo:     userRepo,
+		activityRepo: activityRepo,
+		subRepo:      subRepo,
+		subUC:        subUC,
+	}
+}
+
+type ActivityProgress struct {
+	ActivityPublicID string
+	Title            string
+	DueDate          time.Time
+	SubmissionStatus *entity.ActivitySubmissionStatus // nil when the member has not started the activity
+	TotalQuestions   int
+	PassedQuestions  int
+	PassRate         *float64 // nil when the activity has no questions
+	Questions        []QuestionStatus
+}
+
+// GetMemberProgress returns, for every activity of the group, the member's
+// submission status and question pass rate. Members may only see their own
+// progress; group admins, supervisors and platform admins may see anyone's.
+func (uc *ProgressUseCase) GetMemberProgress(ctx context.Context, groupPublicID, userPublicID, requesterPublicID string, requesterRole entity.UserRole) ([]ActivityProgress, error) {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, apperror.ErrGroupNotFound
+	}
+
+	if requesterRole != entity.UserRoleAdmin {
+		requester, err := uc.userRepo.GetByPublicID(ctx, requesterPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if requester == nil {
+			return nil, apperror.ErrUserNotFound
+		}
+
+		member, err := uc.groupRepo.GetMember(ctx, group.ID, requester.ID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil || !member.IsActive || member.AcceptedByID == nil {
+			return nil, denyGroup(ctx, uc.groupRepo, group, requester)
+		}
+
+		isStaff := member.Role == entity.MemberRoleAdmin || member.Role == entity.MemberRoleSupervisor
+		if !isStaff && requester.PublicID != userPublicID {
+			return nil, apperror.ErrForbidden
+		}
+	}
+
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	member, err := uc.groupRepo.GetMember(ctx, group.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || !member.IsActive || member.AcceptedByID == nil {
+		return nil, apperror.ErrMemberNotFound
+	}
+
+	activities, err := uc.activityRepo.ListByGroup(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ActivityProgress, len(activities))
+	for i, a := range activities {
+		progress := ActivityProgress{
+			ActivityPublicID: a.PublicID,
+			Title:            a.Title,
+			DueDate:          a.DueDate,
+			TotalQuestions:   a.TotalQuestionsCount,
+		}
+
+		sub, err := uc.subRepo.GetByActivityAndUser(ctx, a.ID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if sub != nil {
+			progress.SubmissionStatus = &sub.Status
+
+			progress.Questions, err = uc.subUC.questionStatusesForSubmission(ctx, sub.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, q := range progress.Questions {
+				if q.Passed {
+					progress.PassedQuestions++
+				}
+			}
+		}
+
+		if progress.TotalQuestions > 0 {
+			rate := float64(min(progress.PassedQuestions, progress.TotalQuestions)) / float64(progress.TotalQuestions)
+			progress.PassRate = &rate
+		}
+
+		result[i] = progress
+	}
+
+	return result, nil
+}
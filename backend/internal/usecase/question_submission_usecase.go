@@ -2,31 +2,55 @@ package usecase
 
 import (
 	"context"
-	"math"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+	"proximos-passos/backend/internal/i18n"
 )
 
 type QuestionSubmissionUseCase struct {
-	subRepo  repository.QuestionSubmissionRepository
-	qRepo    repository.QuestionRepository
-	userRepo repository.UserRepository
-	actSubUC *ActivitySubmissionUseCase
+	subRepo      repository.QuestionSubmissionRepository
+	qRepo        repository.QuestionRepository
+	userRepo     repository.UserRepository
+	fileRepo     repository.FileRepository
+	storageSvc   service.StorageService
+	emailSvc     service.EmailService
+	frontendURL  string
+	actSubUC     *ActivitySubmissionUseCase
+	uploadLimits uploadlimits.Limits
 }
 
 func NewQuestionSubmissionUseCase(
 	subRepo repository.QuestionSubmissionRepository,
 	qRepo repository.QuestionRepository,
 	userRepo repository.UserRepository,
+	fileRepo repository.FileRepository,
+	storageSvc service.StorageService,
+	emailSvc service.EmailService,
+	frontendURL string,
 	actSubUC *ActivitySubmissionUseCase,
+	uploadLimits uploadlimits.Limits,
 ) *QuestionSubmissionUseCase {
 	return &QuestionSubmissionUseCase{
-		subRepo:  subRepo,
-		qRepo:    qRepo,
-		userRepo: userRepo,
-		actSubUC: actSubUC,
+		subRepo:      subRepo,
+		qRepo:        qRepo,
+		userRepo:     userRepo,
+		fileRepo:     fileRepo,
+		storageSvc:   storageSvc,
+		emailSvc:     emailSvc,
+		frontendURL:  frontendURL,
+		actSubUC:     actSubUC,
+		uploadLimits: uploadLimits,
 	}
 }
 
@@ -62,6 +86,10 @@ func (uc *QuestionSubmissionUseCase) Submit(ctx context.Context, input SubmitAns
 
 	// Link to activity submission if activity context is provided
 	if input.ActivityPublicID != nil && *input.ActivityPublicID != "" && uc.actSubUC != nil {
+		if err := uc.actSubUC.ensureQuestionInActivity(ctx, *input.ActivityPublicID, question.ID); err != nil {
+			return nil, err
+		}
+
 		actSub, err := uc.actSubUC.GetOrCreateSubmission(ctx, *input.ActivityPublicID, input.UserPublicID)
 		if err != nil {
 			return nil, err
@@ -105,7 +133,8 @@ func (uc *QuestionSubmissionUseCase) Submit(ctx context.Context, input SubmitAns
 		// Open-ended questions are not auto-graded — score stays nil
 	}
 
-	if err := uc.subRepo.Create(ctx, sub); err != nil {
+	// The attempt limit only applies within an activity.
+	if err := uc.subRepo.Create(ctx, sub, question.MaxAttempts); err != nil {
 		return nil, err
 	}
 
@@ -117,6 +146,33 @@ func (uc *QuestionSubmissionUseCase) Submit(ctx context.Context, input SubmitAns
 	return full, nil
 }
 
+// ListMyAttempts returns the user's own attempts at a question within an
+// activity, newest first. Attempts are looked up through the user's activity
+// submission, so other students' attempts are never included.
+func (uc *QuestionSubmissionUseCase) ListMyAttempts(ctx context.Context, activityPublicID, questionPublicID, userPublicID string) ([]entity.QuestionSubmission, error) {
+	question, err := uc.qRepo.GetByPublicID(ctx, questionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if question == nil {
+		return nil, apperror.ErrQuestionNotFound
+	}
+
+	actSub, err := uc.actSubUC.findOwnSubmission(ctx, activityPublicID, userPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if actSub == nil {
+		return nil, nil
+	}
+
+	attempts, err := uc.subRepo.ListByActivitySubmissionAndQuestion(ctx, actSub.ID, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
 func (uc *QuestionSubmissionUseCase) GetByPublicID(ctx context.Context, publicID string) (*entity.QuestionSubmission, error) {
 	s, err := uc.subRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
@@ -128,7 +184,7 @@ func (uc *QuestionSubmissionUseCase) GetByPublicID(ctx context.Context, publicID
 	return s, nil
 }
 
-func (uc *QuestionSubmissionUseCase) ListMySubmissions(ctx context.Context, userPublicID string, page, size int, statement string) ([]entity.QuestionSubmission, int, error) {
+func (uc *QuestionSubmissionUseCase) ListMySubmissions(ctx context.Context, userPublicID string, limit, offset int, statement string) ([]entity.QuestionSubmission, int, error) {
 	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -142,21 +198,14 @@ func (uc *QuestionSubmissionUseCase) ListMySubmissions(ctx context.Context, user
 		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
-	if page > totalPages && totalPages > 0 {
-		page = totalPages
-	}
-	offset := (page - 1) * size
-
-	subs, err := uc.subRepo.ListByUser(ctx, user.ID, size, offset, statement)
+	subs, err := uc.subRepo.ListByUser(ctx, user.ID, limit, offset, statement)
 	if err != nil {
 		return nil, 0, err
 	}
-
 	return subs, total, nil
 }
 
-func (uc *QuestionSubmissionUseCase) ListByQuestion(ctx context.Context, questionPublicID string, page, size int) ([]entity.QuestionSubmission, int, error) {
+func (uc *QuestionSubmissionUseCase) ListByQuestion(ctx context.Context, questionPublicID string, limit, offset int) ([]entity.QuestionSubmission, int, error) {
 	question, err := uc.qRepo.GetByPublicID(ctx, questionPublicID)
 	if err != nil {
 		return nil, 0, err
@@ -170,16 +219,332 @@ func (uc *QuestionSubmissionUseCase) ListByQuestion(ctx context.Context, questio
 		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(size)))
-	if page > totalPages && totalPages > 0 {
-		page = totalPages
+	subs, err := uc.subRepo.ListByQuestion(ctx, question.ID, limit, offset)
+	if err != nil {
+		return nil, 0, err
 	}
-	offset := (page - 1) * size
+	return subs, total, nil
+}
+
+// ==========================================
+// Answer attachments
+// ==========================================
 
-	subs, err := uc.subRepo.ListByQuestion(ctx, question.ID, size, offset)
+// UploadAttachment attaches a file, such as a photo of handwritten work, to
+// the user's own open-ended answer, replacing any previous attachment. Limits
+// follow the group's submission upload settings when the answer belongs to an
+// activity, and the platform defaults otherwise.
+func (uc *QuestionSubmissionUseCase) UploadAttachment(ctx context.Context, submissionPublicID, userPublicID, filename, contentType string, size int64, body io.Reader) (*entity.QuestionSubmission, error) {
+	sub, err := uc.subRepo.GetByPublicID(ctx, submissionPublicID)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	if sub == nil {
+		return nil, apperror.ErrQuestionSubmissionNotFound
 	}
 
-	return subs, total, nil
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+	if sub.UserID != user.ID {
+		return nil, apperror.ErrForbidden
+	}
+	if sub.QuestionType != "open_ended" {
+		return nil, apperror.ErrAttachmentNotAllowed
+	}
+	if sub.ReviewedAt != nil {
+		return nil, apperror.ErrAnswerReviewed
+	}
+
+	limits := submissionUploadSettings(&entity.Group{}, uc.uploadLimits.SubmissionAttachment)
+	actSub, err := uc.activitySubmissionFor(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	if actSub != nil {
+		if err := uc.ensureAnswerEditable(ctx, actSub); err != nil {
+			return nil, err
+		}
+		if limits, err = uc.actSubUC.uploadSettingsForActivity(ctx, actSub.ActivityID); err != nil {
+			return nil, err
+		}
+	}
+	if !slices.Contains(limits.AllowedTypes, contentType) {
+		return nil, apperror.ErrInvalidFileType
+	}
+	if size > limits.MaxSizeBytes {
+		return nil, apperror.ErrFileTooLarge
+	}
+
+	key := fmt.Sprintf("question-submissions/%s%s", newUUID(), filepath.Ext(filename))
+	stored, err := uploadDeduplicated(ctx, uc.storageSvc, uc.fileRepo, key, contentType, size, body)
+	if err != nil {
+		return nil, apperror.ErrUploadFailed
+	}
+
+	attachment := &entity.QuestionSubmissionAttachment{
+		Key:         stored.Key,
+		Filename:    filename,
+		ContentType: contentType,
+		SizeBytes:   size,
+		Checksum:    &stored.Checksum,
+	}
+	if err := uc.subRepo.SetAttachment(ctx, sub.ID, attachment, user.ID); err != nil {
+		releaseStoredObject(ctx, uc.storageSvc, uc.fileRepo, stored.Key)
+		return nil, err
+	}
+	if sub.Attachment != nil {
+		releaseStoredObject(ctx, uc.storageSvc, uc.fileRepo, sub.Attachment.Key)
+	}
+
+	sub.AttachmentFileID = &attachment.FileID
+	sub.Attachment = attachment
+	return sub, nil
+}
+
+// GetAttachmentDownloadURL returns a short-lived signed URL for the
+// attachment of an answer. The author, platform admins and the staff of the
+// activity's group may download it; to anyone else the answer does not exist.
+func (uc *QuestionSubmissionUseCase) GetAttachmentDownloadURL(ctx context.Context, submissionPublicID, userPublicID string) (string, error) {
+	sub, err := uc.subRepo.GetByPublicID(ctx, submissionPublicID)
+	if err != nil {
+		return "", err
+	}
+	if sub == nil {
+		return "", apperror.ErrQuestionSubmissionNotFound
+	}
+
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", apperror.ErrUserNotFound
+	}
+
+	if sub.UserID != user.ID && user.Role != entity.UserRoleAdmin {
+		actSub, err := uc.activitySubmissionFor(ctx, sub)
+		if err != nil {
+			return "", err
+		}
+		isStaff := false
+		if actSub != nil {
+			if isStaff, err = uc.actSubUC.canSeeSubmission(ctx, actSub, user); err != nil {
+				return "", err
+			}
+		}
+		if !isStaff {
+			return "", apperror.ErrQuestionSubmissionNotFound
+		}
+	}
+
+	if sub.Attachment == nil {
+		return "", apperror.ErrAttachmentNotFound
+	}
+	return uc.storageSvc.GetSignedURL(ctx, sub.Attachment.Key, attachmentURLExpiry)
+}
+
+// ensureAnswerEditable rejects changes to answers whose activity submission
+// was already sent for review or whose activity is past its due date.
+func (uc *QuestionSubmissionUseCase) ensureAnswerEditable(ctx context.Context, actSub *entity.ActivitySubmission) error {
+	if actSub.Status != entity.ActivitySubmissionStatusCreated && actSub.Status != entity.ActivitySubmissionStatusPending {
+		return apperror.ErrActivitySubmissionNotPending
+	}
+
+	activity, err := uc.actSubUC.activityRepo.GetByID(ctx, actSub.ActivityID)
+	if err != nil {
+		return err
+	}
+	if activity == nil {
+		return apperror.ErrActivityNotFound
+	}
+	if time.Now().After(activity.DueDate) {
+		return apperror.ErrActivityPastDue
+	}
+	return nil
+}
+
+// ==========================================
+// Reviews
+// ==========================================
+
+type ReviewQuestionSubmissionInput struct {
+	SubmissionPublicID string
+	ReviewerPublicID   string
+	Score              *int
+	Passed             *bool
+	Feedback           *string
+}
+
+// Review grades a single open-ended attempt with a score, pass flag and
+// feedback, then notifies the student. Attempts made within an activity may
+// be reviewed by the group's admins and supervisors; platform admins may
+// review any attempt. When Passed is omitted it follows the question's
+// passing score.
+func (uc *QuestionSubmissionUseCase) Review(ctx context.Context, input ReviewQuestionSubmissionInput) (*entity.QuestionSubmission, error) {
+	if input.Score == nil || *input.Score < 0 || *input.Score > 100 {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	sub, err := uc.subRepo.GetByPublicID(ctx, input.SubmissionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, apperror.ErrQuestionSubmissionNotFound
+	}
+	if sub.QuestionType != "open_ended" {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	reviewer, err := uc.userRepo.GetByPublicID(ctx, input.ReviewerPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewer == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	actSub, err := uc.activitySubmissionFor(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	if reviewer.Role != entity.UserRoleAdmin {
+		// Students may see but not grade their own answers; to anyone else
+		// outside the group's staff the answer does not exist.
+		if sub.UserID == reviewer.ID {
+			return nil, apperror.ErrForbidden
+		}
+		isStaff := false
+		if actSub != nil {
+			if isStaff, err = uc.actSubUC.canSeeSubmission(ctx, actSub, reviewer); err != nil {
+				return nil, err
+			}
+		}
+		if !isStaff {
+			return nil, apperror.ErrQuestionSubmissionNotFound
+		}
+	}
+
+	passed := false
+	if input.Passed != nil {
+		passed = *input.Passed
+	} else {
+		question, err := uc.qRepo.GetByPublicID(ctx, sub.QuestionPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if question == nil {
+			return nil, apperror.ErrQuestionNotFound
+		}
+		passed = question.PassingScore == nil || *input.Score >= *question.PassingScore
+	}
+
+	var feedback *string
+	if input.Feedback != nil {
+		f := strings.TrimSpace(*input.Feedback)
+		if f != "" {
+			feedback = &f
+		}
+	}
+
+	sub.Score = input.Score
+	sub.Passed = passed
+	sub.AnswerFeedback = feedback
+	sub.ReviewedByID = &reviewer.ID
+
+	if err := uc.subRepo.Review(ctx, sub); err != nil {
+		return nil, err
+	}
+	uc.notifyReviewed(ctx, sub, actSub)
+	return sub, nil
+}
+
+// activitySubmissionFor returns the activity submission an attempt was made
+// under, or nil for attempts made outside an activity.
+func (uc *QuestionSubmissionUseCase) activitySubmissionFor(ctx context.Context, sub *entity.QuestionSubmission) (*entity.ActivitySubmission, error) {
+	if sub.ActivitySubmissionID == nil || uc.actSubUC == nil {
+		return nil, nil
+	}
+	return uc.actSubUC.subRepo.GetByID(ctx, *sub.ActivitySubmissionID)
+}
+
+// notifyReviewed emails the student about a reviewed answer. Failures are only
+// logged so a mail outage never undoes the grade.
+func (uc *QuestionSubmissionUseCase) notifyReviewed(ctx context.Context, sub *entity.QuestionSubmission, actSub *entity.ActivitySubmission) {
+	student, err := uc.userRepo.GetByPublicID(ctx, sub.UserPublicID)
+	if err != nil || student == nil {
+		log.Printf("failed to load student %s to notify about reviewed answer %s: %v", sub.UserPublicID, sub.PublicID, err)
+		return
+	}
+
+	locale := userLocale(student)
+	answerURL := fmt.Sprintf("%s/%s/dashboard/submissions", uc.frontendURL, i18n.Resolve(locale))
+	activityTitle := ""
+	if actSub != nil {
+		answerURL = fmt.Sprintf("%s/%s/dashboard/activities/%s", uc.frontendURL, i18n.Resolve(locale), actSub.ActivityPublicID)
+		activityTitle = actSub.ActivityTitle
+	}
+
+	feedback := ""
+	if sub.AnswerFeedback != nil {
+		feedback = *sub.AnswerFeedback
+	}
+	if err := uc.emailSvc.SendAnswerReviewedEmail(ctx, student.Email, student.Name, locale, activityTitle, sub.Passed, feedback, answerURL); err != nil {
+		log.Printf("failed to send answer reviewed email to %s: %v", student.Email, err)
+	}
+}
+
+// ==========================================
+// Time-to-answer tracking
+// ==========================================
+
+type StartAttemptInput struct {
+	QuestionPublicID string
+	UserPublicID     string
+	ActivityPublicID *string
+}
+
+// StartAttempt records when a question was served to the user so the next
+// submission can store how long it took to answer.
+func (uc *QuestionSubmissionUseCase) StartAttempt(ctx context.Context, input StartAttemptInput) (time.Time, error) {
+	question, err := uc.qRepo.GetByPublicID(ctx, input.QuestionPublicID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if question == nil {
+		return time.Time{}, apperror.ErrQuestionNotFound
+	}
+
+	user, err := uc.userRepo.GetByPublicID(ctx, input.UserPublicID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if user == nil {
+		return time.Time{}, apperror.ErrUserNotFound
+	}
+
+	if input.ActivityPublicID != nil && *input.ActivityPublicID != "" && uc.actSubUC != nil {
+		if _, err := uc.actSubUC.GetOrCreateSubmission(ctx, *input.ActivityPublicID, input.UserPublicID); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return uc.subRepo.StartAttempt(ctx, user.ID, question.ID)
+}
+
+func (uc *QuestionSubmissionUseCase) GetTimeStats(ctx context.Context, questionPublicID string) (*entity.QuestionTimeStats, error) {
+	question, err := uc.qRepo.GetByPublicID(ctx, questionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if question == nil {
+		return nil, apperror.ErrQuestionNotFound
+	}
+
+	return uc.subRepo.GetTimeStats(ctx, question.ID)
 }
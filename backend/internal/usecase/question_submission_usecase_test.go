@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+	"proximos-passos/backend/internal/domain/service"
+)
+
+type fakeQuestionSubmissionRepo struct {
+	repository.QuestionSubmissionRepository
+	sub *entity.QuestionSubmission
+}
+
+func (f *fakeQuestionSubmissionRepo) GetByPublicID(_ context.Context, _ string) (*entity.QuestionSubmission, error) {
+	return f.sub, nil
+}
+
+type fakeActivitySubmissionRepo struct {
+	repository.ActivitySubmissionRepository
+	sub *entity.ActivitySubmission
+}
+
+func (f *fakeActivitySubmissionRepo) GetByID(_ context.Context, _ int) (*entity.ActivitySubmission, error) {
+	return f.sub, nil
+}
+
+type fakeActivityRepo struct {
+	repository.ActivityRepository
+	activity *entity.Activity
+}
+
+func (f *fakeActivityRepo) GetByID(_ context.Context, _ int) (*entity.Activity, error) {
+	return f.activity, nil
+}
+
+func TestUploadAttachmentRejectsClosedAnswers(t *testing.T) {
+	actSubID := 10
+	reviewedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		reviewedAt *time.Time
+		status     entity.ActivitySubmissionStatus
+		dueDate    time.Time
+		want       error
+	}{
+		{"answer reviewed", &reviewedAt, entity.ActivitySubmissionStatusCreated, time.Now().Add(time.Hour), apperror.ErrAnswerReviewed},
+		{"submission approved", nil, entity.ActivitySubmissionStatusApproved, time.Now().Add(time.Hour), apperror.ErrActivitySubmissionNotPending},
+		{"submission reproved", nil, entity.ActivitySubmissionStatusReproved, time.Now().Add(time.Hour), apperror.ErrActivitySubmissionNotPending},
+		{"activity past due", nil, entity.ActivitySubmissionStatusPending, time.Now().Add(-time.Hour), apperror.ErrActivityPastDue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := &fakeQuestionSubmissionRepo{sub: &entity.QuestionSubmission{
+				ID:                   1,
+				UserID:               1,
+				QuestionType:         "open_ended",
+				ActivitySubmissionID: &actSubID,
+				ReviewedAt:           tt.reviewedAt,
+			}}
+			actSubUC := &ActivitySubmissionUseCase{
+				subRepo:      &fakeActivitySubmissionRepo{sub: &entity.ActivitySubmission{ID: actSubID, ActivityID: 5, Status: tt.status}},
+				activityRepo: &fakeActivityRepo{activity: &entity.Activity{ID: 5, DueDate: tt.dueDate}},
+			}
+			users := &fakeAuthUserRepo{users: map[string]*entity.User{"student": {ID: 1, PublicID: "student"}}}
+			uc := &QuestionSubmissionUseCase{subRepo: subs, userRepo: users, actSubUC: actSubUC}
+
+			_, err := uc.UploadAttachment(context.Background(), "answer", "student", "work.jpg", "image/jpeg", 4, strings.NewReader("data"))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+type fakeSignedURLStorage struct {
+	service.StorageService
+}
+
+func (fakeSignedURLStorage) GetSignedURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return "https://signed.example.com/" + key, nil
+}
+
+func TestGetAttachmentDownloadURL(t *testing.T) {
+	subs := &fakeQuestionSubmissionRepo{sub: &entity.QuestionSubmission{
+		ID:         1,
+		UserID:     1,
+		Attachment: &entity.QuestionSubmissionAttachment{Key: "question-submissions/a.jpg"},
+	}}
+	users := &fakeAuthUserRepo{users: map[string]*entity.User{
+		"student":  {ID: 1, PublicID: "student", Role: entity.UserRoleRegular},
+		"stranger": {ID: 2, PublicID: "stranger", Role: entity.UserRoleRegular},
+		"admin":    {ID: 3, PublicID: "admin", Role: entity.UserRoleAdmin},
+	}}
+	uc := &QuestionSubmissionUseCase{subRepo: subs, userRepo: users, storageSvc: fakeSignedURLStorage{}}
+
+	for _, viewer := range []string{"student", "admin"} {
+		url, err := uc.GetAttachmentDownloadURL(context.Background(), "answer", viewer)
+		if err != nil || url != "https://signed.example.com/question-submissions/a.jpg" {
+			t.Errorf("%s: url = %q, err = %v", viewer, url, err)
+		}
+	}
+
+	if _, err := uc.GetAttachmentDownloadURL(context.Background(), "answer", "stranger"); !errors.Is(err, apperror.ErrQuestionSubmissionNotFound) {
+		t.Errorf("stranger: err = %v, want ErrQuestionSubmissionNotFound", err)
+	}
+}
+
+type fakeTimeStatsQuestionRepo struct {
+	repository.QuestionRepository
+	question *entity.Question
+}
+
+func (f *fakeTimeStatsQuestionRepo) GetByPublicID(context.Context, string) (*entity.Question, error) {
+	return f.question, nil
+}
+
+type fakeTimeStatsSubmissionRepo struct {
+	repository.QuestionSubmissionRepository
+	stats map[int]*entity.QuestionTimeStats // by question ID
+}
+
+func (f *fakeTimeStatsSubmissionRepo) GetTimeStats(_ context.Context, questionID int) (*entity.QuestionTimeStats, error) {
+	return f.stats[questionID], nil
+}
+
+func TestGetTimeStats(t *testing.T) {
+	avg := 55.0
+	subs := &fakeTimeStatsSubmissionRepo{stats: map[int]*entity.QuestionTimeStats{
+		7: {QuestionID: 7, TimedAttempts: 3, AverageDurationSeconds: &avg},
+	}}
+
+	uc := &QuestionSubmissionUseCase{subRepo: subs, qRepo: &fakeTimeStatsQuestionRepo{question: &entity.Question{ID: 7, PublicID: "q-7"}}}
+	stats, err := uc.GetTimeStats(context.Background(), "q-7")
+	if err != nil {
+		t.Fatalf("GetTimeStats: %v", err)
+	}
+	if stats == nil || stats.QuestionID != 7 || stats.TimedAttempts != 3 {
+		t.Errorf("stats = %+v, want the figures for question 7", stats)
+	}
+
+	uc.qRepo = &fakeTimeStatsQuestionRepo{}
+	if _, err := uc.GetTimeStats(context.Background(), "missing"); !errors.Is(err, apperror.ErrQuestionNotFound) {
+		t.Errorf("unknown question: err = %v, want ErrQuestionNotFound", err)
+	}
+}
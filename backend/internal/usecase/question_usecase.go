@@ -1,18 +1,25 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"math"
+	"log"
 	"mime/multipart"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/grading"
+	"proximos-passos/backend/internal/domain/publicid"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/text"
+	"proximos-passos/backend/internal/domain/uploadlimits"
 )
 
 type QuestionUseCase struct {
@@ -22,6 +29,9 @@ type QuestionUseCase struct {
 	institutionRepo repository.InstitutionRepository
 	userRepo        repository.UserRepository
 	storageSvc      service.StorageService
+	activityRepo    repository.ActivityRepository
+	imageEncoder    service.ImageEncoder
+	uploadLimits    uploadlimits.Limits
 }
 
 func NewQuestionUseCase(
@@ -31,6 +41,9 @@ func NewQuestionUseCase(
 	institutionRepo repository.InstitutionRepository,
 	userRepo repository.UserRepository,
 	storageSvc service.StorageService,
+	activityRepo repository.ActivityRepository,
+	imageEncoder service.ImageEncoder,
+	uploadLimits uploadlimits.Limits,
 ) *QuestionUseCase {
 	return &QuestionUseCase{
 		qRepo:           qRepo,
@@ -39,11 +52,12 @@ func NewQuestionUseCase(
 		institutionRepo: institutionRepo,
 		userRepo:        userRepo,
 		storageSvc:      storageSvc,
+		activityRepo:    activityRepo,
+		imageEncoder:    imageEncoder,
+		uploadLimits:    uploadLimits,
 	}
 }
 
-const maxQuestionImageSize = 10 << 20 // 10MB
-
 var allowedImageTypes = map[string]bool{
 	"image/jpeg": true,
 	"image/png":  true,
@@ -51,16 +65,35 @@ var allowedImageTypes = map[string]bool{
 	"image/webp": true,
 }
 
+const (
+	duplicateMinSimilarity = 0.6
+	duplicateMaxResults    = 5
+)
+
 var validQuestionTypes = map[string]bool{
 	"open_ended":   true,
 	"closed_ended": true,
 }
 
+var validQuestionDifficulties = map[string]bool{
+	"easy":   true,
+	"medium": true,
+	"hard":   true,
+}
+
+const maxTagLength = 50
+
+const maxStatementLength = 20000
+
 type UpdateQuestionInput struct {
 	Type               *string
 	Statement          *string
 	ExpectedAnswerText *string
+	ExpectedKeywords   []string // nil leaves unchanged, empty slice clears
 	PassingScore       *int
+	MaxAttempts        *int     // 0 to remove the limit
+	Difficulty         *string  // empty string to clear
+	Tags               []string // nil leaves unchanged, empty slice clears
 	ExamID             *string  // exam public ID, empty string to unlink
 	TopicIDs           []string // topic public IDs
 	Options            []OptionInput
@@ -86,27 +119,35 @@ func (uc *QuestionUseCase) Create(
 	qType string,
 	statement string,
 	expectedAnswerText *string,
+	expectedKeywords []string,
 	passingScore *int,
+	maxAttempts *int,
+	difficulty string,
+	tags []string,
 	examPublicID string,
 	topicPublicIDs []string,
 	files []*multipart.FileHeader,
 	optionInputs []OptionInput,
-) (*entity.Question, error) {
+) (*entity.Question, []entity.QuestionDuplicate, error) {
 	user, err := uc.userRepo.GetByPublicID(ctx, createdByPublicID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if user == nil {
-		return nil, apperror.ErrUserNotFound
+		return nil, nil, apperror.ErrUserNotFound
 	}
 
-	statement = strings.TrimSpace(statement)
+	v := apperror.NewValidationError()
+
+	statement = text.MultiLine(statement)
 	if statement == "" {
-		return nil, apperror.ErrInvalidInput
+		v.Add("statement", "Statement is required.")
+	} else if utf8.RuneCountInString(statement) > maxStatementLength {
+		v.Add("statement", fmt.Sprintf("Statement must be at most %d characters long.", maxStatementLength))
 	}
 
 	if !validQuestionTypes[qType] {
-		return nil, apperror.ErrInvalidInput
+		v.Add("type", "Type must be open_ended or closed_ended.")
 	}
 
 	var expAnswer *string
@@ -119,25 +160,74 @@ func (uc *QuestionUseCase) Create(
 
 	if passingScore != nil {
 		if *passingScore < 0 || *passingScore > 100 {
-			return nil, apperror.ErrInvalidInput
+			v.Add("passing_score", "Passing score must be between 0 and 100.")
+		}
+	}
+
+	if maxAttempts != nil && *maxAttempts < 1 {
+		v.Add("max_attempts", "Max attempts must be at least 1.")
+	}
+
+	var diff *string
+	if difficulty != "" {
+		if !validQuestionDifficulties[difficulty] {
+			v.Add("difficulty", "Difficulty must be easy, medium or hard.")
 		}
+		diff = &difficulty
+	}
+
+	normalizedTags, err := normalizeTags(tags)
+	if err != nil {
+		v.Add("tags", fmt.Sprintf("Tags must be at most %d characters long.", maxTagLength))
+	}
+
+	// Type-specific validation
+	switch qType {
+	case "open_ended":
+		if expAnswer == nil {
+			v.Add("expected_answer_text", "Expected answer is required for open-ended questions.")
+		}
+		if passingScore == nil {
+			v.Add("passing_score", "Passing score is required for open-ended questions.")
+		}
+	case "closed_ended":
+		if len(optionInputs) < 2 {
+			v.Add("options", "At least two options are required.")
+		}
+		hasCorrect := false
+		for i, oi := range optionInputs {
+			hasText := oi.Text != nil && strings.TrimSpace(*oi.Text) != ""
+			if !hasText && len(oi.ImageFiles) == 0 {
+				v.Add(fmt.Sprintf("options[%d]", i), "Option must have text or at least one image.")
+			}
+			if oi.IsCorrect {
+				hasCorrect = true
+			}
+		}
+		if !hasCorrect {
+			v.Add("options", "At least one option must be marked as correct.")
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, nil, err
 	}
 
 	// Resolve topic IDs
 	topicIDs, err := uc.resolveTopicIDs(ctx, topicPublicIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Resolve exam ID
 	var examID *int
-	if examPublicID != "" {
+	if examPublicID = strings.TrimSpace(examPublicID); examPublicID != "" {
 		exam, err := uc.examRepo.GetByPublicID(ctx, examPublicID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if exam == nil {
-			return nil, apperror.ErrExamNotFound
+			return nil, nil, apperror.ErrExamNotFound
 		}
 		examID = &exam.ID
 	}
@@ -146,34 +236,21 @@ func (uc *QuestionUseCase) Create(
 		Type:               qType,
 		Statement:          statement,
 		ExpectedAnswerText: expAnswer,
+		ExpectedKeywords:   normalizeKeywords(expectedKeywords),
 		PassingScore:       passingScore,
+		MaxAttempts:        maxAttempts,
+		Difficulty:         diff,
+		Tags:               normalizedTags,
 		ExamID:             examID,
 		CreatedByID:        user.ID,
 	}
 
 	uploadedKeys := []string{}
 
-	// Type-specific validation
-	if qType == "open_ended" {
-		if q.ExpectedAnswerText == nil || *q.ExpectedAnswerText == "" {
-			return nil, apperror.ErrInvalidInput
-		}
-		if q.PassingScore == nil {
-			return nil, apperror.ErrInvalidInput
-		}
-	}
-
 	if qType == "closed_ended" {
-		if len(optionInputs) < 2 {
-			return nil, apperror.ErrInvalidInput
-		}
-		hasCorrect := false
+		q.ExpectedKeywords = nil
 		for i, oi := range optionInputs {
 			hasText := oi.Text != nil && strings.TrimSpace(*oi.Text) != ""
-			hasImages := len(oi.ImageFiles) > 0
-			if !hasText && !hasImages {
-				return nil, apperror.ErrInvalidInput
-			}
 			var trimmed *string
 			if hasText {
 				t := strings.TrimSpace(*oi.Text)
@@ -186,97 +263,66 @@ func (uc *QuestionUseCase) Create(
 			}
 			// Upload option images
 			for _, imgFile := range oi.ImageFiles {
-				ct := imgFile.Header.Get("Content-Type")
-				if !allowedImageTypes[ct] {
-					uc.cleanupFiles(ctx, uploadedKeys)
-					return nil, apperror.ErrInvalidFileType
-				}
-				if imgFile.Size > maxQuestionImageSize {
-					uc.cleanupFiles(ctx, uploadedKeys)
-					return nil, apperror.ErrFileTooLarge
-				}
-				f, ferr := imgFile.Open()
-				if ferr != nil {
-					uc.cleanupFiles(ctx, uploadedKeys)
-					return nil, apperror.ErrInvalidInput
-				}
-				ext := filepath.Ext(imgFile.Filename)
-				key := fmt.Sprintf("question-options/%s%s", newUUID(), ext)
-				_, ferr = uc.storageSvc.Upload(ctx, key, ct, f)
-				f.Close()
+				img, ferr := uc.uploadQuestionImage(ctx, imgFile, "question-options")
 				if ferr != nil {
 					uc.cleanupFiles(ctx, uploadedKeys)
-					return nil, apperror.ErrUploadFailed
+					return nil, nil, ferr
 				}
-				uploadedKeys = append(uploadedKeys, key)
-				opt.Images = append(opt.Images, entity.QuestionImage{
-					FileKey:     key,
-					Filename:    imgFile.Filename,
-					ContentType: ct,
-					SizeBytes:   imgFile.Size,
-				})
+				uploadedKeys = append(uploadedKeys, questionImageKeys(img)...)
+				opt.Images = append(opt.Images, img)
 			}
 			q.Options = append(q.Options, opt)
-			if oi.IsCorrect {
-				hasCorrect = true
-			}
-		}
-		if !hasCorrect {
-			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrInvalidInput
 		}
 	}
 
 	// Upload images
 	for _, fh := range files {
-		ct := fh.Header.Get("Content-Type")
-		if !allowedImageTypes[ct] {
-			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrInvalidFileType
-		}
-		if fh.Size > maxQuestionImageSize {
-			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrFileTooLarge
-		}
-
-		f, err := fh.Open()
-		if err != nil {
-			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrInvalidInput
-		}
-
-		ext := filepath.Ext(fh.Filename)
-		key := fmt.Sprintf("questions/%s%s", newUUID(), ext)
-
-		_, err = uc.storageSvc.Upload(ctx, key, ct, f)
-		f.Close()
+		img, err := uc.uploadQuestionImage(ctx, fh, "questions")
 		if err != nil {
 			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrUploadFailed
+			return nil, nil, err
 		}
-		uploadedKeys = append(uploadedKeys, key)
+		uploadedKeys = append(uploadedKeys, questionImageKeys(img)...)
+		q.Images = append(q.Images, img)
+	}
 
-		q.Images = append(q.Images, entity.QuestionImage{
-			FileKey:     key,
-			Filename:    fh.Filename,
-			ContentType: ct,
-			SizeBytes:   fh.Size,
-		})
+	// Look for duplicates before inserting so the new question cannot match itself
+	duplicates, err := uc.CheckDuplicates(ctx, statement)
+	if err != nil {
+		uc.cleanupFiles(ctx, uploadedKeys)
+		return nil, nil, err
 	}
 
 	if err := uc.qRepo.Create(ctx, q, topicIDs); err != nil {
 		uc.cleanupFiles(ctx, uploadedKeys)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Reload to get full data
 	created, err := uc.qRepo.GetByPublicID(ctx, q.PublicID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	uc.resolveImageURLs(created)
-	return created, nil
+	return created, duplicates, nil
+}
+
+// CheckDuplicates returns active questions whose statement matches statement
+// once case, accents and whitespace are normalized, or is close to it by
+// trigram similarity. It only warns; callers decide whether to proceed.
+func (uc *QuestionUseCase) CheckDuplicates(ctx context.Context, statement string) ([]entity.QuestionDuplicate, error) {
+	normalized := normalizeStatement(statement)
+	if normalized == "" {
+		return nil, nil
+	}
+	return uc.qRepo.FindSimilar(ctx, normalized, duplicateMinSimilarity, duplicateMaxResults)
+}
+
+// normalizeStatement mirrors the normalize_statement SQL function: trim,
+// lowercase, strip accents and collapse whitespace.
+func normalizeStatement(statement string) string {
+	return strings.Join(strings.Fields(grading.Fold(statement)), " ")
 }
 
 func (uc *QuestionUseCase) GetByPublicID(ctx context.Context, publicID string) (*entity.Question, error) {
@@ -309,8 +355,8 @@ func (uc *QuestionUseCase) Update(ctx context.Context, publicID string, input Up
 	}
 
 	if input.Statement != nil {
-		s := strings.TrimSpace(*input.Statement)
-		if s == "" {
+		s := text.MultiLine(*input.Statement)
+		if s == "" || utf8.RuneCountInString(s) > maxStatementLength {
 			return nil, apperror.ErrInvalidInput
 		}
 		q.Statement = s
@@ -325,6 +371,13 @@ func (uc *QuestionUseCase) Update(ctx context.Context, publicID string, input Up
 		}
 	}
 
+	if input.ExpectedKeywords != nil {
+		q.ExpectedKeywords = normalizeKeywords(input.ExpectedKeywords)
+	}
+	if q.Type == "closed_ended" {
+		q.ExpectedKeywords = nil
+	}
+
 	if input.PassingScore != nil {
 		if *input.PassingScore < 0 || *input.PassingScore > 100 {
 			return nil, apperror.ErrInvalidInput
@@ -332,6 +385,36 @@ func (uc *QuestionUseCase) Update(ctx context.Context, publicID string, input Up
 		q.PassingScore = input.PassingScore
 	}
 
+	if input.MaxAttempts != nil {
+		if *input.MaxAttempts < 0 {
+			return nil, apperror.ErrInvalidInput
+		}
+		if *input.MaxAttempts == 0 {
+			q.MaxAttempts = nil
+		} else {
+			q.MaxAttempts = input.MaxAttempts
+		}
+	}
+
+	if input.Difficulty != nil {
+		if *input.Difficulty == "" {
+			q.Difficulty = nil
+		} else {
+			if !validQuestionDifficulties[*input.Difficulty] {
+				return nil, apperror.ErrInvalidInput
+			}
+			q.Difficulty = input.Difficulty
+		}
+	}
+
+	var tags []string
+	if input.Tags != nil {
+		tags, err = normalizeTags(input.Tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if input.ExamID != nil {
 		eid := strings.TrimSpace(*input.ExamID)
 		if eid == "" {
@@ -348,23 +431,67 @@ func (uc *QuestionUseCase) Update(ctx context.Context, publicID string, input Up
 		}
 	}
 
-	if err := uc.qRepo.Update(ctx, q); err != nil {
-		return nil, err
-	}
+	changes := repository.QuestionChanges{Tags: tags}
 
 	if input.TopicIDs != nil {
-		topicIDs, err := uc.resolveTopicIDs(ctx, input.TopicIDs)
+		changes.TopicIDs, err = uc.resolveTopicIDs(ctx, input.TopicIDs)
 		if err != nil {
 			return nil, err
 		}
-		if err := uc.qRepo.SetTopics(ctx, q.ID, topicIDs); err != nil {
-			return nil, err
+	}
+
+	// Check the final shape before touching storage or the database, since
+	// nothing is written unless the whole update goes through.
+	if q.Type == "open_ended" {
+		if q.ExpectedAnswerText == nil || *q.ExpectedAnswerText == "" {
+			return nil, apperror.ErrInvalidInput
+		}
+		if q.PassingScore == nil {
+			return nil, apperror.ErrInvalidInput
+		}
+	}
+	if q.Type == "closed_ended" {
+		optionCount, hasCorrect := len(q.Options), false
+		for _, opt := range q.Options {
+			hasCorrect = hasCorrect || opt.IsCorrect
+		}
+		if input.Options != nil {
+			optionCount, hasCorrect = len(input.Options), false
+			for _, oi := range input.Options {
+				hasCorrect = hasCorrect || oi.IsCorrect
+			}
 		}
+		if optionCount < 2 || !hasCorrect {
+			return nil, apperror.ErrInvalidInput
+		}
+	}
+
+	// Preserved images must come from this question's own options, so a
+	// mistyped or foreign ID is reported instead of silently dropping the
+	// image.
+	existingOptImages := map[string]int{}
+	for _, existingOpt := range q.Options {
+		for _, existingImg := range existingOpt.Images {
+			existingOptImages[existingImg.FilePublicID] = existingImg.FileID
+		}
+	}
+	v := apperror.NewValidationError()
+	for i, oi := range input.Options {
+		for _, imgPubID := range oi.ImageIDs {
+			if _, ok := existingOptImages[strings.ToLower(strings.TrimSpace(imgPubID))]; !ok {
+				v.Add(fmt.Sprintf("options[%d].image_ids", i), fmt.Sprintf("Image %s is not an image of this question's options.", imgPubID))
+			}
+		}
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
+	uploadedOptKeys := []string{}
+	var removedOptKeys []string
 	if input.Options != nil {
-		var options []entity.QuestionOption
-		uploadedOptKeys := []string{}
+		options := make([]entity.QuestionOption, 0, len(input.Options))
+		keptFileIDs := map[int]bool{}
 		for i, oi := range input.Options {
 			var text *string
 			if oi.Text != nil {
@@ -381,88 +508,47 @@ func (uc *QuestionUseCase) Update(ctx context.Context, publicID string, input Up
 
 			// Upload new images
 			for _, imgFile := range oi.ImageFiles {
-				ct := imgFile.Header.Get("Content-Type")
-				if !allowedImageTypes[ct] {
-					uc.cleanupFiles(ctx, uploadedOptKeys)
-					return nil, apperror.ErrInvalidFileType
-				}
-				if imgFile.Size > maxQuestionImageSize {
-					uc.cleanupFiles(ctx, uploadedOptKeys)
-					return nil, apperror.ErrFileTooLarge
-				}
-				f, ferr := imgFile.Open()
+				img, ferr := uc.uploadQuestionImage(ctx, imgFile, "question-options")
 				if ferr != nil {
 					uc.cleanupFiles(ctx, uploadedOptKeys)
-					return nil, apperror.ErrInvalidInput
+					return nil, ferr
 				}
-				ext := filepath.Ext(imgFile.Filename)
-				key := fmt.Sprintf("question-options/%s%s", newUUID(), ext)
-				_, ferr = uc.storageSvc.Upload(ctx, key, ct, f)
-				f.Close()
-				if ferr != nil {
-					uc.cleanupFiles(ctx, uploadedOptKeys)
-					return nil, apperror.ErrUploadFailed
-				}
-				uploadedOptKeys = append(uploadedOptKeys, key)
-				opt.Images = append(opt.Images, entity.QuestionImage{
-					FileKey:     key,
-					Filename:    imgFile.Filename,
-					ContentType: ct,
-					SizeBytes:   imgFile.Size,
-				})
+				uploadedOptKeys = append(uploadedOptKeys, questionImageKeys(img)...)
+				opt.Images = append(opt.Images, img)
 			}
 
 			// Preserve existing images by public ID
 			for _, imgPubID := range oi.ImageIDs {
-				for _, existingOpt := range q.Options {
-					for _, existingImg := range existingOpt.Images {
-						if existingImg.FilePublicID == imgPubID {
-							opt.Images = append(opt.Images, entity.QuestionImage{
-								FileID: existingImg.FileID,
-							})
-							break
-						}
-					}
-				}
+				fileID := existingOptImages[strings.ToLower(strings.TrimSpace(imgPubID))]
+				opt.Images = append(opt.Images, entity.QuestionImage{FileID: fileID})
+				keptFileIDs[fileID] = true
 			}
 
 			options = append(options, opt)
 		}
-		if err := uc.qRepo.SetOptions(ctx, q.ID, options, q.CreatedByID); err != nil {
-			uc.cleanupFiles(ctx, uploadedOptKeys)
-			return nil, err
+		changes.Options = options
+
+		for _, existingOpt := range q.Options {
+			for _, existingImg := range existingOpt.Images {
+				if !keptFileIDs[existingImg.FileID] {
+					removedOptKeys = append(removedOptKeys, questionImageKeys(existingImg)...)
+				}
+			}
 		}
 	}
 
-	// Re-load to validate final state
-	updated, err := uc.qRepo.GetByPublicID(ctx, publicID)
-	if err != nil {
+	if err := uc.qRepo.Update(ctx, q, changes); err != nil {
+		uc.cleanupFiles(ctx, uploadedOptKeys)
 		return nil, err
 	}
 
-	// Post-update type validation
-	if updated.Type == "open_ended" {
-		if updated.ExpectedAnswerText == nil || *updated.ExpectedAnswerText == "" {
-			return nil, apperror.ErrInvalidInput
-		}
-		if updated.PassingScore == nil {
-			return nil, apperror.ErrInvalidInput
-		}
-	}
-	if updated.Type == "closed_ended" {
-		if len(updated.Options) < 2 {
-			return nil, apperror.ErrInvalidInput
-		}
-		hasCorrect := false
-		for _, opt := range updated.Options {
-			if opt.IsCorrect {
-				hasCorrect = true
-				break
-			}
-		}
-		if !hasCorrect {
-			return nil, apperror.ErrInvalidInput
-		}
+	// Images of dropped options are only deleted once nothing can roll the
+	// update back.
+	uc.cleanupFiles(ctx, removedOptKeys)
+
+	updated, err := uc.qRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
 	}
 
 	uc.resolveImageURLs(updated)
@@ -495,39 +581,13 @@ func (uc *QuestionUseCase) AddImages(
 	uploadedKeys := []string{}
 
 	for _, fh := range files {
-		ct := fh.Header.Get("Content-Type")
-		if !allowedImageTypes[ct] {
-			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrInvalidFileType
-		}
-		if fh.Size > maxQuestionImageSize {
-			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrFileTooLarge
-		}
-
-		f, err := fh.Open()
+		img, err := uc.uploadQuestionImage(ctx, fh, "questions")
 		if err != nil {
 			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrInvalidInput
-		}
-
-		ext := filepath.Ext(fh.Filename)
-		key := fmt.Sprintf("questions/%s%s", newUUID(), ext)
-
-		_, err = uc.storageSvc.Upload(ctx, key, ct, f)
-		f.Close()
-		if err != nil {
-			uc.cleanupFiles(ctx, uploadedKeys)
-			return nil, apperror.ErrUploadFailed
+			return nil, err
 		}
-		uploadedKeys = append(uploadedKeys, key)
-
-		newImages.Images = append(newImages.Images, entity.QuestionImage{
-			FileKey:     key,
-			Filename:    fh.Filename,
-			ContentType: ct,
-			SizeBytes:   fh.Size,
-		})
+		uploadedKeys = append(uploadedKeys, questionImageKeys(img)...)
+		newImages.Images = append(newImages.Images, img)
 	}
 
 	if err := uc.qRepo.AddImages(ctx, q.ID, newImages, user.ID); err != nil {
@@ -553,10 +613,10 @@ func (uc *QuestionUseCase) RemoveImage(ctx context.Context, publicID string, ima
 	}
 
 	// Find the image to delete from storage
-	var keyToDelete string
+	var keysToDelete []string
 	for _, img := range q.Images {
 		if img.FilePublicID == imagePublicID {
-			keyToDelete = img.FileKey
+			keysToDelete = questionImageKeys(img)
 			break
 		}
 	}
@@ -565,9 +625,7 @@ func (uc *QuestionUseCase) RemoveImage(ctx context.Context, publicID string, ima
 		return nil, err
 	}
 
-	if keyToDelete != "" {
-		_ = uc.storageSvc.Delete(ctx, keyToDelete)
-	}
+	uc.cleanupFiles(ctx, keysToDelete)
 
 	updated, err := uc.qRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
@@ -577,7 +635,20 @@ func (uc *QuestionUseCase) RemoveImage(ctx context.Context, publicID string, ima
 	return updated, nil
 }
 
-func (uc *QuestionUseCase) Delete(ctx context.Context, publicID string) error {
+// ListUsages returns the activities whose items reference the question.
+func (uc *QuestionUseCase) ListUsages(ctx context.Context, publicID string) ([]entity.ActivityRef, error) {
+	q, err := uc.qRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil {
+		return nil, apperror.ErrQuestionNotFound
+	}
+
+	return uc.activityRepo.ListContentReferences(ctx, entity.ActivityItemTypeQuestion, q.ID)
+}
+
+func (uc *QuestionUseCase) Delete(ctx context.Context, publicID string, force bool) error {
 	q, err := uc.qRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
 		return err
@@ -586,38 +657,128 @@ func (uc *QuestionUseCase) Delete(ctx context.Context, publicID string) error {
 		return apperror.ErrQuestionNotFound
 	}
 
-	return uc.qRepo.Delete(ctx, publicID)
+	if !force {
+		if err := ensureContentUnused(ctx, uc.activityRepo, entity.ActivityItemTypeQuestion, q.ID); err != nil {
+			return err
+		}
+	}
+
+	return uc.qRepo.DeleteMany(ctx, []int{q.ID}, force)
+}
+
+// maxBulkDeleteQuestions caps how many questions one DeleteMany call handles.
+const maxBulkDeleteQuestions = 100
+
+// Outcomes reported for each question passed to DeleteMany.
+const (
+	QuestionBulkDeleted  = "deleted"
+	QuestionBulkNotFound = "not_found"
+	QuestionBulkInUse    = "in_use"
+)
+
+type QuestionBulkDeleteResult struct {
+	PublicID   string
+	Status     string
+	Activities []entity.ActivityRef // set when Status is QuestionBulkInUse
+}
+
+// DeleteMany soft-deletes a batch of questions and reports an outcome per
+// ID. Questions used by active activities are skipped as in use unless force
+// is set, in which case their activity items are removed as with Delete.
+// All deletions happen in a single transaction.
+func (uc *QuestionUseCase) DeleteMany(ctx context.Context, publicIDs []string, force bool) ([]QuestionBulkDeleteResult, error) {
+	if len(publicIDs) == 0 || len(publicIDs) > maxBulkDeleteQuestions {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	results := make([]QuestionBulkDeleteResult, 0, len(publicIDs))
+	var deleteIDs []int
+	seen := make(map[string]bool, len(publicIDs))
+	for _, publicID := range publicIDs {
+		if seen[publicID] {
+			continue
+		}
+		seen[publicID] = true
+
+		result := QuestionBulkDeleteResult{PublicID: publicID, Status: QuestionBulkNotFound}
+		if !publicid.Valid(publicID) {
+			results = append(results, result)
+			continue
+		}
+		q, err := uc.qRepo.GetByPublicID(ctx, publicID)
+		if err != nil {
+			return nil, err
+		}
+		if q == nil {
+			results = append(results, result)
+			continue
+		}
+
+		if !force {
+			refs, err := uc.activityRepo.ListContentReferences(ctx, entity.ActivityItemTypeQuestion, q.ID)
+			if err != nil {
+				return nil, err
+			}
+			if len(refs) > 0 {
+				result.Status, result.Activities = QuestionBulkInUse, refs
+				results = append(results, result)
+				continue
+			}
+		}
+
+		result.Status = QuestionBulkDeleted
+		results = append(results, result)
+		deleteIDs = append(deleteIDs, q.ID)
+	}
+
+	if len(deleteIDs) > 0 {
+		if err := uc.qRepo.DeleteMany(ctx, deleteIDs, force); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
 }
 
-func (uc *QuestionUseCase) List(ctx context.Context, pageNumber, pageSize int, filter repository.QuestionFilter) ([]entity.Question, int, int, error) {
-	if pageNumber < 1 {
-		pageNumber = 1
+// SetArchived archives or restores a question. Archived questions keep
+// their existing activity items and submissions.
+func (uc *QuestionUseCase) SetArchived(ctx context.Context, publicID string, archived bool) (*entity.Question, error) {
+	q, err := uc.qRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil {
+		return nil, apperror.ErrQuestionNotFound
 	}
-	if pageSize < 1 {
-		pageSize = 10
+
+	if err := uc.qRepo.SetArchived(ctx, q.ID, archived); err != nil {
+		return nil, err
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	return uc.GetByPublicID(ctx, publicID)
+}
+
+func (uc *QuestionUseCase) List(ctx context.Context, limit, offset int, filter repository.QuestionFilter) ([]entity.Question, int, error) {
+	if filter.Difficulty != "" && !validQuestionDifficulties[filter.Difficulty] {
+		return nil, 0, apperror.ErrInvalidInput
 	}
-	offset := (pageNumber - 1) * pageSize
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
 
-	questions, err := uc.qRepo.List(ctx, pageSize, offset, filter)
+	questions, err := uc.qRepo.List(ctx, limit, offset, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
 	total, err := uc.qRepo.Count(ctx, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
-
 	for i := range questions {
 		uc.resolveImageURLs(&questions[i])
 	}
 
-	return questions, total, totalPages, nil
+	return questions, total, nil
 }
 
 func (uc *QuestionUseCase) ResolveTopicIDs(ctx context.Context, publicIDs []string) ([]int, error) {
@@ -666,18 +827,104 @@ func (uc *QuestionUseCase) resolveTopicIDs(ctx context.Context, publicIDs []stri
 }
 
 func (uc *QuestionUseCase) resolveImageURLs(q *entity.Question) {
+	resolveQuestionImageURLs(uc.storageSvc, q)
+}
+
+func resolveQuestionImageURLs(storageSvc service.StorageService, q *entity.Question) {
 	for i := range q.Images {
-		if q.Images[i].FileKey != "" {
-			q.Images[i].URL = uc.storageSvc.GetPublicURL(q.Images[i].FileKey)
-		}
+		resolveQuestionImageURL(storageSvc, &q.Images[i])
 	}
 	for i := range q.Options {
 		for j := range q.Options[i].Images {
-			if q.Options[i].Images[j].FileKey != "" {
-				q.Options[i].Images[j].URL = uc.storageSvc.GetPublicURL(q.Options[i].Images[j].FileKey)
-			}
+			resolveQuestionImageURL(storageSvc, &q.Options[i].Images[j])
+		}
+	}
+}
+
+func resolveQuestionImageURL(storageSvc service.StorageService, img *entity.QuestionImage) {
+	if img.FileKey != "" {
+		img.URL = storageSvc.GetPublicURL(img.FileKey)
+	}
+	if img.WebPKey != "" {
+		img.WebPURL = storageSvc.GetPublicURL(img.WebPKey)
+	}
+}
+
+// uploadQuestionImage checks one question or option image and stores it
+// under prefix. When an encoder is configured, PNG and JPEG uploads also get
+// a WebP copy next to the original.
+func (uc *QuestionUseCase) uploadQuestionImage(ctx context.Context, fh *multipart.FileHeader, prefix string) (entity.QuestionImage, error) {
+	ct := fh.Header.Get("Content-Type")
+	if !allowedImageTypes[ct] {
+		return entity.QuestionImage{}, apperror.ErrInvalidFileType
+	}
+	if fh.Size > uc.uploadLimits.QuestionImage {
+		return entity.QuestionImage{}, apperror.ErrFileTooLarge
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return entity.QuestionImage{}, apperror.ErrInvalidInput
+	}
+	key := fmt.Sprintf("%s/%s%s", prefix, newUUID(), filepath.Ext(fh.Filename))
+	_, err = uc.storageSvc.Upload(ctx, key, ct, f)
+	f.Close()
+	if err != nil {
+		return entity.QuestionImage{}, apperror.ErrUploadFailed
+	}
+
+	return entity.QuestionImage{
+		FileKey:     key,
+		Filename:    fh.Filename,
+		ContentType: ct,
+		SizeBytes:   fh.Size,
+		WebPKey:     uc.storeWebPVariant(ctx, fh, key, ct),
+	}, nil
+}
+
+// storeWebPVariant uploads a WebP copy of the image stored at key and
+// returns its key, or "" when no copy was made. A failed conversion only
+// costs the copy; the original upload stands either way.
+func (uc *QuestionUseCase) storeWebPVariant(ctx context.Context, fh *multipart.FileHeader, key, contentType string) string {
+	if uc.imageEncoder == nil || contentType == "image/webp" {
+		return ""
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return ""
+	}
+	data, err := uc.imageEncoder.EncodeWebP(ctx, contentType, f)
+	f.Close()
+	if err != nil {
+		if !errors.Is(err, service.ErrUnsupportedImage) {
+			log.Printf("failed to convert %s to webp: %v", key, err)
 		}
+		return ""
+	}
+	// Screenshots that are already tiny can come out larger.
+	if int64(len(data)) >= fh.Size {
+		return ""
+	}
+
+	webpKey := strings.TrimSuffix(key, filepath.Ext(key)) + ".webp"
+	if _, err := uc.storageSvc.Upload(ctx, webpKey, "image/webp", bytes.NewReader(data)); err != nil {
+		log.Printf("failed to upload webp variant %s: %v", webpKey, err)
+		return ""
 	}
+	return webpKey
+}
+
+// questionImageKeys lists the storage objects backing img.
+func questionImageKeys(img entity.QuestionImage) []string {
+	var keys []string
+	if img.FileKey != "" {
+		keys = append(keys, img.FileKey)
+	}
+	if img.WebPKey != "" {
+		keys = append(keys, img.WebPKey)
+	}
+	return keys
 }
 
 func (uc *QuestionUseCase) cleanupFiles(ctx context.Context, keys []string) {
@@ -725,3 +972,41 @@ func (uc *QuestionUseCase) CreateFeedback(
 
 	return feedback, nil
 }
+
+// normalizeKeywords trims keywords and drops blanks and duplicates, treating
+// keywords that differ only in case or accents as the same.
+func normalizeKeywords(keywords []string) []string {
+	seen := make(map[string]bool, len(keywords))
+	result := make([]string, 0, len(keywords))
+	for _, kw := range keywords {
+		kw = strings.TrimSpace(kw)
+		if kw == "" {
+			continue
+		}
+		key := grading.Fold(kw)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, kw)
+	}
+	return result
+}
+
+// normalizeTags lowercases and trims tags, dropping blanks and duplicates.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len([]rune(tag)) > maxTagLength {
+			return nil, apperror.ErrInvalidInput
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+)
+
+// validationFields returns the field map of a validation error, failing the
+// test when err is anything else.
+func validationFields(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperror.CodeInvalidInput || appErr.HTTPStatus != http.StatusUnprocessableEntity {
+		t.Fatalf("err = %v, want a 422 INVALID_INPUT validation error", err)
+	}
+	return appErr.Errors
+}
+
+func TestQuestionCreateReportsInvalidFields(t *testing.T) {
+	users := &fakeAuthUserRepo{users: map[string]*entity.User{"admin": {ID: 1, PublicID: "admin"}}}
+	uc := &QuestionUseCase{userRepo: users}
+
+	text := func(s string) *string { return &s }
+	score := func(n int) *int { return &n }
+	validOptions := []OptionInput{{Text: text("A"), IsCorrect: true}, {Text: text("B")}}
+
+	type input struct {
+		qType          string
+		statement      string
+		expectedAnswer *string
+		passingScore   *int
+		maxAttempts    *int
+		difficulty     string
+		tags           []string
+		options        []OptionInput
+	}
+	openEnded := func(mod func(*input)) input {
+		in := input{qType: "open_ended", statement: "Why?", expectedAnswer: text("Because."), passingScore: score(60)}
+		mod(&in)
+		return in
+	}
+	closedEnded := func(mod func(*input)) input {
+		in := input{qType: "closed_ended", statement: "Which?", options: validOptions}
+		mod(&in)
+		return in
+	}
+
+	tests := []struct {
+		name  string
+		input input
+		want  []string
+	}{
+		{"blank statement", openEnded(func(in *input) { in.statement = "  " }), []string{"statement"}},
+		{"statement too long", openEnded(func(in *input) { in.statement = strings.Repeat("a", maxStatementLength+1) }), []string{"statement"}},
+		{"unknown type", input{qType: "essay", statement: "Why?"}, []string{"type"}},
+		{"passing score out of range", openEnded(func(in *input) { in.passingScore = score(101) }), []string{"passing_score"}},
+		{"missing passing score", openEnded(func(in *input) { in.passingScore = nil }), []string{"passing_score"}},
+		{"missing expected answer", openEnded(func(in *input) { in.expectedAnswer = text(" ") }), []string{"expected_answer_text"}},
+		{"max attempts below one", openEnded(func(in *input) { in.maxAttempts = score(0) }), []string{"max_attempts"}},
+		{"unknown difficulty", openEnded(func(in *input) { in.difficulty = "brutal" }), []string{"difficulty"}},
+		{"tag too long", openEnded(func(in *input) { in.tags = []string{strings.Repeat("t", maxTagLength+1)} }), []string{"tags"}},
+		{"single option", closedEnded(func(in *input) { in.options = validOptions[:1] }), []string{"options"}},
+		{"no correct option", closedEnded(func(in *input) { in.options = []OptionInput{{Text: text("A")}, {Text: text("B")}} }), []string{"options"}},
+		{"empty option", closedEnded(func(in *input) { in.options = []OptionInput{{Text: text("A"), IsCorrect: true}, {Text: text(" ")}} }), []string{"options[1]"}},
+		{
+			"several fields at once",
+			openEnded(func(in *input) {
+				in.statement = ""
+				in.passingScore = score(-1)
+				in.maxAttempts = score(0)
+				in.difficulty = "brutal"
+			}),
+			[]string{"difficulty", "max_attempts", "passing_score", "statement"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := tt.input
+			_, _, err := uc.Create(context.Background(), "admin", in.qType, in.statement, in.expectedAnswer, nil,
+				in.passingScore, in.maxAttempts, in.difficulty, in.tags, "", nil, nil, in.options)
+
+			fields := validationFields(t, err)
+			got := make([]string, 0, len(fields))
+			for field, message := range fields {
+				if message == "" {
+					t.Errorf("field %q has an empty message", field)
+				}
+				got = append(got, field)
+			}
+			slices.Sort(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActivityCreateReportsInvalidFields(t *testing.T) {
+	acceptedBy := 1
+	groups := &fakeGroupRepo{
+		group:   &entity.Group{ID: 1, PublicID: "group", TimeZone: "America/Sao_Paulo"},
+		members: map[int]*entity.GroupMember{1: {UserID: 1, Role: entity.MemberRoleAdmin, IsActive: true, AcceptedByID: &acceptedBy}},
+	}
+	users := &fakeAuthUserRepo{users: map[string]*entity.User{"admin": {ID: 1, PublicID: "admin"}}}
+	uc := &ActivityUseCase{groupRepo: groups, userRepo: users}
+
+	description := strings.Repeat("d", maxActivityDescriptionLength+1)
+	_, err := uc.Create(context.Background(), "group", "admin", CreateActivityInput{
+		Title:       strings.Repeat("t", maxActivityTitleLength+1),
+		Description: &description,
+		DueDate:     "next friday",
+	})
+
+	fields := validationFields(t, err)
+	want := map[string]string{
+		"title":       fmt.Sprintf("Title must be at most %d characters long.", maxActivityTitleLength),
+		"description": fmt.Sprintf("Description must be at most %d characters long.", maxActivityDescriptionLength),
+		"due_date":    dueDateFormatMessage,
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
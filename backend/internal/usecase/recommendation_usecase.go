@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+const (
+	// masteredPassRate is the pass rate from which a topic no longer counts
+	// as weak.
+	masteredPassRate = 0.7
+	// maxWeakTopics caps how many weak topics recommendations draw from.
+	maxWeakTopics  = 5
+	maxRecommended = 50
+)
+
+type RecommendationUseCase struct {
+	recRepo   repository.RecommendationRepository
+	statsRepo repository.StatsRepository
+	userRepo  repository.UserRepository
+}
+
+func NewRecommendationUseCase(recRepo repository.RecommendationRepository, statsRepo repository.StatsRepository, userRepo repository.UserRepository) *RecommendationUseCase {
+	return &RecommendationUseCase{
+		recRepo:   recRepo,
+		statsRepo: statsRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// ForUser suggests up to limit pieces of content the user has not finished
+// yet. Topics are visited weakest first, meaning the lowest pass rate with
+// ties going to the topic attempted most; within a topic the newest content
+// comes first. Each item appears once, under the weakest topic it covers.
+func (uc *RecommendationUseCase) ForUser(ctx context.Context, userPublicID string, limit int) ([]entity.Recommendation, error) {
+	if limit < 1 || limit > maxRecommended {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	mastery, err := uc.statsRepo.TopicMastery(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	weak := make([]entity.TopicMastery, 0, len(mastery))
+	for _, m := range mastery {
+		if m.Attempts > 0 && passRate(m) < masteredPassRate {
+			weak = append(weak, m)
+		}
+	}
+	sort.SliceStable(weak, func(i, j int) bool {
+		if pi, pj := passRate(weak[i]), passRate(weak[j]); pi != pj {
+			return pi < pj
+		}
+		return weak[i].Attempts > weak[j].Attempts
+	})
+	if len(weak) > maxWeakTopics {
+		weak = weak[:maxWeakTopics]
+	}
+
+	result := make([]entity.Recommendation, 0, limit)
+	seen := map[string]bool{}
+	for _, topic := range weak {
+		candidates, err := uc.recRepo.ListForTopic(ctx, user.ID, topic.TopicPublicID, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range candidates {
+			if seen[rec.PublicID] {
+				continue
+			}
+			seen[rec.PublicID] = true
+			rec.Topic = topic
+			result = append(result, rec)
+			if len(result) == limit {
+				return result, nil
+			}
+		}
+	}
+	return result, nil
+}
+
+func passRate(m entity.TopicMastery) float64 {
+	return float64(m.Passed) / float64(m.Attempts)
+}
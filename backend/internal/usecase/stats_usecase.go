@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+type StatsUseCase struct {
+	statsRepo  repository.StatsRepository
+	groupRepo  repository.GroupRepository
+	userRepo   repository.UserRepository
+	accessRepo repository.ContentAccessRepository
+}
+
+func NewStatsUseCase(statsRepo repository.StatsRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, accessRepo repository.ContentAccessRepository) *StatsUseCase {
+	return &StatsUseCase{
+		statsRepo:  statsRepo,
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		accessRepo: accessRepo,
+	}
+}
+
+// GroupStats returns aggregate figures for a group. Only platform admins and
+// the group's admins or supervisors may see them.
+func (uc *StatsUseCase) GroupStats(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole) (*entity.GroupStats, error) {
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, apperror.ErrGroupNotFound
+	}
+
+	if requesterRole != entity.UserRoleAdmin {
+		requester, member, err := uc.acceptedMember(ctx, group.ID, requesterPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil || !isStaffRole(member.Role) {
+			return nil, denyGroup(ctx, uc.groupRepo, group, requester)
+		}
+	}
+
+	return uc.statsRepo.GroupStats(ctx, group.ID)
+}
+
+// GroupLeaderboard ranks the group's members by questions passed ("passed")
+// or by the sum of their best scores ("score") over the last window, or over
+// all time when window is zero. Any member may see it once the group enables
+// it; in anonymous mode non-staff only see their own identity.
+func (uc *StatsUseCase) GroupLeaderboard(ctx context.Context, groupPublicID string, requesterPublicID string, requesterRole entity.UserRole, window time.Duration, metric string) ([]entity.LeaderboardEntry, error) {
+	if metric == "" {
+		metric = "passed"
+	}
+	if (metric != "passed" && metric != "score") || window < 0 {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	group, err := uc.groupRepo.GetByPublicID(ctx, groupPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, apperror.ErrGroupNotFound
+	}
+
+	isStaff := requesterRole == entity.UserRoleAdmin
+	if !isStaff {
+		requester, member, err := uc.acceptedMember(ctx, group.ID, requesterPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, denyGroup(ctx, uc.groupRepo, group, requester)
+		}
+		isStaff = isStaffRole(member.Role)
+	}
+	if !group.LeaderboardEnabled {
+		return nil, apperror.ErrLeaderboardDisabled
+	}
+
+	var since *time.Time
+	if window > 0 {
+		t := time.Now().Add(-window)
+		since = &t
+	}
+
+	entries, err := uc.statsRepo.GroupLeaderboard(ctx, group.ID, since, metric)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.LeaderboardAnonymous && !isStaff {
+		for i := range entries {
+			if entries[i].UserPublicID == requesterPublicID {
+				continue
+			}
+			entries[i].UserPublicID = ""
+			entries[i].UserName = ""
+			entries[i].UserAvatarURL = nil
+		}
+	}
+
+	return entries, nil
+}
+
+// acceptedMember returns the requester's membership in the group, or nil when
+// they are not an active, accepted member.
+func (uc *StatsUseCase) acceptedMember(ctx context.Context, groupID int, userPublicID string) (*entity.User, *entity.GroupMember, error) {
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, apperror.ErrUserNotFound
+	}
+
+	member, err := uc.groupRepo.GetMember(ctx, groupID, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member == nil || !member.IsActive || member.AcceptedByID == nil {
+		return user, nil, nil
+	}
+	return user, member, nil
+}
+
+func isStaffRole(role entity.MemberRole) bool {
+	return role == entity.MemberRoleAdmin || role == entity.MemberRoleSupervisor
+}
+
+// maxContentStats caps how many entries ContentStats returns.
+const maxContentStats = 100
+
+// ContentStats ranks handouts, video lessons and open exercise lists by
+// their views and downloads over the last window. contentType narrows the
+// ranking to one of those item types when non-empty.
+func (uc *StatsUseCase) ContentStats(ctx context.Context, contentType entity.ActivityItemType, window time.Duration, limit int) ([]entity.ContentAccessStats, error) {
+	switch contentType {
+	case "", entity.ActivityItemTypeHandout, entity.ActivityItemTypeVideoLesson, entity.ActivityItemTypeOpenExerciseList:
+	default:
+		return nil, apperror.ErrInvalidInput
+	}
+	if window <= 0 || limit < 1 || limit > maxContentStats {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	return uc.accessRepo.ListStats(ctx, repository.ContentAccessFilter{
+		ContentType: contentType,
+		Since:       time.Now().Add(-window),
+	}, limit)
+}
+
+// GetTopicMastery reports the user's pass rate and average score per topic
+// across all of their question submissions.
+func (uc *StatsUseCase) GetTopicMastery(ctx context.Context, userPublicID string) ([]entity.TopicMastery, error) {
+	user, err := uc.userRepo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	return uc.statsRepo.TopicMastery(ctx, user.ID)
+}
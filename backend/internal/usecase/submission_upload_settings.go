@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+)
+
+// defaultSubmissionAttachmentTypes and the configured submission attachment
+// limit apply to groups that have not overridden their upload settings.
+var defaultSubmissionAttachmentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+	"image/gif",
+	"application/pdf",
+}
+
+// submissionAttachmentSizeCeiling caps what any group can configure.
+var submissionAttachmentSizeCeiling = uploadlimits.Ceilings().SubmissionAttachment
+
+const maxSubmissionAllowedTypes = 50
+
+var mimeTypePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9!#$&^_.+-]*/[a-z0-9][a-z0-9!#$&^_.+-]*$`)
+
+// SubmissionUploadSettings are the effective limits for submission
+// attachments in a group. The Custom flags report whether each value comes
+// from a group override rather than the platform default.
+type SubmissionUploadSettings struct {
+	MaxSizeBytes       int64
+	AllowedTypes       []string
+	MaxSizeCeiling     int64
+	CustomMaxSize      bool
+	CustomAllowedTypes bool
+}
+
+func submissionUploadSettings(group *entity.Group, defaultMaxSize int64) SubmissionUploadSettings {
+	settings := SubmissionUploadSettings{
+		MaxSizeBytes:   defaultMaxSize,
+		AllowedTypes:   defaultSubmissionAttachmentTypes,
+		MaxSizeCeiling: submissionAttachmentSizeCeiling,
+	}
+	if group.SubmissionMaxBytes != nil {
+		// The ceiling also applies to values stored before it was lowered.
+		settings.MaxSizeBytes = min(*group.SubmissionMaxBytes, submissionAttachmentSizeCeiling)
+		settings.CustomMaxSize = true
+	}
+	if group.SubmissionAllowedTypes != nil {
+		settings.AllowedTypes = group.SubmissionAllowedTypes
+		settings.CustomAllowedTypes = true
+	}
+	return settings
+}
+
+type UpdateSubmissionUploadSettingsInput struct {
+	MaxSizeBytes *int64    // nil restores the default
+	AllowedTypes *[]string // nil restores the default
+}
+
+// normalize validates the input and returns the values to store.
+func (input UpdateSubmissionUploadSettingsInput) normalize() (*int64, []string, error) {
+	v := apperror.NewValidationError()
+
+	if input.MaxSizeBytes != nil && (*input.MaxSizeBytes < 1 || *input.MaxSizeBytes > submissionAttachmentSizeCeiling) {
+		v.Add("max_size_bytes", fmt.Sprintf("Max size must be between 1 and %d bytes.", submissionAttachmentSizeCeiling))
+	}
+
+	var types []string
+	if input.AllowedTypes != nil {
+		types = []string{}
+		for i, t := range *input.AllowedTypes {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if !mimeTypePattern.MatchString(t) {
+				v.Add(fmt.Sprintf("allowed_types[%d]", i), "Must be a MIME type such as application/pdf.")
+				continue
+			}
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+		if len(*input.AllowedTypes) == 0 {
+			v.Add("allowed_types", "At least one type is required.")
+		} else if len(types) > maxSubmissionAllowedTypes {
+			v.Add("allowed_types", fmt.Sprintf("At most %d types are allowed.", maxSubmissionAllowedTypes))
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, nil, err
+	}
+	return input.MaxSizeBytes, types, nil
+}
@@ -2,11 +2,11 @@ package usecase
 
 import (
 	"context"
-	"math"
 	"strings"
 
 	"proximos-passos/backend/internal/domain/apperror"
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/publicid"
 	"proximos-passos/backend/internal/domain/repository"
 )
 
@@ -87,9 +87,6 @@ func (uc *TopicUseCase) Create(ctx context.Context, createdByPublicID string, in
 	}
 
 	if err := uc.topicRepo.Create(ctx, topic); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrTopicNameTaken
-		}
 		return nil, err
 	}
 
@@ -153,9 +150,6 @@ func (uc *TopicUseCase) Update(ctx context.Context, publicID string, input Updat
 	}
 
 	if err := uc.topicRepo.Update(ctx, topic); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrTopicNameTaken
-		}
 		return nil, err
 	}
 
@@ -204,27 +198,53 @@ func (uc *TopicUseCase) Delete(ctx context.Context, publicID string, mode string
 	return uc.topicRepo.Delete(ctx, publicID)
 }
 
-func (uc *TopicUseCase) List(ctx context.Context, page, pageSize int, filter repository.TopicFilter) ([]entity.Topic, int, int, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	offset := (page - 1) * pageSize
+func (uc *TopicUseCase) List(ctx context.Context, limit, offset int, filter repository.TopicFilter) ([]entity.Topic, int, error) {
 
-	topics, err := uc.topicRepo.List(ctx, pageSize, offset, filter)
+	topics, err := uc.topicRepo.List(ctx, limit, offset, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
 	total, err := uc.topicRepo.Count(ctx, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+	return topics, total, nil
+}
+
+// maxResolveTopics caps how many topic IDs one Resolve call accepts.
+const maxResolveTopics = 200
+
+// Resolve maps topic public IDs to their breadcrumbs. IDs that are malformed
+// or do not name an active topic are omitted from the result.
+func (uc *TopicUseCase) Resolve(ctx context.Context, publicIDs []string) (map[string]entity.TopicBreadcrumb, error) {
+	if len(publicIDs) > maxResolveTopics {
+		return nil, apperror.ErrInvalidInput
+	}
 
-	return topics, total, totalPages, nil
+	ids := make([]string, 0, len(publicIDs))
+	seen := make(map[string]bool, len(publicIDs))
+	for _, publicID := range publicIDs {
+		publicID = strings.ToLower(publicID)
+		if seen[publicID] || !publicid.Valid(publicID) {
+			continue
+		}
+		seen[publicID] = true
+		ids = append(ids, publicID)
+	}
+
+	result := make(map[string]entity.TopicBreadcrumb, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	breadcrumbs, err := uc.topicRepo.ListBreadcrumbs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range breadcrumbs {
+		result[b.PublicID] = b
+	}
+	return result, nil
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -10,16 +11,19 @@ import (
 	"time"
 
 	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/email"
 	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/password"
+	"proximos-passos/backend/internal/domain/publicid"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+	"proximos-passos/backend/internal/i18n"
 	"proximos-passos/backend/internal/infrastructure/jwt"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
-const maxAvatarSize = 5 << 20 // 5MB
-
 var allowedAvatarTypes = map[string]bool{
 	"image/jpeg": true,
 	"image/png":  true,
@@ -29,21 +33,29 @@ var allowedAvatarTypes = map[string]bool{
 
 type UserUseCase struct {
 	repo                 repository.UserRepository
+	groupRepo            repository.GroupRepository
+	sessionRepo          repository.SessionRepository
 	emailSvc             service.EmailService
 	storageSvc           service.StorageService
 	jwtService           *jwt.Service
 	frontendURL          string
 	verificationCooldown time.Duration
+	passwordPolicy       password.Policy
+	uploadLimits         uploadlimits.Limits
 }
 
-func NewUserUseCase(repo repository.UserRepository, emailSvc service.EmailService, storageSvc service.StorageService, jwtService *jwt.Service, frontendURL string, verificationCooldown time.Duration) *UserUseCase {
+func NewUserUseCase(repo repository.UserRepository, groupRepo repository.GroupRepository, sessionRepo repository.SessionRepository, emailSvc service.EmailService, storageSvc service.StorageService, jwtService *jwt.Service, frontendURL string, verificationCooldown time.Duration, passwordPolicy password.Policy, uploadLimits uploadlimits.Limits) *UserUseCase {
 	return &UserUseCase{
 		repo:                 repo,
+		groupRepo:            groupRepo,
+		sessionRepo:          sessionRepo,
 		emailSvc:             emailSvc,
 		storageSvc:           storageSvc,
 		jwtService:           jwtService,
 		frontendURL:          frontendURL,
 		verificationCooldown: verificationCooldown,
+		passwordPolicy:       passwordPolicy,
+		uploadLimits:         uploadLimits,
 	}
 }
 
@@ -60,15 +72,33 @@ type CreateUserInput struct {
 	Role     entity.UserRole
 }
 
+// UpdateUserInput separates profile fields, which users may change on
+// themselves, from the platform role, which only platform admins may change.
 type UpdateUserInput struct {
-	Name      *string
-	Email     *string
-	AvatarURL *string
-	Role      *entity.UserRole
-	IsActive  *bool
+	Profile UpdateProfileInput
+	Role    *entity.UserRole
+}
+
+type UpdateProfileInput struct {
+	Name              *string
+	Email             *string
+	AvatarURL         *string
+	IsActive          *bool
+	Lang              *string
+	ActivityReminders *bool
+}
+
+// Configured reports whether an admin account is configured at all. The
+// caller is expected to have rejected partial configurations.
+func (input SetupAdminInput) Configured() bool {
+	return input.Name != "" && input.Email != "" && input.Password != ""
 }
 
 func (uc *UserUseCase) SetupAdmin(ctx context.Context, input SetupAdminInput) (*entity.User, error) {
+	if !input.Configured() {
+		return nil, apperror.ErrSetupUnavailable
+	}
+
 	count, err := uc.repo.Count(ctx)
 	if err != nil {
 		return nil, err
@@ -77,24 +107,69 @@ func (uc *UserUseCase) SetupAdmin(ctx context.Context, input SetupAdminInput) (*
 		return nil, apperror.ErrSetupUnavailable
 	}
 
-	return uc.Create(ctx, CreateUserInput{
+	return uc.createAdmin(ctx, input)
+}
+
+// SeedAdmin creates the configured admin at boot unless an account with that
+// email already exists, so restarts are no-ops. It reports whether an account
+// was created.
+func (uc *UserUseCase) SeedAdmin(ctx context.Context, input SetupAdminInput) (bool, error) {
+	address, err := email.Normalize(input.Email)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := uc.repo.GetByEmail(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		return false, nil
+	}
+
+	if _, err := uc.createAdmin(ctx, input); err != nil {
+		// Another instance seeded the same admin concurrently.
+		if errors.Is(err, apperror.ErrEmailTaken) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// createAdmin creates the configured admin with its email already verified;
+// the address comes from the operator, so no verification email is sent.
+func (uc *UserUseCase) createAdmin(ctx context.Context, input SetupAdminInput) (*entity.User, error) {
+	return uc.createUser(ctx, CreateUserInput{
 		Name:     input.Name,
 		Email:    input.Email,
 		Password: input.Password,
 		Role:     entity.UserRoleAdmin,
-	})
+	}, true)
 }
 
 func (uc *UserUseCase) Create(ctx context.Context, input CreateUserInput) (*entity.User, error) {
+	return uc.createUser(ctx, input, false)
+}
+
+func (uc *UserUseCase) createUser(ctx context.Context, input CreateUserInput, verified bool) (*entity.User, error) {
 	name := strings.TrimSpace(input.Name)
-	email := strings.TrimSpace(input.Email)
-	password := strings.TrimSpace(input.Password)
+	pw := strings.TrimSpace(input.Password)
 
-	if name == "" || email == "" || password == "" {
+	if name == "" || strings.TrimSpace(input.Email) == "" || pw == "" {
 		return nil, apperror.ErrInvalidInput
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	address, err := email.Normalize(input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.passwordPolicy.Validate(pw); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pw), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
 	}
@@ -106,38 +181,53 @@ func (uc *UserUseCase) Create(ctx context.Context, input CreateUserInput) (*enti
 
 	user := &entity.User{
 		Name:         name,
-		Email:        email,
+		Email:        address,
 		PasswordHash: string(hash),
 		Role:         role,
 		IsActive:     true,
 	}
+	if verified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
 
 	if err := uc.repo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
-	go uc.sendVerificationEmail(user)
+	// The email is only queued in emails_outbox, so enqueueing inline is cheap
+	// and its failure is logged with the request; the user can still ask for
+	// a new link.
+	if !verified {
+		if err := uc.sendVerificationEmail(ctx, user); err != nil {
+			log.Printf("failed to queue verification email for user %s: %v", user.PublicID, err)
+		}
+	} else {
+		uc.acceptInvitations(ctx, user)
+	}
 
 	return user, nil
 }
 
-func (uc *UserUseCase) sendVerificationEmail(user *entity.User) {
+// sendVerificationEmail queues a verification link for user and starts the
+// resend cooldown.
+func (uc *UserUseCase) sendVerificationEmail(ctx context.Context, user *entity.User) error {
 	token, err := uc.jwtService.GenerateVerificationToken(user.PublicID, 24*time.Hour)
 	if err != nil {
-		log.Printf("failed to generate verification token for user %s: %v", user.PublicID, err)
-		return
+		return err
 	}
 
-	verificationURL := fmt.Sprintf("%s/pt-BR/verify-email?token=%s", uc.frontendURL, token)
+	locale := recipientLocale(ctx, user)
+	verificationURL := fmt.Sprintf("%s/%s/verify-email?token=%s", uc.frontendURL, locale, token)
 
-	if err := uc.emailSvc.SendVerificationEmail(context.Background(), user.Email, user.Name, verificationURL); err != nil {
-		log.Printf("failed to send verification email to %s: %v", user.Email, err)
-		return
+	if err := uc.emailSvc.SendVerificationEmail(ctx, user.Email, user.Name, locale, verificationURL); err != nil {
+		return err
 	}
 
-	if err := uc.repo.UpdateLastVerificationSent(context.Background(), user.PublicID); err != nil {
+	if err := uc.repo.UpdateLastVerificationSent(ctx, user.PublicID); err != nil {
 		log.Printf("failed to update last verification sent for user %s: %v", user.PublicID, err)
 	}
+	return nil
 }
 
 func (uc *UserUseCase) VerifyEmail(ctx context.Context, token string) error {
@@ -146,7 +236,27 @@ func (uc *UserUseCase) VerifyEmail(ctx context.Context, token string) error {
 		return apperror.ErrInvalidToken
 	}
 
-	return uc.repo.VerifyEmail(ctx, claims.UserPublicID)
+	if err := uc.repo.VerifyEmail(ctx, claims.UserPublicID); err != nil {
+		return err
+	}
+
+	user, err := uc.repo.GetByPublicID(ctx, claims.UserPublicID)
+	if err != nil {
+		return err
+	}
+	if user != nil {
+		uc.acceptInvitations(ctx, user)
+	}
+	return nil
+}
+
+// acceptInvitations turns the group invitations sent to the user's now
+// verified email into memberships. Failures are only logged so they never
+// undo the verification itself.
+func (uc *UserUseCase) acceptInvitations(ctx context.Context, user *entity.User) {
+	if _, err := uc.groupRepo.AcceptInvitations(ctx, user.ID, user.Email); err != nil {
+		log.Printf("failed to accept group invitations for user %s: %v", user.PublicID, err)
+	}
 }
 
 func (uc *UserUseCase) ResendVerificationEmail(ctx context.Context, publicID string) error {
@@ -161,57 +271,120 @@ func (uc *UserUseCase) ResendVerificationEmail(ctx context.Context, publicID str
 		return apperror.ErrEmailAlreadyVerified
 	}
 
-	if user.LastVerificationTokenSentAt != nil && time.Since(*user.LastVerificationTokenSentAt) < uc.verificationCooldown {
-		return apperror.ErrVerificationCooldown
+	if err := uc.checkVerificationCooldown(user); err != nil {
+		return err
 	}
 
-	token, err := uc.jwtService.GenerateVerificationToken(user.PublicID, 24*time.Hour)
+	if err := uc.sendVerificationEmail(ctx, user); err != nil {
+		return apperror.ErrEmailSendFailed
+	}
+
+	return nil
+}
+
+func (uc *UserUseCase) RequestVerificationByEmail(ctx context.Context, rawEmail string) error {
+	if strings.TrimSpace(rawEmail) == "" {
+		return apperror.ErrInvalidInput
+	}
+
+	address, err := email.Normalize(rawEmail)
 	if err != nil {
 		return err
 	}
 
-	verificationURL := fmt.Sprintf("%s/pt-BR/verify-email?token=%s", uc.frontendURL, token)
+	user, err := uc.repo.GetByEmail(ctx, address)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+	if user.EmailVerifiedAt != nil {
+		return apperror.ErrEmailAlreadyVerified
+	}
 
-	if err := uc.emailSvc.SendVerificationEmail(ctx, user.Email, user.Name, verificationURL); err != nil {
-		return apperror.ErrEmailSendFailed
+	if err := uc.checkVerificationCooldown(user); err != nil {
+		return err
 	}
 
-	if err := uc.repo.UpdateLastVerificationSent(ctx, user.PublicID); err != nil {
-		log.Printf("failed to update last verification sent for user %s: %v", user.PublicID, err)
+	if err := uc.sendVerificationEmail(ctx, user); err != nil {
+		return apperror.ErrEmailSendFailed
 	}
 
 	return nil
 }
 
-func (uc *UserUseCase) RequestVerificationByEmail(ctx context.Context, email string) error {
-	email = strings.TrimSpace(email)
-	if email == "" {
+// checkVerificationCooldown rejects a resend while the user's last
+// verification email is younger than the configured cooldown, reporting the
+// time left as the error's retry hint.
+func (uc *UserUseCase) checkVerificationCooldown(user *entity.User) error {
+	if user.LastVerificationTokenSentAt == nil {
+		return nil
+	}
+
+	remaining := uc.verificationCooldown - time.Since(*user.LastVerificationTokenSentAt)
+	if remaining <= 0 {
+		return nil
+	}
+
+	return apperror.WithRetryAfter(apperror.ErrVerificationCooldown, remaining)
+}
+
+// RequestEmailChange records newEmail as pending and sends a confirmation
+// link to it, once the user has re-entered their password. The current email
+// stays in use until the link is followed. Requests share the verification
+// email cooldown.
+func (uc *UserUseCase) RequestEmailChange(ctx context.Context, userPublicID, currentPassword, newEmail string) error {
+	if strings.TrimSpace(newEmail) == "" {
 		return apperror.ErrInvalidInput
 	}
 
-	user, err := uc.repo.GetByEmail(ctx, email)
+	newEmail, err := email.Normalize(newEmail)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.repo.GetByPublicID(ctx, userPublicID)
 	if err != nil {
 		return err
 	}
 	if user == nil {
-		return nil
+		return apperror.ErrUserNotFound
 	}
-	if user.EmailVerifiedAt != nil {
-		return apperror.ErrEmailAlreadyVerified
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)); err != nil {
+		return apperror.ErrInvalidCredentials
 	}
 
-	if user.LastVerificationTokenSentAt != nil && time.Since(*user.LastVerificationTokenSentAt) < uc.verificationCooldown {
-		return apperror.ErrVerificationCooldown
+	if user.Email == newEmail {
+		return apperror.ErrInvalidInput
 	}
 
-	token, err := uc.jwtService.GenerateVerificationToken(user.PublicID, 24*time.Hour)
+	if err := uc.checkVerificationCooldown(user); err != nil {
+		return err
+	}
+
+	existing, err := uc.repo.GetByEmail(ctx, newEmail)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return apperror.ErrEmailTaken
+	}
+
+	if err := uc.repo.SetPendingEmail(ctx, user.PublicID, newEmail); err != nil {
+		return err
+	}
+
+	token, err := uc.jwtService.GenerateEmailChangeToken(user.PublicID, newEmail, 24*time.Hour)
 	if err != nil {
 		return err
 	}
 
-	verificationURL := fmt.Sprintf("%s/pt-BR/verify-email?token=%s", uc.frontendURL, token)
+	locale := recipientLocale(ctx, user)
+	confirmationURL := fmt.Sprintf("%s/%s/confirm-email-change?token=%s", uc.frontendURL, locale, token)
 
-	if err := uc.emailSvc.SendVerificationEmail(ctx, user.Email, user.Name, verificationURL); err != nil {
+	if err := uc.emailSvc.SendEmailChangeEmail(ctx, newEmail, user.Name, locale, confirmationURL); err != nil {
 		return apperror.ErrEmailSendFailed
 	}
 
@@ -222,6 +395,30 @@ func (uc *UserUseCase) RequestVerificationByEmail(ctx context.Context, email str
 	return nil
 }
 
+// ConfirmEmailChange applies the change the token was issued for. The token
+// must belong to the signed-in user, so a link forwarded to someone else
+// cannot be redeemed from their account.
+func (uc *UserUseCase) ConfirmEmailChange(ctx context.Context, userPublicID, token string) (*entity.User, error) {
+	claims, err := uc.jwtService.ParseEmailChangeToken(token)
+	if err != nil {
+		return nil, apperror.ErrInvalidToken
+	}
+	if claims.UserPublicID != userPublicID {
+		return nil, apperror.ErrInvalidToken
+	}
+
+	if err := uc.repo.ConfirmPendingEmail(ctx, claims.UserPublicID, claims.Email); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.GetByPublicID(ctx, claims.UserPublicID)
+	if err != nil {
+		return nil, err
+	}
+	uc.acceptInvitations(ctx, user)
+	return user, nil
+}
+
 func (uc *UserUseCase) GetByPublicID(ctx context.Context, publicID string) (*entity.User, error) {
 	user, err := uc.repo.GetByPublicID(ctx, publicID)
 	if err != nil {
@@ -233,20 +430,53 @@ func (uc *UserUseCase) GetByPublicID(ctx context.Context, publicID string) (*ent
 	return user, nil
 }
 
-func (uc *UserUseCase) List(ctx context.Context, pageNumber, pageSize int) ([]entity.User, int, error) {
-	if pageSize <= 0 {
-		pageSize = 10
+// maxBatchUsers caps how many user IDs one GetManyByPublicID call accepts.
+const maxBatchUsers = 200
+
+// GetManyByPublicID looks up several users at once, keyed by public ID.
+// Duplicates are collapsed, and IDs that are malformed or do not name an
+// active user are omitted from the result.
+func (uc *UserUseCase) GetManyByPublicID(ctx context.Context, publicIDs []string) (map[string]*entity.User, error) {
+	if len(publicIDs) > maxBatchUsers {
+		return nil, apperror.ErrInvalidInput
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	ids := make([]string, 0, len(publicIDs))
+	seen := make(map[string]bool, len(publicIDs))
+	for _, publicID := range publicIDs {
+		publicID = strings.ToLower(publicID)
+		if seen[publicID] || !publicid.Valid(publicID) {
+			continue
+		}
+		seen[publicID] = true
+		ids = append(ids, publicID)
+	}
+
+	if len(ids) == 0 {
+		return map[string]*entity.User{}, nil
+	}
+	return uc.repo.GetManyByPublicID(ctx, ids)
+}
+
+// GetContext returns the user together with their active group
+// memberships, so clients can bootstrap a session with a single call.
+func (uc *UserUseCase) GetContext(ctx context.Context, publicID string) (*entity.User, []entity.GroupMembership, error) {
+	user, err := uc.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, nil, err
 	}
-	if pageNumber < 1 {
-		pageNumber = 1
+
+	memberships, err := uc.groupRepo.ListMembershipsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	offset := (pageNumber - 1) * pageSize
+	return user, memberships, nil
+}
+
+func (uc *UserUseCase) List(ctx context.Context, limit, offset int, sort string) ([]entity.User, int, error) {
 
-	users, err := uc.repo.List(ctx, pageSize, offset)
+	users, err := uc.repo.List(ctx, limit, offset, sort)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -259,20 +489,9 @@ func (uc *UserUseCase) List(ctx context.Context, pageNumber, pageSize int) ([]en
 	return users, total, nil
 }
 
-func (uc *UserUseCase) ListAll(ctx context.Context, pageNumber, pageSize int) ([]entity.User, int, error) {
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	offset := (pageNumber - 1) * pageSize
+func (uc *UserUseCase) ListAll(ctx context.Context, limit, offset int, sort string) ([]entity.User, int, error) {
 
-	users, err := uc.repo.ListAll(ctx, pageSize, offset)
+	users, err := uc.repo.ListAll(ctx, limit, offset, sort)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -285,7 +504,10 @@ func (uc *UserUseCase) ListAll(ctx context.Context, pageNumber, pageSize int) ([
 	return users, total, nil
 }
 
-func (uc *UserUseCase) Update(ctx context.Context, publicID string, input UpdateUserInput) (*entity.User, error) {
+// Update applies input to the user. Role changes need requesterPublicID to
+// belong to a platform admin, and neither a role change nor a deactivation
+// may remove the last active platform admin.
+func (uc *UserUseCase) Update(ctx context.Context, publicID, requesterPublicID string, input UpdateUserInput) (*entity.User, error) {
 	user, err := uc.repo.GetByPublicIDUnfiltered(ctx, publicID)
 	if err != nil {
 		return nil, err
@@ -294,24 +516,27 @@ func (uc *UserUseCase) Update(ctx context.Context, publicID string, input Update
 		return nil, apperror.ErrUserNotFound
 	}
 
-	if input.Name != nil {
-		name := strings.TrimSpace(*input.Name)
+	if input.Profile.Name != nil {
+		name := strings.TrimSpace(*input.Profile.Name)
 		if name == "" {
 			return nil, apperror.ErrInvalidInput
 		}
 		user.Name = name
 	}
 
-	if input.Email != nil {
-		email := strings.TrimSpace(*input.Email)
-		if email == "" {
+	if input.Profile.Email != nil {
+		if strings.TrimSpace(*input.Profile.Email) == "" {
 			return nil, apperror.ErrInvalidInput
 		}
-		user.Email = email
+		address, err := email.Normalize(*input.Profile.Email)
+		if err != nil {
+			return nil, err
+		}
+		user.Email = address
 	}
 
-	if input.AvatarURL != nil {
-		trimmed := strings.TrimSpace(*input.AvatarURL)
+	if input.Profile.AvatarURL != nil {
+		trimmed := strings.TrimSpace(*input.Profile.AvatarURL)
 		if trimmed == "" {
 			user.AvatarURL = nil
 		} else {
@@ -319,12 +544,46 @@ func (uc *UserUseCase) Update(ctx context.Context, publicID string, input Update
 		}
 	}
 
-	if input.Role != nil {
+	wasActiveAdmin := user.Role == entity.UserRoleAdmin && user.IsActive && user.Status == entity.UserStatusActive
+
+	if input.Role != nil && *input.Role != user.Role {
+		if *input.Role != entity.UserRoleAdmin && *input.Role != entity.UserRoleRegular {
+			return nil, apperror.ErrInvalidInput
+		}
+		requester, err := uc.repo.GetByPublicID(ctx, requesterPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if requester == nil || requester.Role != entity.UserRoleAdmin {
+			return nil, apperror.ErrForbidden
+		}
 		user.Role = *input.Role
 	}
 
-	if input.IsActive != nil {
-		user.IsActive = *input.IsActive
+	if input.Profile.IsActive != nil {
+		user.IsActive = *input.Profile.IsActive
+	}
+
+	if wasActiveAdmin && (user.Role != entity.UserRoleAdmin || !user.IsActive) {
+		if err := uc.ensureOtherActiveAdmin(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Profile.Lang != nil {
+		if *input.Profile.Lang == "" {
+			user.Lang = nil
+		} else {
+			locale, ok := i18n.Normalize(*input.Profile.Lang)
+			if !ok {
+				return nil, apperror.ErrInvalidInput
+			}
+			user.Lang = &locale
+		}
+	}
+
+	if input.Profile.ActivityReminders != nil {
+		user.ActivityReminders = *input.Profile.ActivityReminders
 	}
 
 	if err := uc.repo.Update(ctx, user); err != nil {
@@ -334,6 +593,39 @@ func (uc *UserUseCase) Update(ctx context.Context, publicID string, input Update
 	return user, nil
 }
 
+// ChangePassword replaces the user's password after checking the current one
+// and signs out every other session, so a leaked password stops working
+// everywhere but on the device that changed it.
+func (uc *UserUseCase) ChangePassword(ctx context.Context, userPublicID, sessionPublicID, currentPassword, newPassword string) error {
+	user, err := uc.repo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)); err != nil {
+		return apperror.ErrInvalidCredentials
+	}
+
+	newPassword = strings.TrimSpace(newPassword)
+	if err := uc.passwordPolicy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.UpdatePassword(ctx, user.PublicID, string(hash)); err != nil {
+		return err
+	}
+
+	return uc.sessionRepo.RevokeOthersByUser(ctx, user.ID, sessionPublicID)
+}
+
 func (uc *UserUseCase) Delete(ctx context.Context, publicID string) error {
 	user, err := uc.repo.GetByPublicID(ctx, publicID)
 	if err != nil {
@@ -343,11 +635,92 @@ func (uc *UserUseCase) Delete(ctx context.Context, publicID string) error {
 		return apperror.ErrUserNotFound
 	}
 
+	if user.Role == entity.UserRoleAdmin && user.Status == entity.UserStatusActive {
+		if err := uc.ensureOtherActiveAdmin(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	// Deleting a group's only admin would leave nobody able to manage it.
+	soleAdminOf, err := uc.groupRepo.ListSoleAdminGroups(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if len(soleAdminOf) > 0 {
+		groups := make([]map[string]string, len(soleAdminOf))
+		for i, g := range soleAdminOf {
+			groups[i] = map[string]string{"id": g.GroupPublicID, "name": g.GroupName}
+		}
+		return apperror.WithDetails(
+			apperror.ErrLastAdmin.Code,
+			apperror.ErrLastAdmin.Message,
+			apperror.ErrLastAdmin.HTTPStatus,
+			map[string]any{"groups": groups},
+		)
+	}
+
 	return uc.repo.Delete(ctx, publicID)
 }
 
+// ensureOtherActiveAdmin rejects changes that would leave the platform
+// without an active admin once user stops being one.
+func (uc *UserUseCase) ensureOtherActiveAdmin(ctx context.Context, user *entity.User) error {
+	others, err := uc.repo.CountActiveAdminsExcept(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if others == 0 {
+		return apperror.ErrLastPlatformAdmin
+	}
+	return nil
+}
+
+// Suspend blocks a user from signing in without touching their data or
+// memberships, and ends their open sessions. Admins cannot suspend
+// themselves.
+func (uc *UserUseCase) Suspend(ctx context.Context, publicID, requesterPublicID string) (*entity.User, error) {
+	user, err := uc.repo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+	if user.PublicID == requesterPublicID {
+		return nil, apperror.ErrForbidden
+	}
+
+	if err := uc.repo.SetStatus(ctx, user.ID, entity.UserStatusSuspended); err != nil {
+		return nil, err
+	}
+	if err := uc.sessionRepo.RevokeAllByUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	user.Status = entity.UserStatusSuspended
+	return user, nil
+}
+
+// Reinstate lets a suspended user sign in again.
+func (uc *UserUseCase) Reinstate(ctx context.Context, publicID string) (*entity.User, error) {
+	user, err := uc.repo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	if err := uc.repo.SetStatus(ctx, user.ID, entity.UserStatusActive); err != nil {
+		return nil, err
+	}
+
+	user.Status = entity.UserStatusActive
+	return user, nil
+}
+
 func (uc *UserUseCase) UploadAvatar(ctx context.Context, publicID string, filename string, contentType string, size int64, body io.Reader) (*entity.User, error) {
-	if size > maxAvatarSize {
+	if size > uc.uploadLimits.Avatar {
 		return nil, apperror.ErrFileTooLarge
 	}
 
@@ -355,6 +728,11 @@ func (uc *UserUseCase) UploadAvatar(ctx context.Context, publicID string, filena
 		return nil, apperror.ErrInvalidFileType
 	}
 
+	body, err := validateImage(body)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := uc.repo.GetByPublicID(ctx, publicID)
 	if err != nil {
 		return nil, err
@@ -417,3 +795,55 @@ func extractKeyFromURL(url string) string {
 	}
 	return ""
 }
+
+// userLocale returns the user's preferred locale, or "" to use the default.
+func userLocale(user *entity.User) string {
+	if user.Lang == nil {
+		return ""
+	}
+	return *user.Lang
+}
+
+// recipientLocale picks the language of an email sent while serving the
+// user's own request: their preference, else the request's locale, which
+// covers accounts that have not chosen one yet, such as at sign-up.
+func recipientLocale(ctx context.Context, user *entity.User) string {
+	if locale := userLocale(user); locale != "" {
+		return i18n.Resolve(locale)
+	}
+	return i18n.FromContext(ctx)
+}
+
+// ListSessions returns the user's live sessions, most recently used first.
+func (uc *UserUseCase) ListSessions(ctx context.Context, userPublicID string) ([]entity.Session, error) {
+	user, err := uc.repo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	return uc.sessionRepo.ListActiveByUser(ctx, user.ID)
+}
+
+// RevokeSession logs out one of the user's sessions, such as a lost device.
+// Sessions of other users look the same as missing ones.
+func (uc *UserUseCase) RevokeSession(ctx context.Context, userPublicID, sessionPublicID string) error {
+	user, err := uc.repo.GetByPublicID(ctx, userPublicID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+
+	revoked, err := uc.sessionRepo.RevokeForUser(ctx, user.ID, sessionPublicID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return apperror.ErrSessionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,567 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"proximos-passos/backend/internal/adapter/response"
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/password"
+	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/i18n"
+	"proximos-passos/backend/internal/infrastructure/jwt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type fakePasswordUserRepo struct {
+	fakeAuthUserRepo
+	updatedHash string
+}
+
+func (f *fakePasswordUserRepo) UpdatePassword(_ context.Context, _ string, passwordHash string) error {
+	f.updatedHash = passwordHash
+	return nil
+}
+
+func TestChangePasswordRevokesOtherSessions(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte("old-password-1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := &fakePasswordUserRepo{fakeAuthUserRepo: fakeAuthUserRepo{users: map[string]*entity.User{
+		"user":  {ID: 1, PublicID: "user", PasswordHash: string(hash)},
+		"other": {ID: 2, PublicID: "other"},
+	}}}
+	sessions := &fakeSessionRepo{}
+	for _, userID := range []int{1, 1, 2} {
+		if err := sessions.Create(ctx, &entity.Session{UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	current, other, someoneElse := sessions.sessions[0], sessions.sessions[1], sessions.sessions[2]
+
+	uc := &UserUseCase{repo: users, sessionRepo: sessions, passwordPolicy: password.DefaultPolicy()}
+
+	if err := uc.ChangePassword(ctx, "user", current.PublicID, "wrong", "New-password-42"); !errors.Is(err, apperror.ErrInvalidCredentials) {
+		t.Fatalf("wrong current password: err = %v, want ErrInvalidCredentials", err)
+	}
+	if other.RevokedAt != nil {
+		t.Fatal("a failed change revoked sessions")
+	}
+
+	if err := uc.ChangePassword(ctx, "user", current.PublicID, "old-password-1", "New-password-42"); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+	if users.updatedHash == "" {
+		t.Error("password was not updated")
+	}
+	if current.RevokedAt != nil {
+		t.Error("the current session was revoked")
+	}
+	if other.RevokedAt == nil {
+		t.Error("another session of the user stayed active")
+	}
+	if someoneElse.RevokedAt != nil {
+		t.Error("another user's session was revoked")
+	}
+}
+
+type fakeEmailChangeUserRepo struct {
+	fakeAuthUserRepo
+	pending          map[string]string
+	confirmed        string
+	verificationSent bool
+}
+
+func (f *fakeEmailChangeUserRepo) GetByEmail(_ context.Context, address string) (*entity.User, error) {
+	for _, u := range f.users {
+		if u.Email == address {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeEmailChangeUserRepo) SetPendingEmail(_ context.Context, publicID, address string) error {
+	f.pending[publicID] = address
+	return nil
+}
+
+func (f *fakeEmailChangeUserRepo) ConfirmPendingEmail(_ context.Context, publicID, address string) error {
+	if f.pending[publicID] != address {
+		return apperror.ErrInvalidToken
+	}
+	f.confirmed = publicID
+	return nil
+}
+
+func (f *fakeEmailChangeUserRepo) VerifyEmail(_ context.Context, publicID string) error {
+	now := time.Now()
+	f.users[publicID].EmailVerifiedAt = &now
+	return nil
+}
+
+func (f *fakeEmailChangeUserRepo) UpdateLastVerificationSent(_ context.Context, _ string) error {
+	f.verificationSent = true
+	return nil
+}
+
+type fakeEmailChangeSender struct {
+	service.EmailService
+	confirmationURL string
+}
+
+func (f *fakeEmailChangeSender) SendEmailChangeEmail(_ context.Context, _, _, _, confirmationURL string) error {
+	f.confirmationURL = confirmationURL
+	return nil
+}
+
+func newEmailChangeFixture(t *testing.T) (*UserUseCase, *fakeEmailChangeUserRepo, *fakeEmailChangeSender, *jwt.Service) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password-1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := &fakeEmailChangeUserRepo{
+		fakeAuthUserRepo: fakeAuthUserRepo{users: map[string]*entity.User{
+			"alice": {ID: 1, PublicID: "alice", Email: "alice@example.com", PasswordHash: string(hash)},
+			"bob":   {ID: 2, PublicID: "bob", Email: "bob@example.com", PasswordHash: string(hash)},
+		}},
+		pending: map[string]string{},
+	}
+	sender := &fakeEmailChangeSender{}
+	jwtService := jwt.NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	uc := &UserUseCase{repo: users, groupRepo: &fakeGroupRepo{}, emailSvc: sender, jwtService: jwtService, verificationCooldown: time.Minute}
+	return uc, users, sender, jwtService
+}
+
+func TestRequestEmailChange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requires the current password", func(t *testing.T) {
+		uc, users, sender, _ := newEmailChangeFixture(t)
+		err := uc.RequestEmailChange(ctx, "alice", "wrong", "new@example.com")
+		if !errors.Is(err, apperror.ErrInvalidCredentials) {
+			t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+		}
+		if len(users.pending) != 0 || sender.confirmationURL != "" {
+			t.Error("a change was started without the password")
+		}
+	})
+
+	t.Run("respects the verification cooldown", func(t *testing.T) {
+		uc, users, sender, _ := newEmailChangeFixture(t)
+		sentAt := time.Now().Add(-10 * time.Second)
+		users.users["alice"].LastVerificationTokenSentAt = &sentAt
+
+		err := uc.RequestEmailChange(ctx, "alice", "password-1", "new@example.com")
+		var appErr *apperror.AppError
+		if !errors.As(err, &appErr) || appErr.Code != apperror.CodeVerificationCooldown {
+			t.Fatalf("err = %v, want VERIFICATION_COOLDOWN", err)
+		}
+		if sender.confirmationURL != "" {
+			t.Error("an email was sent during the cooldown")
+		}
+	})
+
+	t.Run("sends the confirmation and starts the cooldown", func(t *testing.T) {
+		uc, users, sender, _ := newEmailChangeFixture(t)
+		if err := uc.RequestEmailChange(ctx, "alice", "password-1", "New@Example.com"); err != nil {
+			t.Fatalf("RequestEmailChange: %v", err)
+		}
+		if users.pending["alice"] != "new@example.com" {
+			t.Errorf("pending email = %q", users.pending["alice"])
+		}
+		if sender.confirmationURL == "" {
+			t.Error("no confirmation email was sent")
+		}
+		if !users.verificationSent {
+			t.Error("the cooldown was not started")
+		}
+	})
+}
+
+func TestConfirmEmailChangeRequiresTokenOwner(t *testing.T) {
+	ctx := context.Background()
+	uc, users, _, jwtService := newEmailChangeFixture(t)
+	users.pending["alice"] = "new@example.com"
+	token, err := jwtService.GenerateEmailChangeToken("alice", "new@example.com", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uc.ConfirmEmailChange(ctx, "bob", token); !errors.Is(err, apperror.ErrInvalidToken) {
+		t.Fatalf("other user: err = %v, want ErrInvalidToken", err)
+	}
+	if users.confirmed != "" {
+		t.Fatal("the change was applied from another account")
+	}
+
+	if _, err := uc.ConfirmEmailChange(ctx, "alice", token); err != nil {
+		t.Fatalf("owner: %v", err)
+	}
+	if users.confirmed != "alice" {
+		t.Error("the change was not applied")
+	}
+}
+
+type fakeSignupUserRepo struct {
+	fakeEmailChangeUserRepo
+}
+
+func (f *fakeSignupUserRepo) Create(_ context.Context, u *entity.User) error {
+	u.ID = len(f.users) + 1
+	u.PublicID = "new-user"
+	f.users[u.PublicID] = u
+	return nil
+}
+
+type fakeVerificationSender struct {
+	service.EmailService
+	to              string
+	verificationURL string
+}
+
+func (f *fakeVerificationSender) SendVerificationEmail(_ context.Context, to, _, _, verificationURL string) error {
+	f.to = to
+	f.verificationURL = verificationURL
+	return nil
+}
+
+func TestCreateQueuesVerificationEmailBeforeReturning(t *testing.T) {
+	users := &fakeSignupUserRepo{fakeEmailChangeUserRepo{
+		fakeAuthUserRepo: fakeAuthUserRepo{users: map[string]*entity.User{}},
+	}}
+	sender := &fakeVerificationSender{}
+	jwtService := jwt.NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	uc := &UserUseCase{repo: users, emailSvc: sender, jwtService: jwtService, passwordPolicy: password.DefaultPolicy()}
+
+	user, err := uc.Create(context.Background(), CreateUserInput{Name: "Ana", Email: "Ana@Example.com", Password: "Password-42"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if sender.to != user.Email || sender.verificationURL == "" {
+		t.Fatalf("verification email = (%q, %q), want it queued for %q", sender.to, sender.verificationURL, user.Email)
+	}
+	if !users.verificationSent {
+		t.Error("the resend cooldown was not started")
+	}
+}
+
+type fakeProfileUserRepo struct {
+	fakeAuthUserRepo
+	updated *entity.User
+}
+
+func (f *fakeProfileUserRepo) GetByPublicIDUnfiltered(ctx context.Context, publicID string) (*entity.User, error) {
+	return f.GetByPublicID(ctx, publicID)
+}
+
+func (f *fakeProfileUserRepo) Update(_ context.Context, u *entity.User) error {
+	f.updated = u
+	return nil
+}
+
+func TestUpdateTogglesActivityReminders(t *testing.T) {
+	users := &fakeProfileUserRepo{fakeAuthUserRepo: fakeAuthUserRepo{users: map[string]*entity.User{
+		"user": {ID: 1, PublicID: "user", Name: "Ana", ActivityReminders: true, IsActive: true},
+	}}}
+	uc := &UserUseCase{repo: users}
+	off := false
+
+	if _, err := uc.Update(context.Background(), "user", "user", UpdateUserInput{Profile: UpdateProfileInput{ActivityReminders: &off}}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if users.updated == nil || users.updated.ActivityReminders {
+		t.Fatal("the opt-out was not saved")
+	}
+	if users.updated.Name != "Ana" {
+		t.Errorf("name = %q, want it unchanged", users.updated.Name)
+	}
+}
+
+func TestVerifyEmailAcceptsInvitations(t *testing.T) {
+	uc, users, _, jwtService := newEmailChangeFixture(t)
+	groups := &fakeGroupRepo{}
+	uc.groupRepo = groups
+	token, err := jwtService.GenerateVerificationToken("alice", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := uc.VerifyEmail(context.Background(), token); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if groups.accepted[users.users["alice"].ID] != "alice@example.com" {
+		t.Errorf("accepted invitations = %v, want alice's", groups.accepted)
+	}
+}
+
+func TestVerificationLinkUsesRecipientLocale(t *testing.T) {
+	ptBR := "pt-BR"
+	tests := []struct {
+		name       string
+		lang       *string
+		requestTag string
+		want       string
+	}{
+		{"request locale without a preference", nil, "en", "https://example.com/en/verify-email?token="},
+		{"preference over the request locale", &ptBR, "en", "https://example.com/pt-BR/verify-email?token="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeSignupUserRepo{fakeEmailChangeUserRepo{
+				fakeAuthUserRepo: fakeAuthUserRepo{users: map[string]*entity.User{}},
+			}}
+			sender := &fakeVerificationSender{}
+			jwtService := jwt.NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+			uc := &UserUseCase{repo: users, emailSvc: sender, jwtService: jwtService, frontendURL: "https://example.com"}
+
+			ctx := i18n.WithLocale(context.Background(), tt.requestTag)
+			if err := uc.sendVerificationEmail(ctx, &entity.User{PublicID: "u1", Email: "ana@example.com", Lang: tt.lang}); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(sender.verificationURL, tt.want) {
+				t.Errorf("link = %q, want prefix %q", sender.verificationURL, tt.want)
+			}
+		})
+	}
+}
+
+type fakeSuspensionUserRepo struct {
+	fakeAuthUserRepo
+}
+
+func (f *fakeSuspensionUserRepo) GetByEmail(_ context.Context, email string) (*entity.User, error) {
+	for _, u := range f.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSuspensionUserRepo) SetStatus(_ context.Context, userID int, status entity.UserStatus) error {
+	for _, u := range f.users {
+		if u.ID == userID {
+			u.Status = status
+		}
+	}
+	return nil
+}
+
+func (f *fakeSessionRepo) RevokeAllByUser(_ context.Context, userID int) error {
+	now := time.Now()
+	for _, s := range f.sessions {
+		if s.UserID == userID {
+			s.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func TestSuspendBlocksSignInUntilReinstated(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password-1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifiedAt := time.Now()
+	users := &fakeSuspensionUserRepo{fakeAuthUserRepo{users: map[string]*entity.User{
+		"admin":   {ID: 1, PublicID: "admin", Role: entity.UserRoleAdmin, Status: entity.UserStatusActive},
+		"student": {ID: 2, PublicID: "student", Email: "student@example.com", PasswordHash: string(hash), EmailVerifiedAt: &verifiedAt, Status: entity.UserStatusActive},
+	}}}
+	sessions := &fakeSessionRepo{}
+	jwtService := jwt.NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+	auth := NewAuthUseCase(users, sessions, nil, jwtService)
+	uc := &UserUseCase{repo: users, sessionRepo: sessions}
+	login := LoginInput{Email: "student@example.com", Password: "password-1"}
+
+	out, err := auth.Login(ctx, login)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	if _, err := uc.Suspend(ctx, "admin", "admin"); !errors.Is(err, apperror.ErrForbidden) {
+		t.Errorf("self-suspension: err = %v, want ErrForbidden", err)
+	}
+	if _, err := uc.Suspend(ctx, "student", "admin"); err != nil {
+		t.Fatalf("Suspend: %v", err)
+	}
+
+	if sessions.sessions[0].RevokedAt == nil {
+		t.Error("the open session was not revoked")
+	}
+	if _, err := auth.Refresh(ctx, out.Token); err == nil {
+		t.Error("the suspended user refreshed their session")
+	}
+	if _, err := auth.Login(ctx, login); !errors.Is(err, apperror.ErrAccountSuspended) {
+		t.Errorf("Login while suspended: err = %v, want ErrAccountSuspended", err)
+	}
+
+	if _, err := uc.Reinstate(ctx, "student"); err != nil {
+		t.Fatalf("Reinstate: %v", err)
+	}
+	if _, err := auth.Login(ctx, login); err != nil {
+		t.Errorf("Login after reinstating: %v", err)
+	}
+}
+
+type fakeAdminGuardUserRepo struct {
+	fakeProfileUserRepo
+}
+
+func (f *fakeAdminGuardUserRepo) CountActiveAdminsExcept(_ context.Context, userID int) (int, error) {
+	count := 0
+	for _, u := range f.users {
+		if u.ID != userID && u.Role == entity.UserRoleAdmin && u.IsActive && u.Status == entity.UserStatusActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestLastPlatformAdminCannotBeRemoved(t *testing.T) {
+	ctx := context.Background()
+	newUsers := func() *fakeAdminGuardUserRepo {
+		return &fakeAdminGuardUserRepo{fakeProfileUserRepo{fakeAuthUserRepo: fakeAuthUserRepo{users: map[string]*entity.User{
+			"admin":   {ID: 1, PublicID: "admin", Name: "Admin", Role: entity.UserRoleAdmin, IsActive: true, Status: entity.UserStatusActive},
+			"student": {ID: 2, PublicID: "student", Name: "Student", Role: entity.UserRoleRegular, IsActive: true, Status: entity.UserStatusActive},
+		}}}}
+	}
+	regular, inactive := entity.UserRoleRegular, false
+
+	t.Run("demotion", func(t *testing.T) {
+		uc := &UserUseCase{repo: newUsers()}
+		_, err := uc.Update(ctx, "admin", "admin", UpdateUserInput{Role: &regular})
+		if !errors.Is(err, apperror.ErrLastPlatformAdmin) {
+			t.Errorf("err = %v, want ErrLastPlatformAdmin", err)
+		}
+	})
+
+	t.Run("deactivation", func(t *testing.T) {
+		uc := &UserUseCase{repo: newUsers()}
+		_, err := uc.Update(ctx, "admin", "admin", UpdateUserInput{Profile: UpdateProfileInput{IsActive: &inactive}})
+		if !errors.Is(err, apperror.ErrLastPlatformAdmin) {
+			t.Errorf("err = %v, want ErrLastPlatformAdmin", err)
+		}
+	})
+
+	t.Run("deletion", func(t *testing.T) {
+		uc := &UserUseCase{repo: newUsers()}
+		if err := uc.Delete(ctx, "admin"); !errors.Is(err, apperror.ErrLastPlatformAdmin) {
+			t.Errorf("err = %v, want ErrLastPlatformAdmin", err)
+		}
+	})
+
+	t.Run("demotion with another admin", func(t *testing.T) {
+		users := newUsers()
+		users.users["other"] = &entity.User{ID: 3, PublicID: "other", Role: entity.UserRoleAdmin, IsActive: true, Status: entity.UserStatusActive}
+		uc := &UserUseCase{repo: users}
+		if _, err := uc.Update(ctx, "admin", "other", UpdateUserInput{Role: &regular}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if users.updated == nil || users.updated.Role != entity.UserRoleRegular {
+			t.Error("the demotion was not saved")
+		}
+	})
+
+	t.Run("role change by a regular user", func(t *testing.T) {
+		admin := entity.UserRoleAdmin
+		uc := &UserUseCase{repo: newUsers()}
+		_, err := uc.Update(ctx, "student", "student", UpdateUserInput{Role: &admin})
+		if !errors.Is(err, apperror.ErrForbidden) {
+			t.Errorf("err = %v, want ErrForbidden", err)
+		}
+	})
+}
+
+func TestResendVerificationCooldownSetsRetryAfter(t *testing.T) {
+	sentAt := time.Now().Add(-time.Minute)
+	users := &fakeAuthUserRepo{users: map[string]*entity.User{
+		"ana": {ID: 1, PublicID: "ana", Email: "ana@example.com", LastVerificationTokenSentAt: &sentAt},
+	}}
+	uc := &UserUseCase{repo: users, verificationCooldown: 3 * time.Minute}
+
+	err := uc.ResendVerificationEmail(context.Background(), "ana")
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperror.ErrVerificationCooldown.Code {
+		t.Fatalf("err = %v, want ErrVerificationCooldown", err)
+	}
+	// Two minutes are left; allow a second for the time spent in the test.
+	if appErr.RetryAfter < 119 || appErr.RetryAfter > 120 {
+		t.Errorf("RetryAfter = %d, want about 120", appErr.RetryAfter)
+	}
+
+	rec := httptest.NewRecorder()
+	response.Error(rec, err)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != strconv.Itoa(appErr.RetryAfter) {
+		t.Errorf("Retry-After = %q, want %q", got, strconv.Itoa(appErr.RetryAfter))
+	}
+}
+
+func TestResendVerificationAllowedAfterCooldown(t *testing.T) {
+	sentAt := time.Now().Add(-5 * time.Minute)
+	user := &entity.User{ID: 1, PublicID: "ana", LastVerificationTokenSentAt: &sentAt}
+	uc := &UserUseCase{verificationCooldown: 3 * time.Minute}
+
+	if err := uc.checkVerificationCooldown(user); err != nil {
+		t.Errorf("checkVerificationCooldown = %v, want nil once the cooldown has passed", err)
+	}
+}
+
+func TestCreateEnforcesPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		rule     string // empty when the password is accepted
+	}{
+		{"too short", "Ab-1234", password.RuleMinLength},
+		{"at min length", "Ab-12345", ""},
+		{"single class", "abcdefghij", password.RuleCharacterClasses},
+		{"two classes", "abcdefgh12", ""},
+		{"common password", "password1", password.RuleCommonPassword},
+		{"common password in other case", "PASSWORD1", password.RuleCommonPassword},
+		{"uncommon password", "password-42", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeSignupUserRepo{fakeEmailChangeUserRepo{
+				fakeAuthUserRepo: fakeAuthUserRepo{users: map[string]*entity.User{}},
+			}}
+			jwtService := jwt.NewService("secret", "issuer", "audience", 15*time.Minute, 24*time.Hour)
+			uc := &UserUseCase{repo: users, emailSvc: &fakeVerificationSender{}, jwtService: jwtService, passwordPolicy: password.DefaultPolicy()}
+
+			_, err := uc.Create(context.Background(), CreateUserInput{Name: "Ana", Email: "ana@example.com", Password: tt.password})
+			if tt.rule == "" {
+				if err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+				return
+			}
+
+			var appErr *apperror.AppError
+			if !errors.As(err, &appErr) || appErr.Code != apperror.ErrWeakPassword.Code {
+				t.Fatalf("err = %v, want ErrWeakPassword", err)
+			}
+			details, _ := appErr.Details.(map[string]any)
+			if details["rule"] != tt.rule {
+				t.Errorf("rule = %v, want %q", details["rule"], tt.rule)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 	"path/filepath"
 	"strings"
 
@@ -12,13 +11,17 @@ import (
 	"proximos-passos/backend/internal/domain/entity"
 	"proximos-passos/backend/internal/domain/repository"
 	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
 )
 
 type VideoLessonUseCase struct {
-	vlRepo     repository.VideoLessonRepository
-	topicRepo  repository.TopicRepository
-	userRepo   repository.UserRepository
-	storageSvc service.StorageService
+	vlRepo         repository.VideoLessonRepository
+	topicRepo      repository.TopicRepository
+	userRepo       repository.UserRepository
+	storageSvc     service.StorageService
+	activityRepo   repository.ActivityRepository
+	accessRecorder *ContentAccessRecorder
+	uploadLimits   uploadlimits.Limits
 }
 
 func NewVideoLessonUseCase(
@@ -26,17 +29,21 @@ func NewVideoLessonUseCase(
 	topicRepo repository.TopicRepository,
 	userRepo repository.UserRepository,
 	storageSvc service.StorageService,
+	activityRepo repository.ActivityRepository,
+	accessRecorder *ContentAccessRecorder,
+	uploadLimits uploadlimits.Limits,
 ) *VideoLessonUseCase {
 	return &VideoLessonUseCase{
-		vlRepo:     vlRepo,
-		topicRepo:  topicRepo,
-		userRepo:   userRepo,
-		storageSvc: storageSvc,
+		vlRepo:         vlRepo,
+		topicRepo:      topicRepo,
+		userRepo:       userRepo,
+		storageSvc:     storageSvc,
+		activityRepo:   activityRepo,
+		accessRecorder: accessRecorder,
+		uploadLimits:   uploadLimits,
 	}
 }
 
-const maxVideoFileSize = 500 << 20 // 500MB
-
 var allowedVideoTypes = map[string]bool{
 	"video/mp4":       true,
 	"video/webm":      true,
@@ -122,7 +129,7 @@ func (uc *VideoLessonUseCase) Create(
 		if !allowedVideoTypes[contentType] {
 			return nil, apperror.ErrInvalidFileType
 		}
-		if size > maxVideoFileSize {
+		if size > uc.uploadLimits.Video {
 			return nil, apperror.ErrFileTooLarge
 		}
 
@@ -144,9 +151,6 @@ func (uc *VideoLessonUseCase) Create(
 		if vl.FileKey != "" {
 			_ = uc.storageSvc.Delete(ctx, vl.FileKey)
 		}
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrVideoLessonTitleTaken
-		}
 		return nil, err
 	}
 
@@ -160,7 +164,9 @@ func (uc *VideoLessonUseCase) Create(
 	return created, nil
 }
 
-func (uc *VideoLessonUseCase) GetByPublicID(ctx context.Context, publicID string) (*entity.VideoLesson, error) {
+// GetByPublicID returns the video lesson and counts a view by
+// viewerPublicID.
+func (uc *VideoLessonUseCase) GetByPublicID(ctx context.Context, publicID, viewerPublicID string) (*entity.VideoLesson, error) {
 	vl, err := uc.vlRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
 		return nil, err
@@ -169,6 +175,8 @@ func (uc *VideoLessonUseCase) GetByPublicID(ctx context.Context, publicID string
 		return nil, apperror.ErrVideoLessonNotFound
 	}
 
+	uc.accessRecorder.Record(entity.ActivityItemTypeVideoLesson, vl.ID, viewerPublicID, entity.ContentAccessView)
+
 	uc.resolveURL(vl)
 	return vl, nil
 }
@@ -216,9 +224,6 @@ func (uc *VideoLessonUseCase) Update(ctx context.Context, publicID string, input
 	}
 
 	if err := uc.vlRepo.Update(ctx, vl); err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
-			return nil, apperror.ErrVideoLessonTitleTaken
-		}
 		return nil, err
 	}
 
@@ -269,7 +274,7 @@ func (uc *VideoLessonUseCase) ReplaceFile(
 	if !allowedVideoTypes[contentType] {
 		return nil, apperror.ErrInvalidFileType
 	}
-	if size > maxVideoFileSize {
+	if size > uc.uploadLimits.Video {
 		return nil, apperror.ErrFileTooLarge
 	}
 
@@ -307,48 +312,54 @@ func (uc *VideoLessonUseCase) ReplaceFile(
 	return updated, nil
 }
 
-func (uc *VideoLessonUseCase) Delete(ctx context.Context, publicID string) error {
+// ListUsages returns the activities whose items reference the video lesson.
+func (uc *VideoLessonUseCase) ListUsages(ctx context.Context, publicID string) ([]entity.ActivityRef, error) {
 	vl, err := uc.vlRepo.GetByPublicID(ctx, publicID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if vl == nil {
-		return apperror.ErrVideoLessonNotFound
+		return nil, apperror.ErrVideoLessonNotFound
 	}
 
-	return uc.vlRepo.Delete(ctx, publicID)
+	return uc.activityRepo.ListContentReferences(ctx, entity.ActivityItemTypeVideoLesson, vl.ID)
 }
 
-func (uc *VideoLessonUseCase) List(ctx context.Context, pageNumber, pageSize int, filter repository.VideoLessonFilter) ([]entity.VideoLesson, int, int, error) {
-	if pageNumber < 1 {
-		pageNumber = 1
+func (uc *VideoLessonUseCase) Delete(ctx context.Context, publicID string, force bool) error {
+	vl, err := uc.vlRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return err
 	}
-	if pageSize < 1 {
-		pageSize = 10
+	if vl == nil {
+		return apperror.ErrVideoLessonNotFound
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	if !force {
+		if err := ensureContentUnused(ctx, uc.activityRepo, entity.ActivityItemTypeVideoLesson, vl.ID); err != nil {
+			return err
+		}
 	}
-	offset := (pageNumber - 1) * pageSize
 
-	lessons, err := uc.vlRepo.List(ctx, pageSize, offset, filter)
+	return uc.vlRepo.Delete(ctx, publicID, force)
+}
+
+func (uc *VideoLessonUseCase) List(ctx context.Context, limit, offset int, filter repository.VideoLessonFilter) ([]entity.VideoLesson, int, error) {
+	lessons, err := uc.vlRepo.List(ctx, limit, offset, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
 	total, err := uc.vlRepo.Count(ctx, filter)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
-
 	// Set file URLs
 	for i := range lessons {
 		uc.resolveURL(&lessons[i])
 	}
 
-	return lessons, total, totalPages, nil
+	return lessons, total, nil
 }
 
 func (uc *VideoLessonUseCase) ResolveTopicIDs(ctx context.Context, publicIDs []string) ([]int, error) {
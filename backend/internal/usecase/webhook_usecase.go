@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"proximos-passos/backend/internal/domain/apperror"
+	"proximos-passos/backend/internal/domain/entity"
+	"proximos-passos/backend/internal/domain/repository"
+)
+
+const maxWebhookURLLength = 2048
+
+type WebhookUseCase struct {
+	webhookRepo repository.WebhookRepository
+	userRepo    repository.UserRepository
+}
+
+func NewWebhookUseCase(webhookRepo repository.WebhookRepository, userRepo repository.UserRepository) *WebhookUseCase {
+	return &WebhookUseCase{webhookRepo: webhookRepo, userRepo: userRepo}
+}
+
+// Create registers a subscriber URL and generates the secret used to sign its
+// deliveries. The secret is only ever returned here.
+func (uc *WebhookUseCase) Create(ctx context.Context, createdByPublicID string, rawURL string) (*entity.Webhook, error) {
+	user, err := uc.userRepo.GetByPublicID(ctx, createdByPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || len(rawURL) > maxWebhookURLLength {
+		return nil, apperror.ErrInvalidInput
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	webhook := &entity.Webhook{
+		URL:         rawURL,
+		Secret:      hex.EncodeToString(secret),
+		CreatedByID: user.ID,
+	}
+
+	if err := uc.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (uc *WebhookUseCase) Delete(ctx context.Context, publicID string) error {
+	webhook, err := uc.webhookRepo.GetByPublicID(ctx, publicID)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		return apperror.ErrWebhookNotFound
+	}
+	return uc.webhookRepo.Delete(ctx, publicID)
+}
+
+func (uc *WebhookUseCase) List(ctx context.Context, limit, offset int) ([]entity.Webhook, int, error) {
+	webhooks, err := uc.webhookRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := uc.webhookRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, total, nil
+}
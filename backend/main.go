@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -15,10 +16,18 @@ import (
 	docs "proximos-passos/backend/docs"
 	"proximos-passos/backend/internal/adapter/handler"
 	"proximos-passos/backend/internal/adapter/middleware"
+	"proximos-passos/backend/internal/buildinfo"
+	"proximos-passos/backend/internal/domain/password"
+	"proximos-passos/backend/internal/domain/service"
+	"proximos-passos/backend/internal/domain/uploadlimits"
+	"proximos-passos/backend/internal/infrastructure/emailtemplate"
+	"proximos-passos/backend/internal/infrastructure/imaging"
 	"proximos-passos/backend/internal/infrastructure/jwt"
+	"proximos-passos/backend/internal/infrastructure/metrics"
 	"proximos-passos/backend/internal/infrastructure/postgres"
 	"proximos-passos/backend/internal/infrastructure/r2"
 	"proximos-passos/backend/internal/infrastructure/resend"
+	"proximos-passos/backend/internal/infrastructure/webhook"
 	"proximos-passos/backend/internal/usecase"
 )
 
@@ -39,6 +48,8 @@ func main() {
 	// Ignore errors, we might be in production and not need a .env file
 	_ = godotenv.Load("../.env", ".env")
 
+	build := buildinfo.Get()
+
 	databaseURL := os.Getenv("DATABASE_URL")
 	if databaseURL == "" {
 		log.Fatal("DATABASE_URL environment variable is required")
@@ -49,6 +60,45 @@ func main() {
 		log.Fatal("JWT_SECRET environment variable is required")
 	}
 
+	// Distinct values per environment keep a token from one being accepted
+	// by another even if they share JWT_SECRET.
+	jwtIssuer := "proximos-passos"
+	if v := os.Getenv("JWT_ISSUER"); v != "" {
+		jwtIssuer = v
+	}
+	jwtAudience := "proximos-passos"
+	if v := os.Getenv("JWT_AUDIENCE"); v != "" {
+		jwtAudience = v
+	}
+
+	// The session cookie holds a single JWT, not a separate refresh token.
+	// It is checked against the server-side session on every request, so
+	// revocation is immediate; ACCESS_TOKEN_TTL only bounds how stale the
+	// claims inside it can get. Once expired, the same token is renewed
+	// through POST /auth/refresh until the session, which lasts
+	// REFRESH_TOKEN_TTL, ends, so whoever holds the cookie can keep the
+	// session alive until then or until it is revoked.
+	accessTokenTTL := 15 * time.Minute
+	if v := os.Getenv("ACCESS_TOKEN_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Minute {
+			log.Fatal("ACCESS_TOKEN_TTL must be a duration of at least 1m, such as 15m")
+		}
+		accessTokenTTL = d
+	}
+
+	refreshTokenTTL := 24 * time.Hour
+	if v := os.Getenv("REFRESH_TOKEN_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatal("REFRESH_TOKEN_TTL must be a positive duration, such as 720h")
+		}
+		refreshTokenTTL = d
+	}
+	if refreshTokenTTL < accessTokenTTL {
+		log.Fatal("REFRESH_TOKEN_TTL must not be shorter than ACCESS_TOKEN_TTL")
+	}
+
 	resendAPIKey := os.Getenv("RESEND_API_KEY")
 	if resendAPIKey == "" {
 		log.Fatal("RESEND_API_KEY environment variable is required")
@@ -103,16 +153,190 @@ func main() {
 	adminName := os.Getenv("ADMIN_NAME")
 	adminEmail := os.Getenv("ADMIN_EMAIL")
 	adminPassword := os.Getenv("ADMIN_PASSWORD")
+	if (adminName != "" || adminEmail != "" || adminPassword != "") && (adminName == "" || adminEmail == "" || adminPassword == "") {
+		log.Fatal("ADMIN_NAME, ADMIN_EMAIL and ADMIN_PASSWORD must be set together")
+	}
+
+	// Timeouts and intervals are Go durations, like the DB_* settings.
+	// VERIFICATION_COOLDOWN_SECONDS predates that and is still honoured
+	// so existing deployments keep their cooldown.
+	verificationCooldown := 3 * time.Minute
+	if v := os.Getenv("VERIFICATION_COOLDOWN"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			log.Fatal("VERIFICATION_COOLDOWN must be a non-negative duration, such as 3m")
+		}
+		verificationCooldown = d
+	} else if v := os.Getenv("VERIFICATION_COOLDOWN_SECONDS"); v != "" {
+		secs, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatal("VERIFICATION_COOLDOWN_SECONDS must be a valid integer")
+		}
+		verificationCooldown = time.Duration(secs) * time.Second
+	}
+
+	emailTimeout := 10 * time.Second
+	if v := os.Getenv("EMAIL_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatal("EMAIL_TIMEOUT must be a positive duration, such as 10s")
+		}
+		emailTimeout = d
+	}
+
+	emailOutboxRetention := 30 * 24 * time.Hour
+	if v := os.Getenv("EMAIL_OUTBOX_RETENTION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatal("EMAIL_OUTBOX_RETENTION must be a positive duration, such as 720h")
+		}
+		emailOutboxRetention = d
+	}
+
+	contentAccessRetention := 365 * 24 * time.Hour
+	if v := os.Getenv("CONTENT_ACCESS_RETENTION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatal("CONTENT_ACCESS_RETENTION must be a positive duration, such as 8760h")
+		}
+		contentAccessRetention = d
+	}
+
+	storageTimeout := 2 * time.Minute
+	if v := os.Getenv("STORAGE_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatal("STORAGE_TIMEOUT must be a positive duration, such as 2m")
+		}
+		storageTimeout = d
+	}
+
+	reminderWindow := 24 * time.Hour
+	if v := os.Getenv("ACTIVITY_REMINDER_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatal("ACTIVITY_REMINDER_WINDOW must be a positive duration, such as 24h")
+		}
+		reminderWindow = d
+	}
+
+	reminderInterval := 15 * time.Minute
+	if v := os.Getenv("ACTIVITY_REMINDER_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatal("ACTIVITY_REMINDER_INTERVAL must be a positive duration, such as 15m")
+		}
+		reminderInterval = d
+	}
 
-	verificationCooldownStr := os.Getenv("VERIFICATION_COOLDOWN_SECONDS")
-	if verificationCooldownStr == "" {
-		verificationCooldownStr = "180"
+	passwordPolicy := password.DefaultPolicy()
+	if v := os.Getenv("PASSWORD_MIN_LENGTH"); v != "" {
+		passwordPolicy.MinLength, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatal("PASSWORD_MIN_LENGTH must be a valid integer")
+		}
 	}
-	verificationCooldownSecs, err := strconv.Atoi(verificationCooldownStr)
+	if v := os.Getenv("PASSWORD_MIN_CHAR_CLASSES"); v != "" {
+		passwordPolicy.MinClasses, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatal("PASSWORD_MIN_CHAR_CLASSES must be a valid integer")
+		}
+	}
+	if v := os.Getenv("PASSWORD_REJECT_COMMON"); v != "" {
+		passwordPolicy.RejectCommon, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Fatal("PASSWORD_REJECT_COMMON must be a valid boolean")
+		}
+	}
+
+	// Caps request bodies, except on upload routes: those get their upload
+	// limit from handler.UploadRoutes.
+	var maxRequestBodyBytes int64 = 1 << 20
+	if v := os.Getenv("MAX_REQUEST_BODY_BYTES"); v != "" {
+		maxRequestBodyBytes, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || maxRequestBodyBytes < 1 {
+			log.Fatal("MAX_REQUEST_BODY_BYTES must be a positive integer")
+		}
+	}
+
+	// Responses below this size are not worth the gzip overhead.
+	compressMinBytes := 1024
+	if v := os.Getenv("COMPRESS_MIN_BYTES"); v != "" {
+		compressMinBytes, err = strconv.Atoi(v)
+		if err != nil || compressMinBytes < 0 {
+			log.Fatal("COMPRESS_MIN_BYTES must be a non-negative integer")
+		}
+	}
+
+	uploadLimits, err := uploadlimits.Load(os.Getenv)
 	if err != nil {
-		log.Fatal("VERIFICATION_COOLDOWN_SECONDS must be a valid integer")
+		log.Fatal(err)
+	}
+
+	// Session cookie attributes; plain-HTTP local development needs
+	// COOKIE_SECURE=false, cross-site frontends COOKIE_SAMESITE=none.
+	cookieConfig := handler.DefaultCookieConfig()
+	cookieConfig.Domain = os.Getenv("COOKIE_DOMAIN")
+	if v := os.Getenv("COOKIE_PATH"); v != "" {
+		cookieConfig.Path = v
+	}
+	if v := os.Getenv("COOKIE_SECURE"); v != "" {
+		cookieConfig.Secure, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Fatal("COOKIE_SECURE must be a valid boolean")
+		}
+	}
+	switch strings.ToLower(os.Getenv("COOKIE_SAMESITE")) {
+	case "", "lax":
+	case "strict":
+		cookieConfig.SameSite = http.SameSiteStrictMode
+	case "none":
+		cookieConfig.SameSite = http.SameSiteNoneMode
+		if !cookieConfig.Secure {
+			log.Fatal("COOKIE_SAMESITE=none requires COOKIE_SECURE=true")
+		}
+	default:
+		log.Fatal("COOKIE_SAMESITE must be lax, strict or none")
+	}
+
+	// CSRF protection is on by default; set CSRF_ENABLED=false for local development.
+	csrfEnabled := true
+	if csrfEnabledStr := os.Getenv("CSRF_ENABLED"); csrfEnabledStr != "" {
+		csrfEnabled, err = strconv.ParseBool(csrfEnabledStr)
+		if err != nil {
+			log.Fatal("CSRF_ENABLED must be a valid boolean")
+		}
+	}
+
+	// PNG and JPEG question images get a WebP copy when the cwebp binary is
+	// installed; IMAGE_WEBP_ENABLED=false turns the conversion off.
+	var imageEncoder service.ImageEncoder
+	webpEnabled := true
+	if v := os.Getenv("IMAGE_WEBP_ENABLED"); v != "" {
+		webpEnabled, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Fatal("IMAGE_WEBP_ENABLED must be a valid boolean")
+		}
+	}
+	if webpEnabled {
+		webpQuality := 80
+		if v := os.Getenv("IMAGE_WEBP_QUALITY"); v != "" {
+			webpQuality, err = strconv.Atoi(v)
+			if err != nil || webpQuality < 0 || webpQuality > 100 {
+				log.Fatal("IMAGE_WEBP_QUALITY must be an integer between 0 and 100")
+			}
+		}
+		cwebpPath := os.Getenv("CWEBP_PATH")
+		if cwebpPath == "" {
+			cwebpPath = "cwebp"
+		}
+		encoder, err := imaging.NewCWebPEncoder(cwebpPath, webpQuality)
+		if err != nil {
+			log.Printf("WebP conversion disabled: %v", err)
+		} else {
+			imageEncoder = encoder
+		}
 	}
-	verificationCooldown := time.Duration(verificationCooldownSecs) * time.Second
 
 	setupInput := &usecase.SetupAdminInput{
 		Name:     adminName,
@@ -120,10 +344,9 @@ func main() {
 		Password: adminPassword,
 	}
 
-	jwtService := jwt.NewService(jwtSecret, 24*time.Hour)
-	emailSvc := resend.NewEmailService(resendAPIKey, resendFromEmail, logoFullURL)
+	jwtService := jwt.NewService(jwtSecret, jwtIssuer, jwtAudience, accessTokenTTL, refreshTokenTTL)
 
-	storageSvc, err := r2.NewStorageService(r2AccountID, r2AccessKeyID, r2AccessKeySecret, r2Bucket, r2PublicURL)
+	storageSvc, err := r2.NewStorageService(r2AccountID, r2AccessKeyID, r2AccessKeySecret, r2Bucket, r2PublicURL, storageTimeout)
 	if err != nil {
 		log.Fatalf("failed to initialize R2 storage: %v", err)
 	}
@@ -140,21 +363,63 @@ func main() {
 	examRepo := postgres.NewExamRepository(pool)
 	questionSubmissionRepo := postgres.NewQuestionSubmissionRepository(pool)
 	activitySubmissionRepo := postgres.NewActivitySubmissionRepository(pool)
-	userUC := usecase.NewUserUseCase(userRepo, emailSvc, storageSvc, jwtService, frontendURL, verificationCooldown)
-	authUC := usecase.NewAuthUseCase(userRepo, jwtService)
-	groupUC := usecase.NewGroupUseCase(groupRepo, userRepo, storageSvc)
-	activityUC := usecase.NewActivityUseCase(activityRepo, groupRepo, userRepo, questionRepo, videoLessonRepo, handoutRepo, openExerciseListRepo, storageSvc)
+	fileRepo := postgres.NewFileRepository(pool)
+	webhookRepo := postgres.NewWebhookRepository(pool)
+	statsRepo := postgres.NewStatsRepository(pool)
+	idempotencyRepo := postgres.NewIdempotencyRepository(pool)
+	emailOutboxRepo := postgres.NewEmailOutboxRepository(pool)
+	activityReminderRepo := postgres.NewActivityReminderRepository(pool)
+	sessionRepo := postgres.NewSessionRepository(pool)
+	impersonationEventRepo := postgres.NewImpersonationEventRepository(pool)
+	contentAccessRepo := postgres.NewContentAccessRepository(pool)
+	recommendationRepo := postgres.NewRecommendationRepository(pool)
+	emailTemplates, err := emailtemplate.New(logoFullURL, frontendURL)
+	if err != nil {
+		log.Fatalf("failed to load email templates: %v", err)
+	}
+	emailSvc := resend.NewEmailService(resendAPIKey, resendFromEmail, emailTemplates, emailTimeout, emailOutboxRepo)
+	emailWorker := resend.NewOutboxWorker(emailOutboxRepo, emailSvc, 8, 30*time.Second, 5*time.Second, 2*time.Minute, emailOutboxRetention)
+	go emailWorker.Run(ctx)
+	go middleware.PurgeIdempotencyKeys(ctx, idempotencyRepo, time.Hour)
+	contentAccessRecorder := usecase.NewContentAccessRecorder(contentAccessRepo, contentAccessRetention)
+	go contentAccessRecorder.Run(ctx)
+	webhookDispatcher := webhook.NewDispatcher(webhookRepo, &http.Client{Timeout: 10 * time.Second}, 5, 2*time.Second)
+	userUC := usecase.NewUserUseCase(userRepo, groupRepo, sessionRepo, emailSvc, storageSvc, jwtService, frontendURL, verificationCooldown, passwordPolicy, uploadLimits)
+	authUC := usecase.NewAuthUseCase(userRepo, sessionRepo, impersonationEventRepo, jwtService)
+	groupUC := usecase.NewGroupUseCase(groupRepo, userRepo, storageSvc, webhookDispatcher, emailSvc, frontendURL, uploadLimits)
+	activityUC := usecase.NewActivityUseCase(activityRepo, groupRepo, userRepo, questionRepo, videoLessonRepo, handoutRepo, openExerciseListRepo, fileRepo, storageSvc, webhookDispatcher, uploadLimits)
 	topicUC := usecase.NewTopicUseCase(topicRepo, userRepo)
-	handoutUC := usecase.NewHandoutUseCase(handoutRepo, topicRepo, userRepo, storageSvc)
-	videoLessonUC := usecase.NewVideoLessonUseCase(videoLessonRepo, topicRepo, userRepo, storageSvc)
-	openExerciseListUC := usecase.NewOpenExerciseListUseCase(openExerciseListRepo, topicRepo, userRepo, storageSvc)
-	questionUC := usecase.NewQuestionUseCase(questionRepo, topicRepo, examRepo, institutionRepo, userRepo, storageSvc)
-	institutionUC := usecase.NewInstitutionUseCase(institutionRepo, userRepo)
+	handoutUC := usecase.NewHandoutUseCase(handoutRepo, topicRepo, userRepo, storageSvc, activityRepo, contentAccessRecorder, uploadLimits)
+	videoLessonUC := usecase.NewVideoLessonUseCase(videoLessonRepo, topicRepo, userRepo, storageSvc, activityRepo, contentAccessRecorder, uploadLimits)
+	openExerciseListUC := usecase.NewOpenExerciseListUseCase(openExerciseListRepo, topicRepo, userRepo, storageSvc, activityRepo, contentAccessRecorder, uploadLimits)
+	questionUC := usecase.NewQuestionUseCase(questionRepo, topicRepo, examRepo, institutionRepo, userRepo, storageSvc, activityRepo, imageEncoder, uploadLimits)
+	institutionUC := usecase.NewInstitutionUseCase(institutionRepo, userRepo, storageSvc, uploadLimits)
 	examUC := usecase.NewExamUseCase(examRepo, institutionRepo, userRepo)
-	activitySubmissionUC := usecase.NewActivitySubmissionUseCase(activitySubmissionRepo, activityRepo, groupRepo, userRepo, questionSubmissionRepo, storageSvc)
-	questionSubmissionUC := usecase.NewQuestionSubmissionUseCase(questionSubmissionRepo, questionRepo, userRepo, activitySubmissionUC)
+	webhookUC := usecase.NewWebhookUseCase(webhookRepo, userRepo)
+	emailOutboxUC := usecase.NewEmailOutboxUseCase(emailOutboxRepo)
+	activityReminderUC := usecase.NewActivityReminderUseCase(activityReminderRepo, emailSvc, frontendURL, reminderWindow)
+	go activityReminderUC.Run(ctx, reminderInterval)
+	statsUC := usecase.NewStatsUseCase(statsRepo, groupRepo, userRepo, contentAccessRepo)
+	recommendationUC := usecase.NewRecommendationUseCase(recommendationRepo, statsRepo, userRepo)
+	activitySubmissionUC := usecase.NewActivitySubmissionUseCase(activitySubmissionRepo, activityRepo, groupRepo, userRepo, questionSubmissionRepo, fileRepo, storageSvc, webhookDispatcher, emailSvc, frontendURL, uploadLimits)
+	questionSubmissionUC := usecase.NewQuestionSubmissionUseCase(questionSubmissionRepo, questionRepo, userRepo, fileRepo, storageSvc, emailSvc, frontendURL, activitySubmissionUC, uploadLimits)
+	progressUC := usecase.NewProgressUseCase(groupRepo, userRepo, activityRepo, activitySubmissionRepo, activitySubmissionUC)
+
+	if setupInput.Configured() {
+		seeded, err := userUC.SeedAdmin(ctx, *setupInput)
+		if err != nil {
+			log.Fatalf("failed to seed admin %s: %v", setupInput.Email, err)
+		}
+		if seeded {
+			log.Printf("admin setup: created admin %s", setupInput.Email)
+		} else {
+			log.Printf("admin setup: admin %s already exists, skipping", setupInput.Email)
+		}
+	} else {
+		log.Printf("admin setup: ADMIN_* not set, skipping")
+	}
 
-	authHandler := handler.NewAuthHandler(authUC, userUC, setupInput)
+	authHandler := handler.NewAuthHandler(authUC, userUC, setupInput, cookieConfig)
 	userHandler := handler.NewUserHandler(userUC)
 	groupHandler := handler.NewGroupHandler(groupUC)
 	activityHandler := handler.NewActivityHandler(activityUC)
@@ -167,19 +432,30 @@ func main() {
 	examHandler := handler.NewExamHandler(examUC, questionRepo)
 	questionSubmissionHandler := handler.NewQuestionSubmissionHandler(questionSubmissionUC)
 	activitySubmissionHandler := handler.NewActivitySubmissionHandler(activitySubmissionUC)
+	webhookHandler := handler.NewWebhookHandler(webhookUC)
+	emailOutboxHandler := handler.NewEmailOutboxHandler(emailOutboxUC)
+	statsHandler := handler.NewStatsHandler(statsUC)
+	recommendationHandler := handler.NewRecommendationHandler(recommendationUC)
+	progressHandler := handler.NewProgressHandler(progressUC)
 
 	adminOnly := func(next http.Handler) http.Handler {
-		return middleware.Auth(jwtService)(middleware.RequireAdmin(userRepo)(next))
+		return middleware.Auth(jwtService, sessionRepo)(middleware.RequireAdmin(userRepo)(next))
 	}
 
 	authOnly := func(next http.Handler) http.Handler {
-		return middleware.Auth(jwtService)(next)
+		return middleware.Auth(jwtService, sessionRepo)(middleware.RequireVerified(userRepo)(next))
 	}
 
 	authWithRole := func(next http.Handler) http.Handler {
-		return middleware.AuthWithRole(jwtService, userRepo)(next)
+		return middleware.AuthWithRole(jwtService, sessionRepo, userRepo)(middleware.RequireVerified(userRepo)(next))
 	}
 
+	idempotent := middleware.Idempotency(idempotencyRepo, 24*time.Hour)
+
+	httpMetrics := metrics.NewHTTP()
+	metricsHandler := handler.NewMetricsHandler(os.Getenv("METRICS_TOKEN"), httpMetrics, postgres.NewPoolCollector(pool))
+	versionHandler := handler.NewVersionHandler(build)
+
 	mux := http.NewServeMux()
 
 	// @Summary     Health check
@@ -196,9 +472,11 @@ func main() {
 
 	authHandler.RegisterRoutes(mux)
 	authHandler.RegisterProtectedRoutes(mux, adminOnly)
+	authHandler.RegisterImpersonationRoutes(mux, adminOnly, authOnly)
+	authHandler.RegisterSessionRoutes(mux, authOnly)
 	userHandler.RegisterRoutes(mux, adminOnly)
 	userHandler.RegisterSelfRoutes(mux, authOnly)
-	groupHandler.RegisterRoutes(mux, adminOnly, authWithRole)
+	groupHandler.RegisterRoutes(mux, adminOnly, authWithRole, idempotent)
 	groupHandler.RegisterSelfRoutes(mux, authOnly)
 	groupHandler.RegisterMemberRoutes(mux, adminOnly, authWithRole)
 	activityHandler.RegisterRoutes(mux, authWithRole)
@@ -206,11 +484,19 @@ func main() {
 	handoutHandler.RegisterRoutes(mux, adminOnly, authOnly)
 	videoLessonHandler.RegisterRoutes(mux, adminOnly, authOnly)
 	openExerciseListHandler.RegisterRoutes(mux, adminOnly, authOnly)
-	questionHandler.RegisterRoutes(mux, adminOnly, authOnly)
+	questionHandler.RegisterRoutes(mux, adminOnly, authWithRole, idempotent)
 	institutionHandler.RegisterRoutes(mux, adminOnly, authOnly)
 	examHandler.RegisterRoutes(mux, adminOnly, authOnly)
-	questionSubmissionHandler.RegisterRoutes(mux, authOnly)
-	activitySubmissionHandler.RegisterRoutes(mux, authWithRole)
+	questionSubmissionHandler.RegisterRoutes(mux, adminOnly, authOnly, idempotent)
+	activitySubmissionHandler.RegisterRoutes(mux, authWithRole, idempotent)
+	webhookHandler.RegisterRoutes(mux, adminOnly)
+	emailOutboxHandler.RegisterRoutes(mux, adminOnly)
+	statsHandler.RegisterRoutes(mux, authWithRole)
+	statsHandler.RegisterAdminRoutes(mux, adminOnly)
+	recommendationHandler.RegisterRoutes(mux, authOnly)
+	progressHandler.RegisterRoutes(mux, authWithRole)
+	metricsHandler.RegisterRoutes(mux)
+	versionHandler.RegisterRoutes(mux)
 	mux.Handle("GET /swagger/", httpSwagger.WrapHandler)
 
 	port := os.Getenv("PORT")
@@ -227,13 +513,13 @@ func main() {
 		docs.SwaggerInfo.Host = ""
 	}
 
-	log.Printf("Backend listening on :%s", port)
+	log.Printf("Backend listening on :%s (commit %s, built %s, %s)", port, build.Commit, build.BuildTime, build.GoVersion)
 	if swaggerHost != "" {
 		log.Printf("Swagger UI available at https://%s/swagger/index.html", swaggerHost)
 	} else {
 		log.Printf("Swagger UI available at http://localhost:%s/swagger/index.html", port)
 	}
-	if err := http.ListenAndServe(":"+port, middleware.CORS(mux)); err != nil {
+	if err := http.ListenAndServe(":"+port, middleware.CORS(middleware.Locale(middleware.CSRF(csrfEnabled)(middleware.MaxBytes(mux, maxRequestBodyBytes, handler.UploadRoutes(uploadLimits))(middleware.Compress(compressMinBytes)(middleware.Metrics(httpMetrics)(middleware.PublicIDs(mux)))))))); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -1,3 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 -- ==========================================
 -- 1. BASE SYSTEM (Users & Files)
 -- ==========================================
@@ -17,9 +19,14 @@ CREATE TABLE users (
         length(email) <= 255 
         AND length(email) > 0 
         AND email = trim(email)
+        AND email = lower(email)
     ),
     email_verified_at TIMESTAMPTZ,
     last_verification_token_sent_at TIMESTAMPTZ,
+    pending_email TEXT CHECK (
+        pending_email IS NULL
+        OR (length(pending_email) <= 255 AND length(pending_email) > 0 AND pending_email = trim(pending_email) AND pending_email = lower(pending_email))
+    ),
     password_hash TEXT NOT NULL CHECK (
         length(password_hash) <= 255 
         AND length(password_hash) > 0 
@@ -32,6 +39,12 @@ CREATE TABLE users (
             OR (length(avatar_url) > 0 AND avatar_url = trim(avatar_url))
         )
     ),
+    -- NULL means no preference: the Accept-Language header decides
+    lang TEXT CHECK (lang IS NULL OR lang IN ('pt-BR', 'en')),
+    -- false once the user opts out of activity due-date reminder emails
+    activity_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+    -- suspended accounts keep their data but cannot sign in
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'deleted')),
 
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
@@ -44,7 +57,7 @@ CREATE TABLE files (
     id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
     
-    key TEXT UNIQUE NOT NULL CHECK (
+    key TEXT NOT NULL CHECK (
         length(key) <= 1024
         AND length(key) > 0
         AND key = trim(key)
@@ -60,6 +73,9 @@ CREATE TABLE files (
         AND content_type = trim(content_type)
     ),
     size_bytes BIGINT NOT NULL,
+    checksum TEXT CHECK (checksum IS NULL OR checksum ~ '^[0-9a-f]{64}$'),
+    -- Lighter WebP copy of an image, stored next to the original
+    webp_key TEXT CHECK (webp_key IS NULL OR length(webp_key) BETWEEN 1 AND 1024),
     category file_category NOT NULL GENERATED ALWAYS AS (
         CASE 
             WHEN content_type ILIKE 'image/%' THEN 'image'::file_category
@@ -77,6 +93,10 @@ CREATE TABLE files (
     UNIQUE (id, category)
 );
 
+-- Identical uploads share one stored object, so several rows may point at the same key
+CREATE INDEX idx_files_key ON files (key);
+CREATE INDEX idx_files_checksum ON files (checksum) WHERE checksum IS NOT NULL;
+
 -- ==========================================
 -- 2. ACADEMIC CONTEXT
 -- ==========================================
@@ -95,6 +115,10 @@ CREATE TABLE institutions (
         AND length(acronym) > 0
         AND acronym = trim(acronym)
     ),
+    logo_url TEXT CHECK (
+        logo_url IS NULL
+        OR (length(logo_url) > 0 AND logo_url = trim(logo_url))
+    ),
     
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     created_by_id INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
@@ -117,13 +141,14 @@ CREATE TABLE exams (
         OR (length(description) > 0 AND description = trim(description))
     ), 
     year INTEGER NOT NULL,
+    phase SMALLINT CHECK (phase IS NULL OR phase > 0),
     
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     created_by_id INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     
-    UNIQUE (institution_id, title, year)
+    UNIQUE NULLS NOT DISTINCT (institution_id, title, year, phase)
 );
 
 CREATE TABLE topics (
@@ -158,6 +183,8 @@ CREATE TABLE topics (
 
 CREATE TYPE question_type AS ENUM ('open_ended', 'closed_ended');
 
+CREATE TYPE question_difficulty AS ENUM ('easy', 'medium', 'hard');
+
 CREATE TABLE questions (
     id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
@@ -172,7 +199,14 @@ CREATE TABLE questions (
         expected_answer_text IS NULL
         OR (length(expected_answer_text) > 0 AND expected_answer_text = trim(expected_answer_text))
     ),
+    expected_keywords TEXT[] NOT NULL DEFAULT '{}',
     passing_score INT CHECK (passing_score BETWEEN 0 AND 100),
+    max_attempts INT CHECK (max_attempts IS NULL OR max_attempts > 0),
+    difficulty question_difficulty,
+
+    -- Archived questions are hidden from listings and cannot be added to
+    -- activities, but stay readable for existing items and submissions
+    archived_at TIMESTAMPTZ,
 
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     created_by_id INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
@@ -180,6 +214,26 @@ CREATE TABLE questions (
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
+CREATE OR REPLACE FUNCTION normalize_statement(s TEXT)
+RETURNS TEXT AS $$
+    SELECT regexp_replace(
+        translate(lower(trim(s)), 'áàâãäéèêëíìîïóòôõöúùûüçñ', 'aaaaaeeeeiiiiooooouuuucn'),
+        '\s+', ' ', 'g'
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX idx_questions_statement_trgm ON questions USING gin (normalize_statement(statement) gin_trgm_ops);
+
+CREATE TABLE question_tags (
+    question_id INT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL CHECK (
+        length(tag) <= 50
+        AND length(tag) > 0
+        AND tag = trim(tag)
+    ),
+    PRIMARY KEY (question_id, tag)
+);
+
 CREATE TABLE question_images (
     question_id INT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
     image_file_id INT NOT NULL,
@@ -396,6 +450,15 @@ CREATE TABLE groups (
         thumbnail_url IS NULL
         OR (length(thumbnail_url) > 0 AND thumbnail_url = trim(thumbnail_url))
     ),
+    leaderboard_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    leaderboard_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_past_due BOOLEAN NOT NULL DEFAULT FALSE,
+    -- IANA zone activity due dates are entered and shown in
+    time_zone TEXT NOT NULL DEFAULT 'UTC' CHECK (length(time_zone) > 0),
+    -- Submission upload overrides; NULL falls back to the platform defaults
+    submission_max_bytes BIGINT CHECK (submission_max_bytes IS NULL OR submission_max_bytes > 0),
+    submission_allowed_types TEXT[],
+    join_code TEXT UNIQUE CHECK (join_code IS NULL OR join_code ~ '^[A-Z0-9]{8}$'),
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     created_by_id INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
@@ -418,6 +481,20 @@ CREATE TABLE group_members (
     PRIMARY KEY (group_id, user_id)
 );
 
+-- Invitations sent to emails without an account; they become accepted
+-- memberships once an account verifies that email
+CREATE TABLE group_invitations (
+    group_id INT NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    email TEXT NOT NULL CHECK (length(email) > 0 AND email = lower(email)),
+    role member_role NOT NULL DEFAULT 'member',
+    invited_by_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (group_id, email)
+);
+
+CREATE INDEX idx_group_invitations_email ON group_invitations (email);
+
 CREATE TABLE activities (
     id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
@@ -522,6 +599,13 @@ CREATE TABLE activity_submissions (
     ),
     reviewed_at TIMESTAMPTZ,
     reviewed_by_id INT REFERENCES users(id) ON DELETE RESTRICT,
+    -- Set when a group admin sends a reviewed submission back to pending
+    reopen_reason TEXT CHECK (
+        reopen_reason IS NULL
+        OR (length(reopen_reason) > 0 AND reopen_reason = trim(reopen_reason))
+    ),
+    reopened_at TIMESTAMPTZ,
+    reopened_by_id INT REFERENCES users(id) ON DELETE RESTRICT,
     
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
@@ -555,14 +639,161 @@ CREATE TABLE question_submissions (
         OR (length(answer_feedback) > 0 AND answer_feedback = trim(answer_feedback))
     ),
     passed BOOLEAN NOT NULL DEFAULT FALSE,
+    started_at TIMESTAMPTZ,
+    duration_seconds INT CHECK (duration_seconds IS NULL OR duration_seconds >= 0),
+    attachment_file_id INT REFERENCES files(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    reviewed_by_id INT REFERENCES users(id) ON DELETE RESTRICT,
 
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
+CREATE INDEX idx_question_submissions_user_id ON question_submissions (user_id) WHERE is_active = true;
+CREATE INDEX idx_activity_submissions_pending ON activity_submissions (activity_id, submitted_at) WHERE status = 'pending' AND is_active = true;
+
+CREATE TABLE question_attempt_starts (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    question_id INT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, question_id)
+);
+
+-- ==========================================
+-- 6. INTEGRATIONS
+-- ==========================================
+
+CREATE TABLE webhooks (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+
+    url TEXT NOT NULL CHECK (
+        length(url) <= 2048
+        AND length(url) > 0
+        AND url = trim(url)
+    ),
+    secret TEXT NOT NULL CHECK (length(secret) > 0),
+
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by_id INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE idempotency_keys (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key TEXT NOT NULL CHECK (
+        length(idempotency_key) <= 255
+        AND length(idempotency_key) > 0
+    ),
+    route TEXT NOT NULL,
+
+    -- NULL while the first request is still being processed
+    request_hash BYTEA, -- SHA-256 of the request body; retries must match it
+    status_code INT,
+    content_type TEXT,
+    location TEXT, -- replayed as the Location header of created resources
+    response_body BYTEA,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    UNIQUE (user_id, idempotency_key, route)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TYPE email_status AS ENUM ('pending', 'sent', 'failed');
+
+CREATE TABLE emails_outbox (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+
+    recipient TEXT NOT NULL CHECK (length(recipient) > 0),
+    subject TEXT NOT NULL CHECK (length(subject) > 0),
+    html TEXT NOT NULL,
+    text TEXT NOT NULL DEFAULT '',
+
+    status email_status NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_emails_outbox_pending ON emails_outbox (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_emails_outbox_failed ON emails_outbox (created_at DESC) WHERE status = 'failed';
+CREATE INDEX idx_emails_outbox_sent ON emails_outbox (sent_at) WHERE status = 'sent';
+
+-- One row per (activity, member) once a due-date reminder has been queued
+CREATE TABLE activity_reminders (
+    activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (activity_id, user_id)
+);
+
+-- One row per issued session token; revoking it logs that token out
+CREATE TABLE sessions (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- For impersonation sessions, the admin session to restore when it ends
+    impersonator_session_id INT REFERENCES sessions(id) ON DELETE SET NULL,
+
+    user_agent TEXT CHECK (user_agent IS NULL OR length(user_agent) <= 512),
+    ip_address TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id) WHERE revoked_at IS NULL;
+
+-- Audit trail of admins starting and stopping impersonation
+CREATE TABLE impersonation_events (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+
+    admin_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL CHECK (action IN ('start', 'stop')),
+    ip_address TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_impersonation_events_admin_id ON impersonation_events (admin_id, created_at DESC);
+
+-- One row per counted view or download of a handout, video lesson or open
+-- exercise list; repeats by the same user within a short window are dropped.
+-- This is the only view and download counter; the backend purges rows older
+-- than CONTENT_ACCESS_RETENTION
+CREATE TABLE content_access_events (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+
+    content_type TEXT NOT NULL CHECK (content_type IN ('handout', 'video_lesson', 'open_exercise_list')),
+    content_id INT NOT NULL,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL CHECK (action IN ('view', 'download')),
+
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_content_access_events_dedupe ON content_access_events (content_type, content_id, user_id, action, accessed_at DESC);
+CREATE INDEX idx_content_access_events_accessed_at ON content_access_events (accessed_at);
+
 -- ==========================================
--- 6. TRIGGERS
+-- 7. TRIGGERS
 -- ==========================================
 
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -3,3 +3,296 @@
 ALTER TYPE activity_submission_status ADD VALUE 'created' BEFORE 'pending';
 
 ALTER TYPE member_role ADD VALUE 'supervisor' BEFORE 'member';
+
+-- 2026/10/15
+
+ALTER TABLE questions ADD COLUMN max_attempts INT CHECK (max_attempts IS NULL OR max_attempts > 0);
+
+ALTER TABLE question_submissions
+    ADD COLUMN started_at TIMESTAMPTZ,
+    ADD COLUMN duration_seconds INT CHECK (duration_seconds IS NULL OR duration_seconds >= 0);
+
+CREATE TABLE question_attempt_starts (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    question_id INT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, question_id)
+);
+
+ALTER TABLE questions ADD COLUMN expected_keywords TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE OR REPLACE FUNCTION normalize_statement(s TEXT)
+RETURNS TEXT AS $$
+    SELECT regexp_replace(
+        translate(lower(trim(s)), 'áàâãäéèêëíìîïóòôõöúùûüçñ', 'aaaaaeeeeiiiiooooouuuucn'),
+        '\s+', ' ', 'g'
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX idx_questions_statement_trgm ON questions USING gin (normalize_statement(statement) gin_trgm_ops);
+
+CREATE TYPE question_difficulty AS ENUM ('easy', 'medium', 'hard');
+
+ALTER TABLE questions ADD COLUMN difficulty question_difficulty;
+
+CREATE TABLE question_tags (
+    question_id INT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL CHECK (
+        length(tag) <= 50
+        AND length(tag) > 0
+        AND tag = trim(tag)
+    ),
+    PRIMARY KEY (question_id, tag)
+);
+
+ALTER TABLE users ADD COLUMN pending_email TEXT CHECK (
+    pending_email IS NULL
+    OR (length(pending_email) <= 255 AND length(pending_email) > 0 AND pending_email = trim(pending_email))
+);
+
+ALTER TABLE files DROP CONSTRAINT files_key_key;
+ALTER TABLE files ADD COLUMN checksum TEXT CHECK (checksum IS NULL OR checksum ~ '^[0-9a-f]{64}$');
+
+CREATE INDEX idx_files_key ON files (key);
+CREATE INDEX idx_files_checksum ON files (checksum) WHERE checksum IS NOT NULL;
+
+CREATE TABLE webhooks (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+
+    url TEXT NOT NULL CHECK (
+        length(url) <= 2048
+        AND length(url) > 0
+        AND url = trim(url)
+    ),
+    secret TEXT NOT NULL CHECK (length(secret) > 0),
+
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by_id INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE TRIGGER set_updated_at
+BEFORE UPDATE ON webhooks
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE groups ADD COLUMN leaderboard_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE groups ADD COLUMN leaderboard_anonymous BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE groups ADD COLUMN join_code TEXT UNIQUE CHECK (join_code IS NULL OR join_code ~ '^[A-Z0-9]{8}$');
+
+CREATE TABLE idempotency_keys (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key TEXT NOT NULL CHECK (
+        length(idempotency_key) <= 255
+        AND length(idempotency_key) > 0
+    ),
+    route TEXT NOT NULL,
+
+    -- NULL while the first request is still being processed
+    status_code INT,
+    content_type TEXT,
+    response_body BYTEA,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    UNIQUE (user_id, idempotency_key, route)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TYPE email_status AS ENUM ('pending', 'sent', 'failed');
+
+CREATE TABLE emails_outbox (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+
+    recipient TEXT NOT NULL CHECK (length(recipient) > 0),
+    subject TEXT NOT NULL CHECK (length(subject) > 0),
+    html TEXT NOT NULL,
+
+    status email_status NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_emails_outbox_pending ON emails_outbox (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_emails_outbox_failed ON emails_outbox (created_at DESC) WHERE status = 'failed';
+
+CREATE OR REPLACE TRIGGER set_updated_at
+BEFORE UPDATE ON emails_outbox
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE emails_outbox ADD COLUMN text TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE users ADD COLUMN lang TEXT CHECK (lang IS NULL OR lang IN ('pt-BR', 'en'));
+
+ALTER TABLE groups ADD COLUMN allow_past_due BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- One row per (activity, member) once a due-date reminder has been queued
+CREATE TABLE activity_reminders (
+    activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (activity_id, user_id)
+);
+
+ALTER TABLE activity_submissions
+    ADD COLUMN reopen_reason TEXT CHECK (
+        reopen_reason IS NULL
+        OR (length(reopen_reason) > 0 AND reopen_reason = trim(reopen_reason))
+    ),
+    ADD COLUMN reopened_at TIMESTAMPTZ,
+    ADD COLUMN reopened_by_id INT REFERENCES users(id) ON DELETE RESTRICT;
+
+ALTER TABLE groups
+    ADD COLUMN submission_max_bytes BIGINT CHECK (submission_max_bytes IS NULL OR submission_max_bytes > 0),
+    ADD COLUMN submission_allowed_types TEXT[];
+
+ALTER TABLE institutions
+    ADD COLUMN logo_url TEXT CHECK (
+        logo_url IS NULL
+        OR (length(logo_url) > 0 AND logo_url = trim(logo_url))
+    );
+
+ALTER TABLE exams
+    ADD COLUMN phase SMALLINT CHECK (phase IS NULL OR phase > 0),
+    DROP CONSTRAINT exams_institution_id_title_year_key,
+    ADD CONSTRAINT exams_institution_id_title_year_phase_key
+        UNIQUE NULLS NOT DISTINCT (institution_id, title, year, phase);
+
+UPDATE group_members gm
+SET is_active = false
+FROM users u
+WHERE u.id = gm.user_id AND u.is_active = false AND gm.is_active = true;
+
+ALTER TABLE question_submissions
+    ADD COLUMN attachment_file_id INT REFERENCES files(id) ON DELETE SET NULL;
+
+ALTER TABLE question_submissions
+    ADD COLUMN reviewed_at TIMESTAMPTZ,
+    ADD COLUMN reviewed_by_id INT REFERENCES users(id) ON DELETE RESTRICT;
+
+CREATE TABLE sessions (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id) WHERE revoked_at IS NULL;
+
+ALTER TABLE sessions
+    ADD COLUMN user_agent TEXT CHECK (user_agent IS NULL OR length(user_agent) <= 512),
+    ADD COLUMN ip_address TEXT,
+    ADD COLUMN last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- Emails are compared case-insensitively by storing them lowercased. This
+-- fails if two accounts differ only in case; merge those before migrating.
+UPDATE users SET email = lower(email) WHERE email <> lower(email);
+UPDATE users SET pending_email = lower(pending_email) WHERE pending_email <> lower(pending_email);
+
+ALTER TABLE users
+    ADD CONSTRAINT users_email_lowercase CHECK (email = lower(email)),
+    ADD CONSTRAINT users_pending_email_lowercase CHECK (pending_email IS NULL OR pending_email = lower(pending_email));
+
+ALTER TABLE questions
+    ADD COLUMN archived_at TIMESTAMPTZ;
+
+ALTER TABLE groups
+    ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC' CHECK (length(time_zone) > 0);
+
+ALTER TABLE handouts ADD COLUMN download_count INT NOT NULL DEFAULT 0;
+ALTER TABLE open_exercise_lists ADD COLUMN download_count INT NOT NULL DEFAULT 0;
+
+-- One row per counted view or download of a handout, video lesson or open
+-- exercise list; repeats by the same user within a short window are dropped
+CREATE TABLE content_access_events (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+
+    content_type TEXT NOT NULL CHECK (content_type IN ('handout', 'video_lesson', 'open_exercise_list')),
+    content_id INT NOT NULL,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL CHECK (action IN ('view', 'download')),
+
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_content_access_events_dedupe ON content_access_events (content_type, content_id, user_id, action, accessed_at DESC);
+CREATE INDEX idx_content_access_events_accessed_at ON content_access_events (accessed_at);
+
+ALTER TABLE files
+    ADD COLUMN webp_key TEXT CHECK (webp_key IS NULL OR length(webp_key) BETWEEN 1 AND 1024);
+
+CREATE INDEX idx_question_submissions_user_id ON question_submissions (user_id) WHERE is_active = true;
+
+ALTER TABLE users
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'deleted'));
+UPDATE users SET status = 'deleted' WHERE is_active = false;
+
+CREATE INDEX idx_activity_submissions_pending ON activity_submissions (activity_id, submitted_at) WHERE status = 'pending' AND is_active = true;
+
+ALTER TABLE sessions
+    ADD COLUMN impersonator_session_id INT REFERENCES sessions(id) ON DELETE SET NULL;
+
+-- Audit trail of admins starting and stopping impersonation
+CREATE TABLE impersonation_events (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+
+    admin_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL CHECK (action IN ('start', 'stop')),
+    ip_address TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_impersonation_events_admin_id ON impersonation_events (admin_id, created_at DESC);
+
+-- Replay the Location header of idempotent creates
+ALTER TABLE idempotency_keys ADD COLUMN location TEXT;
+
+-- Lets the outbox worker purge delivered emails past their retention
+CREATE INDEX idx_emails_outbox_sent ON emails_outbox (sent_at) WHERE status = 'sent';
+
+-- Let users opt out of activity due-date reminders
+ALTER TABLE users ADD COLUMN activity_reminders BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Invitations sent to emails without an account; they become accepted
+-- memberships once an account verifies that email
+CREATE TABLE group_invitations (
+    group_id INT NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    email TEXT NOT NULL CHECK (length(email) > 0 AND email = lower(email)),
+    role member_role NOT NULL DEFAULT 'member',
+    invited_by_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (group_id, email)
+);
+
+CREATE INDEX idx_group_invitations_email ON group_invitations (email);
+
+-- Reject idempotency keys reused with a different request body
+ALTER TABLE idempotency_keys ADD COLUMN request_hash BYTEA;
+
+-- Downloads are counted from content_access_events only
+ALTER TABLE handouts DROP COLUMN download_count;
+ALTER TABLE open_exercise_lists DROP COLUMN download_count;
@@ -49,7 +49,7 @@ import {
   type SubmissionAttachmentResponse,
 } from "@/lib/activity-submissions";
 import { checkMembership } from "@/lib/groups";
-import { ApiRequestError } from "@/lib/api";
+import { ApiRequestError, apiUrl } from "@/lib/api";
 import { Button } from "@/components/ui/button";
 import { InputField } from "@/components/ui/input-field";
 import { LatexText } from "@/components/ui/latex-text";
@@ -532,7 +532,7 @@ export default function ActivityDetailPage() {
                 className="flex items-center justify-between rounded-lg border border-surface-border bg-background p-3"
               >
                 <a
-                  href={att.url}
+                  href={apiUrl(att.download_url)}
                   target="_blank"
                   rel="noopener noreferrer"
                   className="flex min-w-0 flex-1 items-center gap-3 hover:text-secondary transition-colors"
@@ -701,7 +701,7 @@ export default function ActivityDetailPage() {
                         className="flex items-center justify-between rounded-lg border border-surface-border bg-surface p-2.5"
                       >
                         <a
-                          href={att.url}
+                          href={apiUrl(att.download_url)}
                           target="_blank"
                           rel="noopener noreferrer"
                           className="flex min-w-0 flex-1 items-center gap-3 hover:text-secondary transition-colors"
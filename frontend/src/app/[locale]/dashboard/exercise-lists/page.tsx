@@ -25,6 +25,7 @@ import { Button } from "@/components/ui/button";
 import { useToast } from "@/components/ui/toast";
 import { FilterTooltip } from "@/components/ui/filter-tooltip";
 import { useIsAdmin } from "@/contexts/user-context";
+import { apiUrl } from "@/lib/api";
 
 export default function ExerciseListsPage() {
   const t = useTranslations();
@@ -239,9 +240,9 @@ export default function ExerciseListsPage() {
                   </div>
                 </div>
                 <div className="flex shrink-0 items-center gap-1 self-end sm:self-center">
-                  {list.file_url && (
+                  {(list.file || list.file_url) && (
                     <a
-                      href={list.file_url}
+                      href={list.file ? apiUrl(list.file.download_url) : list.file_url}
                       target="_blank"
                       rel="noopener noreferrer"
                       className="rounded-lg p-2 text-muted transition-colors hover:bg-surface-light hover:text-heading"
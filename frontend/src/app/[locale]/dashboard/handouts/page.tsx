@@ -25,6 +25,7 @@ import { Button } from "@/components/ui/button";
 import { useToast } from "@/components/ui/toast";
 import { FilterTooltip } from "@/components/ui/filter-tooltip";
 import { useIsAdmin } from "@/contexts/user-context";
+import { apiUrl } from "@/lib/api";
 
 export default function HandoutsPage() {
   const t = useTranslations();
@@ -229,7 +230,7 @@ export default function HandoutsPage() {
                 </div>
                 <div className="flex shrink-0 items-center gap-1 self-end sm:self-center">
                   <a
-                    href={handout.file.url}
+                    href={apiUrl(handout.file.download_url)}
                     target="_blank"
                     rel="noopener noreferrer"
                     className="rounded-lg p-2 text-muted transition-colors hover:bg-surface-light hover:text-heading"
@@ -1,6 +1,7 @@
 "use client";
 
 import { useState, type FormEvent } from "react";
+import { useSearchParams } from "next/navigation";
 import { useTranslations } from "next-intl";
 import { Link } from "@/i18n/routing";
 import { register } from "@/lib/auth";
@@ -15,8 +16,10 @@ import { useRedirectIfAuthenticated } from "@/hooks/use-redirect-if-authenticate
 export default function RegisterPage() {
   const t = useTranslations();
   const checking = useRedirectIfAuthenticated();
+  const searchParams = useSearchParams();
   const [name, setName] = useState("");
-  const [email, setEmail] = useState("");
+  // Group invitations link here with the invited address filled in.
+  const [email, setEmail] = useState(searchParams.get("email") ?? "");
   const [password, setPassword] = useState("");
   const [confirmPassword, setConfirmPassword] = useState("");
   const [error, setError] = useState("");
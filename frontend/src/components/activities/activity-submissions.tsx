@@ -30,7 +30,7 @@ import {
   type QuestionSubmissionAttempt,
 } from "@/lib/activity-submissions";
 import { listActivityItems, type ActivityItemResponse } from "@/lib/activities";
-import { ApiRequestError } from "@/lib/api";
+import { ApiRequestError, apiUrl } from "@/lib/api";
 import { Button } from "@/components/ui/button";
 import { LatexText } from "@/components/ui/latex-text";
 import { stripImageMarkers } from "@/components/questions/statement-renderer";
@@ -402,7 +402,7 @@ export function ActivitySubmissions({ activityId, canReview = true }: ActivitySu
                         {expandedAttachments.map((att) => (
                             <a
                               key={att.id}
-                              href={att.url}
+                              href={apiUrl(att.download_url)}
                               target="_blank"
                               rel="noopener noreferrer"
                               className="flex items-center gap-2 rounded-lg border border-surface-border bg-surface p-2 text-sm hover:text-secondary transition-colors"
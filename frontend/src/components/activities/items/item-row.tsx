@@ -16,6 +16,7 @@ import {
     Cpu,
 } from "lucide-react";
 import { type ActivityItemResponse } from "@/lib/activities";
+import { apiUrl } from "@/lib/api";
 import { getVideoLesson } from "@/lib/video-lessons";
 import { getHandout } from "@/lib/handouts";
 import { getExerciseList } from "@/lib/open-exercise-lists";
@@ -62,13 +63,13 @@ export function ItemRow({ item, index, activityId, questionStatus, t }: ItemRowP
                 url = vl.video_url || vl.file?.url;
             } else if (item.type === "handout" && item.handout_id) {
                 const h = await getHandout(item.handout_id);
-                url = h.file?.url;
+                url = h.file && apiUrl(h.file.download_url);
             } else if (
                 item.type === "open_exercise_list" &&
                 item.open_exercise_list_id
             ) {
                 const el = await getExerciseList(item.open_exercise_list_id);
-                url = el.file_url || el.file?.url;
+                url = el.file ? apiUrl(el.file.download_url) : el.file_url;
             }
             if (url) {
                 window.open(url, "_blank", "noopener,noreferrer");
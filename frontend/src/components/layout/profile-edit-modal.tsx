@@ -30,6 +30,9 @@ export function ProfileEditModal({
   const { toast } = useToast();
   const fileInputRef = useRef<HTMLInputElement>(null);
   const [name, setName] = useState(user.name);
+  const [activityReminders, setActivityReminders] = useState(
+    user.activity_reminders,
+  );
   const [avatarPreview, setAvatarPreview] = useState<string | null>(
     user.avatar_url ?? null,
   );
@@ -76,8 +79,14 @@ export function ProfileEditModal({
     try {
       let updatedUser: UserResponse = user;
 
-      if (name !== user.name) {
-        updatedUser = await updateMe(name);
+      if (
+        name !== user.name ||
+        activityReminders !== user.activity_reminders
+      ) {
+        updatedUser = await updateMe({
+          name,
+          activity_reminders: activityReminders,
+        });
       }
 
       if (avatarFile) {
@@ -200,6 +209,17 @@ export function ProfileEditModal({
               </p>
             </div>
 
+            {/* Activity reminders */}
+            <label className="mt-4 flex items-center gap-2 text-sm text-heading">
+              <input
+                type="checkbox"
+                checked={activityReminders}
+                onChange={(e) => setActivityReminders(e.target.checked)}
+                className="h-4 w-4 shrink-0 rounded border-surface-border text-secondary focus:ring-secondary"
+              />
+              {t("PROFILE_ACTIVITY_REMINDERS_LABEL")}
+            </label>
+
             {error && <p className="mt-4 text-sm text-error">{error}</p>}
 
             <div className="mt-6 flex gap-3">
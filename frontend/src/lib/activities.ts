@@ -19,7 +19,7 @@ export interface AttachmentResponse {
   filename: string;
   content_type: string;
   size_bytes: number;
-  url: string;
+  download_url: string;
 }
 
 export interface ActivityDetailResponse {
@@ -189,7 +189,10 @@ export interface UpdateActivityItemInput {
 export async function listActivityItems(
   activityId: string,
 ): Promise<ActivityItemResponse[]> {
-  return api<ActivityItemResponse[]>(`/activities/${activityId}/items`);
+  const res = await api<{ data: ActivityItemResponse[] }>(
+    `/activities/${activityId}/items`,
+  );
+  return res.data;
 }
 
 export async function createActivityItem(
@@ -166,7 +166,7 @@ export interface SubmissionAttachmentResponse {
   filename: string;
   content_type: string;
   size_bytes: number;
-  url: string;
+  download_url: string;
 }
 
 export async function listSubmissionAttachments(
@@ -17,11 +17,31 @@ export class ApiRequestError extends Error {
   }
 }
 
-export async function api<T>(
-  path: string,
-  options: RequestInit = {},
-): Promise<T> {
-  const res = await fetch(`${API_BASE_URL}${path}`, {
+// apiUrl resolves a path returned by the API, such as an attachment's
+// download_url, against the API origin.
+export function apiUrl(path: string): string {
+  return `${API_BASE_URL}${path}`;
+}
+
+let refreshing: Promise<boolean> | null = null;
+
+// refreshSession renews the expired access token in the session cookie,
+// sharing one request between concurrent callers.
+function refreshSession(): Promise<boolean> {
+  refreshing ??= fetch(`${API_BASE_URL}/auth/refresh`, {
+    method: "POST",
+    credentials: "include",
+  })
+    .then((res) => res.ok)
+    .catch(() => false)
+    .finally(() => {
+      refreshing = null;
+    });
+  return refreshing;
+}
+
+function request(path: string, options: RequestInit): Promise<Response> {
+  return fetch(`${API_BASE_URL}${path}`, {
     credentials: "include",
     headers: {
       "Content-Type": "application/json",
@@ -29,6 +49,23 @@ export async function api<T>(
     },
     ...options,
   });
+}
+
+export async function api<T>(
+  path: string,
+  options: RequestInit = {},
+): Promise<T> {
+  let res = await request(path, options);
+
+  // Access tokens are short-lived; renew once and retry while the session
+  // itself is still live.
+  if (
+    res.status === 401 &&
+    !path.startsWith("/auth/") &&
+    (await refreshSession())
+  ) {
+    res = await request(path, options);
+  }
 
   if (!res.ok) {
     const body = await res.json().catch(() => ({
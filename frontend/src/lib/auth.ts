@@ -12,6 +12,7 @@ export interface UserResponse {
   email: string;
   email_verified_at?: string;
   avatar_url?: string;
+  activity_reminders: boolean;
   is_active: boolean;
   created_at: string;
   updated_at: string;
@@ -60,10 +61,13 @@ export async function getMe(): Promise<UserResponse> {
   return api<UserResponse>("/me", { method: "GET" });
 }
 
-export async function updateMe(name: string): Promise<UserResponse> {
+export async function updateMe(changes: {
+  name?: string;
+  activity_reminders?: boolean;
+}): Promise<UserResponse> {
   return api<UserResponse>("/me", {
-    method: "PUT",
-    body: JSON.stringify({ name }),
+    method: "PATCH",
+    body: JSON.stringify(changes),
   });
 }
 
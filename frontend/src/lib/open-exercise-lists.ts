@@ -12,7 +12,7 @@ export interface ExerciseListFileResponse {
   filename: string;
   content_type: string;
   size_bytes: number;
-  url: string;
+  download_url: string;
 }
 
 export interface ExerciseListResponse {